@@ -0,0 +1,11 @@
+// Package agecalc provides calendar-accurate age arithmetic.
+//
+// Ages are computed by comparing the month and day of the birth date with the
+// reference date rather than their day of the year, so results do not shift
+// around leap years. People born on February 29 are considered to have their
+// birthday on March 1 in common years.
+//
+// The functions forward to the agecalc package of the v2 module.
+package agecalc
+
+//go:generate go run ../internal/wrapgen -src ../v2/agecalc -import github.com/i2sac/user-entity-date-verification/v2/agecalc -pkg agecalc
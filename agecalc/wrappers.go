@@ -0,0 +1,32 @@
+// Code generated by wrapgen from github.com/i2sac/user-entity-date-verification/v2/agecalc; DO NOT EDIT.
+
+package agecalc
+
+import (
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/agecalc"
+)
+
+// Years returns the number of full years elapsed between birth and at.
+// The result is negative when at is before birth.
+func Years(birth, at time.Time) int {
+	return agecalc.Years(birth, at)
+}
+
+// Birthday returns the date on which the person born at birth celebrates
+// their birthday in the given year, at midnight in birth's location.
+func Birthday(birth time.Time, year int) time.Time {
+	return agecalc.Birthday(birth, year)
+}
+
+// DateAtAge returns the first day on which the person born at birth is
+// the given number of full years old.
+func DateAtAge(birth time.Time, years int) time.Time {
+	return agecalc.DateAtAge(birth, years)
+}
+
+// IsLeapYear reports whether year is a leap year in the Gregorian calendar.
+func IsLeapYear(year int) bool {
+	return agecalc.IsLeapYear(year)
+}
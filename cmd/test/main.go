@@ -22,20 +22,23 @@ func main() {
 	fmt.Println("Testing User Entity Date Verification Library")
 	fmt.Println("===========================================")
 
+	// Pin the clock so the output is the same on every run
+	now := userdate.WithFixedNow(parseTime("2006-01-02", "2025-07-18"))
+
 	// Test 1: Create a valid user
 	fmt.Println("\n1. Testing NewUser with valid data:")
 	birthDate := parseTime("2006-01-02", "1990-05-15")
-	user, err := userdate.NewUser("user123", birthDate, "John Doe")
+	user, err := userdate.NewUser("user123", birthDate, "John Doe", now)
 	if err != nil {
 		log.Printf("Error creating user: %v", err)
 	} else {
-		fmt.Printf("✓ User created successfully: %s (Age: %d)\n", user.Name, user.GetAge())
+		fmt.Printf("✓ User created successfully: %s (Age: %d)\n", user.Name, user.GetAge(now))
 	}
 
 	// Test 2: Validate a certification date
 	fmt.Println("\n2. Testing ValidateCertification with valid date:")
 	certDate := parseTime("2006-01-02", "2020-03-10")
-	err = userdate.ValidateCertification(user, certDate)
+	err = userdate.ValidateCertification(user, certDate, now)
 	if err != nil {
 		log.Printf("✗ Certification validation failed: %v", err)
 	} else {
@@ -45,7 +48,7 @@ func main() {
 	// Test 3: Test invalid certification (before birth)
 	fmt.Println("\n3. Testing ValidateCertification with invalid date (before birth):")
 	invalidDate := parseTime("2006-01-02", "1989-01-01")
-	err = userdate.ValidateCertification(user, invalidDate)
+	err = userdate.ValidateCertification(user, invalidDate, now)
 	if err != nil {
 		fmt.Printf("✓ Expected error caught: %v\n", err)
 	} else {
@@ -54,8 +57,8 @@ func main() {
 
 	// Test 4: Test future date
 	fmt.Println("\n4. Testing ValidateCertification with future date:")
-	futureDate := time.Now().AddDate(1, 0, 0)
-	err = userdate.ValidateCertification(user, futureDate)
+	futureDate := parseTime("2006-01-02", "2026-07-18")
+	err = userdate.ValidateCertification(user, futureDate, now)
 	if err != nil {
 		fmt.Printf("✓ Expected error caught: %v\n", err)
 	} else {
@@ -65,7 +68,7 @@ func main() {
 	// Test 5: Test employment validation
 	fmt.Println("\n5. Testing ValidateEmployment:")
 	employmentDate := parseTime("2006-01-02", "2006-06-01") // User is 16
-	err = userdate.ValidateEmployment(user, employmentDate)
+	err = userdate.ValidateEmployment(user, employmentDate, now)
 	if err != nil {
 		log.Printf("✗ Employment validation failed: %v", err)
 	} else {
@@ -75,7 +78,7 @@ func main() {
 	// Test 6: Test too young for employment
 	fmt.Println("\n6. Testing ValidateEmployment with too young age:")
 	tooYoungDate := parseTime("2006-01-02", "2003-01-01") // User is 13
-	err = userdate.ValidateEmployment(user, tooYoungDate)
+	err = userdate.ValidateEmployment(user, tooYoungDate, now)
 	if err != nil {
 		fmt.Printf("✓ Expected error caught: %v\n", err)
 	} else {
@@ -90,7 +93,7 @@ func main() {
 
 	// Test 8: Test error handling
 	fmt.Println("\n8. Testing error handling:")
-	err = userdate.ValidateCertification(nil, certDate)
+	err = userdate.ValidateCertification(nil, certDate, now)
 	if err != nil {
 		if dateErr, ok := err.(*userdate.DateValidationError); ok {
 			fmt.Printf("✓ Error code: %s, Message: %s\n", dateErr.Code, dateErr.Message)
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// calendar runs the calendar command
+func calendar(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("calendar", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	now := fs.String("now", "", "reference date (YYYY-MM-DD), the current time by default")
+	notice := fs.Int("notice", 3, "months before an expiry its renewal is due, 0 to leave renewals out")
+	rulesFile := fs.String("rules", "", "rules file written by rules init")
+	jurisdiction := fs.String("jurisdiction", "", "country code of the jurisdiction: "+strings.Join(userdate.Jurisdictions(), ", ")+", or a subdivision such as US-CA")
+	preset := fs.String("preset", "", "rule preset: "+strings.Join(userdate.Presets(), ", "))
+	locale := fs.String("locale", "", "locale of the event summaries, such as fr or de")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate calendar [flags] profile.json > milestones.ics")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || *notice < 0 {
+		fs.Usage()
+		return 2
+	}
+
+	opts, err := ruleOptions(*rulesFile, *jurisdiction, *preset)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *now != "" {
+		t, err := parseDay(*now)
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: invalid -now: %v\n", err)
+			return 2
+		}
+		opts = append(opts, userdate.WithFixedNow(t))
+	}
+	if *locale != "" {
+		opts = append(opts, userdate.WithLocale(*locale))
+	}
+	opts = append(opts, userdate.WithRenewalNotice(userdate.ValidityPeriod{Months: *notice}))
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	var profile userdate.Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		fmt.Fprintf(stderr, "userdate: %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	if err := userdate.WriteCalendar(stdout, profile, opts...); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	return 0
+}
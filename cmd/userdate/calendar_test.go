@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCalendar(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "profile.json")
+	profile := `{"user": {"id": "u1", "birth_date": "1990-05-15T00:00:00Z"},
+		"entities": [{"id": "cpr-1", "type": "cpr_certification", "date": "2024-09-01T00:00:00Z"}]}`
+	if err := os.WriteFile(file, []byte(profile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"calendar", "-now", "2025-07-18", "-notice", "1", "-locale", "de", file}, &stdout, &stderr); status != 0 {
+		t.Fatalf("status = %d, stderr = %s", status, stderr.String())
+	}
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART;VALUE=DATE:20260801\r\n",
+		"DTSTART;VALUE=DATE:20260901\r\n",
+		"CATEGORIES:RENEWAL_DUE\r\n",
+		"SUMMARY:cpr_certification vom 01.09.2024 läuft ab\r\n",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("stdout misses %q:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if status := run([]string{"calendar", filepath.Join(dir, "missing.json")}, &stdout, &stderr); status != 1 {
+		t.Errorf("status = %d for a missing file, want 1", status)
+	}
+	if status := run([]string{"calendar", "-notice", "-1", file}, &stdout, &stderr); status != 2 {
+		t.Errorf("status = %d for a negative -notice, want 2", status)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// compare runs the compare command
+func compare(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	rulesA := fs.String("rules-a", "", "rules file of the current rules, the defaults when empty")
+	rulesB := fs.String("rules-b", "", "rules file of the proposed rules, the defaults when empty")
+	now := fs.String("now", "", "reference date (YYYY-MM-DD), the current time by default")
+	output := fs.String("output", "auto", "output: auto (text on a terminal, JSON otherwise), text or json")
+	color := fs.String("color", "auto", "colors in text output: auto, always or never")
+	quiet := fs.Bool("quiet", false, "print only the summary line")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate compare -rules-a old.yaml -rules-b new.yaml [flags] data.jsonl")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	var common []userdate.Option
+	if *now != "" {
+		t, err := parseDay(*now)
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: invalid -now: %v\n", err)
+			return 2
+		}
+		common = append(common, userdate.WithFixedNow(t))
+	}
+	a, err := ruleOptions(*rulesA, "", "")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	b, err := ruleOptions(*rulesB, "", "")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	out, err := newPrinter(stdout, *output, *color, *quiet)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 2
+	}
+
+	items, err := userdate.ReadItems(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	cmp := userdate.Compare(items, append(a, common...), append(b, common...))
+	if err := out.comparison(cmp); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	if len(cmp.Differences) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// comparison writes a summary line such as "DIFF  3 of 120 items change
+// verdict: 2 newly rejected, 1 newly accepted", then a table of the
+// differences of each responsible rule
+func (p *printer) comparison(cmp *userdate.Comparison) error {
+	if !p.text && !p.quiet {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cmp)
+	}
+
+	if len(cmp.Differences) == 0 {
+		_, err := fmt.Fprintf(p.w, "%s  %s keep their verdict\n", p.paint("SAME", ansiBold, ansiGreen), plural(cmp.Total, "item"))
+		return err
+	}
+	rejected := 0
+	for _, d := range cmp.Differences {
+		if d.NewlyRejected() {
+			rejected++
+		}
+	}
+	fmt.Fprintf(p.w, "%s  %d of %s change verdict: %d newly rejected, %d newly accepted\n",
+		p.paint("DIFF", ansiBold, ansiYellow), len(cmp.Differences), plural(cmp.Total, "item"),
+		rejected, len(cmp.Differences)-rejected)
+	if p.quiet {
+		return nil
+	}
+
+	rules, diffs := cmp.ByRule()
+	for _, rule := range rules {
+		fmt.Fprintf(p.w, "\n%s  %s\n", p.paint(rule, ansiBold), plural(len(diffs[rule]), "item"))
+		tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  ITEM\tUSER\tTYPE\tDATE\tA\tB\tTHRESHOLD\tVALUE")
+		for _, d := range diffs[rule] {
+			userID := "-"
+			if d.Item.User != nil {
+				userID = d.Item.User.ID
+			}
+			a, b := "rejected", "accepted"
+			if d.NewlyRejected() {
+				a, b = b, a
+			}
+			fmt.Fprintf(tw, "  %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", d.Index, userID, d.Item.EntityType,
+				d.Item.EntityDate.Format("2006-01-02"), a, b, dash(d.Step.Threshold), dash(d.Step.Value))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
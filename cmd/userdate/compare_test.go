@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func TestCompare(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "data.jsonl")
+	var lines []string
+	for _, item := range []string{
+		`"entity_date": "2007-01-01T00:00:00Z", "entity_type": "license"`,
+		`"entity_date": "2010-01-01T00:00:00Z", "entity_type": "license"`,
+		`"entity_date": "2004-01-01T00:00:00Z", "entity_type": "employment"`,
+	} {
+		lines = append(lines, `{"user": {"id": "user123", "birth_date": "1990-05-15T00:00:00Z"}, `+item+`}`)
+	}
+	if err := os.WriteFile(data, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	newRules := filepath.Join(dir, "new.yaml")
+	var rules, stderr bytes.Buffer
+	if status := run([]string{"rules", "init", "-jurisdiction", "GB"}, &rules, &stderr); status != 0 {
+		t.Fatalf("rules init = %d: %s", status, stderr.String())
+	}
+	if err := os.WriteFile(newRules, rules.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		want       []string
+	}{
+		{"differences", []string{"-rules-b", newRules, "-output", "text"}, 1, []string{
+			"DIFF  2 of 3 items change verdict: 1 newly rejected, 1 newly accepted\n",
+			"\nminimum_age  2 items\n",
+			"  ITEM  USER     TYPE        DATE        A         B         THRESHOLD          VALUE\n",
+			"  0     user123  license     2007-01-01  accepted  rejected  at least 17 years  16 years\n",
+			"  2     user123  employment  2004-01-01  rejected  accepted  at least 14 years  13 years\n",
+		}},
+		{"same rules", []string{"-rules-a", newRules, "-rules-b", newRules, "-output", "text"}, 0, []string{
+			"SAME  3 items keep their verdict\n",
+		}},
+		{"quiet", []string{"-rules-b", newRules, "-quiet"}, 1, []string{
+			"DIFF  2 of 3 items change verdict",
+		}},
+		{"missing rules", []string{"-rules-b", filepath.Join(dir, "missing.yaml")}, 2, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string{"compare", "-now", "2020-06-15"}, tt.args...), data)
+			if status := run(args, &stdout, &stderr); status != tt.wantStatus {
+				t.Fatalf("run() = %d, want %d (stderr: %s)", status, tt.wantStatus, stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+
+	var stdout bytes.Buffer
+	if status := run([]string{"compare", "-now", "2020-06-15", "-rules-b", newRules, data}, &stdout, &stderr); status != 1 {
+		t.Fatalf("run() = %d, want 1", status)
+	}
+	var cmp userdate.Comparison
+	if err := json.Unmarshal(stdout.Bytes(), &cmp); err != nil || len(cmp.Differences) != 2 {
+		t.Errorf("JSON output = %d differences, error %v:\n%s", len(cmp.Differences), err, stdout.String())
+	}
+}
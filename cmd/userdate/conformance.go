@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// conformance runs the conformance command
+func conformance(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	update := fs.Bool("update", false, "rewrite the expected codes of the dataset with the ones of this implementation")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate conformance [-update] cases.jsonl [command [args...]]")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() < 1 || *update && fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	cases, err := userdate.ReadConformanceCases(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	if *update {
+		userdate.UpdateConformanceCases(cases)
+		var buf bytes.Buffer
+		if err := userdate.WriteConformanceCases(&buf, cases); err != nil {
+			fmt.Fprintf(stderr, "userdate: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(fs.Arg(0), buf.Bytes(), 0o644); err != nil {
+			fmt.Fprintf(stderr, "userdate: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "updated %d cases\n", len(cases))
+		return 0
+	}
+
+	var results []userdate.ConformanceResult
+	if fs.NArg() == 1 {
+		for _, c := range cases {
+			results = append(results, userdate.ConformanceResult{Name: c.Name, Codes: userdate.ConformanceCodes(c)})
+		}
+	} else if results, err = runPort(fs.Args()[1:], cases, stderr); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+
+	failures := userdate.CheckConformance(cases, results)
+	if len(failures) == 0 {
+		fmt.Fprintf(stdout, "PASS  %d cases conform\n", len(cases))
+		return 0
+	}
+	fmt.Fprintf(stdout, "FAIL  %d of %d cases differ\n\n", len(failures), len(cases))
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  CASE\tWANT\tGOT")
+	for _, f := range failures {
+		got := codeList(f.Got)
+		if f.Missing {
+			got = "no result"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", f.Name, codeList(f.Want), got)
+	}
+	tw.Flush()
+	return 1
+}
+
+// runPort runs the command of a port with the cases as JSON Lines on its
+// standard input, and reads a ConformanceResult per line of its output
+func runPort(command []string, cases []userdate.ConformanceCase, stderr io.Writer) ([]userdate.ConformanceResult, error) {
+	var in bytes.Buffer
+	if err := userdate.WriteConformanceCases(&in, cases); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = &in
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", command[0], err)
+	}
+	var results []userdate.ConformanceResult
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var r userdate.ConformanceResult
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s: output line %d: %w", command[0], line, err)
+		}
+		results = append(results, r)
+	}
+	return results, scanner.Err()
+}
+
+// codeList returns codes separated by commas, or "-" when there are none
+func codeList(codes []string) string {
+	if len(codes) == 0 {
+		return "-"
+	}
+	return strings.Join(codes, ",")
+}
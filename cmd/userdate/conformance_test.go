@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	for _, name := range []string{"cat", "true"} {
+		if _, err := exec.LookPath(name); err != nil {
+			t.Skipf("%s not found", name)
+		}
+	}
+	dataset := filepath.Join("..", "..", "v2", "core", "conformance", "cases.jsonl")
+	stale := filepath.Join(t.TempDir(), "cases.jsonl")
+	if err := os.WriteFile(stale, []byte(`{"name":"future","now":"2024-06-15T00:00:00Z","user":{"id":"u","birth_date":"1990-05-15T00:00:00Z"},"entity":{"type":"employment","date":"2025-01-01T00:00:00Z"},"codes":[]}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		want       []string
+	}{
+		// The cases carry their expected codes, so echoing them conforms
+		{"echoing port", []string{dataset, "cat"}, 0, []string{"PASS  48 cases conform\n"}},
+		{"reference", []string{dataset}, 0, []string{"PASS  48 cases conform\n"}},
+		{"silent port", []string{stale, "true"}, 1, []string{"  future  -     no result\n"}},
+		{"stale dataset", []string{stale}, 1, []string{
+			"FAIL  1 of 1 cases differ\n",
+			"  CASE    WANT  GOT\n",
+			"  future  -     FUTURE_DATE\n",
+		}},
+		{"missing command", []string{stale, "userdate-no-such-port"}, 1, nil},
+		{"missing dataset", []string{filepath.Join(t.TempDir(), "missing.jsonl")}, 1, nil},
+		{"update with a command", []string{"-update", stale, "cat"}, 2, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(append([]string{"conformance"}, tt.args...), &stdout, &stderr); status != tt.wantStatus {
+				t.Fatalf("conformance = %d, want %d: %s", status, tt.wantStatus, stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output = %q, want %q", stdout.String(), want)
+				}
+			}
+		})
+	}
+
+	t.Run("update", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		if status := run([]string{"conformance", "-update", stale}, &stdout, &stderr); status != 0 {
+			t.Fatalf("conformance -update = %d: %s", status, stderr.String())
+		}
+		if status := run([]string{"conformance", stale}, &stdout, &stderr); status != 0 {
+			t.Errorf("conformance after -update = %d: %s", status, stdout.String())
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envPrefix starts the names of the environment variables setting flags:
+// -shutdown-timeout is set by USERDATE_SHUTDOWN_TIMEOUT
+const envPrefix = "USERDATE_"
+
+// ruleFlags choose where rules come from. When any of them is on the
+// command line, the environment variables of the others are ignored, so
+// that -rules can replace a USERDATE_JURISDICTION of the environment.
+var ruleFlags = []string{"rules", "jurisdiction", "preset"}
+
+// parseFlags parses args like fs.Parse, then sets the flags left off the
+// command line from their environment variables. Flags therefore take
+// precedence over the environment, which takes precedence over the rules
+// file and the defaults. It prints errors to the output of fs.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range ruleFlags {
+		if set[name] {
+			for _, name := range ruleFlags {
+				set[name] = true
+			}
+			break
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] {
+			return
+		}
+		name := envName(f.Name)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return
+		}
+		if e := fs.Set(f.Name, value); e != nil {
+			err = fmt.Errorf("invalid %s %q: %v", name, value, e)
+			fmt.Fprintf(fs.Output(), "userdate: %v\n", err)
+		}
+	})
+	return err
+}
+
+// envName returns the environment variable of a flag
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		args    []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "environment",
+			env:  map[string]string{"USERDATE_LOCALE": "fr", "USERDATE_METRICS": "false", "USERDATE_SHUTDOWN_TIMEOUT": "3s"},
+			want: map[string]string{"locale": "fr", "metrics": "false", "shutdown-timeout": "3s"},
+		},
+		{
+			name: "flags take precedence",
+			env:  map[string]string{"USERDATE_LOCALE": "fr"},
+			args: []string{"-locale", "de"},
+			want: map[string]string{"locale": "de"},
+		},
+		{
+			name: "rules flag replaces the rule environment",
+			env:  map[string]string{"USERDATE_JURISDICTION": "FR", "USERDATE_PRESET": "strict"},
+			args: []string{"-rules", "rules.yaml"},
+			want: map[string]string{"rules": "rules.yaml", "jurisdiction": "", "preset": ""},
+		},
+		{
+			name: "rule environment",
+			env:  map[string]string{"USERDATE_JURISDICTION": "FR"},
+			args: []string{"-locale", "de"},
+			want: map[string]string{"jurisdiction": "FR", "locale": "de"},
+		},
+		{
+			name:    "invalid value",
+			env:     map[string]string{"USERDATE_METRICS": "maybe"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.String("rules", "", "")
+			fs.String("jurisdiction", "", "")
+			fs.String("preset", "", "")
+			fs.String("locale", "", "")
+			fs.Bool("metrics", true, "")
+			fs.Duration("shutdown-timeout", 10*time.Second, "")
+
+			err := parseFlags(fs, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for name, want := range tt.want {
+				if got := fs.Lookup(name).Value.String(); got != want {
+					t.Errorf("-%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvironmentCommand(t *testing.T) {
+	t.Setenv("USERDATE_NOW", "2020-06-15")
+	t.Setenv("USERDATE_OUTPUT", "text")
+	t.Setenv("USERDATE_LOCALE", "de")
+	var stdout, stderr bytes.Buffer
+	args := []string{"explain", "-birth", "1990-05-15", "-date", "2005-01-01", "-type", "license"}
+	if status := run(args, &stdout, &stderr); status != 1 {
+		t.Fatalf("run() = %d, want 1 (stderr: %s)", status, stderr.String())
+	}
+	if want := "on or before 2020-06-15"; !bytes.Contains(stdout.Bytes(), []byte(want)) {
+		t.Errorf("output does not contain %q:\n%s", want, stdout.String())
+	}
+	if want := "01.01.2005"; !bytes.Contains(stdout.Bytes(), []byte(want)) {
+		t.Errorf("output is not in German:\n%s", stdout.String())
+	}
+}
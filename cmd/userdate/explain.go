@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// explain runs the explain command
+func explain(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	birth := fs.String("birth", "", "birth date of the user (YYYY-MM-DD), required")
+	date := fs.String("date", "", "date of the entity (YYYY-MM-DD), required")
+	entityType := fs.String("type", "", "entity type, such as license or certification, required")
+	death := fs.String("death", "", "death date of the user (YYYY-MM-DD)")
+	renewed := fs.String("renewed", "", "latest renewal date of the entity (YYYY-MM-DD)")
+	end := fs.String("end", "", "end date of the entity, such as the last day of an internship or the expiry of a visa (YYYY-MM-DD)")
+	entry := fs.String("entry", "", "first entry into the country on a visa or permit (YYYY-MM-DD)")
+	guardian := fs.Bool("guardian", false, "the entity is held jointly with a guardian, such as a minor's bank account")
+	juvenile := fs.Bool("juvenile", false, "the entity is handled as a juvenile record, such as a sealed offence of a minor")
+	category := fs.String("category", "", "age category of a competition entry, such as U18, 40+ or masters")
+	status := fs.String("status", "", "status of the entity: claimed, verified, expired or revoked")
+	precision := fs.String("precision", "", "how precisely -date is known: day, month, year or approximate")
+	uncertainty := fs.String("uncertainty", "", "radius around -date, such as 1y or 6m, a year for approximate dates by default")
+	now := fs.String("now", "", "reference date (YYYY-MM-DD), the current time by default")
+	rulesFile := fs.String("rules", "", "rules file written by rules init")
+	jurisdiction := fs.String("jurisdiction", "", "country code of the jurisdiction: "+strings.Join(userdate.Jurisdictions(), ", ")+", or a subdivision such as US-CA")
+	preset := fs.String("preset", "", "rule preset: "+strings.Join(userdate.Presets(), ", "))
+	locale := fs.String("locale", "", "locale of the messages, such as fr or de")
+	output := fs.String("output", "auto", "output: auto (text on a terminal, JSON otherwise), text or json")
+	color := fs.String("color", "auto", "colors in text output: auto, always or never")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate explain -birth 1990-05-15 -date 2005-01-01 -type license [flags]")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 || *birth == "" || *date == "" || *entityType == "" {
+		fs.Usage()
+		return 2
+	}
+	user := &userdate.User{ID: "-"}
+	entity := userdate.Entity{Type: *entityType, Status: userdate.EntityStatus(*status), Guardian: *guardian, Juvenile: *juvenile, Category: *category, DatePrecision: userdate.DatePrecision(*precision)}
+	if *uncertainty != "" {
+		u, err := userdate.ParseUncertainty(*uncertainty)
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: invalid -uncertainty: %v\n", err)
+			return 2
+		}
+		entity.Uncertainty = u
+	}
+	var opts []userdate.Option
+	for _, d := range []struct {
+		flag  string
+		value string
+		set   func(time.Time)
+	}{
+		{"birth", *birth, func(t time.Time) { user.BirthDate = t }},
+		{"date", *date, func(t time.Time) { entity.Date = t }},
+		{"death", *death, func(t time.Time) { user.DeathDate = t }},
+		{"renewed", *renewed, func(t time.Time) { entity.RenewedAt = t }},
+		{"end", *end, func(t time.Time) { entity.EndDate = t }},
+		{"entry", *entry, func(t time.Time) { entity.EntryDate = t }},
+		{"now", *now, func(t time.Time) { opts = append(opts, userdate.WithFixedNow(t)) }},
+	} {
+		if d.value == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", d.value)
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: invalid -%s: %v\n", d.flag, err)
+			return 2
+		}
+		d.set(t)
+	}
+
+	rules, err := ruleOptions(*rulesFile, *jurisdiction, *preset)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	opts = append(opts, rules...)
+	if *locale != "" {
+		opts = append(opts, userdate.WithLocale(*locale))
+	}
+	out, err := newPrinter(stdout, *output, *color, false)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 2
+	}
+
+	e := userdate.Explain(user, entity, opts...)
+	if err := out.explanation(entity, e, true); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	if !e.Report.Valid() {
+		return 1
+	}
+	return 0
+}
+
+// ruleOptions returns the options of the -rules, -jurisdiction and -preset
+// flags. Its errors start with "userdate: ".
+func ruleOptions(rulesFile, jurisdiction, preset string) ([]userdate.Option, error) {
+	switch {
+	case rulesFile != "":
+		if jurisdiction != "" || preset != "" {
+			return nil, errors.New("userdate: -rules cannot be used with -jurisdiction or -preset")
+		}
+		data, err := os.ReadFile(rulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("userdate: %w", err)
+		}
+		cfg, err := userdate.ParseRuleConfig(data)
+		if err != nil {
+			return nil, err
+		}
+		return []userdate.Option{userdate.WithRules(cfg)}, nil
+	case jurisdiction != "" || preset != "":
+		cfg, err := userdate.NewRuleConfig(jurisdiction, preset)
+		if err != nil {
+			return nil, err
+		}
+		return []userdate.Option{userdate.WithRules(cfg)}, nil
+	}
+	return nil, nil
+}
+
+// explanation writes an explanation: a header with the decision and the
+// age of the user, a table of the steps unless steps is false, and the
+// messages of the findings
+func (p *printer) explanation(entity userdate.Entity, e *userdate.Explanation, steps bool) error {
+	if !p.text {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(e)
+	}
+
+	var header string
+	switch e.Verdict() {
+	case userdate.VerdictFail:
+		header = p.paint("REJECTED", ansiBold, ansiRed)
+	case userdate.VerdictIndeterminate:
+		header = p.paint("UNDECIDED", ansiBold, ansiYellow)
+	default:
+		header = p.paint("ACCEPTED", ansiBold, ansiGreen)
+	}
+	header += fmt.Sprintf("  %s dated %s", entity.Type, entity.Date.Format("2006-01-02"))
+	if e.Age >= 0 {
+		header += fmt.Sprintf(", user aged %s", plural(e.Age, "year"))
+	}
+	fmt.Fprintln(p.w, header)
+
+	if steps {
+		fmt.Fprintln(p.w)
+		tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  RULE\tTHRESHOLD\tVALUE\tVERDICT")
+		for _, step := range e.Steps {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", step.Rule, dash(step.Threshold), dash(step.Value), p.verdict(step.Verdict))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	for _, step := range e.Steps {
+		for _, f := range step.Findings {
+			code := p.paint(f.Code, ansiBold, ansiYellow)
+			if f.Severity == userdate.SeverityError {
+				code = p.paint(f.Code, ansiBold, ansiRed)
+			}
+			if _, err := fmt.Fprintf(p.w, "\n%s  %s: %s\n", code, step.Rule, f.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// verdict returns a colored verdict. Verdicts are the last column of the
+// steps table, as tabwriter would count their escape sequences as text.
+func (p *printer) verdict(v userdate.Verdict) string {
+	switch v {
+	case userdate.VerdictPass:
+		return p.paint(string(v), ansiGreen)
+	case userdate.VerdictFail:
+		return p.paint(string(v), ansiRed)
+	case userdate.VerdictWarn, userdate.VerdictIndeterminate:
+		return p.paint(string(v), ansiYellow)
+	}
+	return p.paint(string(v), ansiGray)
+}
+
+// dash returns s, or "-" when it is empty
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func TestExplain(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	var rules bytes.Buffer
+	if status := run([]string{"rules", "init", "-jurisdiction", "FR"}, &rules, &rules); status != 0 {
+		t.Fatalf("rules init = %d: %s", status, rules.String())
+	}
+	if err := os.WriteFile(rulesFile, rules.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	base := []string{"explain", "--birth", "1990-05-15", "--type", "license", "--now", "2020-06-15", "-output", "text"}
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		want       []string
+	}{
+		{"too young", []string{"--date", "2005-01-01"}, 1, []string{
+			"REJECTED  license dated 2005-01-01, user aged 14 years\n",
+			"  minimum_age     at least 16 years                   14 years    fail\n",
+			"  history         -                                   -           skipped\n",
+			"\nUNREALISTIC_AGE  minimum_age: user was too young",
+		}},
+		{"old enough", []string{"--date", "2010-01-01"}, 0, []string{
+			"ACCEPTED  license dated 2010-01-01, user aged 19 years\n",
+			"  history ",
+		}},
+		{"rules file", []string{"--date", "2007-01-01", "--rules", rulesFile}, 1, []string{
+			"at least 17 years",
+		}},
+		{"jurisdiction", []string{"--date", "2007-01-01", "--jurisdiction", "FR"}, 1, []string{
+			"at least 17 years",
+		}},
+		{"locale", []string{"--date", "2005-01-01", "--locale", "fr"}, 1, []string{
+			"minimum_age: l'utilisateur était trop jeune (14 ans)",
+		}},
+		{"year precision", []string{"--date", "2006-01-01", "--precision", "year"}, 0, []string{
+			"UNDECIDED  license dated 2006-01-01",
+			"at least 16 years                   15-16 years             indeterminate\n",
+			"IMPRECISE_DATE  minimum_age: license date is only known to be between 2006-01-01 and 2006-12-31",
+		}},
+		{"uncertainty", []string{"--date", "2007-06-01", "--precision", "approximate", "--uncertainty", "6m"}, 0, []string{
+			"ACCEPTED  license dated 2007-06-01",
+			"2006-12-01..2007-12-01",
+		}},
+		{"invalid uncertainty", []string{"--date", "2007-06-01", "--uncertainty", "6w"}, 2, nil},
+		{"invalid date", []string{"--date", "2005-13-01"}, 2, nil},
+		{"missing date", nil, 2, nil},
+		{"rules and preset", []string{"--date", "2005-01-01", "--rules", rulesFile, "--preset", "strict"}, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(append(base[:len(base):len(base)], tt.args...), &stdout, &stderr); status != tt.wantStatus {
+				t.Fatalf("run() = %d, want %d (stderr: %s)", status, tt.wantStatus, stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestExplainJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"explain", "-birth", "1990-05-15", "-date", "2005-01-01", "-type", "license", "-now", "2020-06-15"}
+	if status := run(args, &stdout, &stderr); status != 1 {
+		t.Fatalf("run() = %d, want 1 (stderr: %s)", status, stderr.String())
+	}
+	var e userdate.Explanation
+	if err := json.Unmarshal(stdout.Bytes(), &e); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if e.Age != 14 || len(e.Steps) == 0 {
+		t.Errorf("explanation = age %d, %d steps, want age 14 and steps", e.Age, len(e.Steps))
+	}
+}
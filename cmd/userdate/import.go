@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// importColumns are the columns the CSV files of the import command need
+var importColumns = []string{"user_id", "birth_date", "entity_date", "entity_type"}
+
+// importItems runs the import command
+func importItems(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	pivot := fs.String("pivot", strconv.Itoa(userdate.DefaultTwoDigitYearPivot),
+		"two-digit years below the pivot are in the 2000s, the others in the 1900s; +N puts years up to N years after the current year in the 2000s")
+	now := fs.String("now", "", "reference date (YYYY-MM-DD) of a +N -pivot, the current time by default")
+	comma := fs.String("comma", ",", "field separator, such as ; for spreadsheets saved in European locales")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate import [flags] export.csv > items.jsonl")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	sep, size := utf8.DecodeRuneInString(*comma)
+	if size == 0 || size != len(*comma) {
+		fmt.Fprintf(stderr, "userdate: invalid -comma %q, want a single character\n", *comma)
+		return 2
+	}
+	opts, err := pivotOptions(*pivot, *now)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 2
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.Comma = sep
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: reading header: %v\n", err)
+		return 1
+	}
+	cols := make(map[string]int)
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importColumns {
+		if _, ok := cols[name]; !ok {
+			fmt.Fprintf(stderr, "userdate: missing column %q, want %s\n", name, strings.Join(importColumns, ", "))
+			return 1
+		}
+	}
+
+	enc := json.NewEncoder(stdout)
+	rows, failed, warned := 0, 0, 0
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		rows++
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: %v\n", err)
+			failed++
+			continue
+		}
+		line, _ := r.FieldPos(0)
+		item, warnings, err := importItem(record, cols, opts)
+		for _, w := range warnings {
+			fmt.Fprintf(stderr, "userdate: line %d: %s\n", line, w)
+		}
+		if len(warnings) > 0 {
+			warned++
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: line %d: %v\n", line, err)
+			failed++
+			continue
+		}
+		if err := enc.Encode(item); err != nil {
+			fmt.Fprintf(stderr, "userdate: %v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "userdate: imported %d of %s, %d with warnings\n", rows-failed, plural(rows, "row"), warned)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// importItem builds the item of a CSV record, parsing its dates with
+// userdate.ParseDateWarnings. The warnings are prefixed with their column.
+func importItem(record []string, cols map[string]int, opts []userdate.Option) (userdate.Item, []string, error) {
+	var dates [2]time.Time
+	var warnings []string
+	for i, column := range []string{"birth_date", "entity_date"} {
+		t, found, err := userdate.ParseDateWarnings(record[cols[column]], opts...)
+		for _, w := range found {
+			warnings = append(warnings, fmt.Sprintf("%s: warning [%s]: %s", column, w.Code, w.Message))
+		}
+		if err != nil {
+			return userdate.Item{}, warnings, fmt.Errorf("%s: %w", column, err)
+		}
+		dates[i] = t
+	}
+	return userdate.Item{
+		User:       &userdate.User{ID: record[cols["user_id"]], BirthDate: dates[0]},
+		EntityDate: dates[1],
+		EntityType: record[cols["entity_type"]],
+	}, warnings, nil
+}
+
+// pivotOptions returns the options of the -pivot and -now flags of import
+func pivotOptions(pivot, now string) ([]userdate.Option, error) {
+	var opts []userdate.Option
+	if now != "" {
+		t, err := parseDay(now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -now: %v", err)
+		}
+		opts = append(opts, userdate.WithFixedNow(t))
+	}
+	ahead, rolling := strings.CutPrefix(pivot, "+")
+	n, err := strconv.Atoi(ahead)
+	if err != nil || n < 0 || n > 100 {
+		return nil, fmt.Errorf("invalid -pivot %q, want a year such as 69 or +N", pivot)
+	}
+	if rolling {
+		return append(opts, userdate.WithRollingYearPivot(n)), nil
+	}
+	return append(opts, userdate.WithTwoDigitYearPivot(n)), nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	export := filepath.Join(dir, "export.csv")
+	csv := strings.Join([]string{
+		"User_ID;Birth_Date;Entity_Date;Entity_Type",
+		"u1;15-05-90;38719;license",
+		"u2;19850301;02.01.06;employment",
+		"u3;60;2006-01-02;license",
+		"u4;15-05-90;someday;license",
+		"u5;15-05-90",
+		"",
+	}, "\n")
+	if err := os.WriteFile(export, []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"import", "-comma", ";", export}, &stdout, &stderr)
+	if status != 1 {
+		t.Errorf("status = %d, want 1 for the rows that cannot be read", status)
+	}
+	wantItems := []string{
+		`{"user":{"id":"u1","birth_date":"1990-05-15T00:00:00Z"},"entity_date":"2006-01-02T00:00:00Z","entity_type":"license"}`,
+		`{"user":{"id":"u2","birth_date":"1985-03-01T00:00:00Z"},"entity_date":"2006-01-02T00:00:00Z","entity_type":"employment"}`,
+	}
+	if got := strings.Split(strings.TrimSpace(stdout.String()), "\n"); strings.Join(got, "\n") != strings.Join(wantItems, "\n") {
+		t.Errorf("stdout =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(wantItems, "\n"))
+	}
+	for _, want := range []string{
+		"line 4: birth_date: date validation error [INVALID_DATE]: Excel serial date 60 is February 29, 1900",
+		`line 5: entity_date: date validation error [INVALID_DATE]: "someday" is not a date in a known format`,
+		`line 2: birth_date: warning [TWO_DIGIT_YEAR]: "15-05-90" has a two-digit year, read as 1990 (two-digit years stand for 1969 to 2068)`,
+		`line 3: entity_date: warning [TWO_DIGIT_YEAR]: "02.01.06" has a two-digit year, read as 2006`,
+		"wrong number of fields",
+		"imported 2 of 5 rows, 3 with warnings",
+	} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr = %q, want %q", stderr.String(), want)
+		}
+	}
+}
+
+func TestImportRollingPivot(t *testing.T) {
+	export := filepath.Join(t.TempDir(), "export.csv")
+	if err := os.WriteFile(export, []byte("user_id,birth_date,entity_date,entity_type\nu1,01/02/28,01/02/26,license\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"import", "-pivot", "+1", "-now", "2026-10-18", export}, &stdout, &stderr); status != 0 {
+		t.Fatalf("status = %d: %s", status, stderr.String())
+	}
+	want := `{"user":{"id":"u1","birth_date":"1928-02-01T00:00:00Z"},"entity_date":"2026-02-01T00:00:00Z","entity_type":"license"}`
+	if got := strings.TrimSpace(stdout.String()); got != want {
+		t.Errorf("stdout = %s, want %s", got, want)
+	}
+	if !strings.Contains(stderr.String(), "two-digit years stand for 1928 to 2027") {
+		t.Errorf("stderr = %q, want the rolling pivot window", stderr.String())
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	dir := t.TempDir()
+	export := filepath.Join(dir, "export.csv")
+	if err := os.WriteFile(export, []byte("user_id,birth_date,entity_date\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		want       string
+	}{
+		{"missing column", []string{"import", export}, 1, `missing column "entity_type"`},
+		{"missing file", []string{"import", filepath.Join(dir, "missing.csv")}, 1, "no such file"},
+		{"invalid comma", []string{"import", "-comma", ";;", export}, 2, "invalid -comma"},
+		{"invalid pivot", []string{"import", "-pivot", "+x", export}, 2, "invalid -pivot"},
+		{"invalid now", []string{"import", "-pivot", "+1", "-now", "soon", export}, 2, "invalid -now"},
+		{"no file", []string{"import"}, 2, "usage: userdate import"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, &stdout, &stderr); status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
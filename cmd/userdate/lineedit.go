@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// errInterrupt is returned by readLine when the user presses Ctrl-C
+var errInterrupt = errors.New("interrupted")
+
+// lineEditor reads command lines. On a terminal it edits them in raw mode
+// with history on the up and down arrows and tab completion; elsewhere it
+// reads plain lines, so scripts can pipe commands in.
+type lineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	terminal *os.File // Terminal in raw mode while editing, nil for plain lines
+
+	history  []string
+	complete func(line string) []string // Completions of the last word of line
+}
+
+// newLineEditor returns an editor reading from in and echoing to out
+func newLineEditor(in io.Reader, out io.Writer, complete func(string) []string) *lineEditor {
+	e := &lineEditor{in: bufio.NewReader(in), out: out, complete: complete}
+	if f, ok := in.(*os.File); ok && isTerminal(f) && isTerminal(out) {
+		e.terminal = f
+	}
+	return e
+}
+
+// readLine reads a line after writing prompt. Non-blank lines are added to
+// the history. It returns io.EOF at the end of the input or on Ctrl-D on an
+// empty line, and errInterrupt on Ctrl-C.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	var line string
+	var err error
+	if e.terminal != nil {
+		line, err = e.editLine(prompt)
+	} else {
+		fmt.Fprint(e.out, prompt)
+		line, err = e.in.ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		line = strings.TrimRight(line, "\r\n")
+	}
+	if err == nil && strings.TrimSpace(line) != "" {
+		if n := len(e.history); n == 0 || e.history[n-1] != line {
+			e.history = append(e.history, line)
+		}
+	}
+	return line, err
+}
+
+// Keys of the raw mode editor
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyTab       = 9
+	keyEnter     = 13
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// editLine edits a line in raw mode
+func (e *lineEditor) editLine(prompt string) (string, error) {
+	restore, err := makeRaw(e.terminal)
+	if err != nil {
+		// Not a terminal after all: read a plain line
+		e.terminal = nil
+		return e.readLine(prompt)
+	}
+	defer restore()
+
+	var buf []rune
+	pos := len(e.history) // Position in the history, len(e.history) for the new line
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r\x1b[K%s%s", prompt, string(buf))
+	}
+	redraw()
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case keyEnter, '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(buf), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupt
+		case keyCtrlD:
+			if len(buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+		case keyBackspace, '\b':
+			if len(buf) > 0 {
+				buf = buf[:len(buf)-1]
+			}
+		case keyCtrlU:
+			buf = buf[:0]
+		case keyTab:
+			line, candidates := completeLine(string(buf), e.complete(string(buf)))
+			buf = []rune(line)
+			if len(candidates) > 1 {
+				fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+			}
+		case keyEscape:
+			// Arrow keys are sent as ESC [ A to ESC [ D
+			if b, _ := e.in.ReadByte(); b != '[' {
+				continue
+			}
+			switch b, _ := e.in.ReadByte(); b {
+			case 'A':
+				if pos > 0 {
+					pos--
+					buf = []rune(e.history[pos])
+				}
+			case 'B':
+				if pos < len(e.history) {
+					pos++
+					buf = buf[:0]
+					if pos < len(e.history) {
+						buf = []rune(e.history[pos])
+					}
+				}
+			}
+		default:
+			if r >= ' ' {
+				buf = append(buf, r)
+			}
+		}
+		redraw()
+	}
+}
+
+// completeLine completes the last word of line from the candidates that
+// start with it. A single candidate is completed and followed by a space;
+// several are completed up to their common prefix and returned so they can
+// be listed.
+func completeLine(line string, candidates []string) (string, []string) {
+	start := strings.LastIndexByte(line, ' ') + 1
+	word := line[start:]
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return line, nil
+	case 1:
+		return line[:start] + matches[0] + " ", nil
+	}
+	sort.Strings(matches)
+	prefix := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return line[:start] + prefix, matches
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompleteLine(t *testing.T) {
+	candidates := []string{"certification", "cpr_certification", "education", "employment"}
+	tests := []struct {
+		line       string
+		want       string
+		wantListed []string
+	}{
+		{"educ", "education ", nil},
+		{"explain e", "explain e", []string{"education", "employment"}},
+		{"explain em", "explain employment ", nil},
+		{"c", "c", []string{"certification", "cpr_certification"}},
+		{"ce", "certification ", nil},
+		{"x", "x", nil},
+		{"", "", candidates},
+	}
+	for _, tt := range tests {
+		got, listed := completeLine(tt.line, candidates)
+		if got != tt.want || !reflect.DeepEqual(listed, tt.wantListed) {
+			t.Errorf("completeLine(%q) = %q, %v, want %q, %v", tt.line, got, listed, tt.want, tt.wantListed)
+		}
+	}
+}
+
+func TestLineEditorHistory(t *testing.T) {
+	var out strings.Builder
+	e := newLineEditor(strings.NewReader("one\n\ntwo\r\ntwo\nthree"), &out, nil)
+	for {
+		if _, err := e.readLine("> "); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("readLine() error = %v", err)
+			}
+			break
+		}
+	}
+	if want := []string{"one", "two", "three"}; !reflect.DeepEqual(e.history, want) {
+		t.Errorf("history = %q, want %q", e.history, want)
+	}
+	if got := strings.Count(out.String(), "> "); got != 6 {
+		t.Errorf("wrote %d prompts, want 6", got)
+	}
+}
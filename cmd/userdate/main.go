@@ -0,0 +1,258 @@
+// Command userdate validates user entity dates from the command line.
+//
+// Usage:
+//
+//	userdate replay [-now 2006-01-02] [-rules rules.yaml] [-locale fr] [-rejects still-failing.jsonl] [-results prefix] [-output auto|text|json] [-quiet] rejects.jsonl
+//
+// replay validates again the items of a rejects file written by
+// userdate.FileRejectWriter and prints the batch result. -rules, or
+// -jurisdiction and -preset, choose the rules as for explain, and -locale
+// the language of the messages. It exits with
+// status 1 when some items still fail. With -results, the report of every
+// item is also streamed to rotated files, see userdate.ResultWriter.
+//
+// On a terminal the result is a colored PASS or FAIL summary followed by a
+// table of the findings of each error code; elsewhere it is JSON. -output
+// picks one explicitly, -color auto|always|never controls colors (NO_COLOR
+// turns them off), and -quiet prints the summary line only.
+//
+//	userdate rules init [-jurisdiction FR] [-preset strict] > rules.yaml
+//
+// rules init prints the effective rules of a jurisdiction and preset as a
+// commented YAML file, see userdate.RuleConfig.
+//
+//	userdate rules show [-layer org.yaml] [-layer tenant.yaml] [-key minimum_ages.license]
+//
+// rules show merges rules files as layers over the defaults, later layers
+// taking precedence, and prints every effective setting with the layer it
+// comes from, see userdate.RuleStack.
+//
+//	userdate rules lint [-strict] rules.yaml...
+//
+// rules lint checks rules files before they are deployed and prints their
+// issues, see userdate.ValidateConfig. It exits with status 1 when a file
+// cannot be read as rules or has errors, or warnings with -strict.
+//
+//	userdate explain -birth 1990-05-15 -date 2005-01-01 -type license [-now 2006-01-02] [-rules rules.yaml]
+//
+// explain validates one entity and prints the rule-by-rule evaluation: the
+// threshold of each rule, the computed age and values, and the verdicts, see
+// userdate.Explain. It exits with status 1 when the entity is rejected.
+//
+//	userdate repl [-birth 1990-05-15] [-now 2006-01-02] [-rules rules.yaml]
+//
+// repl reads commands interactively: set the user once with "user
+// 1990-05-15", then test entities with lines such as "license 2005-01-01".
+// On a terminal, the up and down arrows recall earlier lines and Tab
+// completes commands and entity types. "help" lists the commands.
+//
+//	userdate serve [-addr :8080] [-rules rules.yaml] [-metrics=false]
+//
+// serve runs the HTTP validation API of userdate.NewHandler, with /healthz,
+// /readyz and Prometheus /metrics. On SIGINT or SIGTERM, /readyz fails and
+// requests in flight get -shutdown-timeout to complete.
+//
+//	userdate compare -rules-a old.yaml -rules-b new.yaml [-now 2006-01-02] data.jsonl
+//
+// compare validates the items of a JSON Lines dataset under two rules files
+// and lists the items whose verdict differs, grouped by the rule that
+// rejects them, see userdate.Compare. It exits with status 1 when some
+// verdicts differ.
+//
+//	userdate import [-pivot 69|+N] [-comma ";"] export.csv > items.jsonl
+//
+// import converts a CSV file with the columns user_id, birth_date,
+// entity_date and entity_type, as saved from a spreadsheet, to the JSON
+// Lines items that compare and userdate.ReadItems read. Dates may be Excel
+// serial numbers, YYYYMMDD integers or day-first dates with two-digit
+// years, see userdate.ParseDate. -pivot +N reads two-digit years up to N
+// years after the current year as 2000s, and every date read from a
+// two-digit year is reported with the year chosen. Rows whose dates cannot
+// be read are reported and left out, and the exit status is then 1.
+//
+//	userdate calendar [-now 2006-01-02] [-notice 3] [-rules rules.yaml] [-locale fr] profile.json > milestones.ics
+//
+// calendar reads a userdate.Profile in JSON and writes the upcoming
+// milestones of its user as an iCalendar file: the birthdays that make the
+// user old enough for entity types, and the renewal due dates and expiries
+// of the entities, see userdate.WriteCalendar. -notice sets how many months
+// before an expiry its renewal is due.
+//
+//	userdate conformance [-update] v2/core/conformance/cases.jsonl [command [args...]]
+//
+// conformance checks an implementation of the rules against a conformance
+// dataset, see userdate.ConformanceCase. The command of a port, such as
+// "node port.js", reads the cases as JSON Lines on its standard input and
+// writes one userdate.ConformanceResult per line, with the name of the
+// case and the codes of its findings. Without a command, the Go reference
+// is checked. It exits with status 1 when some cases differ. -update
+// rewrites the expected codes with the ones of the Go reference.
+//
+// Every flag can also be set with an environment variable named after it:
+// USERDATE_RULES sets -rules and USERDATE_SHUTDOWN_TIMEOUT sets
+// -shutdown-timeout. Flags on the command line take precedence over the
+// environment, which takes precedence over the settings of the rules file,
+// which take precedence over the defaults. -rules, -jurisdiction and -preset
+// go together: when one is on the command line, the environment variables
+// of the others are ignored.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	switch args[0] {
+	case "replay":
+		return replay(args[1:], stdout, stderr)
+	case "rules":
+		return rules(args[1:], stdout, stderr)
+	case "explain":
+		return explain(args[1:], stdout, stderr)
+	case "repl":
+		return repl(args[1:], os.Stdin, stdout, stderr)
+	case "serve":
+		return serve(args[1:], stderr)
+	case "compare":
+		return compare(args[1:], stdout, stderr)
+	case "import":
+		return importItems(args[1:], stdout, stderr)
+	case "calendar":
+		return calendar(args[1:], stdout, stderr)
+	case "conformance":
+		return conformance(args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "userdate: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+}
+
+// usage prints the list of commands
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: userdate <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	fmt.Fprintln(w, "  replay      validate the items of a rejects file again")
+	fmt.Fprintln(w, "  rules init  print a rules file with the effective defaults")
+	fmt.Fprintln(w, "  rules show  print the settings of layered rules files and their sources")
+	fmt.Fprintln(w, "  rules lint  check rules files for contradictions and likely mistakes")
+	fmt.Fprintln(w, "  explain     show how each rule decides on one entity")
+	fmt.Fprintln(w, "  repl        test entities of one user interactively")
+	fmt.Fprintln(w, "  serve       run the HTTP validation API")
+	fmt.Fprintln(w, "  compare     find the items two rule sets decide differently")
+	fmt.Fprintln(w, "  import      convert a CSV export with legacy dates to JSON Lines items")
+	fmt.Fprintln(w, "  calendar    export the upcoming milestones of a profile as an iCalendar file")
+	fmt.Fprintln(w, "  conformance check a port of the rules against the conformance dataset")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags can also be set with USERDATE_ environment variables, such as")
+	fmt.Fprintln(w, "USERDATE_LOCALE=fr for -locale; flags on the command line take precedence.")
+}
+
+// replay runs the replay command
+func replay(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	now := fs.String("now", "", "reference date (YYYY-MM-DD), the current time by default")
+	rulesFile := fs.String("rules", "", "rules file written by rules init")
+	jurisdiction := fs.String("jurisdiction", "", "country code of the jurisdiction: "+strings.Join(userdate.Jurisdictions(), ", ")+", or a subdivision such as US-CA")
+	preset := fs.String("preset", "", "rule preset: "+strings.Join(userdate.Presets(), ", "))
+	locale := fs.String("locale", "", "locale of the messages, such as fr or de")
+	rejects := fs.String("rejects", "", "file to write the items that still fail")
+	results := fs.String("results", "", "file name prefix to stream the item reports to")
+	format := fs.String("format", "jsonl", "format of the -results files: jsonl or csv")
+	maxBytes := fs.Int64("max-bytes", 0, "start a new -results file after this many bytes")
+	maxAge := fs.Duration("max-age", 0, "start a new -results file after this duration")
+	gzipped := fs.Bool("gzip", false, "compress the -results files")
+	output := fs.String("output", "auto", "output: auto (text on a terminal, JSON otherwise), text or json")
+	color := fs.String("color", "auto", "colors in text output: auto, always or never")
+	quiet := fs.Bool("quiet", false, "print only the summary line")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate replay [flags] rejects.jsonl")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	opts, err := ruleOptions(*rulesFile, *jurisdiction, *preset)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *now != "" {
+		t, err := time.Parse("2006-01-02", *now)
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: invalid -now: %v\n", err)
+			return 2
+		}
+		opts = append(opts, userdate.WithFixedNow(t))
+	}
+	if *locale != "" {
+		opts = append(opts, userdate.WithLocale(*locale))
+	}
+	if *rejects != "" {
+		opts = append(opts, userdate.WithRejectWriter(userdate.NewFileRejectWriter(*rejects)))
+	}
+	resultFormat, err := userdate.ParseResultFormat(*format)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: invalid -format: %v\n", err)
+		return 2
+	}
+	out, err := newPrinter(stdout, *output, *color, *quiet)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 2
+	}
+
+	result, err := userdate.Replay(fs.Arg(0), opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	if result.RejectErr != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", result.RejectErr)
+	}
+	if *results != "" {
+		w := userdate.NewResultWriter(*results, userdate.ResultWriterConfig{
+			Format:   resultFormat,
+			MaxBytes: *maxBytes,
+			MaxAge:   *maxAge,
+			Gzip:     *gzipped,
+		})
+		if err := errors.Join(w.WriteBatch(result), w.Close()); err != nil {
+			fmt.Fprintf(stderr, "userdate: writing results: %v\n", err)
+			return 1
+		}
+	}
+
+	if err := out.print(result); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	if len(result.Failed()) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rejects.jsonl")
+	birth, _ := time.Parse("2006-01-02", "1990-01-01")
+	issued, _ := time.Parse("2006-01-02", "2021-01-01")
+	then, _ := time.Parse("2006-01-02", "2020-06-15")
+	items := []userdate.Item{{User: &userdate.User{ID: "user123", BirthDate: birth}, EntityDate: issued, EntityType: "training"}}
+	userdate.ValidateBatch(items, userdate.WithFixedNow(then), userdate.WithRejectWriter(userdate.NewFileRejectWriter(path)))
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		wantOut    string
+	}{
+		{"still failing", []string{"replay", "-now", "2020-07-01", path}, 1, `"failed": 1`},
+		{"fixed", []string{"replay", "-now", "2021-06-15", path}, 0, `"failed": 0`},
+		{"missing file argument", []string{"replay"}, 2, ""},
+		{"invalid now", []string{"replay", "-now", "soon", path}, 2, ""},
+		{"invalid format", []string{"replay", "-format", "xml", path}, 2, ""},
+		{"unknown command", []string{"frobnicate"}, 2, ""},
+		{"no command", nil, 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, &stdout, &stderr); status != tt.wantStatus {
+				t.Errorf("run() = %d, want %d (stderr: %s)", status, tt.wantStatus, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("output %s does not contain %s", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestReplayRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rejects.jsonl")
+	birth, _ := time.Parse("2006-01-02", "2010-01-01")
+	issued, _ := time.Parse("2006-01-02", "2016-06-01") // Aged 6
+	items := []userdate.Item{{User: &userdate.User{ID: "user123", BirthDate: birth}, EntityDate: issued, EntityType: "training"}}
+	userdate.ValidateBatch(items, userdate.WithMinimumAge("training", 10), userdate.WithRejectWriter(userdate.NewFileRejectWriter(path)))
+
+	cfg := userdate.EffectiveRules(userdate.WithMinimumAge("training", 10))
+	var yaml bytes.Buffer
+	if err := cfg.WriteYAML(&yaml); err != nil {
+		t.Fatal(err)
+	}
+	rulesFile := filepath.Join(dir, "rules.yaml")
+	if err := os.WriteFile(rulesFile, yaml.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		env        string
+		wantStatus int
+		wantOut    string
+	}{
+		{"default rules", []string{"replay", path}, "", 0, `"failed": 0`},
+		{"rules file", []string{"replay", "-rules", rulesFile, path}, "", 1, `"failed": 1`},
+		{"rules file from the environment", []string{"replay", path}, rulesFile, 1, `"failed": 1`},
+		{"locale", []string{"replay", "-rules", rulesFile, "-locale", "de", path}, "", 1, "01.06.2016"},
+		{"jurisdiction overriding the environment", []string{"replay", "-jurisdiction", "FR", path}, rulesFile, 0, `"failed": 0`},
+		{"rules with a jurisdiction", []string{"replay", "-rules", rulesFile, "-jurisdiction", "FR", path}, "", 2, ""},
+		{"unknown jurisdiction", []string{"replay", "-jurisdiction", "XX", path}, "", 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("USERDATE_RULES", tt.env)
+			}
+			var stdout, stderr bytes.Buffer
+			if status := run(append(tt.args[:1:1], append([]string{"-output", "json"}, tt.args[1:]...)...), &stdout, &stderr); status != tt.wantStatus {
+				t.Errorf("run() = %d, want %d (stderr: %s)", status, tt.wantStatus, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("output %s does not contain %s", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestReplayResults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rejects.jsonl")
+	birth, _ := time.Parse("2006-01-02", "1990-01-01")
+	issued, _ := time.Parse("2006-01-02", "1989-01-01")
+	user := &userdate.User{ID: "user123", BirthDate: birth}
+	items := []userdate.Item{
+		{User: user, EntityDate: issued, EntityType: "training"},
+		{User: user, EntityDate: issued, EntityType: "education"},
+	}
+	userdate.ValidateBatch(items, userdate.WithRejectWriter(userdate.NewFileRejectWriter(path)))
+
+	prefix := filepath.Join(dir, "results")
+	var stdout, stderr bytes.Buffer
+	args := []string{"replay", "-results", prefix, "-format", "csv", "-gzip", "-max-bytes", "1", path}
+	if status := run(args, &stdout, &stderr); status != 1 {
+		t.Fatalf("run() = %d, want 1 (stderr: %s)", status, stderr.String())
+	}
+	files, _ := filepath.Glob(prefix + "-*.csv.gz")
+	if len(files) != 2 {
+		t.Errorf("result files = %v, want 2", files)
+	}
+}
+
+func TestRulesInit(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		wantOut    string
+	}{
+		{"defaults", []string{"rules", "init"}, 0, "preset: default\n"},
+		{"jurisdiction and preset", []string{"rules", "init", "--jurisdiction", "FR", "--preset", "strict"}, 0, "  license: 17\n"},
+		{"unknown jurisdiction", []string{"rules", "init", "-jurisdiction", "XX"}, 2, ""},
+		{"unknown preset", []string{"rules", "init", "-preset", "lax"}, 2, ""},
+		{"no subcommand", []string{"rules"}, 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tt.args, &stdout, &stderr); status != tt.wantStatus {
+				t.Fatalf("run() = %d, want %d (stderr: %s)", status, tt.wantStatus, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("output %s does not contain %q", stdout.String(), tt.wantOut)
+			}
+			if tt.wantStatus == 0 {
+				if _, err := userdate.ParseRuleConfig(stdout.Bytes()); err != nil {
+					t.Errorf("ParseRuleConfig() error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// ANSI escape sequences of the colored output
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiGray   = "\x1b[90m"
+)
+
+// printer writes batch results as JSON or as human-friendly text
+type printer struct {
+	w     io.Writer
+	text  bool // Text instead of JSON
+	color bool // ANSI colors in text
+	quiet bool // Only the summary line
+}
+
+// newPrinter returns the printer for the -output, -color and -quiet flags.
+// Auto output is text on a terminal and JSON otherwise, so pipes and
+// scripts keep getting JSON. Auto color is on for terminals unless NO_COLOR
+// is set.
+func newPrinter(w io.Writer, output, color string, quiet bool) (*printer, error) {
+	tty := isTerminal(w)
+	p := &printer{w: w, quiet: quiet}
+	switch output {
+	case "auto":
+		p.text = tty
+	case "text":
+		p.text = true
+	case "json":
+	default:
+		return nil, fmt.Errorf("unknown output %q, want auto, text or json", output)
+	}
+	switch color {
+	case "auto":
+		p.color = tty && os.Getenv("NO_COLOR") == ""
+	case "always":
+		p.color = true
+	case "never":
+	default:
+		return nil, fmt.Errorf("unknown color mode %q, want auto, always or never", color)
+	}
+	return p, nil
+}
+
+// isTerminal reports whether w is a character device such as a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// paint wraps s in the given escape sequences when colors are on
+func (p *printer) paint(s string, codes ...string) string {
+	if !p.color {
+		return s
+	}
+	return strings.Join(codes, "") + s + ansiReset
+}
+
+// print writes a batch result. Quiet printers only write the summary line,
+// whatever the output.
+func (p *printer) print(result *userdate.BatchResult) error {
+	if p.quiet {
+		return p.summary(result)
+	}
+	if !p.text {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	if err := p.summary(result); err != nil {
+		return err
+	}
+	return p.findings(result)
+}
+
+// summary writes a line such as "FAIL  2 of 10 items fail, 3 warnings"
+func (p *printer) summary(result *userdate.BatchResult) error {
+	total, failed := len(result.Reports), len(result.Failed())
+	warnings := 0
+	for _, report := range result.Reports {
+		warnings += len(report.Warnings())
+	}
+
+	var line string
+	if failed > 0 {
+		line = p.paint("FAIL", ansiBold, ansiRed) + fmt.Sprintf("  %d of %s fail", failed, plural(total, "item"))
+	} else {
+		line = p.paint("PASS", ansiBold, ansiGreen) + fmt.Sprintf("  %s pass", plural(total, "item"))
+	}
+	if warnings > 0 {
+		line += ", " + p.paint(plural(warnings, "warning"), ansiYellow)
+	}
+	_, err := fmt.Fprintln(p.w, line)
+	return err
+}
+
+// finding is a finding with the item it was reported for
+type finding struct {
+	index int
+	item  userdate.Item
+	*userdate.DateValidationError
+}
+
+// findings writes a table of the findings of each code, errors first and
+// then by decreasing count
+func (p *printer) findings(result *userdate.BatchResult) error {
+	groups := make(map[string][]finding)
+	for i, report := range result.Reports {
+		for _, f := range report.Findings {
+			groups[f.Code] = append(groups[f.Code], finding{i, result.Items[i], f})
+		}
+	}
+	codes := make([]string, 0, len(groups))
+	for code := range groups {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		a, b := groups[codes[i]], groups[codes[j]]
+		if ea, eb := isError(a), isError(b); ea != eb {
+			return ea
+		}
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return codes[i] < codes[j]
+	})
+
+	for _, code := range codes {
+		group := groups[code]
+		heading := p.paint(code, ansiBold, ansiYellow)
+		if isError(group) {
+			heading = p.paint(code, ansiBold, ansiRed)
+		}
+		fmt.Fprintf(p.w, "\n%s  %s\n", heading, plural(len(group), "finding"))
+
+		tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  ITEM\tUSER\tTYPE\tDATE\tMESSAGE")
+		for _, f := range group {
+			userID := "-"
+			if f.item.User != nil {
+				userID = f.item.User.ID
+			}
+			date := "-"
+			if !f.item.EntityDate.IsZero() {
+				date = f.item.EntityDate.Format("2006-01-02")
+			}
+			fmt.Fprintf(tw, "  %d\t%s\t%s\t%s\t%s\n", f.index, userID, f.item.EntityType, date, f.Message)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isError reports whether the findings of a group are errors
+func isError(group []finding) bool {
+	for _, f := range group {
+		if f.Severity == userdate.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// plural writes n followed by noun, with an s unless n is 1
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
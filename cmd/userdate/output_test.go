@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// writeRejects writes a rejects file with two items failing BEFORE_BIRTH
+// and one failing FUTURE_DATE, and returns its path
+func writeRejects(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rejects.jsonl")
+	birth, _ := time.Parse("2006-01-02", "1990-01-01")
+	early, _ := time.Parse("2006-01-02", "1989-01-01")
+	late, _ := time.Parse("2006-01-02", "2030-01-01")
+	user := &userdate.User{ID: "user123", BirthDate: birth}
+	items := []userdate.Item{
+		{User: user, EntityDate: early, EntityType: "training"},
+		{User: user, EntityDate: late, EntityType: "certification"},
+		{User: user, EntityDate: early, EntityType: "education"},
+	}
+	userdate.ValidateBatch(items, userdate.WithRejectWriter(userdate.NewFileRejectWriter(path)))
+	return path
+}
+
+func TestReplayOutput(t *testing.T) {
+	path := writeRejects(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		notWant []string
+	}{
+		{"json by default when not a terminal", nil,
+			[]string{`"failed": 3`}, []string{"FAIL"}},
+		{"text", []string{"-output", "text"},
+			[]string{
+				"FAIL  3 of 3 items fail\n",
+				"\nBEFORE_BIRTH  2 findings\n",
+				"  ITEM  USER     TYPE       DATE        MESSAGE\n",
+				"  0     user123  training   1989-01-01  training date (1989-01-01) cannot be before",
+				"  2     user123  education  1989-01-01  education date",
+				"\nFUTURE_DATE  1 finding\n",
+				"  1     user123  certification  2030-01-01  certification date (2030-01-01) cannot be in the future\n",
+			},
+			[]string{"\x1b[", `"failed"`}},
+		{"colors", []string{"-output", "text", "-color", "always"},
+			[]string{"\x1b[1m\x1b[31mFAIL\x1b[0m", "\x1b[1m\x1b[31mBEFORE_BIRTH\x1b[0m"}, nil},
+		{"quiet", []string{"-quiet"},
+			[]string{"FAIL  3 of 3 items fail\n"}, []string{"BEFORE_BIRTH", `"failed"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string{"replay", "-now", "2025-07-18"}, tt.args...), path)
+			if status := run(args, &stdout, &stderr); status != 1 {
+				t.Fatalf("run() = %d, want 1 (stderr: %s)", status, stderr.String())
+			}
+			out := stdout.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out, notWant) {
+					t.Errorf("output contains %q:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+}
+
+func TestReplayOutputFlags(t *testing.T) {
+	path := writeRejects(t)
+	for _, args := range [][]string{
+		{"replay", "-output", "yaml", path},
+		{"replay", "-color", "sometimes", path},
+	} {
+		var stdout, stderr bytes.Buffer
+		if status := run(args, &stdout, &stderr); status != 2 {
+			t.Errorf("run(%v) = %d, want 2", args, status)
+		}
+	}
+}
+
+func TestSummaryPass(t *testing.T) {
+	var buf bytes.Buffer
+	p := &printer{w: &buf, text: true}
+	result := &userdate.BatchResult{Reports: []*userdate.Report{{}}}
+	if err := p.print(result); err != nil {
+		t.Fatalf("print() error = %v", err)
+	}
+	if got := buf.String(); got != "PASS  1 item pass\n" {
+		t.Errorf("output = %q, want the PASS summary only", got)
+	}
+}
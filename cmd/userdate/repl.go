@@ -0,0 +1,289 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// replHelp lists the commands of the REPL
+const replHelp = `commands:
+  user BIRTH [DEATH]       set the user tested, such as user 1990-05-15
+  TYPE DATE [STATUS]       check an entity, such as license 2005-01-01
+  explain TYPE DATE        check an entity and show every rule
+  now DATE|off             pin the current date, or use the clock again
+  min-age TYPE YEARS       change the minimum age of a type, 0 removes it
+  locale LOCALE|off        write messages in a locale, such as fr
+  show                     print the user and the settings
+  types                    list the known entity types
+  history                  list the commands entered
+  help                     print this help
+  quit                     leave (Ctrl-D also works)
+`
+
+// replCommands are the command names completed in first position
+var replCommands = []string{"user", "explain", "now", "min-age", "locale", "show", "types", "history", "help", "quit"}
+
+// session is the state of a REPL: the user and settings that apply to
+// every entity tested
+type session struct {
+	out    *printer
+	editor *lineEditor
+
+	user    *userdate.User
+	now     time.Time // Zero for the clock
+	locale  string
+	rules   []userdate.Option // Options of the command flags
+	minAges map[string]int    // min-age changes, 0 for removed minimums
+}
+
+// repl runs the repl command
+func repl(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("repl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	birth := fs.String("birth", "", "birth date of the user (YYYY-MM-DD), also set with the user command")
+	now := fs.String("now", "", "reference date (YYYY-MM-DD), the current time by default")
+	rulesFile := fs.String("rules", "", "rules file written by rules init")
+	jurisdiction := fs.String("jurisdiction", "", "country code of the jurisdiction: "+strings.Join(userdate.Jurisdictions(), ", ")+", or a subdivision such as US-CA")
+	preset := fs.String("preset", "", "rule preset: "+strings.Join(userdate.Presets(), ", "))
+	locale := fs.String("locale", "", "locale of the messages, such as fr or de")
+	color := fs.String("color", "auto", "colors: auto, always or never")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate repl [flags]")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	rules, err := ruleOptions(*rulesFile, *jurisdiction, *preset)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	out, err := newPrinter(stdout, "text", *color, false)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 2
+	}
+	s := &session{out: out, locale: *locale, rules: rules, minAges: make(map[string]int)}
+	for _, cmd := range [][]string{{"user", *birth}, {"now", *now}} {
+		if cmd[1] == "" {
+			continue
+		}
+		if err := s.exec(cmd); err != nil {
+			fmt.Fprintf(stderr, "userdate: -%s: %v\n", cmd[0], err)
+			return 2
+		}
+	}
+	s.editor = newLineEditor(stdin, stdout, s.complete)
+
+	fmt.Fprintln(stdout, `userdate REPL, "help" lists the commands`)
+	for {
+		line, err := s.editor.readLine("> ")
+		if errors.Is(err, errInterrupt) {
+			continue
+		}
+		if err != nil {
+			return 0
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "quit" || fields[0] == "exit" {
+			return 0
+		}
+		if err := s.exec(fields); err != nil {
+			fmt.Fprintf(stdout, "error: %v\n", err)
+		}
+	}
+}
+
+// exec runs a command of the REPL
+func (s *session) exec(fields []string) error {
+	switch cmd, args := fields[0], fields[1:]; cmd {
+	case "help":
+		fmt.Fprint(s.out.w, replHelp)
+	case "user":
+		if len(args) < 1 || len(args) > 2 {
+			return errors.New("usage: user BIRTH [DEATH]")
+		}
+		user := &userdate.User{ID: "repl"}
+		var err error
+		if user.BirthDate, err = parseDay(args[0]); err != nil {
+			return err
+		}
+		if len(args) == 2 {
+			if user.DeathDate, err = parseDay(args[1]); err != nil {
+				return err
+			}
+		}
+		if err := user.Validate(s.options()...); err != nil {
+			return err
+		}
+		s.user = user
+	case "now":
+		if len(args) != 1 {
+			return errors.New("usage: now DATE|off")
+		}
+		if args[0] == "off" {
+			s.now = time.Time{}
+			return nil
+		}
+		t, err := parseDay(args[0])
+		if err != nil {
+			return err
+		}
+		s.now = t
+	case "min-age":
+		if len(args) != 2 {
+			return errors.New("usage: min-age TYPE YEARS")
+		}
+		age, err := strconv.Atoi(args[1])
+		if err != nil || age < 0 {
+			return fmt.Errorf("invalid age %q", args[1])
+		}
+		s.minAges[args[0]] = age
+	case "locale":
+		if len(args) != 1 {
+			return errors.New("usage: locale LOCALE|off")
+		}
+		s.locale = args[0]
+		if s.locale == "off" {
+			s.locale = ""
+		}
+	case "show":
+		s.show()
+	case "types":
+		fmt.Fprintln(s.out.w, strings.Join(s.types(), "\n"))
+	case "history":
+		for i, line := range s.editor.history {
+			fmt.Fprintf(s.out.w, "%4d  %s\n", i+1, line)
+		}
+	case "explain":
+		if len(args) != 2 {
+			return errors.New("usage: explain TYPE DATE")
+		}
+		return s.check(args, true)
+	default:
+		if len(fields) < 2 || len(fields) > 3 {
+			return fmt.Errorf("unknown command %q, \"help\" lists the commands", cmd)
+		}
+		return s.check(fields, false)
+	}
+	return nil
+}
+
+// check validates the entity of the arguments TYPE DATE [STATUS] and prints
+// the decision, with the rule steps when steps is true
+func (s *session) check(args []string, steps bool) error {
+	if s.user == nil {
+		return errors.New("no user, set one with user BIRTH")
+	}
+	date, err := parseDay(args[1])
+	if err != nil {
+		return err
+	}
+	entity := userdate.Entity{Type: args[0], Date: date}
+	if len(args) == 3 {
+		entity.Status = userdate.EntityStatus(args[2])
+	}
+	return s.out.explanation(entity, userdate.Explain(s.user, entity, s.options()...), steps)
+}
+
+// options returns the validation options of the session
+func (s *session) options() []userdate.Option {
+	opts := slices.Clone(s.rules)
+	if !s.now.IsZero() {
+		opts = append(opts, userdate.WithFixedNow(s.now))
+	}
+	if s.locale != "" {
+		opts = append(opts, userdate.WithLocale(s.locale))
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(s.minAges)) {
+		opts = append(opts, userdate.WithMinimumAge(entityType, s.minAges[entityType]))
+	}
+	return opts
+}
+
+// show prints the user and the settings of the session
+func (s *session) show() {
+	user := "none"
+	if s.user != nil {
+		user = "born " + s.user.BirthDate.Format("2006-01-02")
+		if !s.user.DeathDate.IsZero() {
+			user += ", died " + s.user.DeathDate.Format("2006-01-02")
+		}
+	}
+	now := "clock"
+	if !s.now.IsZero() {
+		now = s.now.Format("2006-01-02")
+	}
+	fmt.Fprintf(s.out.w, "user     %s\nnow      %s\nlocale   %s\n", user, now, dash(s.locale))
+	rules := userdate.EffectiveRules(s.options()...)
+	for _, entityType := range slices.Sorted(maps.Keys(rules.MinimumAges)) {
+		fmt.Fprintf(s.out.w, "min-age  %s %d\n", entityType, rules.MinimumAges[entityType])
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(rules.MaximumAges)) {
+		fmt.Fprintf(s.out.w, "max-age  %s %d\n", entityType, rules.MaximumAges[entityType])
+	}
+}
+
+// types returns the entity types that have rules, sorted
+func (s *session) types() []string {
+	rules := userdate.EffectiveRules(s.options()...)
+	types := make(map[string]bool)
+	for _, m := range []map[string]int{rules.MinimumAges, rules.MaximumAges, s.minAges} {
+		for entityType := range m {
+			types[entityType] = true
+		}
+	}
+	for entityType := range rules.ValidityPeriods {
+		types[entityType] = true
+	}
+	for entityType := range rules.PrenatalWindows {
+		types[entityType] = true
+	}
+	for entityType := range rules.SameDayBirthTypes {
+		types[entityType] = true
+	}
+	return slices.Sorted(maps.Keys(types))
+}
+
+// complete returns the completions of the last word of line: commands and
+// entity types first, entity types after the commands taking one
+func (s *session) complete(line string) []string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || len(fields) == 1 && !strings.HasSuffix(line, " ") {
+		return append(slices.Clone(replCommands), s.types()...)
+	}
+	if len(fields) == 1 || len(fields) == 2 && !strings.HasSuffix(line, " ") {
+		switch fields[0] {
+		case "explain", "min-age":
+			return s.types()
+		}
+	}
+	return nil
+}
+
+// parseDay parses a YYYY-MM-DD date
+func parseDay(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, want YYYY-MM-DD", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestREPL(t *testing.T) {
+	input := strings.Join([]string{
+		"license 2005-01-01",
+		"user 1990-05-15",
+		"license 2005-01-01",
+		"explain license 2007-01-01",
+		"min-age license 18",
+		"license 2007-01-01",
+		"locale fr",
+		"license 2005-01-01",
+		"user 1990-13-01",
+		"frobnicate",
+		"history",
+		"quit",
+		"license 2005-01-01",
+	}, "\n")
+	var stdout, stderr bytes.Buffer
+	if status := repl([]string{"-now", "2020-06-15"}, strings.NewReader(input), &stdout, &stderr); status != 0 {
+		t.Fatalf("repl() = %d, want 0 (stderr: %s)", status, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"error: no user, set one with user BIRTH\n",
+		"REJECTED  license dated 2005-01-01, user aged 14 years\n",
+		"ACCEPTED  license dated 2007-01-01, user aged 16 years\n\n  RULE ",
+		"  minimum_age     at least 16 years                   16 years    pass\n",
+		"REJECTED  license dated 2007-01-01, user aged 16 years\n",
+		"(minimum age: 18)",
+		"l'utilisateur était trop jeune (14 ans)",
+		`error: invalid date "1990-13-01", want YYYY-MM-DD`,
+		`error: unknown command "frobnicate"`,
+		"   6  license 2007-01-01\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "license dated"); n != 4 {
+		t.Errorf("got %d decisions, want 4 as input stops at quit", n)
+	}
+}
+
+func TestREPLFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"-birth", "1990-02-30"},
+		{"-rules", "rules.yaml", "-preset", "strict"},
+		{"-color", "sometimes"},
+		{"extra"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if status := repl(args, strings.NewReader(""), &stdout, &stderr); status != 2 {
+			t.Errorf("repl(%v) = %d, want 2", args, status)
+		}
+	}
+}
+
+func TestREPLComplete(t *testing.T) {
+	s := &session{minAges: map[string]int{}}
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"lic", "license "},
+		{"expl", "explain "},
+		{"explain forkl", "explain forklift_license "},
+		{"min-age cpr", "min-age cpr_certification "},
+		{"explain c", "explain c"},
+		{"license 2005", "license 2005"},
+	}
+	for _, tt := range tests {
+		if got, _ := completeLine(tt.line, s.complete(tt.line)); got != tt.want {
+			t.Errorf("completion of %q = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// rules runs the rules command
+func rules(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "init":
+			return rulesInit(args[1:], stdout, stderr)
+		case "show":
+			return rulesShow(args[1:], stdout, stderr)
+		case "lint":
+			return rulesLint(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintln(stderr, "usage: userdate rules init|show|lint [flags]")
+	return 2
+}
+
+// rulesInit runs the rules init command
+func rulesInit(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("rules init", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	jurisdiction := fs.String("jurisdiction", "", "country code of the jurisdiction: "+strings.Join(userdate.Jurisdictions(), ", ")+", or a subdivision such as US-CA")
+	preset := fs.String("preset", "default", "rule preset: "+strings.Join(userdate.Presets(), ", "))
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate rules init [flags] > rules.yaml")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := userdate.NewRuleConfig(*jurisdiction, *preset)
+	if err != nil {
+		fmt.Fprintf(stderr, "%v\n", err)
+		return 2
+	}
+	if err := cfg.WriteYAML(stdout); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// layerFiles is the repeated -layer flag
+type layerFiles []string
+
+func (l *layerFiles) String() string { return strings.Join(*l, ",") }
+
+func (l *layerFiles) Set(path string) error {
+	*l = append(*l, path)
+	return nil
+}
+
+// rulesShow runs the rules show command
+func rulesShow(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("rules show", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var layers layerFiles
+	fs.Var(&layers, "layer", "rules file of a layer, repeated from the lowest to the highest precedence")
+	key := fs.String("key", "", "print only this setting, such as minimum_ages.license")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate rules show [-layer org.yaml] [-layer tenant.yaml] [-key setting]")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var stack []userdate.RuleLayer
+	for _, path := range layers {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: %v\n", err)
+			return 2
+		}
+		layer, err := userdate.ParseRuleLayer(filepath.Base(path), data)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", path, err)
+			return 2
+		}
+		for _, warning := range layer.Warnings {
+			fmt.Fprintf(stderr, "%s: %s\n", path, warning)
+		}
+		stack = append(stack, layer)
+	}
+
+	settings := userdate.NewRuleStack(stack...).Settings()
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SETTING\tVALUE\tSOURCE")
+	found := false
+	for _, s := range settings {
+		if *key != "" && s.Key != *key {
+			continue
+		}
+		found = true
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Key, dash(s.Value), s.Source)
+	}
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	if !found {
+		fmt.Fprintf(stderr, "userdate: no setting %q\n", *key)
+		return 1
+	}
+	return 0
+}
+
+// rulesLint runs the rules lint command
+func rulesLint(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("rules lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	strict := fs.Bool("strict", false, "fail on warnings as well as errors")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate rules lint [-strict] rules.yaml...")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	status := 0
+	for _, path := range fs.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "userdate: %v\n", err)
+			return 2
+		}
+		cfg, warnings, err := userdate.ParseRuleConfigWarnings(data)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", path, err)
+			status = 1
+			continue
+		}
+		issues := append(userdate.ValidateConfig(cfg), warnings...)
+		for _, issue := range issues {
+			fmt.Fprintf(stdout, "%s: %s\n", path, issue)
+			if issue.Severity == userdate.SeverityError || *strict {
+				status = 1
+			}
+		}
+		if len(issues) == 0 {
+			fmt.Fprintf(stdout, "%s: ok\n", path)
+		}
+	}
+	return status
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRulesShow(t *testing.T) {
+	dir := t.TempDir()
+	org := filepath.Join(dir, "org.yaml")
+	tenant := filepath.Join(dir, "tenant.yaml")
+	bad := filepath.Join(dir, "bad.yaml")
+	for path, data := range map[string]string{
+		org:    "minimum_ages:\n  license: 17\n  employment: 14\n",
+		tenant: "minimum_ages:\n  employment: 15\n",
+		bad:    "min_age: 15\n",
+	} {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		want       []string
+	}{
+		{"layers", []string{"-layer", org, "-layer", tenant}, 0, []string{
+			"minimum_ages.license 17 org.yaml\n",
+			"minimum_ages.employment 15 tenant.yaml\n",
+			"comparison instant default\n",
+		}},
+		{"key", []string{"-layer", org, "-layer", tenant, "-key", "minimum_ages.employment"}, 0, []string{
+			"SETTING VALUE SOURCE\nminimum_ages.employment 15 tenant.yaml\n",
+		}},
+		{"defaults", []string{"-key", "minimum_ages.license"}, 0, []string{"minimum_ages.license 16 default\n"}},
+		{"unknown key", []string{"-key", "min_age"}, 1, nil},
+		{"invalid layer", []string{"-layer", bad}, 2, nil},
+		{"missing layer", []string{"-layer", filepath.Join(dir, "missing.yaml")}, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(append([]string{"rules", "show"}, tt.args...), &stdout, &stderr); status != tt.wantStatus {
+				t.Fatalf("run() = %d, want %d (stderr: %s)", status, tt.wantStatus, stderr.String())
+			}
+			// Compare with the columns separated by single spaces
+			var out strings.Builder
+			for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+				out.WriteString(strings.Join(strings.Fields(line), " ") + "\n")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRulesLint(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"ok.yaml":      "minimum_ages:\n  license: 17\n",
+		"typo.yaml":    "minimum_ages:\n  licence: 17\n",
+		"bad.yaml":     "jurisdiction: XX\nminimum_ages:\n  license: 200\n",
+		"invalid.yaml": "min_age: 15\n",
+		"old.yaml":     "precision: date\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		want       []string
+	}{
+		{"ok", []string{"ok.yaml"}, 0, []string{"ok.yaml: ok\n"}},
+		{"warning", []string{"typo.yaml"}, 0, []string{
+			"typo.yaml: minimum_ages.licence: warning: entity type \"licence\" has no built-in rules, check its spelling\n",
+		}},
+		{"strict", []string{"-strict", "typo.yaml"}, 1, nil},
+		{"errors", []string{"ok.yaml", "bad.yaml"}, 1, []string{
+			"ok.yaml: ok\n",
+			"bad.yaml: jurisdiction: error: unknown jurisdiction \"XX\"",
+			"bad.yaml: minimum_ages.license: error: minimum age 200 is above the maximum age of 150\n",
+		}},
+		{"unreadable rules", []string{"invalid.yaml"}, 1, []string{"invalid.yaml: userdate: rules line 1: min_age: unknown setting\n"}},
+		{"renamed setting", []string{"old.yaml"}, 0, []string{
+			"old.yaml: precision: warning: line 1: renamed to comparison in schema version 2\n",
+		}},
+		{"strict renamed setting", []string{"-strict", "old.yaml"}, 1, nil},
+		{"no files", nil, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := []string{"rules", "lint"}
+			for _, arg := range tt.args {
+				if _, ok := files[arg]; ok {
+					arg = filepath.Join(dir, arg)
+				}
+				args = append(args, arg)
+			}
+			var stdout, stderr bytes.Buffer
+			if status := run(args, &stdout, &stderr); status != tt.wantStatus {
+				t.Fatalf("run() = %d, want %d (stdout: %s, stderr: %s)", status, tt.wantStatus, stdout.String(), stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
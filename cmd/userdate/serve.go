@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// server is the validation service of the serve command
+type server struct {
+	api     http.Handler
+	stats   *userdate.Stats // nil when metrics are off
+	ready   atomic.Bool     // Cleared when shutting down
+	timeout time.Duration   // Time given to requests in flight on shutdown
+	log     io.Writer
+}
+
+// newServer returns a server validating with opts, counting validations
+// for /metrics when metrics is true. At most concurrency API requests are
+// validated at once when it is positive.
+func newServer(opts []userdate.Option, metrics bool, concurrency int, timeout time.Duration, log io.Writer) *server {
+	s := &server{timeout: timeout, log: log}
+	if metrics {
+		s.stats = userdate.NewStats()
+		opts = append(opts, userdate.WithStats(s.stats), userdate.WithRuleProfiling())
+	}
+	s.api = userdate.NewHandler(opts...)
+	if concurrency > 0 {
+		s.api = limit(s.api, concurrency)
+	}
+	s.ready.Store(true)
+	return s
+}
+
+// routes returns the handler of the API, /healthz, /readyz and /metrics.
+// /healthz answers 200 while the process runs; /readyz answers 503 once
+// shutdown starts, so load balancers stop sending requests.
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", s.api)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !s.ready.Load() {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	if s.stats != nil {
+		mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+			s.stats.Snapshot().WritePrometheus(w)
+		})
+	}
+	return mux
+}
+
+// limit lets at most n requests run h at once. The others wait for a slot,
+// and get a 503 if their context ends first.
+func limit(h http.Handler, n int) http.Handler {
+	slots := make(chan struct{}, n)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			h.ServeHTTP(w, r)
+		case <-r.Context().Done():
+			http.Error(w, "too many requests in flight", http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// run serves on ln until ctx is done, then shuts down gracefully: /readyz
+// starts failing and requests in flight get s.timeout to complete
+func (s *server) run(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{Handler: s.routes(), ReadHeaderTimeout: 10 * time.Second}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+	fmt.Fprintf(s.log, "userdate: serving on http://%s\n", ln.Addr())
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	fmt.Fprintln(s.log, "userdate: shutting down")
+	s.ready.Store(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serve runs the serve command
+func serve(args []string, stderr io.Writer) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return serveContext(ctx, args, stderr)
+}
+
+// serveContext runs the serve command until ctx is done
+func serveContext(ctx context.Context, args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	rulesFile := fs.String("rules", "", "rules file written by rules init")
+	jurisdiction := fs.String("jurisdiction", "", "country code of the jurisdiction: "+strings.Join(userdate.Jurisdictions(), ", ")+", or a subdivision such as US-CA")
+	preset := fs.String("preset", "", "rule preset: "+strings.Join(userdate.Presets(), ", "))
+	locale := fs.String("locale", "", "locale of the messages, such as fr or de")
+	metrics := fs.Bool("metrics", true, "serve validation counters on /metrics")
+	concurrency := fs.Int("concurrency", 0, "maximum API requests validated at once, 0 for no limit")
+	timeout := fs.Duration("shutdown-timeout", 10*time.Second, "time given to requests in flight on shutdown")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: userdate serve [flags]")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	opts, err := ruleOptions(*rulesFile, *jurisdiction, *preset)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *locale != "" {
+		opts = append(opts, userdate.WithLocale(*locale))
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	if err := newServer(opts, *metrics, *concurrency, *timeout, stderr).run(ctx, ln); err != nil {
+		fmt.Fprintf(stderr, "userdate: %v\n", err)
+		return 1
+	}
+	return 0
+}
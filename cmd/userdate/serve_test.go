@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerRoutes(t *testing.T) {
+	s := newServer(nil, true, 0, time.Second, io.Discard)
+	h := s.routes()
+	body := `{"user": {"id": "u", "birth_date": "1990-05-15T00:00:00Z"}, "entity": {"type": "license", "date": "2005-01-01T00:00:00Z"}}`
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{"check", "POST", "/v1/check", body, http.StatusUnprocessableEntity, `"UNREALISTIC_AGE"`},
+		{"healthz", "GET", "/healthz", "", http.StatusOK, "ok"},
+		{"readyz", "GET", "/readyz", "", http.StatusOK, "ok"},
+		{"metrics", "GET", "/metrics", "", http.StatusOK, "userdate_findings_total{code=\"UNREALISTIC_AGE\"} 1\n"},
+		{"rule metrics", "GET", "/metrics", "", http.StatusOK, "userdate_rule_evaluations_total{rule=\"minimum_age\"}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("body does not contain %q:\n%s", tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	s.ready.Store(false)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz when shutting down = %d, want 503", rec.Code)
+	}
+}
+
+func TestServerNoMetrics(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newServer(nil, false, 0, time.Second, io.Discard).routes().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("/metrics = %d, want 404 with metrics off", rec.Code)
+	}
+}
+
+func TestServerShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log bytes.Buffer
+	s := newServer(nil, true, 0, time.Second, &log)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.run(ctx, ln) }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("/healthz = %d, want 200", resp.StatusCode)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+	if s.ready.Load() {
+		t.Error("server still ready after shutdown")
+	}
+	if !strings.Contains(log.String(), "userdate: shutting down\n") {
+		t.Errorf("log = %q, want the shutdown", log.String())
+	}
+}
+
+func TestServeFlags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, tt := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{"-addr", "127.0.0.1:0"}, 0},
+		{[]string{"-addr", "127.0.0.1:0", "-jurisdiction", "XX"}, 2},
+		{[]string{"-addr", "not an address"}, 1},
+		{[]string{"extra"}, 2},
+	} {
+		if status := serveContext(ctx, tt.args, io.Discard); status != tt.want {
+			t.Errorf("serve %v = %d, want %d", tt.args, status, tt.want)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	h := limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}), 1)
+
+	done := make(chan struct{})
+	go func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/v1/check", nil))
+		close(done)
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/v1/check", nil).WithContext(ctx))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status of a waiting request = %d, want 503", rec.Code)
+	}
+	close(release)
+	<-done
+}
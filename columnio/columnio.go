@@ -0,0 +1,289 @@
+// Package columnio validates user entity dates stored in columnar Arrow and
+// Parquet data.
+//
+// Input record batches need the columns user_id (string), birth_date,
+// entity_date and entity_type (string). Date columns may be date32, date64,
+// timestamp, or strings in the 2006-01-02 or RFC 3339 format. Validation
+// writes one result row per input row with the columns user_id, valid,
+// code and message; code and message are null for valid rows.
+//
+// The package is a separate module so that the Arrow dependency is only
+// pulled in by programs that use it.
+package columnio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// Input column names
+const (
+	ColumnUserID     = "user_id"
+	ColumnBirthDate  = "birth_date"
+	ColumnEntityDate = "entity_date"
+	ColumnEntityType = "entity_type"
+)
+
+// ResultSchema is the schema of the result record batches
+var ResultSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "user_id", Type: arrow.BinaryTypes.String},
+	{Name: "valid", Type: arrow.FixedWidthTypes.Boolean},
+	{Name: "code", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "message", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+// Columns holds the input of a validation, one slice element per row
+type Columns struct {
+	UserIDs     []string
+	BirthDates  []time.Time
+	EntityDates []time.Time
+	EntityTypes []string
+}
+
+// Len returns the number of rows
+func (c *Columns) Len() int {
+	return len(c.UserIDs)
+}
+
+// Results holds the outcome of a validation, one slice element per row
+type Results struct {
+	UserIDs  []string
+	Valid    []bool
+	Codes    []string // Empty for valid rows
+	Messages []string // Empty for valid rows
+}
+
+// Failed returns the number of invalid rows
+func (r *Results) Failed() int {
+	n := 0
+	for _, valid := range r.Valid {
+		if !valid {
+			n++
+		}
+	}
+	return n
+}
+
+// FromRecord extracts the input columns of a record batch
+func FromRecord(rec arrow.Record) (*Columns, error) {
+	n := int(rec.NumRows())
+	cols := &Columns{
+		UserIDs:     make([]string, n),
+		BirthDates:  make([]time.Time, n),
+		EntityDates: make([]time.Time, n),
+		EntityTypes: make([]string, n),
+	}
+	steps := []struct {
+		name string
+		read func(arrow.Array) error
+	}{
+		{ColumnUserID, func(a arrow.Array) error { return readStrings(a, cols.UserIDs) }},
+		{ColumnBirthDate, func(a arrow.Array) error { return readTimes(a, cols.BirthDates) }},
+		{ColumnEntityDate, func(a arrow.Array) error { return readTimes(a, cols.EntityDates) }},
+		{ColumnEntityType, func(a arrow.Array) error { return readStrings(a, cols.EntityTypes) }},
+	}
+	for _, step := range steps {
+		idx := rec.Schema().FieldIndices(step.name)
+		if len(idx) == 0 {
+			return nil, fmt.Errorf("columnio: missing column %q", step.name)
+		}
+		if err := step.read(rec.Column(idx[0])); err != nil {
+			return nil, fmt.Errorf("columnio: column %q: %w", step.name, err)
+		}
+	}
+	return cols, nil
+}
+
+// readStrings copies a string column into dst; nulls become empty strings
+func readStrings(a arrow.Array, dst []string) error {
+	switch a := a.(type) {
+	case *array.String:
+		for i := range dst {
+			if a.IsValid(i) {
+				dst[i] = a.Value(i)
+			}
+		}
+	case *array.LargeString:
+		for i := range dst {
+			if a.IsValid(i) {
+				dst[i] = a.Value(i)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", a.DataType())
+	}
+	return nil
+}
+
+// readTimes copies a date column into dst; nulls become zero times, which
+// fail validation as invalid dates
+func readTimes(a arrow.Array, dst []time.Time) error {
+	switch a := a.(type) {
+	case *array.Date32:
+		for i := range dst {
+			if a.IsValid(i) {
+				dst[i] = a.Value(i).ToTime()
+			}
+		}
+	case *array.Date64:
+		for i := range dst {
+			if a.IsValid(i) {
+				dst[i] = a.Value(i).ToTime()
+			}
+		}
+	case *array.Timestamp:
+		toTime, err := a.DataType().(*arrow.TimestampType).GetToTimeFunc()
+		if err != nil {
+			return err
+		}
+		for i := range dst {
+			if a.IsValid(i) {
+				dst[i] = toTime(a.Value(i))
+			}
+		}
+	case *array.String:
+		for i := range dst {
+			if !a.IsValid(i) {
+				continue
+			}
+			t, err := parseTime(a.Value(i))
+			if err != nil {
+				return fmt.Errorf("row %d: %w", i, err)
+			}
+			dst[i] = t
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", a.DataType())
+	}
+	return nil
+}
+
+// parseTime parses a date or an RFC 3339 timestamp
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// Validate checks every row of cols with the given options
+func Validate(cols *Columns, opts ...userdate.Option) *Results {
+	n := cols.Len()
+	results := &Results{
+		UserIDs:  cols.UserIDs,
+		Valid:    make([]bool, n),
+		Codes:    make([]string, n),
+		Messages: make([]string, n),
+	}
+	v := userdate.NewValidator(opts...)
+	for i := range n {
+		user := &userdate.User{ID: cols.UserIDs[i], BirthDate: cols.BirthDates[i]}
+		err := v.ValidateEntityDate(user, cols.EntityDates[i], cols.EntityTypes[i])
+		if err == nil {
+			results.Valid[i] = true
+			continue
+		}
+		var dateErr *userdate.DateValidationError
+		if errors.As(err, &dateErr) {
+			results.Codes[i] = dateErr.Code
+			results.Messages[i] = dateErr.Message
+		} else {
+			results.Messages[i] = err.Error()
+		}
+	}
+	return results
+}
+
+// Record builds a result record batch with the ResultSchema. The caller
+// must release it.
+func (r *Results) Record(mem memory.Allocator) arrow.Record {
+	b := array.NewRecordBuilder(mem, ResultSchema)
+	defer b.Release()
+
+	b.Field(0).(*array.StringBuilder).AppendValues(r.UserIDs, nil)
+	b.Field(1).(*array.BooleanBuilder).AppendValues(r.Valid, nil)
+	codes := b.Field(2).(*array.StringBuilder)
+	messages := b.Field(3).(*array.StringBuilder)
+	for i, valid := range r.Valid {
+		if valid {
+			codes.AppendNull()
+			messages.AppendNull()
+			continue
+		}
+		codes.Append(r.Codes[i])
+		messages.Append(r.Messages[i])
+	}
+	return b.NewRecord()
+}
+
+// ValidateRecords validates every record batch of rr and passes the result
+// batches to emit, which must not keep them after it returns
+func ValidateRecords(rr array.RecordReader, emit func(arrow.Record) error, opts ...userdate.Option) error {
+	mem := memory.DefaultAllocator
+	for rr.Next() {
+		cols, err := FromRecord(rr.Record())
+		if err != nil {
+			return err
+		}
+		rec := Validate(cols, opts...).Record(mem)
+		err = emit(rec)
+		rec.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return rr.Err()
+}
+
+// ValidateArrow reads an Arrow IPC stream from r and writes the results as
+// an Arrow IPC stream to w
+func ValidateArrow(r io.Reader, w io.Writer, opts ...userdate.Option) error {
+	rr, err := ipc.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer rr.Release()
+
+	iw := ipc.NewWriter(w, ipc.WithSchema(ResultSchema))
+	if err := ValidateRecords(rr, iw.Write, opts...); err != nil {
+		iw.Close()
+		return err
+	}
+	return iw.Close()
+}
+
+// ValidateParquet reads a Parquet file from r and writes the results as a
+// Parquet file to w
+func ValidateParquet(ctx context.Context, r parquet.ReaderAtSeeker, w io.Writer, opts ...userdate.Option) error {
+	mem := memory.DefaultAllocator
+	table, err := pqarrow.ReadTable(ctx, r, parquet.NewReaderProperties(mem), pqarrow.ArrowReadProperties{}, mem)
+	if err != nil {
+		return err
+	}
+	defer table.Release()
+
+	tr := array.NewTableReader(table, 64*1024)
+	defer tr.Release()
+
+	fw, err := pqarrow.NewFileWriter(ResultSchema, w, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	if err := ValidateRecords(tr, fw.Write, opts...); err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
@@ -0,0 +1,173 @@
+package columnio
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+var now = userdate.WithFixedNow(mustDate("2025-07-18"))
+
+func mustDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// inputRecord builds an input batch with date32 birth dates and string
+// entity dates
+func inputRecord(t *testing.T) arrow.Record {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: ColumnUserID, Type: arrow.BinaryTypes.String},
+		{Name: ColumnBirthDate, Type: arrow.FixedWidthTypes.Date32},
+		{Name: ColumnEntityDate, Type: arrow.BinaryTypes.String},
+		{Name: ColumnEntityType, Type: arrow.BinaryTypes.String},
+	}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.StringBuilder).AppendValues([]string{"u1", "u2", "u3"}, nil)
+	b.Field(1).(*array.Date32Builder).AppendValues([]arrow.Date32{
+		arrow.Date32FromTime(mustDate("1990-01-01")),
+		arrow.Date32FromTime(mustDate("1990-01-01")),
+		arrow.Date32FromTime(mustDate("2015-01-01")),
+	}, nil)
+	b.Field(2).(*array.StringBuilder).AppendValues([]string{"2020-01-01", "1989-01-01", "2020-01-01"}, nil)
+	b.Field(3).(*array.StringBuilder).AppendValues([]string{"certification", "certification", "employment"}, nil)
+	return b.NewRecord()
+}
+
+// wantCodes are the expected codes for inputRecord
+var wantCodes = []string{"", userdate.ErrCodeBeforeBirth, userdate.ErrCodeUnrealisticAge}
+
+func checkResult(t *testing.T, rec arrow.Record) {
+	t.Helper()
+	if rec.NumRows() != int64(len(wantCodes)) {
+		t.Fatalf("result has %d rows, want %d", rec.NumRows(), len(wantCodes))
+	}
+	valid := rec.Column(1).(*array.Boolean)
+	codes := rec.Column(2).(*array.String)
+	for i, want := range wantCodes {
+		if got := valid.Value(i); got != (want == "") {
+			t.Errorf("row %d valid = %v, want %v", i, got, want == "")
+		}
+		if want == "" {
+			if codes.IsValid(i) {
+				t.Errorf("row %d code = %q, want null", i, codes.Value(i))
+			}
+			continue
+		}
+		if got := codes.Value(i); got != want {
+			t.Errorf("row %d code = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	rec := inputRecord(t)
+	defer rec.Release()
+
+	cols, err := FromRecord(rec)
+	if err != nil {
+		t.Fatalf("FromRecord() error = %v", err)
+	}
+	results := Validate(cols, now)
+	if results.Failed() != 2 {
+		t.Errorf("Failed() = %d, want 2", results.Failed())
+	}
+	for i, want := range wantCodes {
+		if results.Codes[i] != want {
+			t.Errorf("row %d code = %q, want %q", i, results.Codes[i], want)
+		}
+	}
+}
+
+func TestFromRecordMissingColumn(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: ColumnUserID, Type: arrow.BinaryTypes.String}}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	if _, err := FromRecord(rec); err == nil {
+		t.Error("FromRecord() error = nil, want missing column error")
+	}
+}
+
+func TestValidateArrow(t *testing.T) {
+	rec := inputRecord(t)
+	defer rec.Release()
+
+	var in, out bytes.Buffer
+	w := ipc.NewWriter(&in, ipc.WithSchema(rec.Schema()))
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	if err := ValidateArrow(&in, &out, now); err != nil {
+		t.Fatalf("ValidateArrow() error = %v", err)
+	}
+
+	r, err := ipc.NewReader(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	if !r.Next() {
+		t.Fatalf("no result batch: %v", r.Err())
+	}
+	checkResult(t, r.Record())
+}
+
+func TestValidateParquet(t *testing.T) {
+	rec := inputRecord(t)
+	defer rec.Release()
+
+	var in, out bytes.Buffer
+	fw, err := pqarrow.NewFileWriter(rec.Schema(), &in, nil, pqarrow.DefaultWriterProps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	fw.Close()
+
+	if err := ValidateParquet(context.Background(), bytes.NewReader(in.Bytes()), &out, now); err != nil {
+		t.Fatalf("ValidateParquet() error = %v", err)
+	}
+
+	pf, err := file.NewParquetReader(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := fr.ReadTable(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Release()
+	tr := array.NewTableReader(table, 1024)
+	defer tr.Release()
+	if !tr.Next() {
+		t.Fatal("no result batch")
+	}
+	checkResult(t, tr.Record())
+}
@@ -0,0 +1,178 @@
+// Package coordinator distributes the validation of a large dataset over
+// several processes.
+//
+// A Leader splits a manifest of items into chunks and serves them over gRPC.
+// Workers lease a chunk, validate it with this module's userdate package and
+// report the rejected items back. A chunk whose worker reports a failure, or
+// whose lease expires because the worker died, is handed out again until it
+// has been tried LeaderConfig.MaxAttempts times.
+//
+// Messages are encoded as JSON with a gRPC codec registered under the "json"
+// content subtype, so no generated protobuf code is needed. The package is a
+// separate module so that the gRPC dependency is only pulled in by programs
+// that use it.
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// ServiceName is the full gRPC name of the coordinator service
+const ServiceName = "userdate.coordinator.v1.Coordinator"
+
+// ErrChunkFailed is returned by Leader.Wait when chunks still failed after
+// their last attempt
+var ErrChunkFailed = errors.New("chunk failed")
+
+// Manifest describes the dataset a leader distributes
+type Manifest struct {
+	Items     []userdate.Item `json:"items"`
+	ChunkSize int             `json:"chunk_size"` // Items per chunk; DefaultChunkSize when zero
+}
+
+// DefaultChunkSize is the number of items per chunk when the manifest does
+// not set one
+const DefaultChunkSize = 1000
+
+// Chunk is a slice of the manifest handed to a worker
+type Chunk struct {
+	ID      string          `json:"id"`
+	Attempt int             `json:"attempt"` // 1 for the first lease of the chunk
+	Items   []userdate.Item `json:"items"`
+}
+
+// ChunkResult is the outcome of validating a chunk
+type ChunkResult struct {
+	ChunkID string            `json:"chunk_id"`
+	Attempt int               `json:"attempt"`
+	Total   int               `json:"total"`
+	Rejects []userdate.Reject `json:"rejects,omitempty"`
+
+	// Error is set when the worker could not validate the chunk, which
+	// makes the leader retry it
+	Error string `json:"error,omitempty"`
+}
+
+// LeaseRequest asks the leader for a chunk
+type LeaseRequest struct {
+	WorkerID string `json:"worker_id"`
+}
+
+// LeaseResponse holds the leased chunk. Chunk is nil when no chunk is
+// available right now; Done is set once every chunk is finished.
+type LeaseResponse struct {
+	Chunk *Chunk `json:"chunk,omitempty"`
+	Done  bool   `json:"done,omitempty"`
+}
+
+// CompleteRequest reports the result of a leased chunk
+type CompleteRequest struct {
+	WorkerID string      `json:"worker_id"`
+	Result   ChunkResult `json:"result"`
+}
+
+// CompleteResponse acknowledges a result. Accepted is false when the lease
+// had already expired and the chunk was handed to another worker.
+type CompleteResponse struct {
+	Accepted bool `json:"accepted"`
+}
+
+// CoordinatorServer is the server side of the coordinator service
+type CoordinatorServer interface {
+	Lease(context.Context, *LeaseRequest) (*LeaseResponse, error)
+	Complete(context.Context, *CompleteRequest) (*CompleteResponse, error)
+}
+
+// RegisterCoordinatorServer registers srv with s
+func RegisterCoordinatorServer(s grpc.ServiceRegistrar, srv CoordinatorServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+// serviceDesc describes the coordinator service to gRPC
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*CoordinatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Lease", Handler: leaseHandler},
+		{MethodName: "Complete", Handler: completeHandler},
+	},
+}
+
+func leaseHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(LeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).Lease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/Lease"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(CoordinatorServer).Lease(ctx, req.(*LeaseRequest))
+	})
+}
+
+func completeHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/Complete"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(CoordinatorServer).Complete(ctx, req.(*CompleteRequest))
+	})
+}
+
+// Client calls the coordinator service
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient returns a client using cc
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{cc: cc}
+}
+
+// Lease asks the leader for a chunk
+func (c *Client) Lease(ctx context.Context, in *LeaseRequest) (*LeaseResponse, error) {
+	out := new(LeaseResponse)
+	err := c.cc.Invoke(ctx, "/"+ServiceName+"/Lease", in, out, grpc.CallContentSubtype(codecName))
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Complete reports the result of a chunk
+func (c *Client) Complete(ctx context.Context, in *CompleteRequest) (*CompleteResponse, error) {
+	out := new(CompleteResponse)
+	err := c.cc.Invoke(ctx, "/"+ServiceName+"/Complete", in, out, grpc.CallContentSubtype(codecName))
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// codecName is the content subtype of the JSON codec
+const codecName = "json"
+
+// jsonCodec encodes gRPC messages as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return codecName }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
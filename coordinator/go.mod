@@ -0,0 +1,21 @@
+module github.com/i2sac/user-entity-date-verification/coordinator
+
+go 1.24.5
+
+require (
+	github.com/i2sac/user-entity-date-verification v0.0.0
+	google.golang.org/grpc v1.75.0
+)
+
+require (
+	github.com/i2sac/user-entity-date-verification/v2 v2.0.0 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+)
+
+replace github.com/i2sac/user-entity-date-verification => ../
+
+replace github.com/i2sac/user-entity-date-verification/v2 => ../v2
@@ -0,0 +1,227 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// LeaderConfig tunes how a leader hands out chunks
+type LeaderConfig struct {
+	// LeaseTimeout is how long a worker may hold a chunk before it is handed
+	// to another worker. Defaults to one minute.
+	LeaseTimeout time.Duration
+
+	// MaxAttempts is how many times a chunk is leased before it is given up.
+	// Defaults to 3.
+	MaxAttempts int
+}
+
+// chunkStatus is the progress of a chunk
+type chunkStatus int
+
+const (
+	chunkPending chunkStatus = iota
+	chunkLeased
+	chunkDone
+	chunkFailed
+)
+
+// chunkState tracks a chunk on the leader
+type chunkState struct {
+	chunk    Chunk
+	status   chunkStatus
+	worker   string
+	deadline time.Time
+	lastErr  string
+	result   ChunkResult
+}
+
+// Summary is the outcome of a distributed validation
+type Summary struct {
+	Chunks  int               `json:"chunks"`
+	Total   int               `json:"total"` // Items validated in completed chunks
+	Rejects []userdate.Reject `json:"rejects,omitempty"`
+
+	// FailedChunks maps the IDs of the chunks given up to their last error
+	FailedChunks map[string]string `json:"failed_chunks,omitempty"`
+}
+
+// Leader splits a manifest into chunks and hands them to workers. It
+// implements CoordinatorServer and is safe for concurrent use.
+type Leader struct {
+	cfg LeaderConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	chunks   []*chunkState
+	byID     map[string]*chunkState
+	queue    []*chunkState
+	finished int
+	done     chan struct{}
+}
+
+// NewLeader splits the manifest into chunks of at most m.ChunkSize items
+func NewLeader(m Manifest, cfg LeaderConfig) *Leader {
+	if cfg.LeaseTimeout <= 0 {
+		cfg.LeaseTimeout = time.Minute
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	size := m.ChunkSize
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+
+	l := &Leader{
+		cfg:  cfg,
+		now:  time.Now,
+		byID: make(map[string]*chunkState),
+		done: make(chan struct{}),
+	}
+	for start := 0; start < len(m.Items); start += size {
+		end := min(start+size, len(m.Items))
+		s := &chunkState{chunk: Chunk{
+			ID:    fmt.Sprintf("chunk-%05d", len(l.chunks)),
+			Items: m.Items[start:end],
+		}}
+		l.chunks = append(l.chunks, s)
+		l.byID[s.chunk.ID] = s
+		l.queue = append(l.queue, s)
+	}
+	if len(l.chunks) == 0 {
+		close(l.done)
+	}
+	return l
+}
+
+// Register registers the leader as the coordinator service of s
+func (l *Leader) Register(s grpc.ServiceRegistrar) {
+	RegisterCoordinatorServer(s, l)
+}
+
+// Lease hands the next pending chunk to the worker
+func (l *Leader) Lease(_ context.Context, req *LeaseRequest) (*LeaseResponse, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.reclaim()
+	if l.finished == len(l.chunks) {
+		return &LeaseResponse{Done: true}, nil
+	}
+	if len(l.queue) == 0 {
+		return &LeaseResponse{}, nil
+	}
+
+	s := l.queue[0]
+	l.queue = l.queue[1:]
+	s.status = chunkLeased
+	s.worker = req.WorkerID
+	s.deadline = l.now().Add(l.cfg.LeaseTimeout)
+	s.chunk.Attempt++
+	chunk := s.chunk
+	return &LeaseResponse{Chunk: &chunk}, nil
+}
+
+// Complete records the result of a chunk, queueing the chunk again when the
+// worker reported an error
+func (l *Leader) Complete(_ context.Context, req *CompleteRequest) (*CompleteResponse, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res := req.Result
+	s, ok := l.byID[res.ChunkID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown chunk %q", res.ChunkID)
+	}
+	// A result for an older lease arrives after the chunk was reclaimed
+	if s.status != chunkLeased || s.chunk.Attempt != res.Attempt {
+		return &CompleteResponse{Accepted: false}, nil
+	}
+
+	if res.Error != "" {
+		l.retry(s, res.Error)
+	} else {
+		s.result = res
+		l.finish(s, chunkDone)
+	}
+	return &CompleteResponse{Accepted: true}, nil
+}
+
+// Wait blocks until every chunk is done or given up, or ctx is done. The
+// error wraps ErrChunkFailed when chunks were given up; the summary then
+// still holds the results of the other chunks.
+func (l *Leader) Wait(ctx context.Context) (*Summary, error) {
+	select {
+	case <-l.done:
+	case <-ctx.Done():
+		return l.Summary(), ctx.Err()
+	}
+
+	summary := l.Summary()
+	if len(summary.FailedChunks) > 0 {
+		return summary, fmt.Errorf("%w: %d of %d chunks", ErrChunkFailed, len(summary.FailedChunks), summary.Chunks)
+	}
+	return summary, nil
+}
+
+// Summary returns the results collected so far
+func (l *Leader) Summary() *Summary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	summary := &Summary{Chunks: len(l.chunks)}
+	for _, s := range l.chunks {
+		switch s.status {
+		case chunkDone:
+			summary.Total += s.result.Total
+			summary.Rejects = append(summary.Rejects, s.result.Rejects...)
+		case chunkFailed:
+			if summary.FailedChunks == nil {
+				summary.FailedChunks = make(map[string]string)
+			}
+			summary.FailedChunks[s.chunk.ID] = s.lastErr
+		}
+	}
+	return summary
+}
+
+// reclaim queues the chunks whose lease has expired again
+func (l *Leader) reclaim() {
+	now := l.now()
+	for _, s := range l.chunks {
+		if s.status == chunkLeased && now.After(s.deadline) {
+			l.retry(s, fmt.Sprintf("lease of worker %q expired", s.worker))
+		}
+	}
+}
+
+// retry queues a chunk again, or gives it up after its last attempt
+func (l *Leader) retry(s *chunkState, reason string) {
+	s.lastErr = reason
+	s.worker = ""
+	if s.chunk.Attempt >= l.cfg.MaxAttempts {
+		l.finish(s, chunkFailed)
+		return
+	}
+	s.status = chunkPending
+	l.queue = append(l.queue, s)
+}
+
+// finish marks a chunk done or failed
+func (l *Leader) finish(s *chunkState, st chunkStatus) {
+	s.status = st
+	s.worker = ""
+	l.finished++
+	if l.finished == len(l.chunks) {
+		close(l.done)
+	}
+}
@@ -0,0 +1,165 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+var now = userdate.WithFixedNow(mustDate("2025-07-18"))
+
+func mustDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// testItems returns n items, every third of which is dated before birth
+func testItems(n int) []userdate.Item {
+	user := &userdate.User{ID: "user123", BirthDate: mustDate("1990-01-01")}
+	items := make([]userdate.Item, n)
+	for i := range items {
+		date := mustDate("2020-01-01")
+		if i%3 == 0 {
+			date = mustDate("1989-01-01")
+		}
+		items[i] = userdate.Item{User: user, EntityDate: date, EntityType: "certification"}
+	}
+	return items
+}
+
+func lease(t *testing.T, l *Leader, worker string) *LeaseResponse {
+	t.Helper()
+	resp, err := l.Lease(context.Background(), &LeaseRequest{WorkerID: worker})
+	if err != nil {
+		t.Fatalf("Lease() error = %v", err)
+	}
+	return resp
+}
+
+func complete(t *testing.T, l *Leader, result ChunkResult) bool {
+	t.Helper()
+	resp, err := l.Complete(context.Background(), &CompleteRequest{WorkerID: "w", Result: result})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	return resp.Accepted
+}
+
+func TestNewLeaderChunks(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		chunkSize int
+		want      []int
+	}{
+		{"exact", 6, 3, []int{3, 3}},
+		{"remainder", 7, 3, []int{3, 3, 1}},
+		{"default size", 5, 0, []int{5}},
+		{"empty", 0, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLeader(Manifest{Items: testItems(tt.items), ChunkSize: tt.chunkSize}, LeaderConfig{})
+			var got []int
+			for {
+				resp := lease(t, l, "w")
+				if resp.Chunk == nil {
+					break
+				}
+				got = append(got, len(resp.Chunk.Items))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("chunk sizes = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("chunk sizes = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaderRetriesFailedChunk(t *testing.T) {
+	l := NewLeader(Manifest{Items: testItems(2), ChunkSize: 2}, LeaderConfig{})
+
+	first := lease(t, l, "w1").Chunk
+	if !complete(t, l, ChunkResult{ChunkID: first.ID, Attempt: first.Attempt, Error: "disk full"}) {
+		t.Fatal("failed result not accepted")
+	}
+
+	second := lease(t, l, "w2").Chunk
+	if second == nil || second.ID != first.ID || second.Attempt != 2 {
+		t.Fatalf("second lease = %+v, want %s attempt 2", second, first.ID)
+	}
+	complete(t, l, ChunkResult{ChunkID: second.ID, Attempt: second.Attempt, Total: 2})
+
+	if resp := lease(t, l, "w1"); !resp.Done {
+		t.Errorf("Lease() after completion = %+v, want done", resp)
+	}
+	summary, err := l.Wait(context.Background())
+	if err != nil || summary.Total != 2 {
+		t.Errorf("Wait() = %+v, %v, want 2 items and no error", summary, err)
+	}
+}
+
+func TestLeaderReclaimsExpiredLease(t *testing.T) {
+	clock := mustDate("2025-07-18")
+	l := NewLeader(Manifest{Items: testItems(2), ChunkSize: 2}, LeaderConfig{LeaseTimeout: time.Minute})
+	l.now = func() time.Time { return clock }
+
+	stale := lease(t, l, "w1").Chunk
+	if resp := lease(t, l, "w2"); resp.Chunk != nil || resp.Done {
+		t.Fatalf("Lease() while leased = %+v, want nothing available", resp)
+	}
+
+	clock = clock.Add(2 * time.Minute)
+	fresh := lease(t, l, "w2").Chunk
+	if fresh == nil || fresh.Attempt != 2 {
+		t.Fatalf("Lease() after expiry = %+v, want attempt 2", fresh)
+	}
+
+	// The dead worker's late result is ignored
+	if complete(t, l, ChunkResult{ChunkID: stale.ID, Attempt: stale.Attempt, Total: 2}) {
+		t.Error("result of the expired lease accepted")
+	}
+	if !complete(t, l, ChunkResult{ChunkID: fresh.ID, Attempt: fresh.Attempt, Total: 2}) {
+		t.Error("result of the current lease rejected")
+	}
+}
+
+func TestLeaderGivesUpAfterMaxAttempts(t *testing.T) {
+	l := NewLeader(Manifest{Items: testItems(4), ChunkSize: 2}, LeaderConfig{MaxAttempts: 2})
+
+	for range 2 {
+		chunk := lease(t, l, "w").Chunk
+		if chunk.ID == "chunk-00001" {
+			complete(t, l, ChunkResult{ChunkID: chunk.ID, Attempt: chunk.Attempt, Total: 2})
+			chunk = lease(t, l, "w").Chunk
+		}
+		complete(t, l, ChunkResult{ChunkID: chunk.ID, Attempt: chunk.Attempt, Error: "boom"})
+	}
+
+	summary, err := l.Wait(context.Background())
+	if !errors.Is(err, ErrChunkFailed) {
+		t.Fatalf("Wait() error = %v, want ErrChunkFailed", err)
+	}
+	if summary.Total != 2 || summary.FailedChunks["chunk-00000"] != "boom" {
+		t.Errorf("Wait() = %+v, want chunk-00000 failed and 2 items validated", summary)
+	}
+}
+
+func TestLeaderCompleteUnknownChunk(t *testing.T) {
+	l := NewLeader(Manifest{Items: testItems(1)}, LeaderConfig{})
+	_, err := l.Complete(context.Background(), &CompleteRequest{Result: ChunkResult{ChunkID: "nope"}})
+	if err == nil {
+		t.Error("Complete() of an unknown chunk succeeded")
+	}
+}
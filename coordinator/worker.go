@@ -0,0 +1,98 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// Worker validates the chunks leased from a leader
+type Worker struct {
+	ID string
+
+	// PollInterval is how long the worker waits when every remaining chunk
+	// is leased to other workers. Defaults to one second.
+	PollInterval time.Duration
+
+	// Options configure the validation of the chunks
+	Options []userdate.Option
+}
+
+// Run leases and validates chunks until the leader reports that every chunk
+// is finished, which returns nil, or ctx is done
+func (w *Worker) Run(ctx context.Context, cc grpc.ClientConnInterface) error {
+	client := NewClient(cc)
+	v := userdate.NewValidator(w.Options...)
+	poll := w.PollInterval
+	if poll <= 0 {
+		poll = time.Second
+	}
+
+	for {
+		lease, err := client.Lease(ctx, &LeaseRequest{WorkerID: w.ID})
+		if err != nil {
+			return rpcError(ctx, err)
+		}
+		if lease.Done {
+			return nil
+		}
+		if lease.Chunk == nil {
+			select {
+			case <-time.After(poll):
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		result := validateChunk(v, lease.Chunk)
+		_, err = client.Complete(ctx, &CompleteRequest{WorkerID: w.ID, Result: result})
+		if err != nil {
+			return rpcError(ctx, err)
+		}
+	}
+}
+
+// rpcError returns the context error instead of the RPC status when the
+// call failed because ctx is done. gRPC can report the deadline a moment
+// before ctx does, so a DeadlineExceeded status past the deadline of ctx is
+// reported as context.DeadlineExceeded.
+func rpcError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if deadline, ok := ctx.Deadline(); ok && status.Code(err) == codes.DeadlineExceeded && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// validateChunk validates the items of a chunk. Failing to write rejects,
+// or a panic, is reported as a chunk error so the leader retries the chunk.
+func validateChunk(v *userdate.Validator, chunk *Chunk) (result ChunkResult) {
+	result = ChunkResult{ChunkID: chunk.ID, Attempt: chunk.Attempt}
+	defer func() {
+		if r := recover(); r != nil {
+			result = ChunkResult{ChunkID: chunk.ID, Attempt: chunk.Attempt, Error: fmt.Sprint("panic: ", r)}
+		}
+	}()
+
+	batch := v.ValidateBatch(chunk.Items)
+	if batch.RejectErr != nil {
+		result.Error = batch.RejectErr.Error()
+		return result
+	}
+	result.Total = len(batch.Items)
+	for i, report := range batch.Reports {
+		if !report.Valid() {
+			result.Rejects = append(result.Rejects, userdate.Reject{Item: batch.Items[i], Report: report})
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// serve runs the leader on an in-memory listener and returns a connection
+// to it
+func serve(t *testing.T, l *Leader) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	l.Register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// flakyRejects fails the first write and accepts the others
+type flakyRejects struct {
+	calls atomic.Int32
+}
+
+func (f *flakyRejects) WriteReject(context.Context, userdate.Reject) error {
+	if f.calls.Add(1) == 1 {
+		return errors.New("reject store unavailable")
+	}
+	return nil
+}
+
+func TestWorkersValidateManifest(t *testing.T) {
+	l := NewLeader(Manifest{Items: testItems(30), ChunkSize: 4}, LeaderConfig{})
+	conn := serve(t, l)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rejects := &flakyRejects{}
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := &Worker{
+				ID:           string(rune('a' + i)),
+				PollInterval: time.Millisecond,
+				Options:      []userdate.Option{now, userdate.WithRejectWriter(rejects)},
+			}
+			errs[i] = w.Run(ctx, conn)
+		}()
+	}
+
+	summary, err := l.Wait(ctx)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("worker %d: Run() error = %v", i, err)
+		}
+	}
+	// The chunk whose reject write failed was retried
+	if summary.Total != 30 || len(summary.Rejects) != 10 {
+		t.Errorf("Wait() = %d items, %d rejects, want 30 items, 10 rejects", summary.Total, len(summary.Rejects))
+	}
+	for _, r := range summary.Rejects {
+		if r.Report.Valid() || r.Report.Findings[0].Code != userdate.ErrCodeBeforeBirth {
+			t.Errorf("reject report = %+v, want BEFORE_BIRTH", r.Report)
+		}
+	}
+}
+
+func TestWorkerStopsWithContext(t *testing.T) {
+	l := NewLeader(Manifest{Items: testItems(2)}, LeaderConfig{})
+	conn := serve(t, l)
+	lease(t, l, "other") // Every chunk is leased, so the worker polls
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	w := &Worker{ID: "w", PollInterval: 5 * time.Millisecond}
+	if err := w.Run(ctx, conn); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() error = %v, want context.DeadlineExceeded", err)
+	}
+}
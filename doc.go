@@ -35,8 +35,8 @@ The package implements several layers of validation:
 
 General Date Validation:
 - Date cannot be zero value
-- Date cannot be before year 1800
-- Date cannot be more than 200 years in the past
+- Date cannot be before year 1800 (see WithMinBirthDate and WithMinEntityDate)
+- Date cannot be more than 200 years in the past (see HistoryCutoff)
 - Date cannot be in the future
 
 User-Specific Validation:
@@ -44,11 +44,31 @@ User-Specific Validation:
 - User's birth date cannot be in the future
 - User's age cannot exceed 150 years
 
+Ages are calendar-accurate: a birthday is reached on the same month and day,
+and February 29 birthdays fall on March 1 in common years. See the agecalc
+subpackage for the underlying arithmetic.
+
 Entity-Specific Age Requirements:
 - Certifications/Training/Education: Minimum age 5 years
 - Employment: Minimum age 14 years
 - Licenses: Minimum age 16 years
 
+# Options
+
+Every validation function accepts optional settings. WithFixedNow pins the
+current time, which keeps tests deterministic:
+
+	now := userdate.WithFixedNow(time.Date(2025, 7, 18, 0, 0, 0, 0, time.UTC))
+	err := userdate.ValidateCertification(user, certDate, now)
+
+A Validator applies the same options to every call:
+
+	v := userdate.NewValidator(userdate.WithMinBirthDate(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)))
+	err := v.ValidateEntityDate(user, employmentDate, "employment")
+
+The *Context variants take the current time from a context set up with
+ContextWithNow, for per-request reference times in servers.
+
 # Error Handling
 
 All validation functions return structured errors with specific codes:
@@ -60,12 +80,27 @@ All validation functions return structured errors with specific codes:
 		}
 	}
 
-Available error codes: INVALID_DATE, BEFORE_BIRTH, FUTURE_DATE, UNREALISTIC_AGE, INVALID_USER, DATE_TOO_OLD
+Available error codes: INVALID_DATE, BEFORE_BIRTH, FUTURE_DATE, UNREALISTIC_AGE, INVALID_USER, DATE_TOO_OLD, PLACEHOLDER_DATE
+
+CheckEntityDate returns a Report that also keeps warnings, such as
+PRENATAL_DATE for entity types given a prenatal window with
+WithPrenatalWindow, or SWAPPED_DATE for rejected dates that would be valid
+with day and month swapped, with WithSwapDetection. Warnings have
+SeverityWarning and do not make a date invalid. Notes, such as the gaps
+of a profile the caller explained, have SeverityInfo.
 
 # Performance
 
 The package is designed for high-performance applications with minimal allocations
 and efficient validation algorithms. Benchmark tests are included to ensure
 performance remains optimal.
+
+# v2 Layout
+
+The implementation lives in the core package of the v2 module. The types of
+this package are aliases of those of core and its functions forward to it,
+see the README.
 */
 package userdate
+
+//go:generate go run ./internal/wrapgen -src v2/core -import github.com/i2sac/user-entity-date-verification/v2/core -pkg userdate
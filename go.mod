@@ -1,3 +1,7 @@
 module github.com/i2sac/user-entity-date-verification
 
 go 1.24.5
+
+require github.com/i2sac/user-entity-date-verification/v2 v2.0.0
+
+replace github.com/i2sac/user-entity-date-verification/v2 => ./v2
@@ -0,0 +1,139 @@
+// Package grpcerr converts validation errors into gRPC statuses.
+//
+// A DateValidationError becomes a status whose code follows
+// userdate.HTTPStatusFor, so overrides registered with
+// userdate.RegisterHTTPStatus apply to gRPC as well. The structured fields
+// travel as an errdetails.BadRequest detail with one field violation per
+// error, whose reason is the error code. The field of a violation is the
+// path attached to the error, such as entities[2].date for
+// /entities/2/date, or the input the code is about.
+//
+// The package is a separate module so that the gRPC dependency is only
+// pulled in by programs that use it.
+package grpcerr
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// httpCodes maps the HTTP statuses of HTTPStatusFor to gRPC codes
+var httpCodes = map[int]codes.Code{
+	http.StatusOK:                  codes.OK,
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// codeFields names the request field an error code points at. Other codes
+// point at the entity date.
+var codeFields = map[string]string{
+	userdate.ErrCodeInvalidUser:   "user",
+	userdate.ErrCodeInvalidStatus: "status",
+}
+
+// GRPCStatusFor returns the gRPC status for a validation error. It is OK for
+// nil. Errors joined with errors.Join, such as ProfileReport.Err, give one
+// field violation each, and the first one sets the code. Errors that are not
+// validation errors are returned by status.Convert unchanged.
+func GRPCStatusFor(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	errs := validationErrors(err)
+	if len(errs) == 0 {
+		return status.Convert(err)
+	}
+
+	code, ok := httpCodes[userdate.HTTPStatusFor(errs[0].Code)]
+	if !ok {
+		code = codes.Internal
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(errs))
+	for i, e := range errs {
+		field := fieldPath(e.Path)
+		if field == "" {
+			var ok bool
+			if field, ok = codeFields[e.Code]; !ok {
+				field = "entity_date"
+			}
+		}
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: e.Message,
+			Reason:      e.Code,
+		}
+	}
+
+	st := status.New(code, err.Error())
+	detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return st
+	}
+	return detailed
+}
+
+// fieldPath converts a JSON pointer such as /entities/2/date into the field
+// path notation of errdetails, entities[2].date
+func fieldPath(pointer string) string {
+	var sb strings.Builder
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		if _, err := strconv.Atoi(token); err == nil && sb.Len() > 0 {
+			sb.WriteString("[" + token + "]")
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(token)
+	}
+	return sb.String()
+}
+
+// validationErrors returns the validation errors in the tree of err, in
+// depth-first order
+func validationErrors(err error) []*userdate.DateValidationError {
+	switch e := err.(type) {
+	case *userdate.DateValidationError:
+		return []*userdate.DateValidationError{e}
+	case interface{ Unwrap() []error }:
+		var errs []*userdate.DateValidationError
+		for _, inner := range e.Unwrap() {
+			errs = append(errs, validationErrors(inner)...)
+		}
+		return errs
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return validationErrors(inner)
+		}
+	}
+	return nil
+}
+
+// UnaryServerInterceptor converts the validation errors returned by unary
+// handlers into statuses with GRPCStatusFor. Other errors pass unchanged.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil && len(validationErrors(err)) > 0 {
+			return resp, GRPCStatusFor(err).Err()
+		}
+		return resp, err
+	}
+}
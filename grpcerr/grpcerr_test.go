@@ -0,0 +1,133 @@
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func mustDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// violations returns the field violations of a status
+func violations(t *testing.T, st *status.Status) []*errdetails.BadRequest_FieldViolation {
+	t.Helper()
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			return br.FieldViolations
+		}
+	}
+	return nil
+}
+
+func TestGRPCStatusFor(t *testing.T) {
+	user := &userdate.User{ID: "user123", BirthDate: mustDate("1990-01-01")}
+	beforeBirth := userdate.ValidateEntityDate(user, mustDate("1989-01-01"), "certification")
+	invalidUser := userdate.ValidateEntityDate(nil, mustDate("2020-01-01"), "certification")
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantReasons []string
+		wantField   string
+	}{
+		{"nil", nil, codes.OK, nil, ""},
+		{"rule failure", beforeBirth, codes.FailedPrecondition, []string{userdate.ErrCodeBeforeBirth}, "entity_date"},
+		{"bad input", invalidUser, codes.InvalidArgument, []string{userdate.ErrCodeInvalidUser}, "user"},
+		{"wrapped", fmt.Errorf("checking: %w", beforeBirth), codes.FailedPrecondition, []string{userdate.ErrCodeBeforeBirth}, "entity_date"},
+		{"joined", errors.Join(invalidUser, beforeBirth), codes.InvalidArgument,
+			[]string{userdate.ErrCodeInvalidUser, userdate.ErrCodeBeforeBirth}, "user"},
+		{"other error", errors.New("database down"), codes.Unknown, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := GRPCStatusFor(tt.err)
+			if st.Code() != tt.wantCode {
+				t.Errorf("Code() = %v, want %v", st.Code(), tt.wantCode)
+			}
+			vs := violations(t, st)
+			if len(vs) != len(tt.wantReasons) {
+				t.Fatalf("violations = %v, want reasons %v", vs, tt.wantReasons)
+			}
+			for i, v := range vs {
+				if v.Reason != tt.wantReasons[i] || v.Description == "" {
+					t.Errorf("violation %d = %v, want reason %s", i, v, tt.wantReasons[i])
+				}
+			}
+			if len(vs) > 0 && vs[0].Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", vs[0].Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestGRPCStatusForPaths(t *testing.T) {
+	user := &userdate.User{ID: "user123", BirthDate: mustDate("1990-01-01")}
+	profile := userdate.Profile{User: user, Entities: []userdate.Entity{
+		{Type: "training", Date: mustDate("2020-01-01")},
+		{Type: "education", Date: mustDate("1989-01-01")},
+	}}
+	report := userdate.ValidateProfile(profile, userdate.WithFixedNow(mustDate("2025-07-18")))
+
+	vs := violations(t, GRPCStatusFor(report.Err()))
+	if len(vs) != 1 || vs[0].Field != "entities[1].date" {
+		t.Errorf("violations = %v, want one on entities[1].date", vs)
+	}
+}
+
+func TestFieldPath(t *testing.T) {
+	tests := []struct{ pointer, want string }{
+		{"", ""},
+		{"/user", "user"},
+		{"/user/birth_date", "user.birth_date"},
+		{"/entities/2/date", "entities[2].date"},
+		{"/a~1b/0/c~0d", "a/b[0].c~d"},
+	}
+	for _, tt := range tests {
+		if got := fieldPath(tt.pointer); got != tt.want {
+			t.Errorf("fieldPath(%q) = %q, want %q", tt.pointer, got, tt.want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := UnaryServerInterceptor()
+	dateErr := &userdate.DateValidationError{Code: userdate.ErrCodeFutureDate, Message: "in the future"}
+	plain := errors.New("database down")
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{"success", nil, codes.OK},
+		{"validation error", dateErr, codes.FailedPrecondition},
+		{"other error", plain, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := intercept(context.Background(), nil, nil, func(context.Context, any) (any, error) {
+				return nil, tt.err
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("status code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
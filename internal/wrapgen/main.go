@@ -0,0 +1,329 @@
+// Command wrapgen writes the v1 wrappers of a v2 package. It is run by go
+// generate in the directories of the v1 packages and declares, for every
+// exported name of the v2 package, an alias of its types, a copy of its
+// constants and variables, and a function forwarding to its functions,
+// with the doc comments of the v2 package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	src := flag.String("src", "", "directory of the v2 package")
+	importPath := flag.String("import", "", "import path of the v2 package")
+	pkg := flag.String("pkg", "", "name of the v1 package")
+	out := flag.String("out", "wrappers.go", "file to write")
+	flag.Parse()
+
+	code, err := generate(*src, *importPath, *pkg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wrapgen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "wrapgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generator writes the wrappers of the files of a package
+type generator struct {
+	fset      *token.FileSet
+	qualifier string // Name of the v2 package in the wrappers
+	buf       bytes.Buffer
+	imports   map[string]string // Import paths by name, of the names used
+	declared  map[string]bool   // Exported names already written
+	used      map[string]bool   // Package names the wrappers of a file use
+}
+
+// generate returns the formatted source of the wrappers of the package in
+// dir, imported as importPath, for the v1 package named pkg
+func generate(dir, importPath, pkg string) ([]byte, error) {
+	if dir == "" || importPath == "" || pkg == "" {
+		return nil, fmt.Errorf("-src, -import and -pkg are required")
+	}
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	g := &generator{
+		fset:      fset,
+		qualifier: path.Base(importPath),
+		imports:   map[string]string{path.Base(importPath): importPath},
+		declared:  make(map[string]bool),
+	}
+	for _, f := range files {
+		if err := g.file(f); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by wrapgen from %s; DO NOT EDIT.\n\npackage %s\n\nimport (\n", importPath, pkg)
+	names := make([]string, 0, len(g.imports))
+	for name := range g.imports {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		// The standard library comes first, as with goimports
+		if std(g.imports[a]) != std(g.imports[b]) {
+			if std(g.imports[a]) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(g.imports[a], g.imports[b])
+	})
+	for i, name := range names {
+		if i > 0 && std(g.imports[name]) != std(g.imports[names[i-1]]) {
+			out.WriteString("\n")
+		}
+		if p := g.imports[name]; path.Base(p) == name {
+			fmt.Fprintf(&out, "\t%q\n", p)
+		} else {
+			fmt.Fprintf(&out, "\t%s %q\n", name, p)
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// file writes the wrappers of the exported declarations of f
+func (g *generator) file(f *ast.File) error {
+	g.used = make(map[string]bool)
+	fileImports := make(map[string]string)
+	for _, spec := range f.Imports {
+		p, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(p)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		fileImports[name] = p
+	}
+	for _, decl := range f.Decls {
+		var err error
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil && decl.Name.IsExported() && !g.declared[decl.Name.Name] {
+				err = g.function(decl)
+			}
+		case *ast.GenDecl:
+			err = g.genDecl(decl)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return g.useImports(fileImports)
+}
+
+// useImports records the imports of a file that the wrappers written so far
+// refer to
+func (g *generator) useImports(fileImports map[string]string) error {
+	for name, p := range fileImports {
+		if !g.used[name] {
+			continue
+		}
+		if other, ok := g.imports[name]; ok && other != p {
+			return fmt.Errorf("import name %s is both %s and %s", name, other, p)
+		}
+		g.imports[name] = p
+	}
+	return nil
+}
+
+// genDecl writes the aliases of the exported types of decl, or the copies
+// of its exported constants or variables
+func (g *generator) genDecl(decl *ast.GenDecl) error {
+	var specs bytes.Buffer
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			if !spec.Name.IsExported() || g.declared[spec.Name.Name] {
+				continue
+			}
+			g.declared[spec.Name.Name] = true
+			g.comment(&specs, spec.Doc)
+			fmt.Fprintf(&specs, "%s%s = %s.%s%s\n", spec.Name.Name, g.typeParams(spec.TypeParams), g.qualifier, spec.Name.Name, typeArgs(spec.TypeParams))
+		case *ast.ValueSpec:
+			var names []string
+			for _, n := range spec.Names {
+				if n.IsExported() && !g.declared[n.Name] {
+					g.declared[n.Name] = true
+					names = append(names, n.Name)
+				}
+			}
+			if len(names) == 0 {
+				continue
+			}
+			g.comment(&specs, spec.Doc)
+			if decl.Tok == token.VAR && !strings.HasPrefix(names[0], "Err") {
+				fmt.Fprintf(&specs, "//\n// It is a copy: assign %s.%s to change validation.\n", g.qualifier, names[0])
+			}
+			values := make([]string, len(names))
+			for i, n := range names {
+				values[i] = g.qualifier + "." + n
+			}
+			fmt.Fprintf(&specs, "%s = %s\n", strings.Join(names, ", "), strings.Join(values, ", "))
+		}
+	}
+	if specs.Len() == 0 {
+		return nil
+	}
+	g.buf.WriteString("\n")
+	g.comment(&g.buf, decl.Doc)
+	if decl.Lparen.IsValid() {
+		fmt.Fprintf(&g.buf, "%s (\n%s)\n", decl.Tok, specs.String())
+	} else {
+		// The comments of a single spec go before the keyword
+		spec := specs.String()
+		for strings.HasPrefix(spec, "//") {
+			line, rest, _ := strings.Cut(spec, "\n")
+			g.buf.WriteString(line + "\n")
+			spec = rest
+		}
+		fmt.Fprintf(&g.buf, "%s %s", decl.Tok, spec)
+	}
+	return nil
+}
+
+// function writes a function forwarding to decl
+func (g *generator) function(decl *ast.FuncDecl) error {
+	g.declared[decl.Name.Name] = true
+	var params, args []string
+	variadic := false
+	for i, field := range fieldList(decl.Type.Params) {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		var fieldNames []string
+		for j, n := range names {
+			name := n.Name
+			if name == "_" {
+				name = fmt.Sprintf("p%d_%d", i, j)
+			}
+			fieldNames = append(fieldNames, name)
+			args = append(args, name)
+		}
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			variadic = true
+		}
+		params = append(params, strings.Join(fieldNames, ", ")+" "+g.node(field.Type))
+	}
+	call := fmt.Sprintf("%s.%s%s(%s", g.qualifier, decl.Name.Name, typeArgs(decl.Type.TypeParams), strings.Join(args, ", "))
+	if variadic {
+		call += "..."
+	}
+	call += ")"
+
+	results := ""
+	if decl.Type.Results != nil {
+		results = " " + g.node(&ast.FuncType{Params: &ast.FieldList{}, Results: decl.Type.Results})[len("func()"):]
+		call = "return " + call
+	}
+	g.buf.WriteString("\n")
+	g.comment(&g.buf, decl.Doc)
+	fmt.Fprintf(&g.buf, "func %s%s(%s)%s {\n\t%s\n}\n", decl.Name.Name, g.typeParams(decl.Type.TypeParams), strings.Join(params, ", "), results, call)
+	return nil
+}
+
+// typeParams returns the type parameter list of a declaration
+func (g *generator) typeParams(list *ast.FieldList) string {
+	if list == nil {
+		return ""
+	}
+	var params []string
+	for _, field := range list.List {
+		params = append(params, identNames(field.Names)+" "+g.node(field.Type))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+// typeArgs returns the type parameters of a declaration as type arguments
+func typeArgs(list *ast.FieldList) string {
+	if list == nil {
+		return ""
+	}
+	var names []string
+	for _, field := range list.List {
+		names = append(names, identNames(field.Names))
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// comment writes a doc comment
+func (g *generator) comment(b *bytes.Buffer, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, c := range doc.List {
+		b.WriteString(c.Text)
+		b.WriteString("\n")
+	}
+}
+
+// node returns the source of an expression, recording the packages it uses
+func (g *generator) node(n ast.Node) string {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				g.used[x.Name] = true
+			}
+		}
+		return true
+	})
+	var b bytes.Buffer
+	if err := format.Node(&b, g.fset, n); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+func fieldList(list *ast.FieldList) []*ast.Field {
+	if list == nil {
+		return nil
+	}
+	return list.List
+}
+
+func identNames(idents []*ast.Ident) string {
+	names := make([]string, len(idents))
+	for i, n := range idents {
+		names[i] = n.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// std reports whether an import path is of the standard library
+func std(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWrappersUpToDate(t *testing.T) {
+	tests := []struct {
+		src, importPath, pkg, out string
+	}{
+		{"../../v2/core", "github.com/i2sac/user-entity-date-verification/v2/core", "userdate", "../../wrappers.go"},
+		{"../../v2/agecalc", "github.com/i2sac/user-entity-date-verification/v2/agecalc", "agecalc", "../../agecalc/wrappers.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			want, err := generate(tt.src, tt.importPath, tt.pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(tt.out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s is out of date, run go generate ./...", tt.out)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(`package a
+
+import "time"
+
+// Limit is a limit
+const Limit = 3
+
+// Default is the default
+var Default = 1
+
+// ErrX is an error
+var ErrX = error(nil)
+
+// Pair is a pair
+type Pair[T any] struct{ A, B T }
+
+// Swap swaps a pair
+func Swap[T any](p Pair[T]) Pair[T] { return Pair[T]{p.B, p.A} }
+
+// After returns d after t
+func After(t time.Time, _ int, d ...time.Duration) time.Time { return t }
+
+func unexported() {}
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := generate(dir, "example.com/v2/a", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"\t\"time\"\n\n\t\"example.com/v2/a\"\n",
+		"const Limit = a.Limit",
+		"// It is a copy: assign a.Default to change validation.\nvar Default = a.Default",
+		"// ErrX is an error\nvar ErrX = a.ErrX",
+		"type Pair[T any] = a.Pair[T]",
+		"func Swap[T any](p Pair[T]) Pair[T] {\n\treturn a.Swap[T](p)\n}",
+		"func After(t time.Time, p1_0 int, d ...time.Duration) time.Time {\n\treturn a.After(t, p1_0, d...)\n}",
+	} {
+		if !strings.Contains(string(code), want) {
+			t.Errorf("generate() lacks %q:\n%s", want, code)
+		}
+	}
+	if strings.Contains(string(code), "unexported") {
+		t.Errorf("generate() wraps unexported functions:\n%s", code)
+	}
+}
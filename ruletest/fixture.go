@@ -0,0 +1,122 @@
+package ruletest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// Fixture is a file of scenarios that services share as regression tests,
+// instead of copying table tests. It is JSON:
+//
+//	{
+//	  "name": "French licenses",
+//	  "now": "2024-06-15T00:00:00Z",
+//	  "jurisdiction": "FR",
+//	  "rule": "minimum_age",
+//	  "scenarios": [
+//	    {
+//	      "name": "licensed at 16",
+//	      "user": {"id": "u1", "birth_date": "2008-01-01T00:00:00Z"},
+//	      "entity": {"type": "license", "date": "2024-02-01T00:00:00Z"},
+//	      "want": [{"code": "UNREALISTIC_AGE"}]
+//	    }
+//	  ]
+//	}
+//
+// The user and the entity are in the JSON of userdate.User and
+// userdate.Entity. A finding of want is an error unless its severity is
+// "warning" or "info", and a scenario without want passes. Now is the
+// reference time, which fixtures should set so that they keep passing;
+// jurisdiction and preset select rules as userdate.NewRuleConfig, and
+// rule is the rule under test as in Suite.
+type Fixture struct {
+	Name         string     `json:"name"`
+	Now          time.Time  `json:"now,omitzero"`
+	Jurisdiction string     `json:"jurisdiction,omitempty"`
+	Preset       string     `json:"preset,omitempty"`
+	Rule         string     `json:"rule,omitempty"`
+	Scenarios    []Scenario `json:"scenarios"`
+}
+
+// LoadFixture reads a fixture file. Unknown fields are errors, so that a
+// misspelled setting does not silently test the defaults.
+func LoadFixture(path string) (Fixture, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Fixture{}, err
+	}
+	defer file.Close()
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	var f Fixture
+	if err := dec.Decode(&f); err != nil {
+		return Fixture{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if f.Name == "" {
+		f.Name = filepath.Base(path)
+	}
+	return f, nil
+}
+
+// LoadFixtures reads the fixture files matching a pattern of
+// filepath.Glob, such as "testdata/scenarios/*.json"
+func LoadFixtures(pattern string) ([]Fixture, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		f, err := LoadFixture(path)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+// Suite returns the suite of the fixture, its settings followed by opts,
+// such as the options of the service that shares the fixture
+func (f Fixture) Suite(opts ...userdate.Option) (Suite, error) {
+	var options []userdate.Option
+	if f.Jurisdiction != "" || f.Preset != "" {
+		rules, err := userdate.NewRuleConfig(f.Jurisdiction, f.Preset)
+		if err != nil {
+			return Suite{}, fmt.Errorf("fixture %s: %w", f.Name, err)
+		}
+		options = append(options, userdate.WithRules(rules))
+	}
+	if !f.Now.IsZero() {
+		options = append(options, userdate.WithFixedNow(f.Now))
+	}
+	return Suite{Rule: f.Rule, Options: append(options, opts...)}, nil
+}
+
+// RunFixtures runs the scenarios of the fixture files matching pattern as
+// subtests of t, one per file, with opts added to the settings of each
+func RunFixtures(t *testing.T, pattern string, opts ...userdate.Option) {
+	t.Helper()
+	fixtures, err := LoadFixtures(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("no fixtures match %s", pattern)
+	}
+	for _, f := range fixtures {
+		t.Run(f.Name, func(t *testing.T) {
+			suite, err := f.Suite(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			Run(t, suite, f.Scenarios)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package ruletest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func TestRunFixtures(t *testing.T) {
+	RunFixtures(t, filepath.Join("..", "testdata", "scenarios", "*.json"))
+}
+
+func TestLoadFixture(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+		check   func(t *testing.T, f Fixture)
+	}{
+		{
+			name: "settings",
+			data: `{"now": "2024-06-15T00:00:00Z", "jurisdiction": "FR", "rule": "minimum_age", "scenarios": [
+				{"name": "s", "user": {"id": "u", "birth_date": "2008-01-01T00:00:00Z"}, "entity": {"type": "license", "date": "2024-02-01T00:00:00Z"},
+				 "want": [{"code": "UNREALISTIC_AGE", "severity": "warning"}]}]}`,
+			check: func(t *testing.T, f Fixture) {
+				if f.Name != "settings.json" {
+					t.Errorf("Name = %q, want the file name", f.Name)
+				}
+				if len(f.Scenarios) != 1 || f.Scenarios[0].Want[0] != (Want{Code: userdate.ErrCodeUnrealisticAge, Severity: userdate.SeverityWarning}) {
+					t.Errorf("Scenarios = %+v", f.Scenarios)
+				}
+				suite, err := f.Suite()
+				if err != nil || suite.Rule != "minimum_age" || len(suite.Options) != 2 {
+					t.Errorf("Suite() = %+v, %v", suite, err)
+				}
+			},
+		},
+		{name: "unknown field", data: `{"jurisdication": "FR", "scenarios": []}`, wantErr: "unknown field"},
+		{name: "invalid date", data: `{"scenarios": [{"user": {"id": "u", "birth_date": "2008-01-01"}}]}`, wantErr: "parsing time"},
+		{
+			name: "unknown jurisdiction",
+			data: `{"jurisdiction": "XX", "scenarios": []}`,
+			check: func(t *testing.T, f Fixture) {
+				if _, err := f.Suite(); err == nil || !strings.Contains(err.Error(), "unknown jurisdiction") {
+					t.Errorf("Suite() error = %v, want unknown jurisdiction", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := LoadFixture(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadFixture() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, f)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+//go:build userdate_nodata
+
+package ruletest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// TestMain loads the datasets of the data directory of the module, which
+// builds with the userdate_nodata tag leave out
+func TestMain(m *testing.M) {
+	if err := userdate.LoadRuleData(os.DirFS("../v2/core/data")); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
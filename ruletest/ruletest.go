@@ -0,0 +1,151 @@
+// Package ruletest runs table-driven tests of validation rules, so that
+// teams plugging their own rules into a validator test them the way the
+// core rules are tested.
+//
+// Custom rules are written as options: thresholds and rule data through
+// userdate.WithRules, and plug-ins such as a userdate.RevocationChecker or
+// a userdate.JurisdictionResolver. A Suite names the rule under test and
+// the options that set it up, and each Scenario gives a user, an entity
+// and the findings the rule should produce:
+//
+//	func TestRevocationChecker(t *testing.T) {
+//		suite := ruletest.Suite{Rule: "revocation", Options: []userdate.Option{userdate.WithRevocationChecker(checker)}}
+//		ruletest.Run(t, suite, []ruletest.Scenario{
+//			{Name: "revoked", User: user, Entity: revoked, Want: []ruletest.Want{{Code: userdate.ErrCodeRevoked}}},
+//			{Name: "valid", User: user, Entity: valid},
+//		})
+//	}
+package ruletest
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// Want is an expected finding. Severity is userdate.SeverityError unless
+// set.
+type Want struct {
+	Code     string            `json:"code"`
+	Severity userdate.Severity `json:"severity,omitempty"`
+}
+
+// String returns the code and severity of the finding
+func (w Want) String() string {
+	return fmt.Sprintf("%s (%s)", w.Code, w.Severity)
+}
+
+// Scenario is a case of a Suite. Want lists the findings the entity gets,
+// in any order; none means that it passes.
+type Scenario struct {
+	Name   string          `json:"name"`
+	User   *userdate.User  `json:"user"`
+	Entity userdate.Entity `json:"entity"`
+	Want   []Want          `json:"want,omitempty"`
+}
+
+// Suite is the setup of the rule under test
+type Suite struct {
+	// Rule is the name of the rule under test, as in the steps of
+	// userdate.Explain, such as "revocation". Only its findings are
+	// compared. When empty, every finding of the report is.
+	Rule string
+
+	// Options configure the validator, including the rule under test
+	Options []userdate.Option
+}
+
+// Run runs each scenario as a subtest of t, reporting the findings that
+// differ from the expected ones
+func Run(t *testing.T, suite Suite, scenarios []Scenario) {
+	t.Helper()
+	v := userdate.NewValidator(suite.Options...)
+	for _, sc := range scenarios {
+		t.Run(sc.Name, func(t *testing.T) {
+			if diff := check(v, suite.Rule, sc); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+// Diff returns a readable difference between the findings of a scenario
+// and the expected ones, or "" when they match
+func Diff(suite Suite, sc Scenario) string {
+	return check(userdate.NewValidator(suite.Options...), suite.Rule, sc)
+}
+
+// check returns the difference of a scenario under v
+func check(v *userdate.Validator, rule string, sc Scenario) string {
+	e := v.Explain(sc.User, sc.Entity)
+	var found []*userdate.DateValidationError
+	if rule == "" {
+		found = e.Report.Findings
+	} else {
+		i := slices.IndexFunc(e.Steps, func(s userdate.Step) bool { return s.Rule == rule })
+		if i < 0 {
+			return fmt.Sprintf("no rule %q among the rules of the validator", rule)
+		}
+		if e.Steps[i].Verdict == userdate.VerdictSkipped && len(sc.Want) > 0 {
+			return fmt.Sprintf("rule %q was skipped, after the findings %v", rule, codes(e.Report.Findings))
+		}
+		found = e.Steps[i].Findings
+	}
+	got := make([]Want, len(found))
+	for i, f := range found {
+		got[i] = Want{Code: f.Code, Severity: f.Severity}
+	}
+	return diff(sc.Want, got, found)
+}
+
+// diff lists the expected findings, prefixed with "-" when missing, then
+// the unexpected ones, prefixed with "+" and followed by their message
+func diff(want, got []Want, found []*userdate.DateValidationError) string {
+	matched := make([]bool, len(got))
+	var b strings.Builder
+	differ := false
+	for _, w := range slices.SortedFunc(slices.Values(want), compareWants) {
+		i := -1
+		for j, g := range got {
+			if g == w && !matched[j] {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			differ = true
+			fmt.Fprintf(&b, "- %v\n", w)
+			continue
+		}
+		matched[i] = true
+		fmt.Fprintf(&b, "  %v\n", w)
+	}
+	for i, g := range got {
+		if !matched[i] {
+			differ = true
+			fmt.Fprintf(&b, "+ %v: %s\n", g, found[i].Message)
+		}
+	}
+	if !differ {
+		return ""
+	}
+	return "findings differ (-want +got):\n" + b.String()
+}
+
+// compareWants orders findings by code, then severity
+func compareWants(a, b Want) int {
+	return cmp.Or(cmp.Compare(a.Code, b.Code), cmp.Compare(a.Severity, b.Severity))
+}
+
+// codes returns the codes of findings
+func codes(findings []*userdate.DateValidationError) []string {
+	out := make([]string, len(findings))
+	for i, f := range findings {
+		out[i] = f.Code
+	}
+	return out
+}
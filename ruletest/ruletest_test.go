@@ -0,0 +1,114 @@
+package ruletest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// revokedChecker is a RevocationChecker revoking one credential
+type revokedChecker struct{}
+
+func (revokedChecker) IsRevoked(_ context.Context, issuer, id string, _ time.Time) (bool, error) {
+	return issuer == "acme" && id == "cert-1", nil
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var (
+	user  = &userdate.User{ID: "user123", BirthDate: date("1990-01-01")}
+	suite = Suite{
+		Rule:    "revocation",
+		Options: []userdate.Option{userdate.WithFixedNow(date("2020-06-15")), userdate.WithRevocationChecker(revokedChecker{})},
+	}
+)
+
+func TestRun(t *testing.T) {
+	Run(t, suite, []Scenario{
+		{
+			Name:   "revoked",
+			User:   user,
+			Entity: userdate.Entity{ID: "cert-1", Issuer: "acme", Type: "certification", Date: date("2019-01-01")},
+			Want:   []Want{{Code: userdate.ErrCodeRevoked}},
+		},
+		{
+			Name:   "valid",
+			User:   user,
+			Entity: userdate.Entity{ID: "cert-2", Issuer: "acme", Type: "certification", Date: date("2019-01-01")},
+		},
+		{
+			Name:   "skipped after a future date",
+			User:   user,
+			Entity: userdate.Entity{ID: "cert-2", Issuer: "acme", Type: "certification", Date: date("2021-01-01")},
+		},
+	})
+}
+
+func TestDiff(t *testing.T) {
+	revoked := userdate.Entity{ID: "cert-1", Issuer: "acme", Type: "certification", Date: date("2019-01-01")}
+
+	tests := []struct {
+		name  string
+		suite Suite
+		sc    Scenario
+		want  []string // Lines of the diff, none when it is empty
+	}{
+		{
+			name:  "match",
+			suite: suite,
+			sc:    Scenario{User: user, Entity: revoked, Want: []Want{{Code: userdate.ErrCodeRevoked}}},
+		},
+		{
+			name:  "missing finding",
+			suite: suite,
+			sc:    Scenario{User: user, Entity: revoked, Want: []Want{{Code: userdate.ErrCodeRevoked}, {Code: userdate.ErrCodeRevocationUnknown, Severity: userdate.SeverityWarning}}},
+			want:  []string{"findings differ (-want +got):", "  REVOKED (error)", "- REVOCATION_UNKNOWN (warning)"},
+		},
+		{
+			name:  "unexpected finding",
+			suite: suite,
+			sc:    Scenario{User: user, Entity: revoked},
+			want:  []string{"findings differ (-want +got):", "+ REVOKED (error): "},
+		},
+		{
+			name:  "wrong severity",
+			suite: suite,
+			sc:    Scenario{User: user, Entity: revoked, Want: []Want{{Code: userdate.ErrCodeRevoked, Severity: userdate.SeverityWarning}}},
+			want:  []string{"- REVOKED (warning)", "+ REVOKED (error): "},
+		},
+		{
+			name:  "unknown rule",
+			suite: Suite{Rule: "revoked", Options: suite.Options},
+			sc:    Scenario{User: user, Entity: revoked},
+			want:  []string{`no rule "revoked"`},
+		},
+		{
+			name:  "every finding",
+			suite: Suite{Options: suite.Options},
+			sc:    Scenario{User: user, Entity: revoked, Want: []Want{{Code: userdate.ErrCodeRevoked}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.suite, tt.sc)
+			if len(tt.want) == 0 && got != "" {
+				t.Errorf("Diff() = %q, want none", got)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(got, line) {
+					t.Errorf("Diff() = %q, want it to contain %q", got, line)
+				}
+			}
+		})
+	}
+}
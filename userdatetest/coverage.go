@@ -0,0 +1,116 @@
+package userdatetest
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// Coverage records the error codes a test suite triggers, so that it can
+// fail when some code the consumer handles was never exercised. It is safe
+// for concurrent use, such as by parallel tests.
+type Coverage struct {
+	codes []string
+	stats *userdate.Stats
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// NewCoverage returns a coverage of codes, or of every code of
+// userdate.ErrorCodes when none are given
+func NewCoverage(codes ...string) *Coverage {
+	if len(codes) == 0 {
+		codes = userdate.ErrorCodes()
+	}
+	return &Coverage{
+		codes: slices.Sorted(slices.Values(codes)),
+		stats: userdate.NewStats(),
+		seen:  make(map[string]int),
+	}
+}
+
+// Option records the findings of every validation of a validator. It sets
+// the userdate.Stats of the validator, so a validator that has its own
+// should pass its reports to Record instead.
+func (c *Coverage) Option() userdate.Option {
+	return userdate.WithStats(c.stats)
+}
+
+// Record records the findings of a report
+func (c *Coverage) Record(report *userdate.Report) {
+	if report == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, f := range report.Findings {
+		c.seen[f.Code]++
+	}
+}
+
+// RecordProfile records the findings of every report of a profile
+func (c *Coverage) RecordProfile(report *userdate.ProfileReport) {
+	if report == nil {
+		return
+	}
+	for _, r := range report.Reports {
+		c.Record(r)
+	}
+}
+
+// RecordError records the codes of the DateValidationErrors in err,
+// including wrapped and joined ones
+func (c *Coverage) RecordError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recordError(err)
+}
+
+// recordError records the codes of err with c.mu held
+func (c *Coverage) recordError(err error) {
+	switch e := err.(type) {
+	case *userdate.DateValidationError:
+		if e != nil {
+			c.seen[e.Code]++
+		}
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			c.recordError(err)
+		}
+	case interface{ Unwrap() error }:
+		c.recordError(e.Unwrap())
+	}
+}
+
+// Counts returns how many findings of each code were recorded, of every
+// code and not only the covered ones
+func (c *Coverage) Counts() map[string]int {
+	c.mu.Lock()
+	counts := maps.Clone(c.seen)
+	c.mu.Unlock()
+	for code, n := range c.stats.Snapshot().ByCode {
+		counts[code] += int(n)
+	}
+	return counts
+}
+
+// Missing returns the codes of the coverage that were never recorded,
+// sorted
+func (c *Coverage) Missing() []string {
+	counts := c.Counts()
+	return slices.DeleteFunc(slices.Clone(c.codes), func(code string) bool { return counts[code] > 0 })
+}
+
+// Check fails t when some codes of the coverage were never recorded. Call
+// it after the tests that exercise them, such as in a final test or with
+// t.Cleanup.
+func (c *Coverage) Check(t testing.TB) {
+	t.Helper()
+	if missing := c.Missing(); len(missing) > 0 {
+		t.Errorf("error codes never triggered: %v", missing)
+	}
+}
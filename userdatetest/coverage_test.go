@@ -0,0 +1,60 @@
+package userdatetest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func TestCoverage(t *testing.T) {
+	user := &userdate.User{ID: "u1", BirthDate: time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC)}
+	cov := NewCoverage(userdate.ErrCodeBeforeBirth, userdate.ErrCodeFutureDate, userdate.ErrCodeRevoked, userdate.ErrCodeExpired)
+	if got := cov.Missing(); len(got) != 4 {
+		t.Fatalf("Missing() = %v, want every code", got)
+	}
+
+	// Through the validator
+	v := userdate.NewValidator(userdate.WithFixedNow(now), cov.Option())
+	v.CheckEntityDate(user, time.Date(1989, 1, 1, 0, 0, 0, 0, time.UTC), "employment")
+
+	// From reports and errors of elsewhere
+	cov.Record(userdate.CheckEntity(user, userdate.Entity{Type: "certification", Date: time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC), Status: userdate.StatusRevoked}))
+	future := userdate.ValidateEntityDate(user, now.AddDate(1, 0, 0), "employment", userdate.WithFixedNow(now))
+	cov.RecordError(fmt.Errorf("saving: %w", errors.Join(errors.New("other"), future)))
+
+	if got, want := cov.Missing(), []string{userdate.ErrCodeExpired}; !slices.Equal(got, want) {
+		t.Errorf("Missing() = %v, want %v", got, want)
+	}
+	counts := cov.Counts()
+	if counts[userdate.ErrCodeBeforeBirth] != 1 || counts[userdate.ErrCodeFutureDate] != 1 || counts[userdate.ErrCodeRevoked] != 1 {
+		t.Errorf("Counts() = %v", counts)
+	}
+
+	cov.Record(userdate.CheckEntity(user, userdate.Entity{Type: "certification", Date: time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC), Status: userdate.StatusExpired}))
+	cov.Check(t)
+
+}
+
+func TestNewCoverageDefault(t *testing.T) {
+	if got := NewCoverage().Missing(); !slices.Equal(got, userdate.ErrorCodes()) {
+		t.Errorf("NewCoverage().Missing() = %v, want every code", got)
+	}
+
+	// The generated violations cover their codes
+	cov := NewCoverage(Violations()...)
+	profile, err := GenerateProfile(3, Options{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range Mutations(profile) {
+		cov.RecordProfile(userdate.ValidateProfile(m.Profile, userdate.WithFixedNow(now)))
+	}
+	if missing := cov.Missing(); len(missing) > 0 {
+		t.Errorf("Missing() = %v after the mutations, counts %v", missing, slices.Sorted(maps.Keys(cov.Counts())))
+	}
+}
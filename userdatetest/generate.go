@@ -0,0 +1,178 @@
+// Package userdatetest generates random, realistic profiles of users for
+// load tests and demo data. The profiles pass the default rules of the
+// userdate package unless asked to carry violations.
+package userdatetest
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// Default ages of the users of generated profiles
+const (
+	DefaultMinAge = 25
+	DefaultMaxAge = 65
+)
+
+// Options control the profiles of GenerateProfile
+type Options struct {
+	// Now is the reference time of the profile: its entities end by then
+	// and the user has an age between MinAge and MaxAge. It is the current
+	// time when zero; validate the profile with userdate.WithFixedNow(Now)
+	// to keep it valid as time passes.
+	Now time.Time
+
+	MinAge int // DefaultMinAge when zero
+	MaxAge int // DefaultMaxAge when zero
+
+	// Corrupt lists the error codes the profile should carry, each in one
+	// entity, see Violations
+	Corrupt []string
+}
+
+// Typical given and family names of the generated users
+var (
+	givenNames  = []string{"Alice", "Amara", "Ben", "Camille", "Diego", "Elif", "Farah", "Hiro", "Ines", "Jonas", "Lena", "Mateo", "Nadia", "Omar", "Priya", "Sven", "Yuki", "Zoe"}
+	familyNames = []string{"Becker", "Costa", "Dubois", "Garcia", "Haddad", "Kowalski", "Martin", "Nakamura", "Okafor", "Rossi", "Schmidt", "Silva", "Tanaka", "Walsh"}
+)
+
+// GenerateProfile returns a random profile, the same for the same seed and
+// options. The user went to secondary school, often to university with an
+// internship, then held a chain of jobs with short gaps, the last one
+// ongoing; most users have a driving license and some certifications.
+// With Corrupt, violations are then injected, one entity each. It returns
+// an error for an unknown code, or a code the profile has no entity to
+// carry.
+func GenerateProfile(seed int64, opts Options) (userdate.Profile, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = day(now)
+	minAge, maxAge := cmp.Or(opts.MinAge, DefaultMinAge), cmp.Or(opts.MaxAge, DefaultMaxAge)
+	if minAge < 12 || maxAge < minAge {
+		return userdate.Profile{}, fmt.Errorf("userdatetest: invalid ages %d to %d, want 12 or older", minAge, maxAge)
+	}
+
+	r := rand.New(rand.NewPCG(uint64(seed), 0x75736572))
+	age := minAge + r.IntN(maxAge-minAge+1)
+	birth := now.AddDate(-age, 0, -r.IntN(365))
+	g := generator{r: r, now: now, birth: birth}
+	g.generate()
+
+	profile := userdate.Profile{
+		User: &userdate.User{
+			ID:        fmt.Sprintf("user-%08x", r.Uint32()),
+			BirthDate: birth,
+			Name:      givenNames[r.IntN(len(givenNames))] + " " + familyNames[r.IntN(len(familyNames))],
+		},
+		Entities: g.entities,
+	}
+	slices.SortStableFunc(profile.Entities, func(a, b userdate.Entity) int { return a.Date.Compare(b.Date) })
+	for i := range profile.Entities {
+		profile.Entities[i].ID = fmt.Sprintf("e%d", i+1)
+	}
+
+	used := make(map[int]bool)
+	for _, code := range opts.Corrupt {
+		if err := inject(r, &profile, code, now, used); err != nil {
+			return userdate.Profile{}, err
+		}
+	}
+	return profile, nil
+}
+
+// generator builds the entities of a profile
+type generator struct {
+	r        *rand.Rand
+	now      time.Time
+	birth    time.Time
+	entities []userdate.Entity
+}
+
+// generate adds the education, jobs, license and certifications of the
+// user
+func (g *generator) generate() {
+	// Secondary school from the September of the year the user turns 11
+	// until the June 7 years later
+	start := time.Date(g.birth.Year()+11, time.September, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(start.Year()+7, time.June, 30, 0, 0, 0, 0, time.UTC)
+	if !end.Before(g.now) {
+		g.add("secondary_education", start, time.Time{})
+		return
+	}
+	g.add("secondary_education", start, end)
+
+	if years := 3 + g.r.IntN(3); g.r.IntN(10) < 7 && end.AddDate(years, 3, 0).Before(g.now) {
+		start = time.Date(end.Year(), time.September, 1, 0, 0, 0, 0, time.UTC)
+		end = time.Date(start.Year()+years, time.June, 30, 0, 0, 0, 0, time.UTC)
+		g.add("tertiary_education", start, end)
+		if g.r.IntN(2) == 0 {
+			internship := time.Date(end.Year()-1, time.March, 1+g.r.IntN(28), 0, 0, 0, 0, time.UTC)
+			g.add("internship", internship, internship.AddDate(0, 3+g.r.IntN(3), 0))
+		}
+	}
+
+	// Jobs, each starting at most 4 months after the previous one ends
+	for {
+		start := end.AddDate(0, g.r.IntN(4), 1+g.r.IntN(28))
+		if !start.Before(g.now) {
+			break
+		}
+		end = start.AddDate(0, 12+g.r.IntN(72), 0)
+		if !end.Before(g.now.AddDate(0, -1, 0)) {
+			g.add("employment", start, time.Time{})
+			break
+		}
+		g.add("employment", start, end)
+	}
+
+	if from := g.birth.AddDate(16, 0, 0); g.r.IntN(100) < 85 && from.Before(g.now) {
+		g.add("license", g.between(from, g.birth.AddDate(25, 0, 0)), time.Time{})
+	}
+	if from := g.birth.AddDate(20, 0, 0); from.Before(g.now) {
+		for range g.r.IntN(4) {
+			types := []string{"certification", "training"}
+			g.add(types[g.r.IntN(len(types))], g.between(from, g.now), time.Time{})
+		}
+	}
+}
+
+// add adds an entity claimed by the user or verified with its issuer
+func (g *generator) add(entityType string, date, endDate time.Time) {
+	status := userdate.StatusClaimed
+	if g.r.IntN(2) == 0 {
+		status = userdate.StatusVerified
+	}
+	g.entities = append(g.entities, userdate.Entity{Type: entityType, Date: date, EndDate: endDate, Status: status})
+}
+
+// between returns a random day from from, before to and now. From must be
+// before now.
+func (g *generator) between(from, to time.Time) time.Time {
+	to = minTime(to, g.now)
+	days := int(to.Sub(from).Hours() / 24)
+	if days <= 0 {
+		return from
+	}
+	return from.AddDate(0, 0, g.r.IntN(days))
+}
+
+// day returns the date of t at midnight UTC
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// minTime returns the earlier of two times
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,90 @@
+package userdatetest
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+var now = time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+// findings returns the codes of the findings of a profile under the
+// default rules
+func findings(p userdate.Profile) []string {
+	var codes []string
+	for _, r := range userdate.ValidateProfile(p, userdate.WithFixedNow(now)).Reports {
+		for _, f := range r.Findings {
+			codes = append(codes, f.Code)
+		}
+	}
+	return codes
+}
+
+func TestGenerateProfile(t *testing.T) {
+	for _, opts := range []Options{{Now: now}, {Now: now, MinAge: 18, MaxAge: 30}, {Now: now, MinAge: 80, MaxAge: 100}} {
+		for seed := range int64(300) {
+			p, err := GenerateProfile(seed, opts)
+			if err != nil {
+				t.Fatalf("GenerateProfile(%d) error = %v", seed, err)
+			}
+			if codes := findings(p); len(codes) > 0 {
+				t.Errorf("GenerateProfile(%d, ages %d-%d) has findings %v: %+v", seed, opts.MinAge, opts.MaxAge, codes, p)
+			}
+			if age := p.User.GetAgeAtDate(now); age < cmp.Or(opts.MinAge, DefaultMinAge) || age > cmp.Or(opts.MaxAge, DefaultMaxAge) {
+				t.Errorf("GenerateProfile(%d) age = %d", seed, age)
+			}
+		}
+	}
+
+	a, _ := GenerateProfile(42, Options{Now: now})
+	b, _ := GenerateProfile(42, Options{Now: now})
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("GenerateProfile() differs for the same seed")
+	}
+}
+
+func TestGenerateProfileCorrupt(t *testing.T) {
+	for _, code := range Violations() {
+		t.Run(code, func(t *testing.T) {
+			for seed := range int64(200) {
+				p, err := GenerateProfile(seed, Options{Now: now, Corrupt: []string{code}})
+				if err != nil {
+					t.Fatalf("GenerateProfile(%d) error = %v", seed, err)
+				}
+				if codes := findings(p); !slices.Equal(codes, []string{code}) {
+					t.Fatalf("GenerateProfile(%d) findings = %v, want %v: %+v", seed, codes, code, p)
+				}
+			}
+		})
+	}
+
+	t.Run("several", func(t *testing.T) {
+		p, err := GenerateProfile(7, Options{Now: now, Corrupt: []string{userdate.ErrCodeRevoked, userdate.ErrCodeBeforeBirth}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		codes := findings(p)
+		slices.Sort(codes)
+		if want := []string{userdate.ErrCodeBeforeBirth, userdate.ErrCodeRevoked}; !slices.Equal(codes, want) {
+			t.Errorf("findings = %v, want %v", codes, want)
+		}
+	})
+
+	for _, tt := range []struct {
+		name string
+		opts Options
+	}{
+		{"unknown code", Options{Now: now, Corrupt: []string{"NO_SUCH_CODE"}}},
+		{"invalid ages", Options{Now: now, MinAge: 40, MaxAge: 30}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateProfile(1, tt.opts); err == nil {
+				t.Errorf("GenerateProfile() expected error but got none")
+			}
+		})
+	}
+}
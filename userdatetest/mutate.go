@@ -0,0 +1,61 @@
+package userdatetest
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// Mutation is a variant of a profile that violates the rule of Code
+type Mutation struct {
+	Code    string
+	Profile userdate.Profile
+}
+
+// MutateToViolate returns a copy of a valid profile changed to get exactly
+// one finding, of code, see Violations. It changes or adds one entity, or
+// for CAREER_GAP cuts the timeline short before one. The same profile and
+// code give the same variant, with dates after the current time for
+// FUTURE_DATE. It returns an error for an unknown code, or a code the
+// profile has no entity to carry.
+func MutateToViolate(profile userdate.Profile, code string) (userdate.Profile, error) {
+	if profile.User == nil {
+		return userdate.Profile{}, errors.New("userdatetest: profile without a user")
+	}
+	h := fnv.New64a()
+	h.Write([]byte(profile.User.ID + "\x00" + code))
+	r := rand.New(rand.NewPCG(h.Sum64(), 0x6d757461))
+
+	variant := clone(profile)
+	if err := inject(r, &variant, code, day(time.Now()), make(map[int]bool)); err != nil {
+		return userdate.Profile{}, err
+	}
+	return variant, nil
+}
+
+// Mutations returns a variant of a valid profile for each code of
+// Violations, as MutateToViolate, so that a consumer can check that its
+// error handling covers every code. Codes the profile has no entity to
+// carry are left out.
+func Mutations(profile userdate.Profile) []Mutation {
+	var mutations []Mutation
+	for _, code := range Violations() {
+		if variant, err := MutateToViolate(profile, code); err == nil {
+			mutations = append(mutations, Mutation{Code: code, Profile: variant})
+		}
+	}
+	return mutations
+}
+
+// clone returns a deep copy of a profile
+func clone(p userdate.Profile) userdate.Profile {
+	user := *p.User
+	p.User = &user
+	p.Entities = slices.Clone(p.Entities)
+	p.GapExplanations = slices.Clone(p.GapExplanations)
+	return p
+}
@@ -0,0 +1,66 @@
+package userdatetest
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+func TestMutations(t *testing.T) {
+	for seed := range int64(50) {
+		profile, err := GenerateProfile(seed, Options{Now: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		original := clone(profile)
+
+		mutations := Mutations(profile)
+		if len(mutations) != len(Violations()) {
+			t.Errorf("Mutations(%d) = %d variants, want %d", seed, len(mutations), len(Violations()))
+		}
+		for _, m := range mutations {
+			if codes := findings(m.Profile); !slices.Equal(codes, []string{m.Code}) {
+				t.Errorf("Mutations(%d) %s variant findings = %v, want %v", seed, m.Code, codes, m.Code)
+			}
+		}
+		if !reflect.DeepEqual(profile, original) {
+			t.Errorf("Mutations(%d) changed the profile", seed)
+		}
+	}
+}
+
+func TestMutateToViolate(t *testing.T) {
+	profile, err := GenerateProfile(1, Options{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, errA := MutateToViolate(profile, userdate.ErrCodeBeforeBirth)
+	b, errB := MutateToViolate(profile, userdate.ErrCodeBeforeBirth)
+	if errA != nil || errB != nil || !reflect.DeepEqual(a, b) {
+		t.Errorf("MutateToViolate() = %v, %v: want the same variant twice", errA, errB)
+	}
+
+	young, err := GenerateProfile(1, Options{Now: now, MinAge: 12, MaxAge: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		profile userdate.Profile
+		code    string
+	}{
+		{"unknown code", profile, "NO_SUCH_CODE"},
+		{"no user", userdate.Profile{}, userdate.ErrCodeBeforeBirth},
+		{"no timeline to cut", young, userdate.ErrCodeCareerGap},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MutateToViolate(tt.profile, tt.code); err == nil {
+				t.Errorf("MutateToViolate() expected error but got none")
+			}
+		})
+	}
+}
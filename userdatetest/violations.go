@@ -0,0 +1,212 @@
+package userdatetest
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+)
+
+// violation makes an entity of a profile break a rule, or returns false
+// when the profile has no entity it can use or the user is too old for it. Entities whose index is in
+// used are left alone, and the changed one is added to it.
+type violation func(r *rand.Rand, p *userdate.Profile, now time.Time, used map[int]bool) bool
+
+// violations are the injectable violations by error code
+var violations = map[string]violation{
+	userdate.ErrCodeBeforeBirth:         beforeBirth,
+	userdate.ErrCodeFutureDate:          futureDate,
+	userdate.ErrCodeUnrealisticAge:      tooYoung,
+	userdate.ErrCodePlaceholderDate:     placeholder,
+	userdate.ErrCodeExpired:             withStatus(userdate.StatusExpired),
+	userdate.ErrCodeRevoked:             withStatus(userdate.StatusRevoked),
+	userdate.ErrCodeImplausibleDuration: longInternship,
+	userdate.ErrCodeCareerGap:           careerGap,
+}
+
+// Violations returns the error codes that Options.Corrupt and
+// MutateToViolate can inject, sorted
+func Violations() []string {
+	return slices.Sorted(maps.Keys(violations))
+}
+
+// inject applies the violation of code to a profile
+func inject(r *rand.Rand, p *userdate.Profile, code string, now time.Time, used map[int]bool) error {
+	v, ok := violations[code]
+	if !ok {
+		return fmt.Errorf("userdatetest: cannot inject %s, want one of %v", code, Violations())
+	}
+	if !v(r, p, now, used) {
+		return fmt.Errorf("userdatetest: profile of %s cannot carry %s", p.User.ID, code)
+	}
+	return nil
+}
+
+// pick returns a random unused entity outside the timeline of a type
+// accepted by ok and marks it used. When there is none, it adds a
+// certification to the profile.
+func pick(r *rand.Rand, p *userdate.Profile, used map[int]bool, ok func(userdate.Entity) bool) int {
+	var candidates []int
+	for i, e := range p.Entities {
+		if !used[i] && !isTimeline(e) && ok(e) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		birth := p.User.BirthDate
+		p.Entities = append(p.Entities, userdate.Entity{
+			ID:     fmt.Sprintf("e%d", len(p.Entities)+1),
+			Type:   "certification",
+			Date:   birth.AddDate(userdate.MinCertAge+r.IntN(5), r.IntN(12), 0),
+			Status: userdate.StatusClaimed,
+		})
+		candidates = append(candidates, len(p.Entities)-1)
+	}
+	i := candidates[r.IntN(len(candidates))]
+	used[i] = true
+	return i
+}
+
+// anyEntity accepts every entity
+func anyEntity(userdate.Entity) bool {
+	return true
+}
+
+// isTimeline reports whether an entity counts in the career timeline,
+// which date changes could break with gaps
+func isTimeline(e userdate.Entity) bool {
+	switch e.Type {
+	case "employment", "internship", "apprenticeship", "secondary_education", "tertiary_education", "education":
+		return true
+	}
+	return false
+}
+
+// beforeBirth moves an entity up to 10 years before the birth date
+func beforeBirth(r *rand.Rand, p *userdate.Profile, _ time.Time, used map[int]bool) bool {
+	i := pick(r, p, used, anyEntity)
+	p.Entities[i].Date = avoidPlaceholders(p.User.BirthDate.AddDate(0, 0, -1-r.IntN(3650)))
+	return true
+}
+
+// futureDate moves an entity up to a year after now
+func futureDate(r *rand.Rand, p *userdate.Profile, now time.Time, used map[int]bool) bool {
+	i := pick(r, p, used, anyEntity)
+	p.Entities[i].Date = now.AddDate(0, 0, 1+r.IntN(365))
+	return true
+}
+
+// tooYoung moves an entity with a minimum age to before the user reaches
+// it
+func tooYoung(r *rand.Rand, p *userdate.Profile, _ time.Time, used map[int]bool) bool {
+	ages := userdate.EffectiveRules().MinimumAges
+	i := pick(r, p, used, func(e userdate.Entity) bool { return ages[e.Type] > 0 })
+	min := ages[p.Entities[i].Type]
+	p.Entities[i].Date = avoidPlaceholders(p.User.BirthDate.AddDate(min-1, 0, r.IntN(300)))
+	return true
+}
+
+// placeholder sets an entity to a common default date, such as the Unix
+// epoch, before the birth date so that the placeholder is an error rather
+// than a warning
+func placeholder(r *rand.Rand, p *userdate.Profile, _ time.Time, used map[int]bool) bool {
+	// The zero year is also an invalid date
+	dates := slices.DeleteFunc(userdate.DefaultPlaceholderDates(), func(t time.Time) bool {
+		return t.IsZero() || !t.Before(p.User.BirthDate)
+	})
+	if len(dates) == 0 {
+		return false
+	}
+	i := pick(r, p, used, anyEntity)
+	p.Entities[i].Date = dates[r.IntN(len(dates))]
+	return true
+}
+
+// withStatus gives an entity a status that fails it
+func withStatus(status userdate.EntityStatus) violation {
+	return func(r *rand.Rand, p *userdate.Profile, _ time.Time, used map[int]bool) bool {
+		i := pick(r, p, used, anyEntity)
+		p.Entities[i].Status = status
+		return true
+	}
+}
+
+// longInternship adds an internship of 2 to 3 years during the working
+// life of the user
+func longInternship(r *rand.Rand, p *userdate.Profile, now time.Time, _ map[int]bool) bool {
+	start := p.User.BirthDate.AddDate(18, 0, 0)
+	end := now.AddDate(-3, 0, 0)
+	if !start.Before(end) {
+		return false
+	}
+	date := start.AddDate(0, 0, r.IntN(int(end.Sub(start).Hours()/24)))
+	p.Entities = append(p.Entities, userdate.Entity{
+		ID:      fmt.Sprintf("e%d", len(p.Entities)+1),
+		Type:    "internship",
+		Date:    date,
+		EndDate: date.AddDate(2, r.IntN(12), 0),
+		Status:  userdate.StatusClaimed,
+	})
+	return true
+}
+
+// careerGap ends the timeline entities before one of them 8 months before
+// it starts, leaving a gap
+func careerGap(r *rand.Rand, p *userdate.Profile, now time.Time, used map[int]bool) bool {
+	end := func(e userdate.Entity) time.Time {
+		if e.EndDate.IsZero() {
+			return now
+		}
+		return e.EndDate
+	}
+	var candidates []int
+	for i, next := range p.Entities {
+		if !isTimeline(next) {
+			continue
+		}
+		gapStart := next.Date.AddDate(0, -8, 0)
+		before, fits := false, true
+		for j, e := range p.Entities {
+			if j == i || !isTimeline(e) {
+				continue
+			}
+			switch {
+			case e.Date.Before(gapStart):
+				// Entities ending after the gap starts are cut short,
+				// which must not open another gap after next
+				before = true
+				cut := !end(e).Before(gapStart)
+				fits = fits && (!cut || !used[j] && !end(e).After(end(next)))
+			case e.Date.Before(next.Date):
+				fits = false
+			}
+		}
+		if before && fits {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+	next := p.Entities[candidates[r.IntN(len(candidates))]]
+	gapStart := next.Date.AddDate(0, -8, 0)
+	for j, e := range p.Entities {
+		if isTimeline(e) && e.Date.Before(gapStart) && !end(e).Before(gapStart) {
+			p.Entities[j].EndDate = gapStart.AddDate(0, 0, -1)
+			used[j] = true
+		}
+	}
+	return true
+}
+
+// avoidPlaceholders moves a date off the common default dates, so that it
+// breaks its intended rule only
+func avoidPlaceholders(t time.Time) time.Time {
+	if slices.ContainsFunc(userdate.DefaultPlaceholderDates(), t.Equal) {
+		return t.AddDate(0, 0, 1)
+	}
+	return t
+}
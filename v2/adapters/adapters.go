@@ -0,0 +1,174 @@
+// Package adapters connects validation to the outside: the HTTP handler,
+// webhooks, result caches, revocation services, stores and sinks. It groups
+// part of the API of the core package, see the v2 package.
+package adapters
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/core"
+)
+
+// HTTP
+type (
+	CheckRequest = core.CheckRequest
+	BatchRequest = core.BatchRequest
+)
+
+// MaxRequestBytes is the largest request body the handler reads
+const MaxRequestBytes = core.MaxRequestBytes
+
+// Webhooks
+type (
+	WebhookConfig  = core.WebhookConfig
+	WebhookEvent   = core.WebhookEvent
+	WebhookPayload = core.WebhookPayload
+	WebhookSink    = core.WebhookSink
+	DeadLetter     = core.DeadLetter
+)
+
+// Webhook events
+const (
+	WebhookFailure  = core.WebhookFailure
+	WebhookAnomaly  = core.WebhookAnomaly
+	WebhookExpiring = core.WebhookExpiring
+)
+
+// Remote services, caches, stores and sinks
+type (
+	ResiliencePolicy           = core.ResiliencePolicy
+	RevocationChecker          = core.RevocationChecker
+	MemoryRevocationList       = core.MemoryRevocationList
+	CachingRevocationChecker   = core.CachingRevocationChecker
+	ResilientRevocationChecker = core.ResilientRevocationChecker
+	ResultCache                = core.ResultCache
+	MemoryResultCache          = core.MemoryResultCache
+	ReviewSink                 = core.ReviewSink
+	ChanReviewSink             = core.ChanReviewSink
+	RejectWriter               = core.RejectWriter
+	FileRejectWriter           = core.FileRejectWriter
+	FileAuditStore             = core.FileAuditStore
+	FileStatsStore             = core.FileStatsStore
+	SQLStore                   = core.SQLStore
+	ResultWriter               = core.ResultWriter
+	ResultWriterConfig         = core.ResultWriterConfig
+)
+
+// Errors
+var (
+	ErrCircuitOpen     = core.ErrCircuitOpen
+	ErrReviewQueueFull = core.ErrReviewQueueFull
+)
+
+// NewHandler returns an HTTP handler serving the validation API
+func NewHandler(opts ...core.Option) http.Handler {
+	return core.NewHandler(opts...)
+}
+
+// HTTPStatusFor returns the HTTP status of an error code
+func HTTPStatusFor(code string) int {
+	return core.HTTPStatusFor(code)
+}
+
+// HTTPStatusForError returns the HTTP status of an error
+func HTTPStatusForError(err error) int {
+	return core.HTTPStatusForError(err)
+}
+
+// RegisterHTTPStatus overrides the HTTP status of an error code
+func RegisterHTTPStatus(code string, status int) {
+	core.RegisterHTTPStatus(code, status)
+}
+
+// NewWebhookSink returns a sink delivering to cfg.URL
+func NewWebhookSink(cfg WebhookConfig) *WebhookSink {
+	return core.NewWebhookSink(cfg)
+}
+
+// VerifyWebhook checks the signature of a webhook request body
+func VerifyWebhook(secret, body []byte, signature string) error {
+	return core.VerifyWebhook(secret, body, signature)
+}
+
+// ReadDeadLetters reads the dead-letter file of a webhook sink
+func ReadDeadLetters(path string) ([]DeadLetter, error) {
+	return core.ReadDeadLetters(path)
+}
+
+// WithWebhook sends the outcomes of validations to s
+func WithWebhook(s *WebhookSink) core.Option {
+	return core.WithWebhook(s)
+}
+
+// NewMemoryRevocationList returns an empty in-memory revocation list
+func NewMemoryRevocationList() *MemoryRevocationList {
+	return core.NewMemoryRevocationList()
+}
+
+// NewCachingRevocationChecker caches the answers of next for ttl
+func NewCachingRevocationChecker(next RevocationChecker, ttl time.Duration) *CachingRevocationChecker {
+	return core.NewCachingRevocationChecker(next, ttl)
+}
+
+// NewResilientRevocationChecker guards next with policy
+func NewResilientRevocationChecker(next RevocationChecker, policy ResiliencePolicy, fallback RevocationChecker) *ResilientRevocationChecker {
+	return core.NewResilientRevocationChecker(next, policy, fallback)
+}
+
+// WithRevocationChecker checks the entities with an ID against rc
+func WithRevocationChecker(rc RevocationChecker) core.Option {
+	return core.WithRevocationChecker(rc)
+}
+
+// NewMemoryResultCache returns an empty in-process result cache
+func NewMemoryResultCache() *MemoryResultCache {
+	return core.NewMemoryResultCache()
+}
+
+// WithResultCache reuses the reports of earlier validations for ttl
+func WithResultCache(cache ResultCache, ttl time.Duration) core.Option {
+	return core.WithResultCache(cache, ttl)
+}
+
+// NewChanReviewSink returns a review sink backed by a channel
+func NewChanReviewSink(size int) *ChanReviewSink {
+	return core.NewChanReviewSink(size)
+}
+
+// WithReviewSink sends reports that need a review to s
+func WithReviewSink(s ReviewSink) core.Option {
+	return core.WithReviewSink(s)
+}
+
+// NewFileRejectWriter returns a reject writer appending to path
+func NewFileRejectWriter(path string) *FileRejectWriter {
+	return core.NewFileRejectWriter(path)
+}
+
+// WithRejectWriter writes every batch item that fails to w
+func WithRejectWriter(w RejectWriter) core.Option {
+	return core.WithRejectWriter(w)
+}
+
+// NewFileAuditStore returns an audit store appending to path
+func NewFileAuditStore(path string) *FileAuditStore {
+	return core.NewFileAuditStore(path)
+}
+
+// NewFileStatsStore returns a stats store writing to path
+func NewFileStatsStore(path string) *FileStatsStore {
+	return core.NewFileStatsStore(path)
+}
+
+// NewSQLStore returns a store of audit records and stats in db
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return core.NewSQLStore(db)
+}
+
+// NewResultWriter returns a writer of validation results to files named
+// after prefix
+func NewResultWriter(prefix string, cfg ResultWriterConfig) *ResultWriter {
+	return core.NewResultWriter(prefix, cfg)
+}
@@ -0,0 +1,56 @@
+// Package agecalc provides calendar-accurate age arithmetic.
+//
+// Ages are computed by comparing the month and day of the birth date with the
+// reference date rather than their day of the year, so results do not shift
+// around leap years. People born on February 29 are considered to have their
+// birthday on March 1 in common years.
+package agecalc
+
+import "time"
+
+// Years returns the number of full years elapsed between birth and at.
+// The result is negative when at is before birth.
+func Years(birth, at time.Time) int {
+	if at.Before(birth) {
+		return -Years(at, birth)
+	}
+
+	by, bm, bd := birth.Date()
+	ay, am, ad := at.Date()
+
+	age := ay - by
+	if am < bm || (am == bm && ad < birthdayDay(bm, bd, ay)) {
+		age--
+	}
+	return age
+}
+
+// Birthday returns the date on which the person born at birth celebrates
+// their birthday in the given year, at midnight in birth's location.
+func Birthday(birth time.Time, year int) time.Time {
+	_, m, d := birth.Date()
+	if m == time.February && d == 29 && !IsLeapYear(year) {
+		return time.Date(year, time.March, 1, 0, 0, 0, 0, birth.Location())
+	}
+	return time.Date(year, m, d, 0, 0, 0, 0, birth.Location())
+}
+
+// DateAtAge returns the first day on which the person born at birth is
+// the given number of full years old.
+func DateAtAge(birth time.Time, years int) time.Time {
+	return Birthday(birth, birth.Year()+years)
+}
+
+// IsLeapYear reports whether year is a leap year in the Gregorian calendar.
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// birthdayDay returns the day of month on which a birthday falls in year
+func birthdayDay(month time.Month, day, year int) int {
+	if month == time.February && day == 29 && !IsLeapYear(year) {
+		// March 1 is compared as February 30, which no date reaches
+		return 30
+	}
+	return day
+}
@@ -0,0 +1,101 @@
+package agecalc
+
+import (
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestYears(t *testing.T) {
+	tests := []struct {
+		name  string
+		birth time.Time
+		at    time.Time
+		want  int
+	}{
+		{"same day", date(1990, 5, 15), date(1990, 5, 15), 0},
+		{"day before first birthday", date(1990, 5, 15), date(1991, 5, 14), 0},
+		{"first birthday", date(1990, 5, 15), date(1991, 5, 15), 1},
+		{"day before birthday in leap year", date(1990, 5, 15), date(2020, 5, 14), 29},
+		{"birthday in leap year", date(1990, 5, 15), date(2020, 5, 15), 30},
+		{"born in leap year, day before birthday", date(2000, 3, 1), date(2001, 2, 28), 0},
+		{"born in leap year, birthday", date(2000, 3, 1), date(2001, 3, 1), 1},
+		{"born after leap day, day before birthday", date(2000, 12, 31), date(2004, 12, 30), 3},
+		{"born after leap day, birthday", date(2000, 12, 31), date(2004, 12, 31), 4},
+		{"leap day birth, Feb 28 of common year", date(2000, 2, 29), date(2001, 2, 28), 0},
+		{"leap day birth, Mar 1 of common year", date(2000, 2, 29), date(2001, 3, 1), 1},
+		{"leap day birth, Feb 28 of leap year", date(2000, 2, 29), date(2004, 2, 28), 3},
+		{"leap day birth, Feb 29 of leap year", date(2000, 2, 29), date(2004, 2, 29), 4},
+		{"leap day birth, century common year", date(1896, 2, 29), date(1900, 2, 28), 3},
+		{"leap day birth, century common year Mar 1", date(1896, 2, 29), date(1900, 3, 1), 4},
+		{"Dec 31 birth, Jan 1", date(1999, 12, 31), date(2000, 1, 1), 0},
+		{"Jan 1 birth, Dec 31", date(2000, 1, 1), date(2000, 12, 31), 0},
+		{"before birth", date(1990, 5, 15), date(1989, 5, 15), -1},
+		{"shortly before birth", date(1990, 5, 15), date(1990, 5, 14), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Years(tt.birth, tt.at); got != tt.want {
+				t.Errorf("Years() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYearsIgnoresTimeOfDay(t *testing.T) {
+	birth := time.Date(1990, 5, 15, 23, 0, 0, 0, time.UTC)
+	at := time.Date(2000, 5, 15, 1, 0, 0, 0, time.UTC)
+	if got := Years(birth, at); got != 10 {
+		t.Errorf("Years() = %v, want %v", got, 10)
+	}
+}
+
+func TestBirthday(t *testing.T) {
+	tests := []struct {
+		name  string
+		birth time.Time
+		year  int
+		want  time.Time
+	}{
+		{"regular date", date(1990, 5, 15), 2020, date(2020, 5, 15)},
+		{"leap day in leap year", date(2000, 2, 29), 2004, date(2004, 2, 29)},
+		{"leap day in common year", date(2000, 2, 29), 2001, date(2001, 3, 1)},
+		{"leap day in century common year", date(1896, 2, 29), 1900, date(1900, 3, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Birthday(tt.birth, tt.year); !got.Equal(tt.want) {
+				t.Errorf("Birthday() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateAtAgeRoundTrip(t *testing.T) {
+	births := []time.Time{date(1990, 5, 15), date(2000, 2, 29), date(1999, 12, 31), date(2000, 1, 1)}
+	for _, birth := range births {
+		for years := 0; years <= 20; years++ {
+			at := DateAtAge(birth, years)
+			if got := Years(birth, at); got != years {
+				t.Errorf("Years(%v, DateAtAge(%d)) = %v", birth.Format("2006-01-02"), years, got)
+			}
+			if got := Years(birth, at.AddDate(0, 0, -1)); years > 0 && got != years-1 {
+				t.Errorf("Years(%v, day before DateAtAge(%d)) = %v", birth.Format("2006-01-02"), years, got)
+			}
+		}
+	}
+}
+
+func TestIsLeapYear(t *testing.T) {
+	tests := map[int]bool{1900: false, 2000: true, 2004: true, 2001: false, 2100: false, 2400: true}
+	for year, want := range tests {
+		if got := IsLeapYear(year); got != want {
+			t.Errorf("IsLeapYear(%d) = %v, want %v", year, got, want)
+		}
+	}
+}
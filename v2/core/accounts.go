@@ -0,0 +1,47 @@
+package core
+
+import "time"
+
+// Entity types of the account timeline. The signup needs the age of
+// digital consent, its minimum age; the KYC completion and the first
+// transaction must follow it.
+const (
+	signupType           = "signup"
+	kycCompletionType    = "kyc_completion"
+	firstTransactionType = "first_transaction"
+)
+
+// accountEventTypes are the account events dated on or after the signup
+var accountEventTypes = map[string]bool{
+	kycCompletionType:    true,
+	firstTransactionType: true,
+}
+
+// accountSignup returns the date of the earliest signup of a profile, or
+// the zero time when it has none. Revoked signups, such as of closed
+// accounts, are left out.
+func (c *config) accountSignup(entities []Entity) time.Time {
+	var signup time.Time
+	for _, entity := range entities {
+		if entity.Type != signupType || entity.Status == StatusRevoked || entity.Date.IsZero() {
+			continue
+		}
+		if date := c.truncate(entity.Date); signup.IsZero() || date.Before(signup) {
+			signup = date
+		}
+	}
+	return signup
+}
+
+// checkAccountEvent rejects a KYC completion or a first transaction dated
+// before the signup of its profile. Profiles without a signup are not
+// checked, as the account may predate the records.
+func (c *config) checkAccountEvent(entity Entity, signup time.Time, report *Report) {
+	if !accountEventTypes[entity.Type] || signup.IsZero() || entity.Date.IsZero() {
+		return
+	}
+	if date := c.truncate(entity.Date); date.Before(signup) {
+		err := newError(msgBeforeSignup, "type", entityTypeArg(entity.Type), "date", date, "signup", signup)
+		report.add(c.localize(atField(err, fieldEntityDate)))
+	}
+}
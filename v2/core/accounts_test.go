@@ -0,0 +1,78 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAccountEvents(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-06-15")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	signup := Entity{Type: "signup", Date: mustParseDate("2022-03-01")}
+
+	tests := []struct {
+		name     string
+		entities []Entity
+		want     [][]string
+	}{
+		{"in order", []Entity{signup, {Type: "kyc_completion", Date: mustParseDate("2022-03-01")}, {Type: "first_transaction", Date: mustParseDate("2022-04-10")}},
+			[][]string{nil, nil, nil}},
+		{"KYC before signup", []Entity{{Type: "kyc_completion", Date: mustParseDate("2022-02-01")}, signup},
+			[][]string{{ErrCodeBeforeSignup}, nil}},
+		{"activity before signup", []Entity{signup, {Type: "first_transaction", Date: mustParseDate("2021-12-24")}},
+			[][]string{nil, {ErrCodeBeforeSignup}}},
+		{"earliest signup", []Entity{signup, {Type: "signup", Date: mustParseDate("2021-01-01")}, {Type: "first_transaction", Date: mustParseDate("2021-12-24")}},
+			[][]string{nil, nil, nil}},
+		{"revoked signup", []Entity{{Type: "signup", Date: mustParseDate("2021-01-01"), Status: StatusRevoked}, signup, {Type: "first_transaction", Date: mustParseDate("2021-12-24")}},
+			[][]string{{ErrCodeRevoked}, nil, {ErrCodeBeforeSignup}}},
+		{"no signup", []Entity{{Type: "first_transaction", Date: mustParseDate("2021-12-24")}},
+			[][]string{nil}},
+		{"signup of a minor", []Entity{{Type: "signup", Date: mustParseDate("2015-03-01")}},
+			[][]string{nil}},
+		{"signup under the age of digital consent", []Entity{{Type: "signup", Date: mustParseDate("2012-03-01")}},
+			[][]string{{ErrCodeUnrealisticAge}}},
+		{"signup with a guardian", []Entity{{Type: "signup", Date: mustParseDate("2012-03-01"), Guardian: true}},
+			[][]string{nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateProfile(Profile{User: user, Entities: tt.entities}, now)
+			for i, r := range report.Reports {
+				if got := findingCodes(r); !slices.Equal(got, tt.want[i]) {
+					t.Errorf("entity %d findings = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSignupConsentAge(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-06-15")}
+	signup := Entity{Type: "signup", Date: mustParseDate("2015-03-01")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	resolve := func(code string) Option {
+		return WithJurisdictionResolver(StaticJurisdictions{Default: code})
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"default", nil, nil},
+		{"GB", []Option{resolve("GB")}, nil},
+		{"FR", []Option{resolve("FR")}, []string{ErrCodeUnrealisticAge}},
+		{"DE", []Option{resolve("DE")}, []string{ErrCodeUnrealisticAge}},
+		{"configured", []Option{WithMinimumAge("signup", 16)}, []string{ErrCodeUnrealisticAge}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, signup, append(tt.opts, now)...)
+			if got := findingCodes(report); !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
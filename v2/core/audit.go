@@ -0,0 +1,165 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrBrokenAuditChain is returned by VerifyAuditChain when records have been
+// modified, removed, inserted or reordered
+var ErrBrokenAuditChain = errors.New("userdate: broken audit chain")
+
+// AuditRecord is an entry of an audit log. Each record holds the hash of the
+// previous one, so the log can only be extended, not rewritten.
+type AuditRecord struct {
+	Sequence     uint64    `json:"sequence"`
+	RecordedAt   time.Time `json:"recorded_at"`
+	Report       *Report   `json:"report"`
+	PreviousHash string    `json:"previous_hash"`
+	Hash         string    `json:"hash"`
+}
+
+// AuditLog is an append-only, hash-chained log of validation reports. It is
+// safe for concurrent use.
+type AuditLog struct {
+	now   func() time.Time
+	store AuditStore
+
+	mu      sync.Mutex
+	records []AuditRecord
+}
+
+// NewAuditLog creates an empty, in-memory audit log
+func NewAuditLog() *AuditLog {
+	return &AuditLog{now: time.Now}
+}
+
+// OpenAuditLog creates an audit log persisted in store. Existing records are
+// loaded and verified, and new records are written to the store as they are
+// appended.
+func OpenAuditLog(ctx context.Context, store AuditStore) (*AuditLog, error) {
+	records, err := store.LoadAudit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyAuditChain(records); err != nil {
+		return nil, err
+	}
+	return &AuditLog{now: time.Now, store: store, records: records}, nil
+}
+
+// WithAuditLog records every report produced by the validation in log.
+// Validations returning only an error are recorded too.
+func WithAuditLog(log *AuditLog) Option {
+	return func(c *config) {
+		c.audit = log
+	}
+}
+
+// Append adds a copy of report to the log and returns the new record. The
+// log is left unchanged if the record cannot be written to its store.
+func (l *AuditLog) Append(report *Report) (AuditRecord, error) {
+	if l == nil || report == nil {
+		return AuditRecord{}, ErrNilValue
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	record := AuditRecord{
+		Sequence:   uint64(len(l.records)) + 1,
+		RecordedAt: l.now(),
+		Report:     copyReport(report),
+	}
+	if len(l.records) > 0 {
+		record.PreviousHash = l.records[len(l.records)-1].Hash
+	}
+	record.Hash = record.computeHash()
+
+	if l.store != nil {
+		if err := l.store.AppendAudit(context.Background(), record); err != nil {
+			return AuditRecord{}, err
+		}
+	}
+	l.records = append(l.records, record)
+	return record, nil
+}
+
+// Records returns the records of the log in order
+func (l *AuditLog) Records() []AuditRecord {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]AuditRecord(nil), l.records...)
+}
+
+// Verify checks the integrity of the whole log
+func (l *AuditLog) Verify() error {
+	if l == nil {
+		return ErrNilValue
+	}
+	return VerifyAuditChain(l.Records())
+}
+
+// VerifyAuditChain checks that records form an unbroken chain starting at
+// the first record of a log: sequences are consecutive, each record links to
+// the hash of its predecessor and every hash matches its record's contents.
+func VerifyAuditChain(records []AuditRecord) error {
+	previous := ""
+	for i, record := range records {
+		if record.Sequence != uint64(i)+1 {
+			return fmt.Errorf("%w: record %d has sequence %d", ErrBrokenAuditChain, i+1, record.Sequence)
+		}
+		if record.PreviousHash != previous {
+			return fmt.Errorf("%w: record %d does not link to its predecessor", ErrBrokenAuditChain, record.Sequence)
+		}
+		if record.Hash != record.computeHash() {
+			return fmt.Errorf("%w: record %d has been modified", ErrBrokenAuditChain, record.Sequence)
+		}
+		previous = record.Hash
+	}
+	return nil
+}
+
+// computeHash hashes the record's contents together with the previous hash
+func (r AuditRecord) computeHash() string {
+	var report []byte
+	if r.Report != nil {
+		// Encoding plain strings and times cannot fail
+		report, _ = r.Report.canonical()
+	}
+	data, _ := json.Marshal(struct {
+		Sequence     uint64          `json:"sequence"`
+		RecordedAt   string          `json:"recorded_at"`
+		Report       json.RawMessage `json:"report"`
+		PreviousHash string          `json:"previous_hash"`
+	}{r.Sequence, canonicalTime(r.RecordedAt), report, r.PreviousHash})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// copyReport returns a copy of report that does not share its findings
+func copyReport(report *Report) *Report {
+	if report == nil {
+		return nil
+	}
+	copied := *report
+	copied.pooled = false
+	copied.Sampled = slices.Clone(report.Sampled)
+	copied.Findings = make([]*DateValidationError, len(report.Findings))
+	for i, f := range report.Findings {
+		finding := *f
+		finding.Reasons = slices.Clone(f.Reasons)
+		copied.Findings[i] = &finding
+	}
+	return &copied
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAuditLogChain(t *testing.T) {
+	log := NewAuditLog()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	v := NewValidator(WithAuditLog(log))
+
+	_ = v.CheckEntityDate(user, mustParseDate("2020-01-01"), "certification")
+	_ = v.ValidateEntityDate(user, mustParseDate("1989-01-01"), "certification")
+	_ = v.CheckEntity(user, Entity{Type: "cpr_certification", Date: mustParseDate("2019-01-01")})
+	_ = ValidateEntityDate(user, mustParseDate("2020-01-01"), "training", WithAuditLog(log))
+
+	records := log.Records()
+	if len(records) != 4 {
+		t.Fatalf("Records() returned %d records, want 4", len(records))
+	}
+	if records[0].PreviousHash != "" || records[1].PreviousHash != records[0].Hash {
+		t.Errorf("Records() are not chained: %+v", records[:2])
+	}
+	if records[1].Report.Findings[0].Code != ErrCodeBeforeBirth {
+		t.Errorf("Records()[1] findings = %v, want %v", records[1].Report.Findings, ErrCodeBeforeBirth)
+	}
+	if err := log.Verify(); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+
+	// The chain survives a JSON round trip
+	data, err := json.Marshal(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []AuditRecord
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAuditChain(decoded); err != nil {
+		t.Errorf("VerifyAuditChain() after JSON round trip error = %v", err)
+	}
+}
+
+func TestVerifyAuditChainDetectsTampering(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	tests := map[string]func(records []AuditRecord) []AuditRecord{
+		"modified report": func(records []AuditRecord) []AuditRecord {
+			records[1].Report.Findings = nil
+			return records
+		},
+		"modified reasons": func(records []AuditRecord) []AuditRecord {
+			records[1].Report.Findings[0].Reasons[0].Value = "other"
+			return records
+		},
+		"modified time": func(records []AuditRecord) []AuditRecord {
+			records[0].RecordedAt = records[0].RecordedAt.AddDate(0, 0, 1)
+			return records
+		},
+		"removed record": func(records []AuditRecord) []AuditRecord {
+			return append(records[:1], records[2:]...)
+		},
+		"reordered records": func(records []AuditRecord) []AuditRecord {
+			records[0], records[1] = records[1], records[0]
+			return records
+		},
+		"rehashed record": func(records []AuditRecord) []AuditRecord {
+			records[1].Report.UserID = "user456"
+			records[1].Hash = records[1].computeHash()
+			return records
+		},
+	}
+
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			log := NewAuditLog()
+			for _, date := range []string{"2020-01-01", "1989-01-01", "2019-01-01"} {
+				_ = CheckEntityDate(user, mustParseDate(date), "certification", WithAuditLog(log))
+			}
+
+			err := VerifyAuditChain(tamper(log.Records()))
+			if !errors.Is(err, ErrBrokenAuditChain) {
+				t.Errorf("VerifyAuditChain() error = %v, want %v", err, ErrBrokenAuditChain)
+			}
+		})
+	}
+}
+
+func TestAuditLogKeepsCopies(t *testing.T) {
+	log := NewAuditLog()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	report := CheckEntityDate(user, mustParseDate("1989-01-01"), "certification", WithAuditLog(log))
+	report.Findings[0].Code = "CHANGED"
+	report.Findings[0].Reasons[0].Value = "CHANGED"
+	report.UserID = "user456"
+
+	if err := log.Verify(); err != nil {
+		t.Errorf("Verify() error = %v after changing the returned report", err)
+	}
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Item is a single entity date to validate as part of a batch
+type Item struct {
+	User       *User     `json:"user"`
+	EntityDate time.Time `json:"entity_date"`
+	EntityType string    `json:"entity_type"`
+}
+
+// BatchResult holds the reports of a batch validation. Reports[i] is the
+// report for Items[i].
+type BatchResult struct {
+	Items   []Item
+	Reports []*Report
+
+	// Shard is the shard the batch was restricted to with WithShard; Items
+	// then only holds the items of that shard. It is zero otherwise.
+	Shard Shard
+
+	// RejectErr holds the errors of writing failed items to the reject
+	// writer, if one is configured
+	RejectErr error
+}
+
+// ValidateBatch validates every item and returns their reports in input order
+func ValidateBatch(items []Item, opts ...Option) *BatchResult {
+	cfg := newConfig(opts)
+	return cfg.validateBatch(items)
+}
+
+// ValidateBatch validates every item using the validator's settings
+func (v *Validator) ValidateBatch(items []Item) *BatchResult {
+	return v.config().validateBatch(items)
+}
+
+// validateBatch checks each item in turn
+func (c *config) validateBatch(items []Item) *BatchResult {
+	if c.shard.Count > 1 {
+		var owned []Item
+		for _, item := range items {
+			if c.shard.Owns(itemUserID(item)) {
+				owned = append(owned, item)
+			}
+		}
+		items = owned
+	}
+	result := &BatchResult{
+		Items:   items,
+		Reports: make([]*Report, len(items)),
+		Shard:   c.shard,
+	}
+	for i, item := range items {
+		result.Reports[i] = c.checkEntityDate(context.Background(), item.User, item.EntityDate, item.EntityType)
+	}
+	c.writeRejects(result)
+	return result
+}
+
+// Failed returns the items whose reports hold an error-level finding
+func (b *BatchResult) Failed() []Item {
+	if b == nil {
+		return nil
+	}
+	var failed []Item
+	for i, report := range b.Reports {
+		if !report.Valid() {
+			failed = append(failed, b.Items[i])
+		}
+	}
+	return failed
+}
+
+// Indeterminate returns the items whose reports have VerdictIndeterminate,
+// for manual review
+func (b *BatchResult) Indeterminate() []Item {
+	if b == nil {
+		return nil
+	}
+	var items []Item
+	for i, report := range b.Reports {
+		if report.Verdict == VerdictIndeterminate {
+			items = append(items, b.Items[i])
+		}
+	}
+	return items
+}
+
+// CountByCode returns how many findings of each code the batch produced,
+// warnings included
+func (b *BatchResult) CountByCode() map[string]int {
+	if b == nil {
+		return map[string]int{}
+	}
+	counts := make(map[string]int)
+	for _, report := range b.Reports {
+		for _, f := range report.Findings {
+			counts[f.Code]++
+		}
+	}
+	return counts
+}
+
+// Worst returns the most severe finding in the batch, or SeverityNone if
+// every item passed without warnings
+func (b *BatchResult) Worst() Severity {
+	if b == nil {
+		return SeverityNone
+	}
+	worst := SeverityNone
+	for _, report := range b.Reports {
+		for _, f := range report.Findings {
+			if f.Severity.rank() > worst.rank() {
+				worst = f.Severity
+			}
+		}
+	}
+	return worst
+}
+
+// MarshalJSON encodes the batch as a summary followed by the per-item reports
+func (b *BatchResult) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(struct {
+		Shard         *Shard         `json:"shard,omitempty"`
+		Total         int            `json:"total"`
+		Failed        int            `json:"failed"`
+		Indeterminate int            `json:"indeterminate"`
+		Worst         Severity       `json:"worst"`
+		CountByCode   map[string]int `json:"count_by_code"`
+		Reports       []*Report      `json:"reports"`
+	}{
+		Shard:         shardTag(b.Shard),
+		Total:         len(b.Reports),
+		Failed:        len(b.Failed()),
+		Indeterminate: len(b.Indeterminate()),
+		Worst:         b.Worst(),
+		CountByCode:   b.CountByCode(),
+		Reports:       b.Reports,
+	})
+}
+
+// shardTag returns the shard for JSON output, or nil when unsharded
+func shardTag(s Shard) *Shard {
+	if s.Count < 2 {
+		return nil
+	}
+	return &s
+}
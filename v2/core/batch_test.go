@@ -0,0 +1,96 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateBatch(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	items := []Item{
+		{User: user, EntityDate: mustParseDate("2020-01-01"), EntityType: "certification"},
+		{User: user, EntityDate: mustParseDate("1989-01-01"), EntityType: "certification"},
+		{User: user, EntityDate: mustParseDate("1989-12-01"), EntityType: "prenatal_screening"},
+		{User: nil, EntityDate: mustParseDate("2020-01-01"), EntityType: "training"},
+		{User: user, EntityDate: mustParseDate("1988-01-01"), EntityType: "license"},
+	}
+
+	result := ValidateBatch(items, WithPrenatalWindow(60*24*time.Hour, "prenatal_screening"))
+	if len(result.Reports) != len(items) {
+		t.Fatalf("ValidateBatch() returned %d reports, want %d", len(result.Reports), len(items))
+	}
+
+	failed := result.Failed()
+	if want := []Item{items[1], items[3], items[4]}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("Failed() = %v, want %v", failed, want)
+	}
+
+	wantCounts := map[string]int{ErrCodeBeforeBirth: 2, ErrCodeInvalidUser: 1, ErrCodePrenatal: 1}
+	if counts := result.CountByCode(); !reflect.DeepEqual(counts, wantCounts) {
+		t.Errorf("CountByCode() = %v, want %v", counts, wantCounts)
+	}
+
+	if worst := result.Worst(); worst != SeverityError {
+		t.Errorf("Worst() = %v, want %v", worst, SeverityError)
+	}
+}
+
+func TestBatchResultWorst(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	v := NewValidator(WithPrenatalWindow(60*24*time.Hour, "prenatal_screening"))
+
+	tests := []struct {
+		name  string
+		items []Item
+		want  Severity
+	}{
+		{"empty", nil, SeverityNone},
+		{"all valid", []Item{{User: user, EntityDate: mustParseDate("2020-01-01"), EntityType: "training"}}, SeverityNone},
+		{"warning only", []Item{{User: user, EntityDate: mustParseDate("1989-12-01"), EntityType: "prenatal_screening"}}, SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.ValidateBatch(tt.items).Worst(); got != tt.want {
+				t.Errorf("Worst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchResultMarshalJSON(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	result := ValidateBatch([]Item{
+		{User: user, EntityDate: mustParseDate("2020-01-01"), EntityType: "certification"},
+		{User: user, EntityDate: mustParseDate("1989-01-01"), EntityType: "certification"},
+	})
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var decoded struct {
+		Total         int            `json:"total"`
+		Failed        int            `json:"failed"`
+		Indeterminate int            `json:"indeterminate"`
+		Worst         Severity       `json:"worst"`
+		CountByCode   map[string]int `json:"count_by_code"`
+		Reports       []*Report      `json:"reports"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if decoded.Total != 2 || decoded.Failed != 1 || decoded.Indeterminate != 0 || decoded.Worst != SeverityError {
+		t.Errorf("decoded summary = %+v", decoded)
+	}
+	if decoded.CountByCode[ErrCodeBeforeBirth] != 1 {
+		t.Errorf("decoded count_by_code = %v", decoded.CountByCode)
+	}
+	if len(decoded.Reports) != 2 || decoded.Reports[1].Findings[0].Code != ErrCodeBeforeBirth {
+		t.Errorf("decoded reports = %+v", decoded.Reports)
+	}
+}
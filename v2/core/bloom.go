@@ -0,0 +1,200 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BloomFilter is a probabilistic set of input hashes, see HashInputs. It
+// answers that a hash may be in the set, with a false positive rate chosen
+// on creation, or that it certainly is not. It is safe for concurrent use.
+type BloomFilter struct {
+	mu     sync.RWMutex
+	bits   []uint64
+	hashes int // Number of bits set per key
+}
+
+// NewBloomFilter returns an empty filter sized for expected keys with the
+// given false positive rate, such as 0.001
+func NewBloomFilter(expected int, falsePositiveRate float64) *BloomFilter {
+	n := float64(max(expected, 1))
+	p := min(max(falsePositiveRate, 1e-9), 0.5)
+	m := math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := max(int(math.Round(m/n*math.Ln2)), 1)
+	return &BloomFilter{bits: make([]uint64, (int(m)+63)/64), hashes: k}
+}
+
+// NewKnownBadFilter returns a filter of the inputs of rejected items, such
+// as the ones ReadRejects reads, for WithPrescreen and Prioritize. Rejects
+// that may pass later are left out: the ones without a report, and the
+// ones with a finding of a failed lookup, a future date or a minimum age
+// not yet reached.
+func NewKnownBadFilter(rejects []Reject, falsePositiveRate float64) *BloomFilter {
+	var known []Reject
+	for _, reject := range rejects {
+		if reject.Report != nil && !slices.ContainsFunc(reject.Report.Findings, mayClear) {
+			known = append(known, reject)
+		}
+	}
+	// Each reject adds its input hash for Prioritize, and the key of
+	// WithPrescreen that also covers the options of the validation
+	f := NewBloomFilter(2*len(known), falsePositiveRate)
+	for _, reject := range known {
+		f.Add(itemHash(reject.Item))
+		f.Add(knownBadKey(reject.Item, reject.Report.ConfigHash))
+	}
+	return f
+}
+
+// mayClear reports whether a finding may not recur when the same inputs
+// are validated again: a failed lookup, or a finding that depends on the
+// reference time
+func mayClear(f *DateValidationError) bool {
+	switch f.Code {
+	case ErrCodeJurisdictionUnknown, ErrCodeRevocationUnknown, ErrCodeFutureDate:
+		return true
+	case ErrCodeUnrealisticAge, ErrCodeAgeCategory:
+		// Minimum ages, as opposed to maximum ones, are reached in time
+		return slices.ContainsFunc(f.Reasons, func(r Reason) bool { return r.Fact == "min" })
+	}
+	return false
+}
+
+// Add adds a key to the filter
+func (f *BloomFilter) Add(key string) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.positions(key, func(bit uint64) {
+		f.bits[bit/64] |= 1 << (bit % 64)
+	})
+}
+
+// MayContain reports whether the key may have been added. A false answer
+// is certain.
+func (f *BloomFilter) MayContain(key string) bool {
+	if f == nil {
+		return false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	found := true
+	f.positions(key, func(bit uint64) {
+		found = found && f.bits[bit/64]&(1<<(bit%64)) != 0
+	})
+	return found
+}
+
+// positions calls fn with the bits of key, by double hashing
+func (f *BloomFilter) positions(key string, fn func(bit uint64)) {
+	sum := sha256.Sum256([]byte(key))
+	h1, h2 := binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16])|1
+	m := uint64(len(f.bits)) * 64
+	for i := range uint64(f.hashes) {
+		fn((h1 + i*h2) % m)
+	}
+}
+
+// MarshalBinary encodes the filter, so that a pipeline can keep it between
+// runs
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	if f == nil {
+		return nil, ErrNilValue
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	data := binary.BigEndian.AppendUint32(nil, uint32(f.hashes))
+	for _, word := range f.bits {
+		data = binary.BigEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if f == nil {
+		return ErrNilValue
+	}
+	if len(data) < 12 || (len(data)-4)%8 != 0 || binary.BigEndian.Uint32(data) == 0 {
+		return errors.New("userdate: invalid bloom filter encoding")
+	}
+	bits := make([]uint64, (len(data)-4)/8)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(data[4+8*i:])
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hashes, f.bits = int(binary.BigEndian.Uint32(data)), bits
+	return nil
+}
+
+// Prioritize returns the items that may be in the filter first, then the
+// others, each in their order, so that a pipeline re-validates the likely
+// failures first
+func (f *BloomFilter) Prioritize(items []Item) []Item {
+	if f == nil {
+		return slices.Clone(items)
+	}
+	known := make([]bool, len(items))
+	for i, item := range items {
+		known[i] = f.MayContain(itemHash(item))
+	}
+	sorted := make([]Item, 0, len(items))
+	for _, first := range []bool{true, false} {
+		for i, item := range items {
+			if known[i] == first {
+				sorted = append(sorted, item)
+			}
+		}
+	}
+	return slices.Clip(sorted)
+}
+
+// itemHash returns the input hash of a batch item under the current rules
+func itemHash(item Item) string {
+	return HashInputs(item.User, Entity{Type: item.EntityType, Date: item.EntityDate}, RuleVersion)
+}
+
+// knownBadKey returns the key of WithPrescreen of a batch item validated
+// with the options of configHash, see Report.ConfigHash
+func knownBadKey(item Item, configHash string) string {
+	return itemHash(item) + ":" + configHash
+}
+
+// WithPrescreen short-circuits the entity date validations whose inputs
+// may be in filter, such as a NewKnownBadFilter of the rejects of an
+// earlier run: their reports hold a KNOWN_BAD error instead of the
+// findings of the rules. As the keys cover RuleVersion and the ConfigHash
+// of the rejects, records fail the pre-screen only while they, the rules
+// and the options of the validator are unchanged. A valid
+// record fails it at the false positive rate of the filter, so keep the
+// rate low and validate KNOWN_BAD items again without the pre-screen
+// before acting on them.
+func WithPrescreen(filter *BloomFilter) Option {
+	return func(c *config) {
+		c.prescreen = filter
+	}
+}
+
+// prescreened returns the report of an entity date that may be known to
+// fail, or nil
+func (c *config) prescreened(user *User, entityDate time.Time, entityType string) *Report {
+	if c.prescreen == nil {
+		return nil
+	}
+	// The rejects come from runs with or without the pre-screen
+	item := Item{User: user, EntityDate: entityDate, EntityType: entityType}
+	if !c.prescreen.MayContain(knownBadKey(item, c.configHash)) && !c.prescreen.MayContain(knownBadKey(item, c.unscreenedHash)) {
+		return nil
+	}
+	report := c.newReport(user, entityDate, entityType)
+	report.add(c.localize(newError(msgKnownBad, "type", entityTypeArg(entityType), "date", entityDate)))
+	return report
+}
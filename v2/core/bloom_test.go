@@ -0,0 +1,138 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	f := NewBloomFilter(1000, 0.01)
+	for i := range 1000 {
+		f.Add(fmt.Sprint("bad-", i))
+	}
+	for i := range 1000 {
+		if !f.MayContain(fmt.Sprint("bad-", i)) {
+			t.Fatalf("MayContain(bad-%d) = false, want true", i)
+		}
+	}
+	positives := 0
+	for i := range 10000 {
+		if f.MayContain(fmt.Sprint("good-", i)) {
+			positives++
+		}
+	}
+	// 1% of 10000, with a generous margin
+	if positives > 300 {
+		t.Errorf("%d false positives in 10000, want about 100", positives)
+	}
+
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g BloomFilter
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 1000 {
+		if !g.MayContain(fmt.Sprint("bad-", i)) {
+			t.Fatalf("decoded MayContain(bad-%d) = false, want true", i)
+		}
+	}
+	for _, data := range [][]byte{nil, {0, 0, 0, 1}, {0, 0, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8}, make([]byte, 13)} {
+		if err := g.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%v) = nil, want an error", data)
+		}
+	}
+}
+
+func TestPrescreen(t *testing.T) {
+	bad := Item{User: &User{ID: "u1", BirthDate: mustParseDate("2000-01-01")}, EntityDate: mustParseDate("1999-01-01"), EntityType: "account"}
+	good := Item{User: &User{ID: "u2", BirthDate: mustParseDate("1990-01-01")}, EntityDate: mustParseDate("2020-01-01"), EntityType: "account"}
+	fixed := Item{User: bad.User, EntityDate: mustParseDate("2020-01-01"), EntityType: "account"}
+
+	first := ValidateBatch([]Item{bad, good})
+	var rejects []Reject
+	for i, report := range first.Reports {
+		if !report.Valid() {
+			rejects = append(rejects, Reject{Item: first.Items[i], Report: report})
+		}
+	}
+	if len(rejects) != 1 {
+		t.Fatalf("got %d rejects, want 1", len(rejects))
+	}
+	filter := NewKnownBadFilter(rejects, 0.001)
+
+	result := ValidateBatch([]Item{good, bad, fixed}, WithPrescreen(filter))
+	want := [][]string{{}, {ErrCodeKnownBad}, {}}
+	for i, report := range result.Reports {
+		if got := findingCodes(report); fmt.Sprint(got) != fmt.Sprint(want[i]) {
+			t.Errorf("item %d: findings = %v, want %v", i, got, want[i])
+		}
+	}
+	if result.Reports[1].InputHash != itemHash(bad) {
+		t.Errorf("InputHash = %q, want the hash of the item", result.Reports[1].InputHash)
+	}
+
+	sorted := filter.Prioritize([]Item{good, fixed, bad})
+	if len(sorted) != 3 || sorted[0] != bad || sorted[1] != good || sorted[2] != fixed {
+		t.Errorf("Prioritize() did not put the known-bad item first, keeping the others in order")
+	}
+}
+
+func TestKnownBadFilterLeavesOutMayPass(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	user := &User{ID: "u1", BirthDate: mustParseDate("2000-01-01")}
+	tests := []struct {
+		name string
+		item Item
+		opts []Option
+		want bool
+	}{
+		{"before birth", Item{User: user, EntityDate: mustParseDate("1999-01-01"), EntityType: "account"}, nil, true},
+		{"too old for the type", Item{User: user, EntityDate: mustParseDate("2020-01-01"), EntityType: "kindergarten"}, nil, true},
+		{"future date", Item{User: user, EntityDate: mustParseDate("2030-01-01"), EntityType: "account"}, nil, false},
+		{"minimum age", Item{User: user, EntityDate: mustParseDate("2010-01-01"), EntityType: "license"}, nil, false},
+		{"failed lookup", Item{User: user, EntityDate: mustParseDate("1999-01-01"), EntityType: "account"}, []Option{WithJurisdictionResolver(failingResolver{})}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator(append([]Option{now}, tt.opts...)...)
+			report := v.CheckEntityDate(tt.item.User, tt.item.EntityDate, tt.item.EntityType)
+			if report.Valid() {
+				t.Fatalf("findings = %v, want a reject", findingCodes(report))
+			}
+			filter := NewKnownBadFilter([]Reject{{Item: tt.item, Report: report}}, 0.001)
+			screened := NewValidator(append([]Option{now, WithPrescreen(filter)}, tt.opts...)...)
+			codes := findingCodes(screened.CheckEntityDate(tt.item.User, tt.item.EntityDate, tt.item.EntityType))
+			if got := slices.Contains(codes, ErrCodeKnownBad); got != tt.want {
+				t.Errorf("findings = %v, want KNOWN_BAD %v", codes, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrescreenConfigHash(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	bad := Item{User: &User{ID: "u1", BirthDate: mustParseDate("2000-01-01")}, EntityDate: mustParseDate("1999-01-01"), EntityType: "account"}
+	report := NewValidator(now).CheckEntityDate(bad.User, bad.EntityDate, bad.EntityType)
+	filter := NewKnownBadFilter([]Reject{{Item: bad, Report: report}}, 0.001)
+
+	// Rejects of another configuration, or of package functions, do not
+	// short-circuit validations
+	for name, opts := range map[string][]Option{
+		"same options":  {now},
+		"other options": {now, WithMinimumAge("account", 21)},
+	} {
+		v := NewValidator(append(opts, WithPrescreen(filter))...)
+		got := slices.Contains(findingCodes(v.CheckEntityDate(bad.User, bad.EntityDate, bad.EntityType)), ErrCodeKnownBad)
+		if want := name == "same options"; got != want {
+			t.Errorf("%s: KNOWN_BAD = %v, want %v", name, got, want)
+		}
+	}
+	if codes := findingCodes(CheckEntityDate(bad.User, bad.EntityDate, bad.EntityType, now, WithPrescreen(filter))); slices.Contains(codes, ErrCodeKnownBad) {
+		t.Errorf("package function findings = %v, want the rules evaluated", codes)
+	}
+}
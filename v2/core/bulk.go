@@ -0,0 +1,228 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Errors returned by BulkValidator.Submit
+var (
+	ErrQueueFull  = errors.New("userdate: bulk validator queue is full")
+	ErrBulkClosed = errors.New("userdate: bulk validator is closed")
+)
+
+// Backpressure is what Submit does when the queue of a BulkValidator is full
+type Backpressure int
+
+// Backpressure strategies
+const (
+	BackpressureBlock Backpressure = iota // Wait for room in the queue (default)
+	BackpressureDrop                      // Discard the item and count it as dropped
+	BackpressureError                     // Return ErrQueueFull
+)
+
+// String returns the name of the strategy
+func (b Backpressure) String() string {
+	switch b {
+	case BackpressureDrop:
+		return "drop"
+	case BackpressureError:
+		return "error"
+	default:
+		return "block"
+	}
+}
+
+// BulkConfig tunes a BulkValidator
+type BulkConfig struct {
+	// Workers is the number of concurrent validations, GOMAXPROCS by default
+	Workers int
+
+	// QueueDepth is the number of submitted items that may wait for a
+	// worker, four per worker by default
+	QueueDepth int
+
+	// Backpressure is the behavior of Submit when the queue is full
+	Backpressure Backpressure
+}
+
+// BulkResult is the outcome of a submitted item
+type BulkResult struct {
+	Seq    uint64 // Submission order, starting at 1; dropped or refused items leave gaps
+	Shard  Shard  // The shard set with WithShard, zero otherwise
+	Item   Item
+	Report *Report
+}
+
+// BulkMetrics describes the state of a BulkValidator
+type BulkMetrics struct {
+	Workers       int    `json:"workers"`
+	QueueLength   int    `json:"queue_length"`   // Items waiting for a worker
+	QueueCapacity int    `json:"queue_capacity"` // Maximum waiting items
+	InFlight      int64  `json:"in_flight"`      // Items being validated
+	Submitted     uint64 `json:"submitted"`      // Items accepted into the queue
+	Processed     uint64 `json:"processed"`      // Items validated and handled
+	Dropped       uint64 `json:"dropped"`        // Items discarded by BackpressureDrop
+	Rejected      uint64 `json:"rejected"`       // Items refused with ErrQueueFull
+	Skipped       uint64 `json:"skipped"`        // Items of other shards, see WithShard
+}
+
+// BulkValidator validates a stream of items on a pool of workers, such as
+// records consumed from a message queue. Results are passed to a handler
+// as they complete, so they may arrive out of submission order; use
+// BulkResult.Seq to restore it. A BulkValidator is safe for concurrent use.
+type BulkValidator struct {
+	cfg    config
+	bulk   BulkConfig
+	handle func(BulkResult)
+
+	queue chan bulkJob
+	wg    sync.WaitGroup
+
+	mu     sync.RWMutex // Guards closed against Submit racing Close
+	closed bool
+
+	seq       atomic.Uint64
+	submitted atomic.Uint64
+	inFlight  atomic.Int64
+	processed atomic.Uint64
+	dropped   atomic.Uint64
+	rejected  atomic.Uint64
+	skipped   atomic.Uint64
+}
+
+// bulkJob is a queued item
+type bulkJob struct {
+	seq  uint64
+	item Item
+}
+
+// NewBulkValidator starts a bulk validator applying opts to every item.
+// handle is called from the worker goroutines, concurrently, for every
+// validated item.
+func NewBulkValidator(bulk BulkConfig, handle func(BulkResult), opts ...Option) *BulkValidator {
+	return newBulkValidator(newConfig(opts), bulk, handle)
+}
+
+// NewBulkValidator starts a bulk validator using the validator's settings
+func (v *Validator) NewBulkValidator(bulk BulkConfig, handle func(BulkResult)) *BulkValidator {
+	return newBulkValidator(*v.config(), bulk, handle)
+}
+
+// newBulkValidator applies the defaults and starts the workers
+func newBulkValidator(cfg config, bulk BulkConfig, handle func(BulkResult)) *BulkValidator {
+	if bulk.Workers <= 0 {
+		bulk.Workers = runtime.GOMAXPROCS(0)
+	}
+	if bulk.QueueDepth <= 0 {
+		bulk.QueueDepth = 4 * bulk.Workers
+	}
+	b := &BulkValidator{
+		cfg:    cfg,
+		bulk:   bulk,
+		handle: handle,
+		queue:  make(chan bulkJob, bulk.QueueDepth),
+	}
+	b.wg.Add(bulk.Workers)
+	for range bulk.Workers {
+		go b.work()
+	}
+	return b
+}
+
+// Submit queues an item for validation. When the queue is full it waits,
+// drops the item or returns ErrQueueFull depending on the backpressure
+// strategy. A blocked Submit returns the context's error when ctx is done.
+// Items of users outside the shard set with WithShard are skipped.
+func (b *BulkValidator) Submit(ctx context.Context, item Item) error {
+	if b == nil {
+		return ErrNilValue
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrBulkClosed
+	}
+	if !b.cfg.shard.Owns(itemUserID(item)) {
+		b.skipped.Add(1)
+		return nil
+	}
+
+	job := bulkJob{seq: b.seq.Add(1), item: item}
+	if b.bulk.Backpressure == BackpressureBlock {
+		select {
+		case b.queue <- job:
+			b.submitted.Add(1)
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case b.queue <- job:
+		b.submitted.Add(1)
+		return nil
+	default:
+	}
+	if b.bulk.Backpressure == BackpressureDrop {
+		b.dropped.Add(1)
+		return nil
+	}
+	b.rejected.Add(1)
+	return ErrQueueFull
+}
+
+// Close stops accepting items, waits until the queued items are validated
+// and handled, and stops the workers
+func (b *BulkValidator) Close() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBulkClosed
+	}
+	b.closed = true
+	close(b.queue)
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return nil
+}
+
+// Metrics returns the current queue occupancy and counters
+func (b *BulkValidator) Metrics() BulkMetrics {
+	if b == nil {
+		return BulkMetrics{}
+	}
+	return BulkMetrics{
+		Workers:       b.bulk.Workers,
+		QueueLength:   len(b.queue),
+		QueueCapacity: cap(b.queue),
+		InFlight:      b.inFlight.Load(),
+		Submitted:     b.submitted.Load(),
+		Processed:     b.processed.Load(),
+		Dropped:       b.dropped.Load(),
+		Rejected:      b.rejected.Load(),
+		Skipped:       b.skipped.Load(),
+	}
+}
+
+// work validates queued items until the queue is closed
+func (b *BulkValidator) work() {
+	defer b.wg.Done()
+	for job := range b.queue {
+		b.inFlight.Add(1)
+		report := b.cfg.checkEntityDate(context.Background(), job.item.User, job.item.EntityDate, job.item.EntityType)
+		if b.handle != nil {
+			b.handle(BulkResult{Seq: job.seq, Shard: b.cfg.shard, Item: job.item, Report: report})
+		}
+		b.inFlight.Add(-1)
+		b.processed.Add(1)
+	}
+}
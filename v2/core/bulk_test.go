@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBulkValidator(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	var mu sync.Mutex
+	var seqs []uint64
+	failed := 0
+	b := NewBulkValidator(BulkConfig{Workers: 4}, func(r BulkResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		seqs = append(seqs, r.Seq)
+		if !r.Report.Valid() {
+			failed++
+		}
+	}, WithFixedNow(mustParseDate("2025-07-18")))
+
+	for i := range 100 {
+		date := mustParseDate("2020-01-01")
+		if i%10 == 0 {
+			date = mustParseDate("1980-01-01")
+		}
+		if err := b.Submit(context.Background(), Item{User: user, EntityDate: date, EntityType: "training"}); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if len(seqs) != 100 || failed != 10 {
+		t.Fatalf("handled %d results with %d failures, want 100 with 10", len(seqs), failed)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	for i, seq := range seqs {
+		if seq != uint64(i+1) {
+			t.Fatalf("sequence numbers = %v, want 1 to 100", seqs)
+		}
+	}
+	m := b.Metrics()
+	if m.Workers != 4 || m.QueueCapacity != 16 || m.Submitted != 100 || m.Processed != 100 || m.InFlight != 0 {
+		t.Errorf("Metrics() = %+v", m)
+	}
+
+	if err := b.Submit(context.Background(), Item{User: user}); !errors.Is(err, ErrBulkClosed) {
+		t.Errorf("Submit() after Close error = %v, want %v", err, ErrBulkClosed)
+	}
+	if err := b.Close(); !errors.Is(err, ErrBulkClosed) {
+		t.Errorf("second Close() error = %v, want %v", err, ErrBulkClosed)
+	}
+}
+
+func TestBulkValidatorBackpressure(t *testing.T) {
+	item := Item{User: &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}, EntityDate: mustParseDate("2020-01-01"), EntityType: "training"}
+
+	tests := []struct {
+		strategy Backpressure
+		wantErr  error
+		dropped  uint64
+		rejected uint64
+	}{
+		{BackpressureBlock, context.DeadlineExceeded, 0, 0},
+		{BackpressureDrop, nil, 1, 0},
+		{BackpressureError, ErrQueueFull, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			started := make(chan struct{}, 2)
+			release := make(chan struct{})
+			b := NewBulkValidator(BulkConfig{Workers: 1, QueueDepth: 1, Backpressure: tt.strategy}, func(BulkResult) {
+				started <- struct{}{}
+				<-release
+			})
+
+			// The first item occupies the worker, the second fills the queue
+			ctx := context.Background()
+			if err := b.Submit(ctx, item); err != nil {
+				t.Fatal(err)
+			}
+			<-started
+			if err := b.Submit(ctx, item); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+			if err := b.Submit(ctx, item); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Submit() on a full queue error = %v, want %v", err, tt.wantErr)
+			}
+
+			m := b.Metrics()
+			if m.QueueLength != 1 || m.InFlight != 1 || m.Dropped != tt.dropped || m.Rejected != tt.rejected || m.Submitted != 2 {
+				t.Errorf("Metrics() = %+v", m)
+			}
+
+			close(release)
+			b.Close()
+			if m := b.Metrics(); m.Processed != 2 || m.QueueLength != 0 {
+				t.Errorf("Metrics() after Close = %+v, want 2 processed", m)
+			}
+		})
+	}
+}
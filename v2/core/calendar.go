@@ -0,0 +1,234 @@
+package core
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MilestoneKind is the kind of a compliance milestone
+type MilestoneKind string
+
+const (
+	MilestoneAge        MilestoneKind = "age"         // The user becomes old enough for some entity types
+	MilestoneRenewalDue MilestoneKind = "renewal_due" // An entity is due for renewal before it expires
+	MilestoneExpiry     MilestoneKind = "expiry"      // An entity expires
+)
+
+// Milestone is an upcoming compliance event of a user
+type Milestone struct {
+	Date        time.Time     `json:"date"`
+	Kind        MilestoneKind `json:"kind"`
+	Age         int           `json:"age,omitempty"`          // Age the user turns, for MilestoneAge
+	EntityTypes []string      `json:"entity_types,omitempty"` // Types the user becomes old enough for, for MilestoneAge
+	EntityID    string        `json:"entity_id,omitempty"`
+	EntityType  string        `json:"entity_type,omitempty"`
+	Summary     string        `json:"summary"` // In the locale of WithLocale
+
+	index int // Index of the entity in its profile
+}
+
+// defaultRenewalNotice is how long before an expiry its renewal is due
+var defaultRenewalNotice = ValidityPeriod{Months: 3}
+
+// WithRenewalNotice sets how long before an entity expires its renewal is
+// due in Milestones, 3 months by default. A zero period leaves renewal
+// milestones out.
+func WithRenewalNotice(period ValidityPeriod) Option {
+	return func(c *config) {
+		c.renewalNotice = period
+	}
+}
+
+// Milestones returns the upcoming compliance events of the user of a
+// profile, from today on and sorted by date:
+//   - the birthdays on which the user reaches the minimum age of entity
+//     types, such as turning 16 and becoming eligible for a license;
+//   - the expiry of each entity, from its EndDate for documents and from
+//     the validity period of its type otherwise;
+//   - the date each entity is due for renewal, see WithRenewalNotice.
+//
+// Entities that fail validation are left out, and nothing falls after the
+// death of the user. It returns the error of an invalid user.
+func Milestones(profile Profile, opts ...Option) ([]Milestone, error) {
+	cfg := newConfig(opts)
+	return cfg.milestones(profile)
+}
+
+func (c *config) milestones(profile Profile) ([]Milestone, error) {
+	user := profile.User
+	if err := c.validateUser(user); err != nil {
+		return nil, err
+	}
+	today := c.truncate(c.now())
+	upcoming := func(date time.Time) bool {
+		return !date.Before(today) && (user.DeathDate.IsZero() || !date.After(c.truncate(user.DeathDate)))
+	}
+	birthDate := c.truncate(user.BirthDate)
+
+	var milestones []Milestone
+	eligible := make(map[int][]string)
+	for entityType, age := range c.minimumAges {
+		if age > 0 && upcoming(c.dateAtAge(birthDate, age)) {
+			eligible[age] = append(eligible[age], entityType)
+		}
+	}
+	for age, types := range eligible {
+		slices.Sort(types)
+		names := make([]string, len(types))
+		for i, t := range types {
+			names[i] = c.messageCatalog().formatArg(entityTypeArg(t))
+		}
+		date := c.dateAtAge(birthDate, age)
+		milestones = append(milestones, Milestone{
+			Date: date, Kind: MilestoneAge, Age: age, EntityTypes: types, index: -1,
+			Summary: c.message(msgMilestoneAge, "age", age, "date", date, "types", strings.Join(names, ", ")),
+		})
+	}
+
+	for i, entity := range profile.Entities {
+		expiry, ok := c.entityExpiry(entity, birthDate)
+		if !ok || !upcoming(expiry) {
+			continue
+		}
+		report := c.newReport(user, entity.Date, entity.Type)
+		c.evaluateEntity(context.Background(), user, entity, report)
+		valid := report.Valid()
+		report.Release()
+		if !valid {
+			continue
+		}
+		typeArg := entityTypeArg(entity.Type)
+		if c.renewalNotice != (ValidityPeriod{}) {
+			due := expiry.AddDate(-c.renewalNotice.Years, -c.renewalNotice.Months, 0)
+			if due.After(c.truncate(entity.validFrom())) && upcoming(due) {
+				milestones = append(milestones, Milestone{
+					Date: due, Kind: MilestoneRenewalDue, EntityID: entity.ID, EntityType: entity.Type, index: i,
+					Summary: c.message(msgMilestoneRenewal, "type", typeArg, "date", entity.Date, "expiry", expiry),
+				})
+			}
+		}
+		milestones = append(milestones, Milestone{
+			Date: expiry, Kind: MilestoneExpiry, EntityID: entity.ID, EntityType: entity.Type, index: i,
+			Summary: c.message(msgMilestoneExpiry, "type", typeArg, "date", entity.Date, "expiry", expiry),
+		})
+	}
+
+	slices.SortFunc(milestones, func(a, b Milestone) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.index, b.index), cmp.Compare(a.Kind, b.Kind))
+	})
+	return milestones, nil
+}
+
+// entityExpiry returns the date an entity expires, if it does: the EndDate
+// of a document, or the end of the validity period of its type. Entities
+// marked expired or revoked have no upcoming expiry.
+func (c *config) entityExpiry(entity Entity, birthDate time.Time) (time.Time, bool) {
+	if entity.Date.IsZero() || entity.Status == StatusExpired || entity.Status == StatusRevoked {
+		return time.Time{}, false
+	}
+	if documentTypes[entity.Type] && !entity.EndDate.IsZero() {
+		return c.truncate(entity.EndDate), true
+	}
+	period, ok := c.documentValidity(entity, birthDate)
+	if !ok {
+		return time.Time{}, false
+	}
+	return c.truncate(period.expiry(entity.validFrom())), true
+}
+
+// messageCatalog returns the catalog of the configured locale, English by
+// default
+func (c *config) messageCatalog() *MessageCatalog {
+	if c.messages != nil {
+		return c.messages
+	}
+	return english()
+}
+
+// message renders the message of key in the configured locale
+func (c *config) message(key messageKey, args ...any) string {
+	return c.messageCatalog().format(key, args, c.locale)
+}
+
+// WriteCalendar writes the Milestones of the user of a profile to w as an
+// iCalendar (RFC 5545) file of all-day events, which HR tools can import or
+// subscribe to. Events keep their UID across exports, so calendars update
+// them in place when a renewal moves an expiry. It returns the error of an
+// invalid user.
+func WriteCalendar(w io.Writer, profile Profile, opts ...Option) error {
+	if w == nil {
+		return ErrNilValue
+	}
+	cfg := newConfig(opts)
+	milestones, err := cfg.milestones(profile)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//userdate//milestones//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", escapeText(profile.User.ID))
+	stamp := cfg.now().UTC().Format("20060102T150405Z")
+	for _, m := range milestones {
+		line("BEGIN", "VEVENT")
+		line("UID", m.uid(profile.User.ID)+"@userdate")
+		line("DTSTAMP", stamp)
+		line("DTSTART;VALUE=DATE", m.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE", m.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escapeText(m.Summary))
+		line("CATEGORIES", strings.ToUpper(string(m.Kind)))
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// uid identifies a milestone of a user independently of its date
+func (m Milestone) uid(userID string) string {
+	subject := strconv.Itoa(m.Age)
+	if m.Kind != MilestoneAge {
+		subject = m.EntityID
+		if subject == "" {
+			subject = m.EntityType + "#" + strconv.Itoa(m.index)
+		}
+	}
+	sum := sha256.Sum256([]byte(userID + "\x00" + string(m.Kind) + "\x00" + subject))
+	return hex.EncodeToString(sum[:16])
+}
+
+// escapeText escapes a TEXT property value of RFC 5545
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// writeFolded writes a content line ended with CRLF, folded into lines of
+// at most 75 octets without splitting characters
+func writeFolded(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		fmt.Fprintf(w, "%s\r\n ", line[:cut])
+		line, limit = line[cut:], 74
+	}
+	fmt.Fprintf(w, "%s\r\n", line)
+}
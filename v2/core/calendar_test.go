@@ -0,0 +1,138 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMilestones(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("2010-03-01")}
+	profile := Profile{User: user, Entities: []Entity{
+		{ID: "cpr-1", Type: "cpr_certification", Date: mustParseDate("2024-09-01")},
+		{ID: "pass-1", Type: "passport", Date: mustParseDate("2021-05-01"), EndDate: mustParseDate("2026-05-01")},
+		{ID: "cpr-0", Type: "cpr_certification", Date: mustParseDate("2022-09-01")},
+		{ID: "cpr-r", Type: "cpr_certification", Date: mustParseDate("2024-10-01"), Status: StatusRevoked},
+	}}
+	opts := []Option{now, WithMinimumAge("license", 16), WithMinimumAge("employment", 16)}
+
+	milestones, err := Milestones(profile, opts...)
+	if err != nil {
+		t.Fatalf("Milestones() error = %v", err)
+	}
+	var got []string
+	for _, m := range milestones {
+		if m.Kind == MilestoneAge && m.Age != 16 {
+			continue
+		}
+		got = append(got, m.Date.Format("2006-01-02")+" "+string(m.Kind)+" "+m.EntityID+" "+m.Summary)
+	}
+	want := []string{
+		"2026-02-01 renewal_due pass-1 passport from 2021-05-01: renewal due before it expires on 2026-05-01",
+		"2026-03-01 age  Turns 16: old enough for employment, license",
+		"2026-05-01 expiry pass-1 passport from 2021-05-01 expires",
+		"2026-06-01 renewal_due cpr-1 cpr_certification from 2024-09-01: renewal due before it expires on 2026-09-01",
+		"2026-09-01 expiry cpr-1 cpr_certification from 2024-09-01 expires",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Milestones() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	for _, m := range milestones {
+		if m.Kind == MilestoneAge && m.Age <= 15 {
+			t.Errorf("Milestones() has the past age %d", m.Age)
+		}
+	}
+
+	milestones, err = Milestones(profile, append(opts, WithRenewalNotice(ValidityPeriod{}), WithLocale("fr"))...)
+	if err != nil {
+		t.Fatalf("Milestones() error = %v", err)
+	}
+	for _, m := range milestones {
+		if m.Kind == MilestoneRenewalDue {
+			t.Errorf("Milestones() has a renewal without a renewal notice: %+v", m)
+		}
+		if m.EntityID == "pass-1" && m.Summary != "passeport du 1 mai 2021 : arrive à expiration" {
+			t.Errorf("Summary = %q", m.Summary)
+		}
+	}
+
+	if _, err := Milestones(Profile{}, now); codeOf(err) != ErrCodeInvalidUser {
+		t.Errorf("Milestones() without a user error = %v, want %s", err, ErrCodeInvalidUser)
+	}
+}
+
+func TestMilestonesAfterDeath(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1950-03-01"), DeathDate: mustParseDate("2025-01-01")}
+	profile := Profile{User: user, Entities: []Entity{
+		{Type: "cpr_certification", Date: mustParseDate("2024-09-01")},
+	}}
+	milestones, err := Milestones(profile, WithFixedNow(mustParseDate("2025-07-18")))
+	if err != nil || len(milestones) != 0 {
+		t.Errorf("Milestones() = %+v, %v, want none", milestones, err)
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-03-01")}
+	profile := Profile{User: user, Entities: []Entity{
+		{ID: "cpr-1", Type: "cpr_certification", Date: mustParseDate("2024-09-01")},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteCalendar(&buf, profile, now, WithRenewalNotice(ValidityPeriod{})); err != nil {
+		t.Fatalf("WriteCalendar() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"DTSTAMP:20250718T000000Z\r\n",
+		"DTSTART;VALUE=DATE:20260901\r\nDTEND;VALUE=DATE:20260902\r\n",
+		"SUMMARY:cpr_certification from 2024-09-01 expires\r\n",
+		"CATEGORIES:EXPIRY\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteCalendar() output misses %q:\n%s", want, out)
+		}
+	}
+
+	// A renewal moves the event but keeps its UID
+	uid := func(out string) string {
+		_, rest, _ := strings.Cut(out, "UID:")
+		uid, _, _ := strings.Cut(rest, "\r\n")
+		return uid
+	}
+	profile.Entities[0].RenewedAt = mustParseDate("2025-06-01")
+	var renewed bytes.Buffer
+	if err := WriteCalendar(&renewed, profile, now, WithRenewalNotice(ValidityPeriod{})); err != nil {
+		t.Fatalf("WriteCalendar() error = %v", err)
+	}
+	if !strings.Contains(renewed.String(), "DTSTART;VALUE=DATE:20270601") || uid(renewed.String()) != uid(out) {
+		t.Errorf("renewed calendar =\n%s\nwant the UID %s on 2027-06-01", renewed.String(), uid(out))
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeFolded(w, "SUMMARY:"+strings.Repeat("é", 70))
+	w.Flush()
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line %d has %d octets", i, len(line))
+		}
+		if i > 0 && !strings.HasPrefix(line, " ") {
+			t.Errorf("continuation line %d does not start with a space", i)
+		}
+		if !strings.ContainsRune(line, 'é') {
+			t.Errorf("line %d = %q", i, line)
+		}
+	}
+	if got := escapeText("a,b;c\\d\ne"); got != `a\,b\;c\\d\ne` {
+		t.Errorf("escapeText() = %q", got)
+	}
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// CallOptions vary the settings of a single validation, such as those of
+// an HTTP request, without building a new Validator: they only replace a
+// few fields of a copy of the settings, and the rule set is shared. Zero
+// fields keep the configured settings.
+type CallOptions struct {
+	Now    time.Time // Reference time, as with ContextWithNow
+	Locale string    // Locale of the messages, as with WithLocale
+
+	// Jurisdiction is the code of the jurisdiction whose ages apply, as
+	// accepted by NewRuleConfig, instead of the one of the
+	// JurisdictionResolver
+	Jurisdiction string
+
+	// SkipRules are the rules not to evaluate, named as in the steps of
+	// Explain
+	SkipRules []string
+}
+
+// callKey is the context key for the call options
+type callKey struct{}
+
+// ContextWithCallOptions returns a copy of ctx carrying opts for the
+// validations run with that context: the Context variants of the
+// validation functions and methods, and the HTTP handlers.
+func ContextWithCallOptions(ctx context.Context, opts CallOptions) context.Context {
+	return context.WithValue(ctx, callKey{}, opts)
+}
+
+// CallOptionsFromContext returns the call options stored in ctx by
+// ContextWithCallOptions
+func CallOptionsFromContext(ctx context.Context) (CallOptions, bool) {
+	if ctx == nil {
+		return CallOptions{}, false
+	}
+	opts, ok := ctx.Value(callKey{}).(CallOptions)
+	return opts, ok
+}
+
+// fixedJurisdiction resolves every entity to the same jurisdiction
+type fixedJurisdiction string
+
+// ResolveJurisdiction returns the jurisdiction
+func (j fixedJurisdiction) ResolveJurisdiction(context.Context, *User, Entity) (string, error) {
+	return string(j), nil
+}
+
+// withCall returns a copy of c with the call options applied. Reports of
+// calls with call options are not cached, as the cache key does not cover
+// them, and their ConfigHash covers the options other than Now.
+func (c config) withCall(opts CallOptions) config {
+	if !opts.Now.IsZero() {
+		c.now = func() time.Time { return opts.Now }
+		c.uncached = true
+	}
+	if opts.Locale != "" {
+		c.messages = lookupCatalog(opts.Locale)
+		c.locale = opts.Locale
+		c.uncached = true
+	}
+	if opts.Jurisdiction != "" {
+		c.jurisdictions = fixedJurisdiction(opts.Jurisdiction)
+		c.uncached = true
+	}
+	if len(opts.SkipRules) > 0 {
+		c.skipRules = opts.SkipRules
+		c.uncached = true
+	}
+	if opts.Locale != "" || opts.Jurisdiction != "" || len(opts.SkipRules) > 0 {
+		c.configHash = opts.foldHash(c.configHash)
+		c.unscreenedHash = opts.foldHash(c.unscreenedHash)
+	}
+	return c
+}
+
+// foldHash returns the hash of a configuration of a Validator with the
+// call options other than Now applied. Configurations without a hash, of
+// the package functions, keep none.
+func (opts CallOptions) foldHash(hash string) string {
+	if hash == "" {
+		return ""
+	}
+	// Encoding strings cannot fail
+	data, _ := json.Marshal(struct {
+		Hash         string   `json:"hash"`
+		Locale       string   `json:"locale,omitempty"`
+		Jurisdiction string   `json:"jurisdiction,omitempty"`
+		SkipRules    []string `json:"skip_rules,omitempty"`
+	}{hash, opts.Locale, opts.Jurisdiction, opts.SkipRules})
+	return hashBytes(data)
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCallOptions(t *testing.T) {
+	cache := NewMemoryResultCache()
+	v := NewValidator(WithFixedNow(mustParseDate("2025-01-01")), WithResultCache(cache, time.Hour))
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-01-01")}
+	at16 := Entity{Type: "license", Date: mustParseDate("2016-06-01")} // Aged 16
+
+	tests := []struct {
+		name  string
+		opts  CallOptions
+		codes []string
+	}{
+		{"none", CallOptions{}, nil},
+		{"reference time", CallOptions{Now: mustParseDate("2015-01-01")}, []string{ErrCodeFutureDate}},
+		{"jurisdiction", CallOptions{Jurisdiction: "us-nj"}, []string{ErrCodeUnrealisticAge}},
+		{"unknown jurisdiction", CallOptions{Jurisdiction: "XX"}, []string{ErrCodeJurisdictionUnknown}},
+		{"skipped rule", CallOptions{Jurisdiction: "us-nj", SkipRules: []string{"minimum_age"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ContextWithCallOptions(context.Background(), tt.opts)
+			for _, report := range []*Report{
+				v.CheckEntityContext(ctx, user, at16),
+				v.CheckEntityDateContext(ctx, user, at16.Date, at16.Type),
+				CheckEntityContext(ctx, user, at16, WithFixedNow(mustParseDate("2025-01-01"))),
+			} {
+				if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+					t.Errorf("codes = %v, want %v", codes, tt.codes)
+				}
+			}
+		})
+	}
+
+	// The validator itself is unchanged
+	if report := v.CheckEntity(user, at16); !report.Valid() {
+		t.Errorf("CheckEntity() codes = %v, want valid", findingCodes(report))
+	}
+
+	ctx := ContextWithCallOptions(context.Background(), CallOptions{Locale: "fr", Now: mustParseDate("2015-01-01")})
+	err := v.ValidateEntityDateContext(ctx, user, at16.Date, at16.Type)
+	want := ValidateEntityDate(user, at16.Date, at16.Type, WithLocale("fr"), WithFixedNow(mustParseDate("2015-01-01")))
+	if err == nil || want == nil || err.Error() != want.Error() {
+		t.Errorf("ValidateEntityDateContext() error = %v, want %v", err, want)
+	}
+	if stats := cache.Stats(); stats.Entries != 1 {
+		t.Errorf("cache entries = %d, want only the report without call options", stats.Entries)
+	}
+}
+
+func TestCallOptionsConfigHash(t *testing.T) {
+	v := NewValidator(WithFixedNow(mustParseDate("2025-01-01")))
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-01-01")}
+	date := mustParseDate("2016-06-01")
+	hash := func(opts CallOptions) string {
+		ctx := ContextWithCallOptions(context.Background(), opts)
+		return v.CheckEntityDateContext(ctx, user, date, "license").ConfigHash
+	}
+
+	base := v.Config().Hash
+	if got := hash(CallOptions{}); got != base {
+		t.Errorf("ConfigHash without call options = %q, want %q", got, base)
+	}
+	if got := hash(CallOptions{Now: mustParseDate("2020-01-01")}); got != base {
+		t.Errorf("ConfigHash with a reference time = %q, want %q", got, base)
+	}
+	seen := map[string]string{base: "none"}
+	for name, opts := range map[string]CallOptions{
+		"locale":       {Locale: "fr"},
+		"jurisdiction": {Jurisdiction: "US-NJ"},
+		"skipped rule": {SkipRules: []string{"minimum_age"}},
+		"both":         {Jurisdiction: "US-NJ", SkipRules: []string{"minimum_age"}},
+	} {
+		got := hash(opts)
+		if other, ok := seen[got]; ok {
+			t.Errorf("%s: ConfigHash = %q, the same as %s", name, got, other)
+		}
+		if again := hash(opts); again != got {
+			t.Errorf("%s: ConfigHash = %q, then %q", name, got, again)
+		}
+		seen[got] = name
+	}
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrInvalidCatalog is returned for catalog data that cannot be decoded
+var ErrInvalidCatalog = errors.New("userdate: invalid catalog data")
+
+// catalogMagic starts every encoded catalog
+var catalogMagic = []byte("UDC1")
+
+// Catalog is a read-only credential catalog decoded lazily from a compact
+// binary encoding (see EncodeCatalog). Only the index is checked when the
+// catalog is opened; an entry is decoded on its first lookup. This keeps
+// large catalogs cheap to load compared to Go map literals. It backs the
+// catalogs of WithCatalog, not the built-in datasets of LoadRuleData. A
+// Catalog is safe for concurrent use.
+//
+// Layout: the magic "UDC1", the entry count as a uint32, then one index
+// slot per entry sorted by type (key offset uint32, key length uint16,
+// value offset uint32), then the keys and the varint-encoded values. All
+// integers are little endian.
+type Catalog struct {
+	data  []byte
+	count int
+
+	mu      sync.Mutex
+	decoded map[int]CatalogEntry
+
+	lookups atomic.Uint64
+	hits    atomic.Uint64
+}
+
+// CatalogEntry describes a credential type
+type CatalogEntry struct {
+	Type     string         `json:"type"`
+	Validity ValidityPeriod `json:"validity"`
+}
+
+// CatalogStats describes the memory use and activity of a catalog
+type CatalogStats struct {
+	Entries int    `json:"entries"` // Number of entries
+	Bytes   int    `json:"bytes"`   // Size of the encoded data
+	Decoded int    `json:"decoded"` // Entries decoded so far
+	Lookups uint64 `json:"lookups"`
+	Hits    uint64 `json:"hits"`
+}
+
+const (
+	catalogHeaderSize = 8  // Magic and count
+	catalogSlotSize   = 10 // Key offset, key length, value offset
+)
+
+// EncodeCatalog encodes entries in the catalog format. Types must be unique.
+func EncodeCatalog(entries []CatalogEntry) ([]byte, error) {
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, func(a, b CatalogEntry) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Type == sorted[i-1].Type {
+			return nil, fmt.Errorf("userdate: duplicate catalog entry %q", sorted[i].Type)
+		}
+	}
+
+	var keys, values []byte
+	index := make([]byte, 0, len(sorted)*catalogSlotSize)
+	dataStart := catalogHeaderSize + len(sorted)*catalogSlotSize
+	for _, e := range sorted {
+		if len(e.Type) > 0xffff {
+			return nil, fmt.Errorf("userdate: catalog type %.20q... is too long", e.Type)
+		}
+		index = binary.LittleEndian.AppendUint32(index, uint32(dataStart+len(keys)))
+		index = binary.LittleEndian.AppendUint16(index, uint16(len(e.Type)))
+		index = binary.LittleEndian.AppendUint32(index, uint32(len(values)))
+		keys = append(keys, e.Type...)
+		values = binary.AppendVarint(values, int64(e.Validity.Years))
+		values = binary.AppendVarint(values, int64(e.Validity.Months))
+	}
+
+	// Value offsets are relative until the size of the keys is known
+	valuesStart := dataStart + len(keys)
+	for i := range sorted {
+		slot := index[i*catalogSlotSize+6:]
+		binary.LittleEndian.PutUint32(slot, binary.LittleEndian.Uint32(slot)+uint32(valuesStart))
+	}
+
+	out := make([]byte, 0, valuesStart+len(values))
+	out = append(out, catalogMagic...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(sorted)))
+	out = append(out, index...)
+	out = append(out, keys...)
+	return append(out, values...), nil
+}
+
+// NewCatalog opens a catalog over data, which must not be modified afterwards
+func NewCatalog(data []byte) (*Catalog, error) {
+	if len(data) < catalogHeaderSize || !bytes.Equal(data[:4], catalogMagic) {
+		return nil, ErrInvalidCatalog
+	}
+	count := int(binary.LittleEndian.Uint32(data[4:]))
+	if count > (len(data)-catalogHeaderSize)/catalogSlotSize {
+		return nil, ErrInvalidCatalog
+	}
+	c := &Catalog{data: data, count: count, decoded: make(map[int]CatalogEntry)}
+	for i := range count {
+		keyOff, keyLen, valueOff := c.slot(i)
+		if keyOff+keyLen > len(data) || valueOff >= len(data) {
+			return nil, ErrInvalidCatalog
+		}
+	}
+	return c, nil
+}
+
+// LoadCatalog reads a catalog file written with EncodeCatalog
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalog(data)
+}
+
+// Len returns the number of entries
+func (c *Catalog) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.count
+}
+
+// Lookup returns the entry of a credential type
+func (c *Catalog) Lookup(entityType string) (CatalogEntry, bool) {
+	if c == nil {
+		return CatalogEntry{}, false
+	}
+	c.lookups.Add(1)
+	i, found := c.search(entityType)
+	if !found {
+		return CatalogEntry{}, false
+	}
+	c.hits.Add(1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.decoded[i]; ok {
+		return e, true
+	}
+	e, ok := c.decode(i)
+	if ok {
+		c.decoded[i] = e
+	}
+	return e, ok
+}
+
+// Stats returns the size and activity of the catalog
+func (c *Catalog) Stats() CatalogStats {
+	if c == nil {
+		return CatalogStats{}
+	}
+	c.mu.Lock()
+	decoded := len(c.decoded)
+	c.mu.Unlock()
+	return CatalogStats{
+		Entries: c.count,
+		Bytes:   len(c.data),
+		Decoded: decoded,
+		Lookups: c.lookups.Load(),
+		Hits:    c.hits.Load(),
+	}
+}
+
+// search finds the index slot of a type by binary search over the keys
+func (c *Catalog) search(entityType string) (int, bool) {
+	i := sort.Search(c.count, func(i int) bool {
+		return string(c.keyBytes(i)) >= entityType
+	})
+	return i, i < c.count && string(c.keyBytes(i)) == entityType
+}
+
+// slot returns the key offset, key length and value offset of entry i
+func (c *Catalog) slot(i int) (keyOff, keyLen, valueOff int) {
+	s := c.data[catalogHeaderSize+i*catalogSlotSize:]
+	return int(binary.LittleEndian.Uint32(s)), int(binary.LittleEndian.Uint16(s[4:])), int(binary.LittleEndian.Uint32(s[6:]))
+}
+
+// keyBytes returns the type of entry i
+func (c *Catalog) keyBytes(i int) []byte {
+	keyOff, keyLen, _ := c.slot(i)
+	return c.data[keyOff : keyOff+keyLen]
+}
+
+// decode decodes entry i
+func (c *Catalog) decode(i int) (CatalogEntry, bool) {
+	_, _, valueOff := c.slot(i)
+	value := c.data[valueOff:]
+	years, n := binary.Varint(value)
+	if n <= 0 {
+		return CatalogEntry{}, false
+	}
+	months, m := binary.Varint(value[n:])
+	if m <= 0 {
+		return CatalogEntry{}, false
+	}
+	return CatalogEntry{
+		Type:     string(c.keyBytes(i)),
+		Validity: ValidityPeriod{Years: int(years), Months: int(months)},
+	}, true
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	data, err := EncodeCatalog([]CatalogEntry{
+		{Type: "scuba_certification", Validity: ValidityPeriod{Years: 2}},
+		{Type: "boating_license", Validity: ValidityPeriod{Years: 5, Months: 6}},
+		{Type: "cpr_certification", Validity: ValidityPeriod{Years: 1}},
+		{Type: "library_card"},
+	})
+	if err != nil {
+		t.Fatalf("EncodeCatalog() error = %v", err)
+	}
+	cat, err := NewCatalog(data)
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	return cat
+}
+
+func TestCatalogLookup(t *testing.T) {
+	cat := testCatalog(t)
+
+	tests := []struct {
+		entityType string
+		want       ValidityPeriod
+		found      bool
+	}{
+		{"boating_license", ValidityPeriod{Years: 5, Months: 6}, true},
+		{"cpr_certification", ValidityPeriod{Years: 1}, true},
+		{"scuba_certification", ValidityPeriod{Years: 2}, true},
+		{"library_card", ValidityPeriod{}, true},
+		{"a", ValidityPeriod{}, false},
+		{"passport", ValidityPeriod{}, false},
+		{"zzz", ValidityPeriod{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entityType, func(t *testing.T) {
+			e, found := cat.Lookup(tt.entityType)
+			if found != tt.found || e.Validity != tt.want {
+				t.Errorf("Lookup() = %v, %v, want %v, %v", e.Validity, found, tt.want, tt.found)
+			}
+			if found && e.Type != tt.entityType {
+				t.Errorf("Lookup() type = %q, want %q", e.Type, tt.entityType)
+			}
+		})
+	}
+
+	stats := cat.Stats()
+	if stats.Entries != 4 || stats.Decoded != 4 || stats.Lookups != 7 || stats.Hits != 4 || stats.Bytes == 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestCatalogLazyDecoding(t *testing.T) {
+	cat := testCatalog(t)
+	if got := cat.Stats().Decoded; got != 0 {
+		t.Errorf("Decoded after open = %d, want 0", got)
+	}
+	cat.Lookup("boating_license")
+	cat.Lookup("boating_license")
+	if got := cat.Stats().Decoded; got != 1 {
+		t.Errorf("Decoded after repeated lookup = %d, want 1", got)
+	}
+}
+
+func TestEncodeCatalogDuplicate(t *testing.T) {
+	_, err := EncodeCatalog([]CatalogEntry{{Type: "a"}, {Type: "a"}})
+	if err == nil {
+		t.Error("EncodeCatalog() error = nil, want duplicate error")
+	}
+}
+
+func TestNewCatalogInvalid(t *testing.T) {
+	valid, _ := EncodeCatalog([]CatalogEntry{{Type: "passport", Validity: ValidityPeriod{Years: 10}}})
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad magic", []byte("XXXX\x00\x00\x00\x00")},
+		{"count too large", []byte("UDC1\xff\x00\x00\x00")},
+		{"truncated", valid[:len(valid)-12]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCatalog(tt.data); !errors.Is(err, ErrInvalidCatalog) {
+				t.Errorf("NewCatalog() error = %v, want %v", err, ErrInvalidCatalog)
+			}
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	data, _ := EncodeCatalog([]CatalogEntry{{Type: "passport", Validity: ValidityPeriod{Years: 5}}})
+	path := filepath.Join(t.TempDir(), "catalog.bin")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cat, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+	if cat.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", cat.Len())
+	}
+}
+
+func TestWithCatalog(t *testing.T) {
+	cat := testCatalog(t)
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	now := WithFixedNow(mustParseDate("2021-06-15"))
+
+	tests := []struct {
+		name    string
+		entity  Entity
+		opts    []Option
+		expired bool
+	}{
+		{"catalog period elapsed", Entity{Type: "scuba_certification", Date: mustParseDate("2019-01-01")}, nil, true},
+		{"catalog period running", Entity{Type: "boating_license", Date: mustParseDate("2019-01-01")}, nil, false},
+		{"built-in period wins", Entity{Type: "cpr_certification", Date: mustParseDate("2019-09-01")}, nil, false},
+		{"zero catalog period", Entity{Type: "library_card", Date: mustParseDate("1999-01-01")}, nil, false},
+		{
+			name:   "option disables catalog period",
+			entity: Entity{Type: "scuba_certification", Date: mustParseDate("2019-01-01")},
+			opts:   []Option{WithValidityPeriod("scuba_certification", ValidityPeriod{})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{now, WithCatalog(cat)}, tt.opts...)
+			report := CheckEntity(user, tt.entity, opts...)
+			expired := len(report.Warnings()) > 0 && report.Warnings()[0].Code == ErrCodeExpired
+			if expired != tt.expired {
+				t.Errorf("expired = %v, want %v (findings: %v)", expired, tt.expired, report.Findings)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrColumnLengths is returned by ValidateColumns for columns of different
+// lengths
+var ErrColumnLengths = errors.New("userdate: columns of different lengths")
+
+// ValidateColumns validates entity dates held in columns: row i is the
+// entity of type entityTypes[i] dated entityDates[i], for a user born on
+// birthDates[i]. It returns the error code of each row, or "" for valid
+// rows. The slices must have the same length, or ErrColumnLengths is
+// returned.
+//
+// Rows are checked in one pass against a single reference time, reusing
+// the same scratch user, so valid rows do not allocate. Use it for
+// analytics workloads that already hold columnar data in memory; use
+// ValidateBatch when the findings and messages are needed.
+func ValidateColumns(birthDates, entityDates []time.Time, entityTypes []string, opts ...Option) ([]string, error) {
+	cfg := newConfig(opts)
+	return cfg.validateColumns(birthDates, entityDates, entityTypes)
+}
+
+// ValidateColumns validates column slices using the validator's settings
+func (v *Validator) ValidateColumns(birthDates, entityDates []time.Time, entityTypes []string) ([]string, error) {
+	return v.config().validateColumns(birthDates, entityDates, entityTypes)
+}
+
+// validateColumns checks each row of the columns
+func (c config) validateColumns(birthDates, entityDates []time.Time, entityTypes []string) ([]string, error) {
+	n := len(birthDates)
+	if len(entityDates) != n || len(entityTypes) != n {
+		return nil, fmt.Errorf("%w: %d birth dates, %d entity dates and %d entity types", ErrColumnLengths, n, len(entityDates), len(entityTypes))
+	}
+
+	// Pin the reference time so every row sees the same "now"
+	now := c.now()
+	c.now = func() time.Time { return now }
+
+	codes := make([]string, n)
+	var user User
+	for i := range n {
+		user.BirthDate = birthDates[i]
+		if err := c.validateEntityDate(context.Background(), &user, entityDates[i], entityTypes[i]); err != nil {
+			codes[i] = errorCode(err)
+		}
+	}
+	return codes, nil
+}
+
+// errorCode returns the code of a validation error
+func errorCode(err error) string {
+	var dateErr *DateValidationError
+	if errors.As(err, &dateErr) {
+		return dateErr.Code
+	}
+	return ErrCodeInvalidDate
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateColumns(t *testing.T) {
+	birthDates := []time.Time{
+		mustParseDate("1990-01-01"),
+		mustParseDate("1990-01-01"),
+		mustParseDate("2015-01-01"),
+		mustParseDate("1990-01-01"),
+		{},
+	}
+	entityDates := []time.Time{
+		mustParseDate("2020-01-01"),
+		mustParseDate("1989-01-01"),
+		mustParseDate("2020-01-01"),
+		mustParseDate("2026-01-01"),
+		mustParseDate("2020-01-01"),
+	}
+	entityTypes := []string{"certification", "certification", "employment", "training", "training"}
+	want := []string{"", ErrCodeBeforeBirth, ErrCodeUnrealisticAge, ErrCodeFutureDate, ErrCodeInvalidDate}
+
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	if got, err := ValidateColumns(birthDates, entityDates, entityTypes, now); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateColumns() = %q, %v, want %q", got, err, want)
+	}
+	if got, err := NewValidator(now).ValidateColumns(birthDates, entityDates, entityTypes); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Validator.ValidateColumns() = %q, %v, want %q", got, err, want)
+	}
+
+	// The codes match those of row-by-row validation
+	for i := range birthDates {
+		err := ValidateEntityDate(&User{BirthDate: birthDates[i]}, entityDates[i], entityTypes[i], now)
+		if code := codeOf(err); code != want[i] {
+			t.Errorf("row %d: ValidateEntityDate() code = %q, ValidateColumns() = %q", i, code, want[i])
+		}
+	}
+}
+
+func TestValidateColumnsLengthMismatch(t *testing.T) {
+	codes, err := ValidateColumns(make([]time.Time, 2), make([]time.Time, 1), make([]string, 2))
+	if !errors.Is(err, ErrColumnLengths) || codes != nil {
+		t.Errorf("ValidateColumns() = %q, %v, want %v", codes, err, ErrColumnLengths)
+	}
+}
+
+func TestValidateColumnsAllocs(t *testing.T) {
+	n := 100
+	birthDates := make([]time.Time, n)
+	entityDates := make([]time.Time, n)
+	entityTypes := make([]string, n)
+	for i := range n {
+		birthDates[i] = mustParseDate("1990-01-01")
+		entityDates[i] = mustParseDate("2020-01-01")
+		entityTypes[i] = "certification"
+	}
+	v := NewValidator(WithFixedNow(mustParseDate("2025-07-18")))
+
+	allocs := testing.AllocsPerRun(10, func() {
+		v.ValidateColumns(birthDates, entityDates, entityTypes)
+	})
+	// The output slice and the pinned clock; nothing per row
+	if allocs > 5 {
+		t.Errorf("ValidateColumns() made %v allocations for %d valid rows", allocs, n)
+	}
+}
+
+// codeOf returns the code of a validation error, or "" for nil
+func codeOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.(*DateValidationError).Code
+}
+
+func BenchmarkValidateColumns(b *testing.B) {
+	n := 10000
+	birthDates := make([]time.Time, n)
+	entityDates := make([]time.Time, n)
+	entityTypes := make([]string, n)
+	for i := range n {
+		birthDates[i] = mustParseDate("1990-01-01")
+		entityDates[i] = mustParseDate("2020-01-01").AddDate(0, 0, i%365)
+		entityTypes[i] = "certification"
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateColumns(birthDates, entityDates, entityTypes)
+	}
+}
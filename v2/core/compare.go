@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+)
+
+// Difference is an item accepted under one rule set and rejected under the
+// other
+type Difference struct {
+	Index int  `json:"index"` // Position of the item in the dataset
+	Item  Item `json:"item"`
+
+	// Rule is the rule that rejects the item, with its step on the
+	// rejecting side
+	Rule string `json:"rule"`
+	Step Step   `json:"step"`
+
+	A *Report `json:"a"`
+	B *Report `json:"b"`
+}
+
+// NewlyRejected reports whether the item is rejected under rule set B only
+func (d Difference) NewlyRejected() bool {
+	return d.A.Valid()
+}
+
+// Comparison is the outcome of validating a dataset under two rule sets
+type Comparison struct {
+	Total       int          `json:"total"`
+	Differences []Difference `json:"differences"` // In dataset order
+}
+
+// ByRule groups the differences by responsible rule. Rules are sorted by
+// decreasing number of differences, then by name.
+func (c *Comparison) ByRule() (rules []string, diffs map[string][]Difference) {
+	if c == nil {
+		return nil, map[string][]Difference{}
+	}
+	diffs = make(map[string][]Difference)
+	for _, d := range c.Differences {
+		diffs[d.Rule] = append(diffs[d.Rule], d)
+	}
+	for rule := range diffs {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if a, b := len(diffs[rules[i]]), len(diffs[rules[j]]); a != b {
+			return a > b
+		}
+		return rules[i] < rules[j]
+	})
+	return rules, diffs
+}
+
+// Compare validates every item under rule sets a and b, such as the
+// WithRules options of the current and of a proposed rules file, and
+// returns the items whose verdict differs. The rule responsible for a
+// difference is the first failing rule on the side that rejects the item,
+// found with Explain.
+func Compare(items []Item, a, b []Option) *Comparison {
+	cfgA, cfgB := newConfig(a), newConfig(b)
+	cmp := &Comparison{Total: len(items)}
+	for i, item := range items {
+		entity := Entity{Type: item.EntityType, Date: item.EntityDate}
+		ea := cfgA.explainEntity(context.Background(), item.User, entity)
+		eb := cfgB.explainEntity(context.Background(), item.User, entity)
+		if ea.Report.Valid() == eb.Report.Valid() {
+			continue
+		}
+		d := Difference{Index: i, Item: item, A: ea.Report, B: eb.Report}
+		rejecting := eb
+		if eb.Report.Valid() {
+			rejecting = ea
+		}
+		for _, step := range rejecting.Steps {
+			if step.Verdict == VerdictFail {
+				d.Rule, d.Step = step.Rule, step
+				break
+			}
+		}
+		cmp.Differences = append(cmp.Differences, d)
+	}
+	return cmp
+}
+
+// ReadItems reads a dataset of items from a JSON Lines file, one Item per
+// line, as ValidateBatch takes them
+func ReadItems(path string) ([]Item, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	file := jsonlFile{path: path}
+	var items []Item
+	err := file.read(func(line []byte) error {
+		var item Item
+		if err := json.Unmarshal(line, &item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	items := []Item{
+		{User: user, EntityDate: mustParseDate("2007-01-01"), EntityType: "license"},    // 16: rejected by B
+		{User: user, EntityDate: mustParseDate("2010-01-01"), EntityType: "license"},    // 19: accepted by both
+		{User: user, EntityDate: mustParseDate("2004-01-01"), EntityType: "employment"}, // 13: accepted by B only
+		{User: user, EntityDate: mustParseDate("1990-05-15"), EntityType: "vaccination"},
+		{User: user, EntityDate: mustParseDate("1980-01-01"), EntityType: "training"}, // rejected by both
+	}
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+	a := []Option{now}
+	b := []Option{now, WithMinimumAge("license", 17), WithMinimumAge("employment", 13), WithSameDayBirth(false)}
+
+	cmp := Compare(items, a, b)
+	if cmp.Total != 5 {
+		t.Errorf("Total = %d, want 5", cmp.Total)
+	}
+	want := []struct {
+		index         int
+		rule          string
+		newlyRejected bool
+	}{
+		{0, "minimum_age", true},
+		{2, "minimum_age", false},
+		{3, "same_day_birth", true},
+	}
+	if len(cmp.Differences) != len(want) {
+		t.Fatalf("got %d differences, want %d", len(cmp.Differences), len(want))
+	}
+	for i, w := range want {
+		d := cmp.Differences[i]
+		if d.Index != w.index || d.Rule != w.rule || d.NewlyRejected() != w.newlyRejected {
+			t.Errorf("difference %d = item %d, rule %s, newly rejected %v, want item %d, rule %s, %v",
+				i, d.Index, d.Rule, d.NewlyRejected(), w.index, w.rule, w.newlyRejected)
+		}
+	}
+	if d := cmp.Differences[0]; d.Step.Threshold != "at least 17 years" {
+		t.Errorf("step threshold = %q, want the threshold of B", d.Step.Threshold)
+	}
+
+	rules, diffs := cmp.ByRule()
+	if len(rules) != 2 || rules[0] != "minimum_age" || len(diffs["minimum_age"]) != 2 {
+		t.Errorf("ByRule() = %v, %d minimum_age differences", rules, len(diffs["minimum_age"]))
+	}
+}
+
+func TestReadItems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.jsonl")
+	data := `{"user": {"id": "u1", "birth_date": "1990-05-15T00:00:00Z"}, "entity_date": "2010-01-01T00:00:00Z", "entity_type": "license"}
+
+{"user": {"id": "u2", "birth_date": "1985-01-01T00:00:00Z"}, "entity_date": "2001-01-01T00:00:00Z", "entity_type": "training"}
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	items, err := ReadItems(path)
+	if err != nil {
+		t.Fatalf("ReadItems() error = %v", err)
+	}
+	if len(items) != 2 || items[1].User.ID != "u2" || items[1].EntityType != "training" {
+		t.Errorf("ReadItems() = %+v", items)
+	}
+
+	if _, err := ReadItems(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("ReadItems() of a missing file succeeded")
+	}
+	if err := os.WriteFile(path, []byte("{\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadItems(path); err == nil || !strings.Contains(err.Error(), "data.jsonl:1:") {
+		t.Errorf("ReadItems() error = %v, want the line", err)
+	}
+}
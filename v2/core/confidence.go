@@ -0,0 +1,53 @@
+package core
+
+import "time"
+
+// Confidences of the heuristic rules
+const (
+	confidenceImpossible     = 0.99 // The date cannot be a real one
+	confidenceShifted        = 0.95 // A placeholder instant written in another zone
+	confidencePlaceholder    = 0.8  // A placeholder that is also a plausible date
+	confidenceSwapImpossible = 0.85 // The swapped date fixes an impossible date
+	confidenceSwapAge        = 0.55 // The swapped date only fixes a young age
+)
+
+// withConfidence sets the confidence of a heuristic finding
+func withConfidence(err *DateValidationError, confidence float64) *DateValidationError {
+	err.Confidence = confidence
+	return err
+}
+
+// certainty returns the confidence of the finding, 1 for the findings of
+// deterministic rules, which have none
+func (e *DateValidationError) certainty() float64 {
+	if e.Confidence == 0 {
+		return 1
+	}
+	return e.Confidence
+}
+
+// placeholderConfidence returns how sure the placeholder rule is that date
+// stands for a missing date: nearly certain before the earliest accepted
+// date, where no real date falls, and for a placeholder instant written in
+// another zone, such as the Unix epoch at 19:00 the day before, but less so
+// at midnight of a date that people are also born or hired on
+func placeholderConfidence(date, floor time.Time) float64 {
+	switch h, m, s := date.Clock(); {
+	case date.Before(floor):
+		return confidenceImpossible
+	case h != 0 || m != 0 || s != 0 || date.Nanosecond() != 0:
+		return confidenceShifted
+	default:
+		return confidencePlaceholder
+	}
+}
+
+// excessConfidence returns the confidence of a finding on a value past its
+// limit, such as days worked: 0.5 just past the limit, growing to 0.99 at
+// twice the limit
+func excessConfidence(value, limit int) float64 {
+	if limit <= 0 {
+		return confidenceImpossible
+	}
+	return min(0.5+0.5*float64(value-limit)/float64(limit), confidenceImpossible)
+}
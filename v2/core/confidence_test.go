@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeuristicConfidence(t *testing.T) {
+	user := &User{ID: "u1", BirthDate: mustParseDate("1990-05-15")}
+	eastern := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		entity Entity
+		code   string
+		want   float64
+	}{
+		{"placeholder", nil, Entity{Type: "employment", Date: mustParseDate("1970-01-01")}, ErrCodePlaceholderDate, 0.8},
+		{"shifted placeholder", nil, Entity{Type: "employment", Date: time.Date(1969, 12, 31, 19, 0, 0, 0, eastern)}, ErrCodePlaceholderDate, 0.95},
+		{"placeholder before the floor", []Option{WithPlaceholderDates(mustParseDate("1700-01-01"))},
+			Entity{Type: "employment", Date: mustParseDate("1700-01-01")}, ErrCodePlaceholderDate, 0.99},
+		{"swap of a future date", []Option{WithSwapDetection()}, Entity{Type: "employment", Date: mustParseDate("2024-12-06")}, ErrCodeSwappedDate, 0.85},
+		{"swap of a young age", []Option{WithSwapDetection()}, Entity{Type: "employment", Date: mustParseDate("2004-01-06")}, ErrCodeSwappedDate, 0.55},
+		{"long internship", nil, Entity{Type: "internship", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2016-07-01")}, ErrCodeImplausibleDuration, 0.75},
+		{"deterministic", nil, Entity{Type: "employment", Date: mustParseDate("2030-01-01")}, ErrCodeFutureDate, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithFixedNow(mustParseDate("2024-06-15"))}, tt.opts...)
+			report := CheckEntity(user, tt.entity, opts...)
+			for _, f := range report.Findings {
+				if f.Code != tt.code {
+					continue
+				}
+				if diff := f.Confidence - tt.want; diff < -0.01 || diff > 0.01 {
+					t.Errorf("%s confidence = %v, want %v", f.Code, f.Confidence, tt.want)
+				}
+				return
+			}
+			t.Errorf("findings = %v, want %s", report.Findings, tt.code)
+		})
+	}
+}
+
+func TestExcessConfidence(t *testing.T) {
+	tests := []struct {
+		value, limit int
+		want         float64
+	}{
+		{101, 100, 0.505},
+		{150, 100, 0.75},
+		{200, 100, 0.99},
+		{500, 100, 0.99},
+		{10, 0, 0.99},
+	}
+
+	for _, tt := range tests {
+		if got := excessConfidence(tt.value, tt.limit); got != tt.want {
+			t.Errorf("excessConfidence(%d, %d) = %v, want %v", tt.value, tt.limit, got, tt.want)
+		}
+	}
+}
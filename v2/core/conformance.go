@@ -0,0 +1,111 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// ConformanceCase is a case of the conformance dataset, see
+// conformance/cases.jsonl: an entity of a user, validated at Now under the
+// default rules, and the codes of the findings it gets, sorted. Ports of
+// the rules to other languages prove that they match this implementation
+// by giving the same codes for every case.
+type ConformanceCase struct {
+	Name        string    `json:"name"`
+	Now         time.Time `json:"now"`
+	User        *User     `json:"user"`
+	Entity      Entity    `json:"entity"`
+	RuleVersion string    `json:"rule_version"`
+	Codes       []string  `json:"codes"`
+}
+
+// ConformanceResult is the outcome of a case under an implementation, as
+// a port writes it for the conformance runner
+type ConformanceResult struct {
+	Name  string   `json:"name"`
+	Codes []string `json:"codes"`
+}
+
+// ConformanceFailure is a case an implementation gets wrong, or gives no
+// result for
+type ConformanceFailure struct {
+	Name    string   `json:"name"`
+	Want    []string `json:"want"`
+	Got     []string `json:"got"`
+	Missing bool     `json:"missing,omitempty"`
+}
+
+// ReadConformanceCases reads a conformance dataset from a JSON Lines file,
+// one ConformanceCase per line
+func ReadConformanceCases(path string) ([]ConformanceCase, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	file := jsonlFile{path: path}
+	var cases []ConformanceCase
+	err := file.read(func(line []byte) error {
+		var c ConformanceCase
+		if err := json.Unmarshal(line, &c); err != nil {
+			return err
+		}
+		cases = append(cases, c)
+		return nil
+	})
+	return cases, err
+}
+
+// WriteConformanceCases writes cases as JSON Lines, the format of
+// ReadConformanceCases
+func WriteConformanceCases(w io.Writer, cases []ConformanceCase) error {
+	enc := json.NewEncoder(w)
+	for _, c := range cases {
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConformanceCodes returns the sorted codes of the findings of a case
+// under this implementation, the reference of the dataset
+func ConformanceCodes(c ConformanceCase) []string {
+	report := CheckEntity(c.User, c.Entity, WithFixedNow(c.Now))
+	codes := make([]string, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		codes = append(codes, f.Code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// UpdateConformanceCases sets the codes and rule version of every case to
+// the ones of this implementation. Run it when RuleVersion changes, and
+// review the codes that changed.
+func UpdateConformanceCases(cases []ConformanceCase) {
+	for i := range cases {
+		cases[i].Codes = ConformanceCodes(cases[i])
+		cases[i].RuleVersion = RuleVersion
+	}
+}
+
+// CheckConformance compares the results of an implementation with the
+// expected codes of the cases, by name and in any order of the codes. A
+// case without a result fails.
+func CheckConformance(cases []ConformanceCase, results []ConformanceResult) []ConformanceFailure {
+	got := make(map[string][]string, len(results))
+	for _, r := range results {
+		got[r.Name] = slices.Sorted(slices.Values(r.Codes))
+	}
+	var failures []ConformanceFailure
+	for _, c := range cases {
+		want := slices.Sorted(slices.Values(c.Codes))
+		codes, ok := got[c.Name]
+		if !ok || !slices.Equal(codes, want) {
+			failures = append(failures, ConformanceFailure{Name: c.Name, Want: want, Got: codes, Missing: !ok})
+		}
+	}
+	return failures
+}
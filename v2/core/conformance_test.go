@@ -0,0 +1,76 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConformanceDataset(t *testing.T) {
+	cases, err := ReadConformanceCases("conformance/cases.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, c := range cases {
+		if names[c.Name] {
+			t.Errorf("duplicate case %q", c.Name)
+		}
+		names[c.Name] = true
+		if c.RuleVersion != RuleVersion {
+			t.Errorf("case %q has rule version %q, want %q: run userdate conformance -update", c.Name, c.RuleVersion, RuleVersion)
+		}
+	}
+
+	var results []ConformanceResult
+	for _, c := range cases {
+		results = append(results, ConformanceResult{Name: c.Name, Codes: ConformanceCodes(c)})
+	}
+	for _, f := range CheckConformance(cases, results) {
+		t.Errorf("case %q: codes = %v, want %v", f.Name, f.Got, f.Want)
+	}
+}
+
+func TestCheckConformance(t *testing.T) {
+	cases := []ConformanceCase{
+		{Name: "valid"},
+		{Name: "two findings", Codes: []string{"IMPRECISE_DATE", "UNREALISTIC_AGE"}},
+	}
+
+	tests := []struct {
+		name    string
+		results []ConformanceResult
+		want    []ConformanceFailure
+	}{
+		{
+			name: "conforms in any order",
+			results: []ConformanceResult{
+				{Name: "two findings", Codes: []string{"UNREALISTIC_AGE", "IMPRECISE_DATE"}},
+				{Name: "valid", Codes: []string{}},
+			},
+		},
+		{
+			name: "wrong codes",
+			results: []ConformanceResult{
+				{Name: "valid", Codes: []string{"FUTURE_DATE"}},
+				{Name: "two findings", Codes: []string{"UNREALISTIC_AGE"}},
+			},
+			want: []ConformanceFailure{
+				{Name: "valid", Got: []string{"FUTURE_DATE"}},
+				{Name: "two findings", Want: []string{"IMPRECISE_DATE", "UNREALISTIC_AGE"}, Got: []string{"UNREALISTIC_AGE"}},
+			},
+		},
+		{
+			name:    "missing result",
+			results: []ConformanceResult{{Name: "two findings", Codes: []string{"IMPRECISE_DATE", "UNREALISTIC_AGE"}}},
+			want:    []ConformanceFailure{{Name: "valid", Missing: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckConformance(cases, tt.results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckConformance() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultAgeOfMajority is the age of majority of jurisdictions without one
+// of their own, see WithAgeOfMajority
+const DefaultAgeOfMajority = 18
+
+// defaultConsentTypes are the legal consents of the user, dated on the
+// acceptance or the signature, see WithConsentTypes
+var defaultConsentTypes = map[string]bool{
+	"consent":            true,
+	"terms_acceptance":   true,
+	"contract_signature": true,
+}
+
+// guardianConsentType is the type of the consent of a parent or guardian,
+// which covers the consents of a minor dated the same day in a profile
+const guardianConsentType = "guardian_consent"
+
+// WithConsentTypes replaces the entity types that are legal consents, such
+// as terms acceptances and contract signatures, which the user must give
+// on or after the age of majority. Call it without types to turn the
+// consent rule off.
+func WithConsentTypes(entityTypes ...string) Option {
+	return func(c *config) {
+		c.consentTypes = make(map[string]bool, len(entityTypes))
+		for _, t := range entityTypes {
+			c.consentTypes[t] = true
+		}
+	}
+}
+
+// WithAgeOfMajority sets the age from which users give legal consents
+// alone, instead of the age of majority of the jurisdiction whose rules
+// apply, or DefaultAgeOfMajority.
+func WithAgeOfMajority(age int) Option {
+	return func(c *config) {
+		c.majority = max(age, 0)
+		c.pinnedMajority = true
+	}
+}
+
+// ageOfMajority returns the age of majority of consents: the pinned one, or
+// the one of the jurisdiction whose rules apply
+func (c *config) ageOfMajority() int {
+	if c.pinnedMajority {
+		return c.majority
+	}
+	if c.jurisdiction != "" {
+		if ages, ok := jurisdictionRules(strings.ToUpper(c.jurisdiction)); ok && ages.majority > 0 {
+			return ages.majority
+		}
+	}
+	return DefaultAgeOfMajority
+}
+
+// withGuardianConsents returns the settings for the entities of a profile,
+// with the dates of its guardian consents. It returns c itself when the
+// profile has none.
+func (c *config) withGuardianConsents(entities []Entity) *config {
+	var dates []time.Time
+	for _, entity := range entities {
+		if entity.Type == guardianConsentType && !entity.Date.IsZero() && entity.Status != StatusRevoked {
+			dates = append(dates, c.truncate(entity.Date))
+		}
+	}
+	if len(dates) == 0 {
+		return c
+	}
+	resolved := *c
+	resolved.guardianConsents = dates
+	return &resolved
+}
+
+// guardianConsented reports whether a guardian consented on date
+func (c *config) guardianConsented(date time.Time) bool {
+	return slices.ContainsFunc(c.guardianConsents, func(d time.Time) bool { return sameDay(d, date) })
+}
+
+// validateConsent rejects the consents of users under the age of majority,
+// unless a guardian consented as well: the entity is held with a guardian,
+// or a guardian_consent entity of the profile is dated the same day
+func (c *config) validateConsent(in *ruleInput) error {
+	entity := in.entity
+	if !c.consentTypes[entity.Type] || entity.Guardian || in.entityDate.Before(in.birthDate) {
+		return nil
+	}
+	majority := c.ageOfMajority()
+	if age := c.ageAt(in.birthDate, in.entityDate); age < majority && !c.guardianConsented(in.entityDate) {
+		return newError(msgMinorConsent, "type", entityTypeArg(entity.Type), "date", in.entityDate, "age", age, "majority", majority)
+	}
+	return nil
+}
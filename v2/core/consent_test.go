@@ -0,0 +1,71 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConsent(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-06-15")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	mississippi, err := NewRuleConfig("US-MS", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolve := func(code string) Option {
+		return WithJurisdictionResolver(StaticJurisdictions{Default: code})
+	}
+
+	tests := []struct {
+		name      string
+		entity    Entity
+		opts      []Option
+		wantCodes []string
+	}{
+		{"adult", Entity{Type: "terms_acceptance", Date: mustParseDate("2019-03-01")}, nil, nil},
+		{"on the day of majority", Entity{Type: "consent", Date: mustParseDate("2018-06-15")}, nil, nil},
+		{"minor", Entity{Type: "contract_signature", Date: mustParseDate("2017-03-01")}, nil, []string{ErrCodeMinorConsent}},
+		{"minor with a guardian", Entity{Type: "contract_signature", Date: mustParseDate("2017-03-01"), Guardian: true}, nil, nil},
+		{"majority at 21 in Mississippi", Entity{Type: "consent", Date: mustParseDate("2020-03-01")}, []Option{resolve("US-MS")}, []string{ErrCodeMinorConsent}},
+		{"majority at 19 in Alabama", Entity{Type: "consent", Date: mustParseDate("2020-03-01")}, []Option{resolve("us-al")}, nil},
+		{"majority of the rules", Entity{Type: "consent", Date: mustParseDate("2020-03-01")}, []Option{WithRules(mississippi)}, []string{ErrCodeMinorConsent}},
+		{"pinned majority", Entity{Type: "consent", Date: mustParseDate("2017-03-01")}, []Option{resolve("US-MS"), WithAgeOfMajority(16)}, nil},
+		{"rule off", Entity{Type: "consent", Date: mustParseDate("2017-03-01")}, []Option{WithConsentTypes()}, nil},
+		{"custom type", Entity{Type: "waiver", Date: mustParseDate("2017-03-01")}, []Option{WithConsentTypes("waiver")}, []string{ErrCodeMinorConsent}},
+		{"other types", Entity{Type: "certification", Date: mustParseDate("2017-03-01")}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, tt.entity, append(tt.opts, now)...)
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("findings = %v, want %v", got, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestProfileGuardianConsent(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-06-15")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	signature := Entity{Type: "contract_signature", Date: mustParseDate("2017-03-01")}
+
+	tests := []struct {
+		name     string
+		guardian Entity
+		want     []string
+	}{
+		{"same day", Entity{Type: "guardian_consent", Date: mustParseDate("2017-03-01")}, nil},
+		{"another day", Entity{Type: "guardian_consent", Date: mustParseDate("2016-03-01")}, []string{ErrCodeMinorConsent}},
+		{"revoked", Entity{Type: "guardian_consent", Date: mustParseDate("2017-03-01"), Status: StatusRevoked}, []string{ErrCodeMinorConsent}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateProfile(Profile{User: user, Entities: []Entity{signature, tt.guardian}}, now)
+			if got := findingCodes(report.Reports[0]); !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// nowKey is the context key for the reference time
+type nowKey struct{}
+
+// ContextWithNow returns a copy of ctx carrying t as the reference time for
+// validations run with that context, such as a request's transaction time.
+func ContextWithNow(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, nowKey{}, t)
+}
+
+// NowFromContext returns the reference time stored in ctx by ContextWithNow
+func NowFromContext(ctx context.Context) (time.Time, bool) {
+	if ctx == nil {
+		return time.Time{}, false
+	}
+	t, ok := ctx.Value(nowKey{}).(time.Time)
+	return t, ok
+}
+
+// withContext returns a copy of c that uses the reference time from ctx, if
+// any, instead of its clock, and the call options of ctx. Reports with a
+// reference time from ctx are not cached, as the cache key does not cover
+// it.
+func (c config) withContext(ctx context.Context) config {
+	if t, ok := NowFromContext(ctx); ok {
+		c.now = func() time.Time { return t }
+		c.uncached = true
+	}
+	if opts, ok := CallOptionsFromContext(ctx); ok {
+		c = c.withCall(opts)
+	}
+	return c
+}
+
+// ValidateEntityDateContext validates a date for a user entity like
+// ValidateEntityDate, taking the current time from ctx when it carries one
+// (see ContextWithNow) and from the clock otherwise
+func ValidateEntityDateContext(ctx context.Context, user *User, entityDate time.Time, entityType string, opts ...Option) error {
+	cfg := newConfig(opts).withContext(ctx)
+	return cfg.validateEntityDate(ctx, user, entityDate, entityType)
+}
+
+// CheckEntityDateContext is like CheckEntityDate, taking the current time
+// from ctx when it carries one
+func CheckEntityDateContext(ctx context.Context, user *User, entityDate time.Time, entityType string, opts ...Option) *Report {
+	cfg := newConfig(opts).withContext(ctx)
+	return cfg.checkEntityDate(ctx, user, entityDate, entityType)
+}
+
+// ValidateEntityDateContext validates a date for a user entity using the
+// validator's settings, taking the current time from ctx when it carries one
+func (v *Validator) ValidateEntityDateContext(ctx context.Context, user *User, entityDate time.Time, entityType string) error {
+	cfg := v.config().withContext(ctx)
+	return cfg.validateEntityDate(ctx, user, entityDate, entityType)
+}
+
+// CheckEntityDateContext is like CheckEntityDate, taking the current time
+// from ctx when it carries one
+func (v *Validator) CheckEntityDateContext(ctx context.Context, user *User, entityDate time.Time, entityType string) *Report {
+	cfg := v.config().withContext(ctx)
+	return cfg.checkEntityDate(ctx, user, entityDate, entityType)
+}
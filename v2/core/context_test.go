@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestNowFromContext(t *testing.T) {
+	if _, ok := NowFromContext(context.Background()); ok {
+		t.Errorf("NowFromContext() ok = true for empty context")
+	}
+
+	now := mustParseDate("2020-06-15")
+	got, ok := NowFromContext(ContextWithNow(context.Background(), now))
+	if !ok || !got.Equal(now) {
+		t.Errorf("NowFromContext() = %v, %v, want %v, true", got, ok, now)
+	}
+}
+
+func TestValidateEntityDateContext(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	ctx := ContextWithNow(context.Background(), mustParseDate("2020-06-15"))
+	v := NewValidator()
+
+	// Dated after the transaction time carried by the context
+	date := mustParseDate("2020-07-01")
+	for name, err := range map[string]error{
+		"package":   ValidateEntityDateContext(ctx, user, date, "certification"),
+		"validator": v.ValidateEntityDateContext(ctx, user, date, "certification"),
+		"report":    CheckEntityDateContext(ctx, user, date, "certification").Err(),
+		"v report":  v.CheckEntityDateContext(ctx, user, date, "certification").Err(),
+	} {
+		if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeFutureDate {
+			t.Errorf("%s: error = %v, want code %v", name, err, ErrCodeFutureDate)
+		}
+	}
+
+	// Without a reference time the clock is used
+	if err := v.ValidateEntityDateContext(context.Background(), user, date, "certification"); err != nil {
+		t.Errorf("ValidateEntityDateContext() unexpected error = %v", err)
+	}
+
+	// The context takes precedence over the validator's clock
+	fixed := NewValidator(WithFixedNow(mustParseDate("2019-01-01")))
+	if err := fixed.ValidateEntityDateContext(ctx, user, mustParseDate("2020-01-01"), "certification"); err != nil {
+		t.Errorf("ValidateEntityDateContext() unexpected error = %v", err)
+	}
+}
+
+func TestContextReachesResolver(t *testing.T) {
+	type key struct{}
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	date := mustParseDate("2020-01-01")
+	var got []any
+	resolver := resolverFunc(func(ctx context.Context) (string, error) {
+		got = append(got, ctx.Value(key{}))
+		return "", ctx.Err()
+	})
+	opts := []Option{WithJurisdictionResolver(resolver)}
+	ctx := context.WithValue(context.Background(), key{}, "caller")
+
+	ValidateEntityDateContext(ctx, user, date, "certification", opts...)
+	CheckEntityDateContext(ctx, user, date, "certification", opts...)
+	NewValidator(opts...).ValidateEntityDateContext(ctx, user, date, "certification")
+	sealed, err := Seal(user, opts...)
+	if err != nil {
+		t.Fatalf("Seal() unexpected error = %v", err)
+	}
+	sealed.ValidateEntityDateContext(ctx, date, "certification")
+	if len(got) == 0 {
+		t.Fatalf("resolver not called")
+	}
+	for i, v := range got {
+		if v != "caller" {
+			t.Errorf("call %d: resolver got context value %v, want caller", i, v)
+		}
+	}
+
+	// A cancelled lookup fails and does not pin the sealed user's window
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	sealed, _ = Seal(user, opts...)
+	report := CheckEntityDateContext(cancelled, user, date, "certification", opts...)
+	if codes := findingCodes(report); !slices.Contains(codes, ErrCodeJurisdictionUnknown) {
+		t.Errorf("findings = %v, want %v", codes, ErrCodeJurisdictionUnknown)
+	}
+	if err := sealed.ValidateEntityDateContext(cancelled, date, "certification"); err != nil {
+		t.Errorf("ValidateEntityDateContext() unexpected error = %v", err)
+	}
+	if stats := sealed.CacheStats(); stats.Evaluated != 1 {
+		t.Errorf("CacheStats() = %+v, want the date evaluated in full", stats)
+	}
+	sealed.ValidateEntityDateContext(ctx, date, "certification")
+	if stats := sealed.CacheStats(); stats.Misses != 2 || stats.Evaluated != 1 {
+		t.Errorf("CacheStats() = %+v, want the window computed again", stats)
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"maps"
+	"time"
+)
+
+// AgeCutoff is the date on which the age of a user is taken for the age
+// rules of an entity: its minimum and maximum ages and its age category
+type AgeCutoff int
+
+// Age cutoffs
+const (
+	CutoffEventDate     AgeCutoff = iota // The date of the entity (default)
+	CutoffYearStart                      // January 1 of the year of the entity
+	CutoffYearEnd                        // December 31 of the year of the entity
+	CutoffSchoolYearEnd                  // End of the school year of the entity, see WithSchoolYearEnd
+)
+
+// String returns the name of the cutoff
+func (a AgeCutoff) String() string {
+	switch a {
+	case CutoffYearStart:
+		return "year-start"
+	case CutoffYearEnd:
+		return "year-end"
+	case CutoffSchoolYearEnd:
+		return "school-year-end"
+	default:
+		return "event-date"
+	}
+}
+
+// WithAgeCutoff sets the date on which users must be of the minimum,
+// maximum or category age of entities of the given types, as institutions
+// rarely use the exact date: schools admit children by their age at the
+// end of the school year, and federations fix ages for a season. When no
+// types are given it sets the default for all types not configured
+// explicitly, the entity date by default.
+func WithAgeCutoff(cutoff AgeCutoff, entityTypes ...string) Option {
+	return func(c *config) {
+		cutoffs := maps.Clone(c.ageCutoffs)
+		if cutoffs == nil {
+			cutoffs = make(map[string]AgeCutoff, len(entityTypes)+1)
+		}
+		if len(entityTypes) == 0 {
+			cutoffs[""] = cutoff
+		}
+		for _, entityType := range entityTypes {
+			cutoffs[entityType] = cutoff
+		}
+		c.ageCutoffs = cutoffs
+	}
+}
+
+// WithSchoolYearEnd sets the last day of the school year for
+// CutoffSchoolYearEnd, August 31 by default
+func WithSchoolYearEnd(month time.Month, day int) Option {
+	return func(c *config) {
+		c.schoolYearEnd = time.Date(0, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// defaultSchoolYearEnd is the last day of the school year in most of
+// Europe and North America
+var defaultSchoolYearEnd = time.Date(0, time.August, 31, 0, 0, 0, 0, time.UTC)
+
+// ageCutoff returns the age cutoff of an entity type
+func (c *config) ageCutoff(entityType string) AgeCutoff {
+	if cutoff, ok := c.ageCutoffs[entityType]; ok {
+		return cutoff
+	}
+	return c.ageCutoffs[""]
+}
+
+// cutoffDate returns the date on which ages are taken for an entity of the
+// given type dated on date
+func (c *config) cutoffDate(entityType string, date time.Time) time.Time {
+	switch c.ageCutoff(entityType) {
+	case CutoffYearStart:
+		return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location())
+	case CutoffYearEnd:
+		return time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, date.Location())
+	case CutoffSchoolYearEnd:
+		year := date.Year()
+		if month, day := date.Month(), date.Day(); month > c.schoolYearEnd.Month() || month == c.schoolYearEnd.Month() && day > c.schoolYearEnd.Day() {
+			year++
+		}
+		return time.Date(year, c.schoolYearEnd.Month(), c.schoolYearEnd.Day(), 0, 0, 0, 0, date.Location())
+	}
+	return date
+}
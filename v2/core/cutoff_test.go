@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgeCutoff(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2030-01-01"))
+
+	tests := []struct {
+		name     string
+		birth    string
+		entity   Entity
+		opts     []Option
+		wantCode string
+	}{
+		{"too young on the entity date", "2021-08-15", Entity{Type: "kindergarten", Date: mustParseDate("2024-06-01")}, nil, ErrCodeUnrealisticAge},
+		{"of age by the end of the school year", "2021-08-15", Entity{Type: "kindergarten", Date: mustParseDate("2024-06-01")}, []Option{WithAgeCutoff(CutoffSchoolYearEnd, "kindergarten")}, ""},
+		{"earlier end of the school year", "2021-08-15", Entity{Type: "kindergarten", Date: mustParseDate("2024-06-01")}, []Option{WithAgeCutoff(CutoffSchoolYearEnd, "kindergarten"), WithSchoolYearEnd(time.July, 31)}, ErrCodeUnrealisticAge},
+		{"too old by the end of the school year", "2016-07-01", Entity{Type: "kindergarten", Date: mustParseDate("2024-06-15")}, []Option{WithAgeCutoff(CutoffSchoolYearEnd, "kindergarten")}, ErrCodeUnrealisticAge},
+		{"of age by December 31", "2008-11-01", Entity{Type: "license", Date: mustParseDate("2024-06-01")}, []Option{WithAgeCutoff(CutoffYearEnd)}, ""},
+		{"too young on January 1", "2010-03-01", Entity{Type: "employment", Date: mustParseDate("2024-06-01")}, []Option{WithAgeCutoff(CutoffYearStart)}, ErrCodeUnrealisticAge},
+		{"type overrides the default", "2008-11-01", Entity{Type: "license", Date: mustParseDate("2024-06-01")}, []Option{WithAgeCutoff(CutoffYearEnd), WithAgeCutoff(CutoffEventDate, "license")}, ErrCodeUnrealisticAge},
+		{"other types keep the default", "2008-11-01", Entity{Type: "license", Date: mustParseDate("2024-06-01")}, []Option{WithAgeCutoff(CutoffYearEnd, "kindergarten")}, ErrCodeUnrealisticAge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "user123", BirthDate: mustParseDate(tt.birth)}
+			err := ValidateEntity(user, tt.entity, append(tt.opts, now)...)
+			if code := codeOf(err); code != tt.wantCode {
+				t.Errorf("ValidateEntity() code = %q, want %q: %v", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestCutoffDate(t *testing.T) {
+	tests := []struct {
+		cutoff AgeCutoff
+		date   string
+		want   string
+	}{
+		{CutoffEventDate, "2024-06-15", "2024-06-15"},
+		{CutoffYearStart, "2024-06-15", "2024-01-01"},
+		{CutoffYearEnd, "2024-06-15", "2024-12-31"},
+		{CutoffSchoolYearEnd, "2024-06-15", "2024-08-31"},
+		{CutoffSchoolYearEnd, "2024-08-31", "2024-08-31"},
+		{CutoffSchoolYearEnd, "2024-09-01", "2025-08-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cutoff.String()+" "+tt.date, func(t *testing.T) {
+			cfg := newConfig([]Option{WithAgeCutoff(tt.cutoff)})
+			if got := cfg.cutoffDate("license", mustParseDate(tt.date)); !got.Equal(mustParseDate(tt.want)) {
+				t.Errorf("cutoffDate() = %s, want %s", got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
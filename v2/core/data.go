@@ -0,0 +1,296 @@
+package core
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidRuleData is returned for datasets that cannot be loaded
+var ErrInvalidRuleData = errors.New("userdate: invalid rule data")
+
+// Files of a rule data directory, see LoadRuleData
+const (
+	jurisdictionsFile = "jurisdictions.json"
+	credentialsFile   = "credentials.json"
+)
+
+// Codes of jurisdictions: ISO 3166-1 alpha-2 for countries, ISO 3166-2 for
+// their subdivisions such as US-CA
+var (
+	countryCode     = regexp.MustCompile(`^[A-Z]{2}$`)
+	subdivisionCode = regexp.MustCompile(`^[A-Z]{2}-[A-Z0-9]{1,3}$`)
+)
+
+// RuleData holds the datasets behind the built-in rules: the minimum ages
+// of each jurisdiction, keyed by ISO 3166-1 alpha-2 code, and the validity
+// periods of common credentials.
+type RuleData struct {
+	Jurisdictions map[string]JurisdictionData `json:"jurisdictions"`
+	Credentials   []CatalogEntry              `json:"credentials"`
+}
+
+// JurisdictionData holds the minimum and maximum ages that differ in a
+// jurisdiction. Employment is the youngest age at which light or holiday
+// work is allowed, license the age for a car driving license, and the
+// stages of education, such as kindergarten, have a window of entry ages.
+// ChildValidity holds the validity of documents issued to young users,
+// such as passports, and AgeOfMajority the age from which users give legal
+// consents alone. Subdivisions of a country, keyed by ISO 3166-2 code
+// such as US-CA, hold the ages that differ from the country's, such as the
+// license age of a state.
+type JurisdictionData struct {
+	MinimumAges   map[string]int              `json:"minimum_ages"`
+	MaximumAges   map[string]int              `json:"maximum_ages,omitempty"`
+	ChildValidity map[string]ChildValidity    `json:"child_validity,omitempty"`
+	AgeOfMajority int                         `json:"age_of_majority,omitempty"` // DefaultAgeOfMajority when zero
+	Source        string                      `json:"source,omitempty"`          // Legal reference of the ages
+	Subdivisions  map[string]JurisdictionData `json:"subdivisions,omitempty"`
+}
+
+// ruleData holds the loaded datasets, the embedded ones unless LoadRuleData
+// replaced them
+var ruleData struct {
+	once            sync.Once
+	mu              sync.RWMutex
+	jurisdictions   map[string]jurisdictionAges
+	validityPeriods map[string]ValidityPeriod
+	version         string // SHA-256 of the datasets
+}
+
+// jurisdictionAges are the minimum and maximum ages of a jurisdiction and
+// the validity of documents of young users, including those of its country
+// for a subdivision
+type jurisdictionAges struct {
+	minimum, maximum map[string]int
+	childValidity    map[string]ChildValidity
+	majority         int // Age of majority, zero when unknown
+}
+
+// loadRuleData loads the embedded datasets once
+func loadRuleData() {
+	ruleData.once.Do(func() {
+		d, err := ReadRuleData(embeddedRuleData)
+		if err != nil {
+			panic(fmt.Sprintf("userdate: embedded rule data: %v", err))
+		}
+		setRuleData(d)
+	})
+}
+
+// EmbeddedRuleData reports whether the datasets are embedded in the binary.
+// Builds with the userdate_nodata tag leave them out to save space, and
+// start without jurisdictions or credential validity periods until
+// LoadRuleData is called.
+func EmbeddedRuleData() bool {
+	return hasEmbeddedRuleData
+}
+
+// ReadRuleData reads the datasets of a directory laid out like the data
+// directory of this module: jurisdictions.json, an object of
+// JurisdictionData by code, and credentials.json, an array of
+// CatalogEntry. A missing file is an empty dataset.
+func ReadRuleData(fsys fs.FS) (RuleData, error) {
+	var d RuleData
+	if err := readDataFile(fsys, jurisdictionsFile, &d.Jurisdictions); err != nil {
+		return RuleData{}, err
+	}
+	if err := readDataFile(fsys, credentialsFile, &d.Credentials); err != nil {
+		return RuleData{}, err
+	}
+	if err := d.validate(); err != nil {
+		return RuleData{}, err
+	}
+	return d, nil
+}
+
+// readDataFile decodes a JSON file of a data directory, if it exists
+func readDataFile(fsys fs.FS, name string, v any) error {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidRuleData, name, err)
+	}
+	return nil
+}
+
+// validate checks the codes, ages and periods of the datasets
+func (d RuleData) validate() error {
+	for code, j := range d.Jurisdictions {
+		if !countryCode.MatchString(code) {
+			return fmt.Errorf("%w: %s: jurisdiction %q is not an ISO 3166-1 alpha-2 code", ErrInvalidRuleData, jurisdictionsFile, code)
+		}
+		if err := j.validateAges(code); err != nil {
+			return err
+		}
+		for sub, s := range j.Subdivisions {
+			if !subdivisionCode.MatchString(sub) || !strings.HasPrefix(sub, code+"-") {
+				return fmt.Errorf("%w: %s: %s: subdivision %q is not an ISO 3166-2 code of %s", ErrInvalidRuleData, jurisdictionsFile, code, sub, code)
+			}
+			if len(s.Subdivisions) > 0 {
+				return fmt.Errorf("%w: %s: %s: subdivisions of subdivisions are not supported", ErrInvalidRuleData, jurisdictionsFile, sub)
+			}
+			if err := s.validateAges(sub); err != nil {
+				return err
+			}
+		}
+	}
+	seen := make(map[string]bool, len(d.Credentials))
+	for _, c := range d.Credentials {
+		switch {
+		case c.Type == "":
+			return fmt.Errorf("%w: %s: credential without a type", ErrInvalidRuleData, credentialsFile)
+		case seen[c.Type]:
+			return fmt.Errorf("%w: %s: duplicate credential %q", ErrInvalidRuleData, credentialsFile, c.Type)
+		case c.Validity.Years < 0 || c.Validity.Months < 0:
+			return fmt.Errorf("%w: %s: negative validity period of %q", ErrInvalidRuleData, credentialsFile, c.Type)
+		}
+		seen[c.Type] = true
+	}
+	return nil
+}
+
+// validateAges checks the ages and child validities of the jurisdiction code
+func (j JurisdictionData) validateAges(code string) error {
+	for _, bound := range []struct {
+		name string
+		ages map[string]int
+	}{{"minimum", j.MinimumAges}, {"maximum", j.MaximumAges}} {
+		for entityType, age := range bound.ages {
+			if age < 0 || age > MaxHumanAge {
+				return fmt.Errorf("%w: %s: %s: %s age %d of %s is out of range", ErrInvalidRuleData, jurisdictionsFile, code, bound.name, age, entityType)
+			}
+		}
+	}
+	if j.AgeOfMajority < 0 || j.AgeOfMajority > MaxHumanAge {
+		return fmt.Errorf("%w: %s: %s: age of majority %d is out of range", ErrInvalidRuleData, jurisdictionsFile, code, j.AgeOfMajority)
+	}
+	for entityType, v := range j.ChildValidity {
+		if v.UnderAge <= 0 || v.UnderAge > MaxHumanAge || v.Validity.Years < 0 || v.Validity.Months < 0 {
+			return fmt.Errorf("%w: %s: %s: child validity %s of %s is out of range", ErrInvalidRuleData, jurisdictionsFile, code, v, entityType)
+		}
+	}
+	return nil
+}
+
+// LoadRuleData replaces the datasets with those of a directory, see
+// ReadRuleData, such as os.DirFS("/etc/userdate/data") in builds with the
+// userdate_nodata tag. Validators and rule configurations made before keep
+// the datasets they were made with.
+func LoadRuleData(fsys fs.FS) error {
+	d, err := ReadRuleData(fsys)
+	if err != nil {
+		return err
+	}
+	loadRuleData()
+	setRuleData(d)
+	return nil
+}
+
+// setRuleData installs datasets. The tables are replaced rather than
+// changed, as configs share them.
+func setRuleData(d RuleData) {
+	jurisdictions := make(map[string]jurisdictionAges, len(d.Jurisdictions))
+	for code, j := range d.Jurisdictions {
+		country := jurisdictionAges{
+			minimum:       maps.Clone(j.MinimumAges),
+			maximum:       maps.Clone(j.MaximumAges),
+			childValidity: maps.Clone(j.ChildValidity),
+			majority:      j.AgeOfMajority,
+		}
+		jurisdictions[code] = country
+		for sub, s := range j.Subdivisions {
+			jurisdictions[sub] = jurisdictionAges{
+				minimum:       mergeRules(country.minimum, s.MinimumAges),
+				maximum:       mergeRules(country.maximum, s.MaximumAges),
+				childValidity: mergeRules(country.childValidity, s.ChildValidity),
+				majority:      cmp.Or(s.AgeOfMajority, country.majority),
+			}
+		}
+	}
+	periods := make(map[string]ValidityPeriod, len(d.Credentials))
+	for _, c := range d.Credentials {
+		periods[c.Type] = c.Validity
+	}
+	// Validated datasets hold maps with string keys, which encode in a
+	// stable order
+	data, _ := json.Marshal(d)
+
+	ruleData.mu.Lock()
+	defer ruleData.mu.Unlock()
+	ruleData.jurisdictions = jurisdictions
+	ruleData.validityPeriods = periods
+	ruleData.version = hashBytes(data)
+}
+
+// ruleDataVersion returns the SHA-256 of the loaded datasets in hex
+func ruleDataVersion() string {
+	loadRuleData()
+	ruleData.mu.RLock()
+	defer ruleData.mu.RUnlock()
+	return ruleData.version
+}
+
+// mergeRules returns the rules of a country overridden by those of a
+// subdivision
+func mergeRules[V any](country, subdivision map[string]V) map[string]V {
+	rules := make(map[string]V, len(country)+len(subdivision))
+	maps.Copy(rules, country)
+	maps.Copy(rules, subdivision)
+	return rules
+}
+
+// jurisdictionRules returns the ages of a jurisdiction, which must not be
+// modified. A subdivision without ages of its own, such as FR-IDF, has the
+// ages of its country.
+func jurisdictionRules(code string) (jurisdictionAges, bool) {
+	loadRuleData()
+	ruleData.mu.RLock()
+	defer ruleData.mu.RUnlock()
+	if ages, ok := ruleData.jurisdictions[code]; ok {
+		return ages, true
+	}
+	if !subdivisionCode.MatchString(code) {
+		return jurisdictionAges{}, false
+	}
+	country, _, _ := strings.Cut(code, "-")
+	ages, ok := ruleData.jurisdictions[country]
+	return ages, ok
+}
+
+// jurisdictionCodes returns the codes of the loaded countries, or of the
+// loaded subdivisions of a country, sorted
+func jurisdictionCodes(country string) []string {
+	loadRuleData()
+	ruleData.mu.RLock()
+	defer ruleData.mu.RUnlock()
+	var codes []string
+	for code := range ruleData.jurisdictions {
+		if parent, _, sub := strings.Cut(code, "-"); (country == "" && !sub) || (country != "" && sub && parent == country) {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// defaultValidityPeriods returns the loaded validity periods of common
+// credentials, which must not be modified
+func defaultValidityPeriods() map[string]ValidityPeriod {
+	loadRuleData()
+	ruleData.mu.RLock()
+	defer ruleData.mu.RUnlock()
+	return ruleData.validityPeriods
+}
@@ -0,0 +1,16 @@
+//go:build !userdate_nodata
+
+package core
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed data/*.json
+var embeddedData embed.FS
+
+// embeddedRuleData holds the datasets of the data directory
+var embeddedRuleData, _ = fs.Sub(embeddedData, "data")
+
+const hasEmbeddedRuleData = true
@@ -0,0 +1,18 @@
+//go:build userdate_nodata
+
+package core
+
+import "io/fs"
+
+// embeddedRuleData is empty, the datasets are loaded with LoadRuleData
+var embeddedRuleData fs.FS = noRuleData{}
+
+const hasEmbeddedRuleData = false
+
+// noRuleData is a file system without files
+type noRuleData struct{}
+
+// Open returns fs.ErrNotExist for every file
+func (noRuleData) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// testRuleData holds the datasets the tests run with, restored by tests
+// loading others. Builds with the userdate_nodata tag read the data
+// directory, see TestMain.
+var testRuleData fs.FS = embeddedRuleData
+
+func TestEmbeddedRuleData(t *testing.T) {
+	if !EmbeddedRuleData() {
+		t.Skip("built with userdate_nodata")
+	}
+	d, err := ReadRuleData(embeddedRuleData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fr := d.Jurisdictions["FR"]; fr.MinimumAges["employment"] != 14 || fr.MinimumAges["license"] != 17 || fr.MaximumAges["kindergarten"] != 6 {
+		t.Errorf("FR ages = %v, %v", fr.MinimumAges, fr.MaximumAges)
+	}
+	if ms := d.Jurisdictions["US"].Subdivisions["US-MS"]; ms.AgeOfMajority != 21 {
+		t.Errorf("US-MS age of majority = %d, want 21", ms.AgeOfMajority)
+	}
+	if p, ok := DefaultValidityPeriod("passport"); !ok || p.Years != 10 {
+		t.Errorf("DefaultValidityPeriod(passport) = %v, %v, want 10y", p, ok)
+	}
+}
+
+func TestLoadRuleData(t *testing.T) {
+	t.Cleanup(func() {
+		if err := LoadRuleData(testRuleData); err != nil {
+			t.Fatal(err)
+		}
+	})
+	err := LoadRuleData(fstest.MapFS{
+		"jurisdictions.json": {Data: []byte(`{"IT": {"minimum_ages": {"license": 18}}}`)},
+		"credentials.json":   {Data: []byte(`[{"type": "visa", "validity": {"months": 6}}]`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := Jurisdictions(); !reflect.DeepEqual(got, []string{"IT"}) {
+		t.Errorf("Jurisdictions() = %v, want [IT]", got)
+	}
+	r, err := NewRuleConfig("it", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.MinimumAges["license"] != 18 {
+		t.Errorf("license minimum age = %d, want 18", r.MinimumAges["license"])
+	}
+	if p, ok := DefaultValidityPeriod("visa"); !ok || p.Months != 6 {
+		t.Errorf("DefaultValidityPeriod(visa) = %v, %v, want 6m", p, ok)
+	}
+	if _, ok := DefaultValidityPeriod("passport"); ok {
+		t.Error("DefaultValidityPeriod(passport) found after loading data without it")
+	}
+}
+
+func TestReadRuleDataErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"bad JSON", fstest.MapFS{"credentials.json": {Data: []byte(`{`)}}},
+		{"bad code", fstest.MapFS{"jurisdictions.json": {Data: []byte(`{"france": {}}`)}}},
+		{"bad subdivision", fstest.MapFS{"jurisdictions.json": {Data: []byte(`{"US": {"subdivisions": {"FR-IDF": {}}}}`)}}},
+		{"bad subdivision age", fstest.MapFS{"jurisdictions.json": {Data: []byte(`{"US": {"subdivisions": {"US-CA": {"minimum_ages": {"license": 200}}}}}`)}}},
+		{"bad age", fstest.MapFS{"jurisdictions.json": {Data: []byte(`{"FR": {"minimum_ages": {"license": -1}}}`)}}},
+		{"bad age of majority", fstest.MapFS{"jurisdictions.json": {Data: []byte(`{"FR": {"age_of_majority": -18}}`)}}},
+		{"no type", fstest.MapFS{"credentials.json": {Data: []byte(`[{"validity": {"years": 1}}]`)}}},
+		{"duplicate", fstest.MapFS{"credentials.json": {Data: []byte(`[{"type": "visa"}, {"type": "visa"}]`)}}},
+		{"negative period", fstest.MapFS{"credentials.json": {Data: []byte(`[{"type": "visa", "validity": {"years": -1}}]`)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadRuleData(tt.files); !errors.Is(err, ErrInvalidRuleData) {
+				t.Errorf("ReadRuleData() error = %v, want %v", err, ErrInvalidRuleData)
+			}
+		})
+	}
+
+	if d, err := ReadRuleData(fstest.MapFS{}); err != nil || len(d.Jurisdictions) != 0 || len(d.Credentials) != 0 {
+		t.Errorf("ReadRuleData(empty) = %+v, %v, want empty datasets", d, err)
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/agecalc"
+)
+
+// Shares of dates above which ProfileDataset reports an issue, for columns
+// of at least minIssueDates dates. Real dates fall on January 1 about once
+// in 365 days, on the first of a month about once in 30, in a year that is
+// a multiple of 10 about once in 10, and on a day of 12 or less about 39
+// times in 100. Many more low days hint at day and month swapped.
+const (
+	minIssueDates        = 20
+	maxJanFirstShare     = 0.02
+	maxFirstOfMonthShare = 0.10
+	maxRoundYearShare    = 0.20
+	maxLowDayShare       = 0.60
+)
+
+// DateStats describes the distribution of one date column of a dataset
+type DateStats struct {
+	Count        int     `json:"count"`          // Dates present
+	Missing      int     `json:"missing"`        // Zero dates
+	JanFirst     float64 `json:"jan_first"`      // Share of dates on January 1
+	FirstOfMonth float64 `json:"first_of_month"` // Share of dates on the first of a month
+	RoundYear    float64 `json:"round_year"`     // Share of dates in a year that is a multiple of 10
+	LowDay       float64 `json:"low_day"`        // Share of dates on a day of 12 or less
+}
+
+// add counts a date
+func (s *DateStats) add(t time.Time) {
+	if t.IsZero() {
+		s.Missing++
+		return
+	}
+	s.Count++
+	if t.Day() == 1 {
+		s.FirstOfMonth++
+		if t.Month() == time.January {
+			s.JanFirst++
+		}
+	}
+	if t.Year()%10 == 0 {
+		s.RoundYear++
+	}
+	if t.Day() <= 12 {
+		s.LowDay++
+	}
+}
+
+// finish turns the counts of add into shares
+func (s *DateStats) finish() {
+	if s.Count == 0 {
+		return
+	}
+	n := float64(s.Count)
+	s.JanFirst /= n
+	s.FirstOfMonth /= n
+	s.RoundYear /= n
+	s.LowDay /= n
+}
+
+// RecencyBucket counts the entity dates of an age range
+type RecencyBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// recencyBuckets are the labels of DatasetProfile.Recency and the age in
+// years each range starts at
+var recencyBuckets = []struct {
+	label string
+	from  int
+}{
+	{"future", -1},
+	{"< 1 year", 0},
+	{"1-5 years", 1},
+	{"5-10 years", 5},
+	{"10-20 years", 10},
+	{"20+ years", 20},
+}
+
+// DatasetProfile describes the dates of a dataset as a whole. Problems such
+// as placeholder birth dates or dates typed as a year only pass validation
+// one by one but stand out in the distributions.
+type DatasetProfile struct {
+	Records int            `json:"records"`
+	Failed  int            `json:"failed"`  // Records with an error-level finding
+	ByCode  map[string]int `json:"by_code"` // Findings by code
+
+	// BirthYears is the histogram of the birth years of distinct users.
+	// Users are told apart by ID; users without one count once per record.
+	BirthYears map[int]int `json:"birth_years"`
+	Birth      DateStats   `json:"birth"` // Birth dates of distinct users
+	Entity     DateStats   `json:"entity"`
+
+	// Recency is the distribution of how long ago entity dates are, from
+	// the future to 20 years or more
+	Recency []RecencyBucket `json:"recency"`
+
+	// Issues describes the shares that are far above what real dates give,
+	// such as "38.2% of birth dates are on January 1"
+	Issues []string `json:"issues,omitempty"`
+}
+
+// ProfileDataset validates the records like ValidateBatch and profiles
+// their dates, surfacing systemic data issues that the findings of single
+// records do not reveal
+func ProfileDataset(records []Item, opts ...Option) *DatasetProfile {
+	cfg := newConfig(opts)
+	now := cfg.now()
+	p := &DatasetProfile{
+		Records:    len(records),
+		ByCode:     make(map[string]int),
+		BirthYears: make(map[int]int),
+		Recency:    make([]RecencyBucket, len(recencyBuckets)),
+	}
+	for i, b := range recencyBuckets {
+		p.Recency[i].Label = b.label
+	}
+
+	result := cfg.validateBatch(records)
+	p.Failed = len(result.Failed())
+	for code, n := range result.CountByCode() {
+		p.ByCode[code] = n
+	}
+
+	seen := make(map[string]bool)
+	for _, item := range result.Items {
+		if item.User != nil && !(item.User.ID != "" && seen[item.User.ID]) {
+			seen[item.User.ID] = true
+			p.Birth.add(item.User.BirthDate)
+			if !item.User.BirthDate.IsZero() {
+				p.BirthYears[item.User.BirthDate.Year()]++
+			}
+		}
+		p.Entity.add(item.EntityDate)
+		if !item.EntityDate.IsZero() {
+			p.Recency[recencyBucket(item.EntityDate, now)].Count++
+		}
+	}
+	p.Birth.finish()
+	p.Entity.finish()
+
+	for _, c := range []struct {
+		name  string
+		stats DateStats
+	}{{"birth", p.Birth}, {"entity", p.Entity}} {
+		if c.stats.Count < minIssueDates {
+			continue
+		}
+		if c.stats.JanFirst > maxJanFirstShare {
+			p.Issues = append(p.Issues, fmt.Sprintf("%.1f%% of %s dates are on January 1", 100*c.stats.JanFirst, c.name))
+		}
+		if c.stats.FirstOfMonth > maxFirstOfMonthShare {
+			p.Issues = append(p.Issues, fmt.Sprintf("%.1f%% of %s dates are on the first of a month", 100*c.stats.FirstOfMonth, c.name))
+		}
+		if c.stats.RoundYear > maxRoundYearShare {
+			p.Issues = append(p.Issues, fmt.Sprintf("%.1f%% of %s dates are in a round year", 100*c.stats.RoundYear, c.name))
+		}
+		if c.stats.LowDay > maxLowDayShare {
+			p.Issues = append(p.Issues, fmt.Sprintf("%.1f%% of %s dates are on a day of 12 or less, day and month may be swapped", 100*c.stats.LowDay, c.name))
+		}
+	}
+	if n := p.Recency[0].Count; n > 0 {
+		p.Issues = append(p.Issues, fmt.Sprintf("%d entity dates are in the future", n))
+	}
+	return p
+}
+
+// recencyBucket returns the index of the recency bucket of date
+func recencyBucket(date, now time.Time) int {
+	if date.After(now) {
+		return 0
+	}
+	years := agecalc.Years(date, now)
+	i := len(recencyBuckets) - 1
+	for i > 1 && years < recencyBuckets[i].from {
+		i--
+	}
+	return i
+}
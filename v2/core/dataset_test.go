@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestProfileDataset(t *testing.T) {
+	now := mustParseDate("2020-06-15")
+	var records []Item
+	for i := range 40 {
+		// Half the users have a placeholder January 1 birth date
+		birth := mustParseDate(fmt.Sprintf("%d-03-%02d", 1971+i%9, 13+i%15))
+		if i%2 == 0 {
+			birth = mustParseDate(fmt.Sprintf("%d-01-01", 1971+i%9))
+		}
+		user := &User{ID: fmt.Sprintf("user%d", i), BirthDate: birth}
+		records = append(records,
+			Item{User: user, EntityDate: mustParseDate(fmt.Sprintf("2019-%02d-%02d", 1+i%12, 2+i%25)), EntityType: "training"},
+			Item{User: user, EntityDate: mustParseDate("2005-07-14"), EntityType: "education"})
+	}
+	records = append(records,
+		Item{User: records[0].User, EntityDate: mustParseDate("2030-01-01"), EntityType: "training"},
+		Item{User: &User{ID: "nobirth"}, EntityDate: mustParseDate("2019-02-03"), EntityType: "training"})
+
+	p := ProfileDataset(records, WithFixedNow(now))
+	if p.Records != 82 {
+		t.Errorf("Records = %d, want 82", p.Records)
+	}
+	if p.Failed != 2 || p.ByCode[ErrCodeFutureDate] != 1 || p.ByCode[ErrCodeInvalidDate] != 1 {
+		t.Errorf("Failed = %d, ByCode = %v, want a future date and an invalid birth date", p.Failed, p.ByCode)
+	}
+	if p.Birth.Count != 40 || p.Birth.Missing != 1 {
+		t.Errorf("Birth = %+v, want 40 distinct birth dates and 1 missing", p.Birth)
+	}
+	if p.Birth.JanFirst != 0.5 {
+		t.Errorf("Birth.JanFirst = %v, want 0.5", p.Birth.JanFirst)
+	}
+	if p.BirthYears[1971] != 5 {
+		t.Errorf("BirthYears[1971] = %d, want 5", p.BirthYears[1971])
+	}
+	recency := map[string]int{}
+	for _, b := range p.Recency {
+		recency[b.Label] = b.Count
+	}
+	want := map[string]int{"future": 1, "< 1 year": 19, "1-5 years": 22, "5-10 years": 0, "10-20 years": 40, "20+ years": 0}
+	if !reflect.DeepEqual(recency, want) {
+		t.Errorf("Recency = %v, want %v", recency, want)
+	}
+	wantIssues := []string{
+		"50.0% of birth dates are on January 1",
+		"50.0% of birth dates are on the first of a month",
+		"1 entity dates are in the future",
+	}
+	if !reflect.DeepEqual(p.Issues, wantIssues) {
+		t.Errorf("Issues = %q, want %q", p.Issues, wantIssues)
+	}
+}
+
+func TestProfileDatasetSmall(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	p := ProfileDataset([]Item{{User: user, EntityDate: mustParseDate("2010-01-01"), EntityType: "training"}},
+		WithFixedNow(mustParseDate("2020-06-15")))
+	if len(p.Issues) != 0 {
+		t.Errorf("Issues = %q, want none for a single record", p.Issues)
+	}
+	if p.Birth.JanFirst != 1 || p.Birth.RoundYear != 1 {
+		t.Errorf("Birth = %+v, want every date on January 1 of a round year", p.Birth)
+	}
+}
+
+func TestProfileDatasetSwappedDays(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	var records []Item
+	for i := range 30 {
+		// Days read as months are never above 12
+		records = append(records, Item{User: user, EntityDate: mustParseDate(fmt.Sprintf("2015-%02d-%02d", 1+i%12, 2+i%11)), EntityType: "training"})
+	}
+	p := ProfileDataset(records, WithFixedNow(mustParseDate("2020-06-15")))
+	want := "100.0% of entity dates are on a day of 12 or less, day and month may be swapped"
+	if !slices.Contains(p.Issues, want) {
+		t.Errorf("Issues = %q, want %q", p.Issues, want)
+	}
+}
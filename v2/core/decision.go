@@ -0,0 +1,154 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Decision is the terminal outcome a service acts on
+type Decision string
+
+// Decisions
+const (
+	DecisionAccept Decision = "ACCEPT"
+	DecisionReview Decision = "REVIEW"
+	DecisionReject Decision = "REJECT"
+)
+
+// Valid reports whether d is a known decision
+func (d Decision) Valid() bool {
+	return d == DecisionAccept || d == DecisionReview || d == DecisionReject
+}
+
+// DecisionRule maps the reports it matches to a decision. Every condition
+// that is set must hold; a list matches when any of its values does.
+// Codes and Severities match the findings: a report matches when one of
+// its findings has one of the codes and one of the severities. A rule
+// without conditions matches every report.
+type DecisionRule struct {
+	Name       string     `json:"name"`
+	Codes      []string   `json:"codes,omitempty"`
+	Severities []Severity `json:"severities,omitempty"`
+	Verdicts   []Verdict  `json:"verdicts,omitempty"`
+	MinScore   int        `json:"min_score,omitempty"` // Report score of at least MinScore, when positive
+	Decision   Decision   `json:"decision"`
+
+	// MinConfidence restricts Codes and Severities to the findings with a
+	// Confidence of at least MinConfidence, when positive. The findings of
+	// deterministic rules count as certain.
+	MinConfidence float64 `json:"min_confidence,omitempty"`
+}
+
+// DecisionPolicy decides what to do with reports. Rules are tried in order
+// and the first one matching decides; Default applies when none does.
+// Weights give the score of each finding code, a report scoring the sum of
+// the weights of its findings.
+type DecisionPolicy struct {
+	Rules   []DecisionRule `json:"rules"`
+	Weights map[string]int `json:"weights,omitempty"`
+	Default Decision       `json:"default"`
+}
+
+// DecisionResult is a decision with the reasons that led to it: the rule
+// that matched, then the findings and score it matched on
+type DecisionResult struct {
+	Decision Decision `json:"decision"`
+	Rule     string   `json:"rule,omitempty"` // Empty for the default decision
+	Reasons  []string `json:"reasons"`
+}
+
+// DefaultDecisionPolicy rejects reports with an error, sends indeterminate
+// reports and reports with warnings to review, and accepts the others
+func DefaultDecisionPolicy() DecisionPolicy {
+	return DecisionPolicy{
+		Rules: []DecisionRule{
+			{Name: "reject_errors", Severities: []Severity{SeverityError}, Decision: DecisionReject},
+			{Name: "review_indeterminate", Verdicts: []Verdict{VerdictIndeterminate}, Decision: DecisionReview},
+			{Name: "review_warnings", Severities: []Severity{SeverityWarning}, Decision: DecisionReview},
+		},
+		Default: DecisionAccept,
+	}
+}
+
+// Validate checks that every rule has a name and a known decision, and
+// that the default decision is known
+func (p DecisionPolicy) Validate() error {
+	if !p.Default.Valid() {
+		return fmt.Errorf("userdate: unknown default decision %q", p.Default)
+	}
+	seen := make(map[string]bool)
+	for i, rule := range p.Rules {
+		switch {
+		case rule.Name == "":
+			return fmt.Errorf("userdate: decision rule %d has no name", i)
+		case seen[rule.Name]:
+			return fmt.Errorf("userdate: duplicate decision rule %q", rule.Name)
+		case !rule.Decision.Valid():
+			return fmt.Errorf("userdate: decision rule %q has unknown decision %q", rule.Name, rule.Decision)
+		case rule.MinConfidence < 0 || rule.MinConfidence > 1:
+			return fmt.Errorf("userdate: decision rule %q has minimum confidence %v outside [0, 1]", rule.Name, rule.MinConfidence)
+		}
+		seen[rule.Name] = true
+	}
+	return nil
+}
+
+// Decide returns the decision of the first rule matching report, or the
+// default decision
+func (p DecisionPolicy) Decide(report *Report) DecisionResult {
+	if report == nil {
+		return DecisionResult{Decision: DecisionReview, Reasons: []string{"no report"}}
+	}
+	score := p.Score(report)
+	for _, rule := range p.Rules {
+		if reasons, ok := rule.match(report, score); ok {
+			return DecisionResult{Decision: rule.Decision, Rule: rule.Name, Reasons: append([]string{"rule " + rule.Name + " matched"}, reasons...)}
+		}
+	}
+	return DecisionResult{Decision: p.Default, Reasons: []string{"no rule matched, default decision"}}
+}
+
+// Score returns the sum of the weights of the findings of report
+func (p DecisionPolicy) Score(report *Report) int {
+	if report == nil {
+		return 0
+	}
+	score := 0
+	for _, f := range report.Findings {
+		score += p.Weights[f.Code]
+	}
+	return score
+}
+
+// match reports whether the rule matches report, with the reasons it does
+func (r DecisionRule) match(report *Report, score int) (reasons []string, ok bool) {
+	if len(r.Codes) > 0 || len(r.Severities) > 0 {
+		for _, f := range report.Findings {
+			if (len(r.Codes) == 0 || slices.Contains(r.Codes, f.Code)) &&
+				(len(r.Severities) == 0 || slices.Contains(r.Severities, f.Severity)) &&
+				f.certainty() >= r.MinConfidence {
+				reason := fmt.Sprintf("%s %s: %s", f.Severity, f.Code, f.Message)
+				if f.Confidence > 0 {
+					reason += fmt.Sprintf(" (confidence %.2f)", f.Confidence)
+				}
+				reasons = append(reasons, reason)
+			}
+		}
+		if len(reasons) == 0 {
+			return nil, false
+		}
+	}
+	if len(r.Verdicts) > 0 {
+		if !slices.Contains(r.Verdicts, report.Verdict) {
+			return nil, false
+		}
+		reasons = append(reasons, "verdict "+string(report.Verdict))
+	}
+	if r.MinScore > 0 {
+		if score < r.MinScore {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("score %d, at least %d", score, r.MinScore))
+	}
+	return reasons, true
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDefaultDecisionPolicy(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+	policy := DefaultDecisionPolicy()
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		entity   Entity
+		decision Decision
+		rule     string
+		reasons  int
+	}{
+		{"clean", Entity{Type: "account", Date: mustParseDate("2010-01-01")}, DecisionAccept, "", 1},
+		{"error", Entity{Type: "account", Date: mustParseDate("1989-01-01")}, DecisionReject, "reject_errors", 2},
+		{"indeterminate", Entity{Type: "account", Date: mustParseDate("1990-01-01"), DatePrecision: DatePrecisionYear}, DecisionReview, "review_indeterminate", 2},
+		{"warning", Entity{Type: "account", Date: mustParseDate("2010-01-01"), Status: StatusExpired}, DecisionReview, "review_warnings", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policy.Decide(CheckEntity(user, tt.entity, now))
+			if got.Decision != tt.decision || got.Rule != tt.rule || len(got.Reasons) != tt.reasons {
+				t.Errorf("Decide() = %+v, want %s by %q with %d reasons", got, tt.decision, tt.rule, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestDecisionPolicyScore(t *testing.T) {
+	policy := DecisionPolicy{
+		Rules: []DecisionRule{
+			{Name: "revoked", Codes: []string{ErrCodeRevoked}, Decision: DecisionReject},
+			{Name: "risky", MinScore: 5, Decision: DecisionReview},
+		},
+		Weights: map[string]int{ErrCodeExpired: 2, ErrCodeSwappedDate: 4},
+		Default: DecisionAccept,
+	}
+	report := &Report{Verdict: VerdictPass, Findings: []*DateValidationError{
+		{Code: ErrCodeExpired, Severity: SeverityWarning, Message: "expired"},
+		{Code: ErrCodeSwappedDate, Severity: SeverityWarning, Message: "swapped"},
+	}}
+
+	if score := policy.Score(report); score != 6 {
+		t.Errorf("Score() = %d, want 6", score)
+	}
+	got := policy.Decide(report)
+	want := DecisionResult{Decision: DecisionReview, Rule: "risky", Reasons: []string{"rule risky matched", "score 6, at least 5"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decide() = %+v, want %+v", got, want)
+	}
+
+	report.Findings = report.Findings[:1]
+	if got := policy.Decide(report); got.Decision != DecisionAccept || got.Rule != "" {
+		t.Errorf("Decide() below the score = %+v, want the default", got)
+	}
+}
+
+func TestDecisionPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		valid  bool
+	}{
+		{"valid", `{"rules":[{"name":"errors","severities":["error"],"decision":"REJECT"}],"default":"ACCEPT"}`, true},
+		{"no default", `{"rules":[]}`, false},
+		{"unknown decision", `{"rules":[{"name":"errors","decision":"BLOCK"}],"default":"ACCEPT"}`, false},
+		{"unnamed rule", `{"rules":[{"decision":"REJECT"}],"default":"ACCEPT"}`, false},
+		{"duplicate rule", `{"rules":[{"name":"a","decision":"REJECT"},{"name":"a","decision":"REVIEW"}],"default":"ACCEPT"}`, false},
+		{"confidence above 1", `{"rules":[{"name":"a","min_confidence":95,"decision":"REJECT"}],"default":"ACCEPT"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policy DecisionPolicy
+			if err := json.Unmarshal([]byte(tt.policy), &policy); err != nil {
+				t.Fatal(err)
+			}
+			if err := policy.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestDecisionPolicyConfidence(t *testing.T) {
+	policy := DecisionPolicy{
+		Rules: []DecisionRule{
+			{Name: "sure_placeholder", Codes: []string{ErrCodePlaceholderDate}, MinConfidence: 0.9, Decision: DecisionReject},
+			{Name: "placeholder", Codes: []string{ErrCodePlaceholderDate}, Decision: DecisionReview},
+		},
+		Default: DecisionAccept,
+	}
+
+	tests := []struct {
+		name       string
+		confidence float64
+		rule       string
+		reasons    []string
+	}{
+		{"confident", 0.95, "sure_placeholder", []string{"rule sure_placeholder matched", "error PLACEHOLDER_DATE: placeholder (confidence 0.95)"}},
+		{"unsure", 0.55, "placeholder", []string{"rule placeholder matched", "error PLACEHOLDER_DATE: placeholder (confidence 0.55)"}},
+		{"deterministic", 0, "sure_placeholder", []string{"rule sure_placeholder matched", "error PLACEHOLDER_DATE: placeholder"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &Report{Verdict: VerdictFail, Findings: []*DateValidationError{
+				{Code: ErrCodePlaceholderDate, Message: "placeholder", Confidence: tt.confidence},
+			}}
+			got := policy.Decide(report)
+			if got.Rule != tt.rule || !reflect.DeepEqual(got.Reasons, tt.reasons) {
+				t.Errorf("Decide() = %+v, want %q with reasons %q", got, tt.rule, tt.reasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package core validates the dates of user entities: their users, entities
+// and profiles, the rules and datasets they are checked against, and the
+// reports, adapters and message catalogs of the outcomes. It holds the
+// implementation of the module. The rules, report, adapters and i18n
+// packages group parts of its API by topic, and the flat userdate package
+// of v1 forwards to it, see the v2 package.
+package core
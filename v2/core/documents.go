@@ -0,0 +1,127 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// documentTypes are the identity and travel documents whose EndDate is
+// their expiry as printed on the document
+var documentTypes = map[string]bool{
+	"passport":         true,
+	"national_id":      true,
+	"visa":             true,
+	"residence_permit": true,
+	workPermitType:     true,
+}
+
+// ChildValidity is the shorter validity of documents issued to users
+// younger than UnderAge, such as 5 years for passports issued before 16
+type ChildValidity struct {
+	UnderAge int            `json:"under_age"`
+	Validity ValidityPeriod `json:"validity"`
+}
+
+// String returns the rule in the form of rules files, such as "5y under 16"
+func (v ChildValidity) String() string {
+	return fmt.Sprintf("%s under %d", v.Validity, v.UnderAge)
+}
+
+// defaultChildValidity is the validity of documents issued to children in
+// most jurisdictions; jurisdictions such as DE change it
+var defaultChildValidity = map[string]ChildValidity{
+	"passport": {UnderAge: 16, Validity: ValidityPeriod{Years: 5}},
+}
+
+// validFrom returns the start of the current validity of an entity, its
+// latest renewal or else its date
+func (e Entity) validFrom() time.Time {
+	if e.RenewedAt.After(e.Date) {
+		return e.RenewedAt
+	}
+	return e.Date
+}
+
+// documentValidity returns the validity period of an entity, the child
+// validity of its type when the user was younger than its age bracket on
+// the start of the validity
+func (c *config) documentValidity(entity Entity, birthDate time.Time) (ValidityPeriod, bool) {
+	if v, ok := c.childValidity[entity.Type]; ok && !birthDate.IsZero() {
+		if c.ageAt(birthDate, entity.validFrom()) < v.UnderAge {
+			return v.Validity, v.Validity != ValidityPeriod{}
+		}
+	}
+	return c.validityPeriod(entity.Type)
+}
+
+// validateDocumentValidity rejects documents whose expiry is later than
+// their validity period allows for the age of the user on issue
+func (c *config) validateDocumentValidity(entity Entity, birthDate time.Time) error {
+	if !documentTypes[entity.Type] || entity.EndDate.IsZero() {
+		return nil
+	}
+	period, ok := c.documentValidity(entity, birthDate)
+	if !ok {
+		return nil
+	}
+	from, end := c.truncate(entity.validFrom()), c.truncate(entity.EndDate)
+	if end.After(c.truncate(period.expiry(from))) {
+		return newError(msgValidityTooLong, "type", entityTypeArg(entity.Type), "date", from, "end", end, "period", period)
+	}
+	return nil
+}
+
+// checkRenewalChain checks the document an entity replaces, found by ID
+// among the entities of its profile: it must be of the same type, dated
+// before the entity and not lead back to it. A replaced document missing
+// from the profile only gives a warning, as profiles may be partial.
+func (c *config) checkRenewalChain(entity Entity, byID map[string]Entity, report *Report) {
+	if entity.Replaces == "" {
+		return
+	}
+	previous, ok := byID[entity.Replaces]
+	if !ok {
+		report.add(c.localize(atField(newWarning(msgReplacedMissing, "type", entityTypeArg(entity.Type), "replaces", entity.Replaces), fieldReplaces)))
+		return
+	}
+	var err error
+	switch {
+	case replacesItself(entity, byID):
+		err = newError(msgReplacedCycle, "type", entityTypeArg(entity.Type), "id", entity.ID)
+	case previous.Type != entity.Type:
+		err = newError(msgReplacedType, "type", entityTypeArg(entity.Type), "replaces", entity.Replaces, "other", entityTypeArg(previous.Type))
+	case !c.truncate(previous.Date).Before(c.truncate(entity.Date)):
+		err = newError(msgReplacedOrder, "type", entityTypeArg(entity.Type), "date", entity.Date, "replaces", entity.Replaces, "previous", previous.Date)
+	}
+	if err != nil {
+		report.add(c.localize(atField(err, fieldReplaces)))
+	}
+}
+
+// replacesItself reports whether following the documents an entity
+// replaces leads back to it
+func replacesItself(entity Entity, byID map[string]Entity) bool {
+	next := entity.Replaces
+	for range len(byID) {
+		if next == entity.ID {
+			return true
+		}
+		previous, ok := byID[next]
+		if !ok || previous.Replaces == "" {
+			return false
+		}
+		next = previous.Replaces
+	}
+	return false
+}
+
+// entitiesByID indexes the entities of a profile that have an ID
+func entitiesByID(entities []Entity) map[string]Entity {
+	byID := make(map[string]Entity, len(entities))
+	for _, entity := range entities {
+		if entity.ID != "" {
+			byID[entity.ID] = entity
+		}
+	}
+	return byID
+}
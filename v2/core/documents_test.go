@@ -0,0 +1,95 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDocumentValidity(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	child := &User{ID: "child", BirthDate: mustParseDate("2015-01-01")}
+	student := &User{ID: "student", BirthDate: mustParseDate("2004-01-01")}
+	adult := &User{ID: "adult", BirthDate: mustParseDate("1980-01-01")}
+	germany, err := NewRuleConfig("DE", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		user      *User
+		entity    Entity
+		opts      []Option
+		wantValid bool
+		wantCodes []string
+	}{
+		{"adult passport of 10 years", student, Entity{Type: "passport", Date: mustParseDate("2023-01-01"), EndDate: mustParseDate("2033-01-01")}, nil, true, nil},
+		{"child passport of 5 years", child, Entity{Type: "passport", Date: mustParseDate("2023-01-01"), EndDate: mustParseDate("2028-01-01")}, nil, true, nil},
+		{"child passport of 10 years", child, Entity{Type: "passport", Date: mustParseDate("2023-01-01"), EndDate: mustParseDate("2033-01-01")}, nil, false, []string{ErrCodeDocumentValidity}},
+		{"passport of 10 years before 24 in DE", student, Entity{Type: "passport", Date: mustParseDate("2023-01-01"), EndDate: mustParseDate("2033-01-01")}, []Option{WithRules(germany)}, false, []string{ErrCodeDocumentValidity}},
+		{"passport of 6 years before 24 in DE", student, Entity{Type: "passport", Date: mustParseDate("2023-01-01"), EndDate: mustParseDate("2029-01-01")}, []Option{WithRules(germany)}, true, nil},
+		{"child validity removed", child, Entity{Type: "passport", Date: mustParseDate("2023-01-01"), EndDate: mustParseDate("2033-01-01")}, []Option{WithChildValidity("passport", 0, ValidityPeriod{})}, true, nil},
+		{"expired passport", adult, Entity{Type: "passport", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2025-01-01")}, nil, true, []string{ErrCodeExpired}},
+		{"child passport expired without end date", child, Entity{Type: "passport", Date: mustParseDate("2019-01-01")}, nil, true, []string{ErrCodeExpired}},
+		{"adult passport valid without end date", student, Entity{Type: "passport", Date: mustParseDate("2022-01-01")}, nil, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(tt.user, tt.entity, append(tt.opts, now)...)
+			if report.Valid() != tt.wantValid {
+				t.Errorf("Valid() = %v, want %v: %v", report.Valid(), tt.wantValid, report.Findings)
+			}
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("findings = %v, want %v", got, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestDocumentValidityJurisdiction(t *testing.T) {
+	student := &User{ID: "student", BirthDate: mustParseDate("2004-01-01")}
+	passport := Entity{Type: "passport", Issuer: "Bundesdruckerei", Date: mustParseDate("2023-01-01"), EndDate: mustParseDate("2033-01-01")}
+	resolver := WithJurisdictionResolver(StaticJurisdictions{Issuers: map[string]string{"Bundesdruckerei": "DE"}})
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+
+	if code := codeOf(CheckEntity(student, passport, now, resolver).Err()); code != ErrCodeDocumentValidity {
+		t.Errorf("CheckEntity(DE) code = %q, want %s", code, ErrCodeDocumentValidity)
+	}
+	pinned := WithChildValidity("passport", 16, ValidityPeriod{Years: 5})
+	if err := CheckEntity(student, passport, now, resolver, pinned).Err(); err != nil {
+		t.Errorf("CheckEntity(DE, pinned child validity) error = %v", err)
+	}
+}
+
+func TestRenewalChain(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	old := Entity{ID: "p1", Type: "passport", Date: mustParseDate("2012-05-01"), EndDate: mustParseDate("2022-05-01")}
+
+	tests := []struct {
+		name      string
+		renewal   Entity
+		others    []Entity
+		wantCodes []string
+	}{
+		{"renews the old passport", Entity{ID: "p2", Type: "passport", Date: mustParseDate("2022-03-01"), Replaces: "p1"}, []Entity{old}, nil},
+		{"replaced document missing", Entity{ID: "p2", Type: "passport", Date: mustParseDate("2022-03-01"), Replaces: "p0"}, []Entity{old}, []string{ErrCodeRenewalChain}},
+		{"replaces another type", Entity{ID: "n2", Type: "national_id", Date: mustParseDate("2022-03-01"), Replaces: "p1"}, []Entity{old}, []string{ErrCodeRenewalChain}},
+		{"issued before the replaced one", Entity{ID: "p2", Type: "passport", Date: mustParseDate("2011-03-01"), Replaces: "p1"}, []Entity{old}, []string{ErrCodeExpired, ErrCodeRenewalChain}},
+		{"cycle", Entity{ID: "p2", Type: "passport", Date: mustParseDate("2022-03-01"), Replaces: "p1"}, []Entity{{ID: "p1", Type: "passport", Date: mustParseDate("2012-05-01"), Replaces: "p2"}}, []string{ErrCodeRenewalChain}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := Profile{User: user, Entities: append([]Entity{tt.renewal}, tt.others...)}
+			report := ValidateProfile(profile, now).Reports[0]
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Fatalf("findings = %v, want %v", got, tt.wantCodes)
+			}
+			if n := len(report.Findings); n > 0 && report.Findings[n-1].Path != "/entities/0/replaces" {
+				t.Errorf("finding path = %q, want /entities/0/replaces", report.Findings[n-1].Path)
+			}
+		})
+	}
+}
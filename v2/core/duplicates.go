@@ -0,0 +1,109 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// LikelyDuplicate is a pair of users that may be the same person, as
+// candidates for merging their records. First and Second are indexes in the
+// users given to FindLikelyDuplicates, First being the lower one.
+type LikelyDuplicate struct {
+	First  int `json:"first"`
+	Second int `json:"second"`
+
+	// SimilarName and SimilarID tell which of the names and IDs of the two
+	// users are identical or differ by a typo; at least one of them is set
+	SimilarName bool `json:"similar_name,omitempty"`
+	SimilarID   bool `json:"similar_id,omitempty"`
+}
+
+// FindLikelyDuplicates returns the pairs of users born the same day whose
+// names or IDs are identical or nearly so, sorted by index. Names and IDs
+// are compared once normalized, see WithIdentityNormalizer. Names are then
+// nearly identical when they differ by at most one edit per 5 letters, and
+// IDs when they differ by one edit, such as a mistyped or transposed
+// character. Users without a birth date are left
+// out. It only flags candidates: a merge needs a review.
+func FindLikelyDuplicates(users []User, opts ...Option) []LikelyDuplicate {
+	cfg := newConfig(opts)
+	// Users are grouped by the calendar date of their birth, whatever the
+	// precision, time of day and location of their birth dates
+	byBirth := make(map[time.Time][]int)
+	for i, user := range users {
+		if !user.BirthDate.IsZero() {
+			y, m, d := user.BirthDate.Date()
+			birth := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+			byBirth[birth] = append(byBirth[birth], i)
+		}
+	}
+	var found []LikelyDuplicate
+	for _, indexes := range byBirth {
+		for i, first := range indexes {
+			for _, second := range indexes[i+1:] {
+				pair := LikelyDuplicate{
+					First:       first,
+					Second:      second,
+					SimilarName: cfg.similarNames(users[first].Name, users[second].Name),
+					SimilarID:   cfg.similarIDs(users[first].ID, users[second].ID),
+				}
+				if pair.SimilarName || pair.SimilarID {
+					found = append(found, pair)
+				}
+			}
+		}
+	}
+	slices.SortFunc(found, func(a, b LikelyDuplicate) int {
+		if a.First != b.First {
+			return a.First - b.First
+		}
+		return a.Second - b.Second
+	})
+	return found
+}
+
+// similarNames reports whether two normalized names differ by at most one
+// edit per 5 letters. Empty names are never similar.
+func (c *config) similarNames(a, b string) bool {
+	a, b = c.identity.NormalizeName(a), c.identity.NormalizeName(b)
+	if a == "" || b == "" {
+		return false
+	}
+	ra, rb := []rune(a), []rune(b)
+	return editDistance(ra, rb) <= max(len(ra), len(rb), 5)/5
+}
+
+// similarIDs reports whether two normalized IDs differ by at most one
+// edit. Empty IDs are never similar.
+func (c *config) similarIDs(a, b string) bool {
+	a, b = c.identity.NormalizeID(a), c.identity.NormalizeID(b)
+	if a == "" || b == "" {
+		return false
+	}
+	return editDistance([]rune(a), []rune(b)) <= 1
+}
+
+// editDistance returns the number of insertions, deletions, substitutions
+// and transpositions of adjacent runes turning a into b
+func editDistance(a, b []rune) int {
+	// Rows i-2, i-1 and i of the distances between the prefixes
+	before, previous, current := make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], before[j-2]+1)
+			}
+		}
+		before, previous, current = previous, current, before
+	}
+	return previous[len(b)]
+}
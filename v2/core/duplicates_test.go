@@ -0,0 +1,69 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFindLikelyDuplicates(t *testing.T) {
+	birth := mustParseDate("1990-05-15")
+	users := []User{
+		{ID: "u-1001", Name: "Marie Dupont", BirthDate: birth},
+		{ID: "u-5555", Name: "marie  dupont", BirthDate: birth},
+		{ID: "u-1010", Name: "Jean Martin", BirthDate: birth},
+		{ID: "u-3000", Name: "Marie Dupond", BirthDate: birth},
+		{ID: "u-1001", Name: "Marie Dupont", BirthDate: mustParseDate("1990-05-16")},
+		{ID: "u-0110", Name: "Jean Martin"},
+		{ID: "u-1100", Name: "Paul Durand", BirthDate: birth},
+	}
+
+	want := []LikelyDuplicate{
+		{First: 0, Second: 1, SimilarName: true},
+		{First: 0, Second: 2, SimilarID: true},
+		{First: 0, Second: 3, SimilarName: true},
+		{First: 1, Second: 3, SimilarName: true},
+		{First: 2, Second: 6, SimilarID: true},
+	}
+	if got := FindLikelyDuplicates(users); !slices.Equal(got, want) {
+		t.Errorf("FindLikelyDuplicates() = %+v, want %+v", got, want)
+	}
+	if got := FindLikelyDuplicates(users, WithPrecision(PrecisionDate)); !slices.Equal(got, want) {
+		t.Errorf("FindLikelyDuplicates() with date precision = %+v, want %+v", got, want)
+	}
+
+	// Times and locations of birth dates do not matter
+	tokyo := time.FixedZone("JST", 9*3600)
+	times := []User{
+		{ID: "a-1", Name: "Ann Lee", BirthDate: time.Date(1990, 5, 15, 8, 30, 0, 0, time.UTC)},
+		{ID: "b-2", Name: "Ann Lee", BirthDate: time.Date(1990, 5, 15, 22, 0, 0, 0, tokyo)},
+	}
+	for _, opts := range [][]Option{nil, {WithPrecision(PrecisionDate)}} {
+		if got := FindLikelyDuplicates(times, opts...); len(got) != 1 {
+			t.Errorf("FindLikelyDuplicates() of birth times = %+v, want one pair", got)
+		}
+	}
+	if got := FindLikelyDuplicates(nil); got != nil {
+		t.Errorf("FindLikelyDuplicates(nil) = %+v, want nil", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"u-1001", "u-1010", 1},
+		{"dupont", "dupond", 1},
+		{"józef", "jozef", 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
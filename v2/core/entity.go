@@ -0,0 +1,273 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Entity is a dated record belonging to a user, such as a certification or
+// a license
+type Entity struct {
+	ID        string       `json:"id,omitempty"`
+	Issuer    string       `json:"issuer,omitempty"`
+	Type      string       `json:"type"`
+	Date      time.Time    `json:"date"`
+	RenewedAt time.Time    `json:"renewed_at,omitzero"` // Latest renewal, zero if never renewed
+	EndDate   time.Time    `json:"end_date,omitzero"`   // End of a placement or expiry of a visa, zero while ongoing
+	EntryDate time.Time    `json:"entry_date,omitzero"` // First entry into the country on a visa, zero if unused
+	Replaces  string       `json:"replaces,omitempty"`  // ID of the document this one renews, such as an old passport
+	Guardian  bool         `json:"guardian,omitempty"`  // Held jointly with a parent or guardian, such as a minor's bank account
+	Juvenile  bool         `json:"juvenile,omitempty"`  // Handled as a juvenile record, such as a sealed offence of a minor
+	Category  string       `json:"category,omitempty"`  // Age category of a competition entry, such as U18 or masters
+	Industry  string       `json:"industry,omitempty"`  // Industry of an employment, such as healthcare, see WithIndustries
+	Status    EntityStatus `json:"status,omitempty"`    // StatusClaimed when empty
+
+	// DatePrecision is how precisely Date is known, DatePrecisionDay when
+	// empty, and Uncertainty widens the range of days it stands for by a
+	// radius, DefaultUncertainty for approximate dates. Rules pass or fail
+	// imprecise dates only when every day they stand for does.
+	DatePrecision DatePrecision `json:"date_precision,omitempty"`
+	Uncertainty   Uncertainty   `json:"uncertainty,omitzero"`
+}
+
+// EntityStatus is the lifecycle state of an entity on a verification platform
+type EntityStatus string
+
+// Entity statuses
+const (
+	StatusClaimed  EntityStatus = "claimed"  // Declared by the user, not yet checked
+	StatusVerified EntityStatus = "verified" // Confirmed with the issuer
+	StatusExpired  EntityStatus = "expired"  // Lapsed and not renewed
+	StatusRevoked  EntityStatus = "revoked"  // Withdrawn by the issuer; final
+)
+
+// statusTransitions lists the statuses each status may move to
+var statusTransitions = map[EntityStatus][]EntityStatus{
+	StatusClaimed:  {StatusVerified, StatusRevoked},
+	StatusVerified: {StatusExpired, StatusRevoked},
+	StatusExpired:  {StatusVerified, StatusRevoked},
+	StatusRevoked:  nil,
+}
+
+// normalize returns the status with the empty value mapped to StatusClaimed
+func (s EntityStatus) normalize() EntityStatus {
+	if s == "" {
+		return StatusClaimed
+	}
+	return s
+}
+
+// Valid reports whether s is a known status. The empty status is valid and
+// means StatusClaimed.
+func (s EntityStatus) Valid() bool {
+	_, ok := statusTransitions[s.normalize()]
+	return ok
+}
+
+// CanTransitionTo reports whether an entity may move from s to next
+func (s EntityStatus) CanTransitionTo(next EntityStatus) bool {
+	for _, allowed := range statusTransitions[s.normalize()] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the entity to the next status, or returns an
+// ErrCodeInvalidStatus error if the lifecycle does not allow it
+func (e *Entity) Transition(next EntityStatus) error {
+	if e == nil {
+		return ErrNilValue
+	}
+	if !e.Status.CanTransitionTo(next) {
+		return newError(msgStatusTransition, "type", entityTypeArg(e.Type), "from", e.Status.normalize(), "to", next)
+	}
+	e.Status = next
+	return nil
+}
+
+// ValidityPeriod is how long a credential stays valid after it is issued or
+// renewed, in calendar years and months
+type ValidityPeriod struct {
+	Years  int `json:"years,omitempty"`
+	Months int `json:"months,omitempty"`
+}
+
+// String returns the period in a compact form such as "2y" or "1y6m"
+func (p ValidityPeriod) String() string {
+	switch {
+	case p.Months == 0:
+		return fmt.Sprintf("%dy", p.Years)
+	case p.Years == 0:
+		return fmt.Sprintf("%dm", p.Months)
+	default:
+		return fmt.Sprintf("%dy%dm", p.Years, p.Months)
+	}
+}
+
+// expiry returns the end of the period starting at from
+func (p ValidityPeriod) expiry(from time.Time) time.Time {
+	return from.AddDate(p.Years, p.Months, 0)
+}
+
+// DefaultValidityPeriod returns the validity period of an entity type in
+// the credential dataset, see LoadRuleData
+func DefaultValidityPeriod(entityType string) (ValidityPeriod, bool) {
+	p, ok := defaultValidityPeriods()[entityType]
+	return p, ok
+}
+
+// CheckEntity validates an entity of a user. Revoked entities fail with
+// ErrCodeRevoked. Besides the checks of CheckEntityDate, it warns with
+// ErrCodeExpired when the entity is marked expired, or when the entity type
+// has a validity period and the entity would have expired by now, unless a
+// renewal keeps it valid.
+func CheckEntity(user *User, entity Entity, opts ...Option) *Report {
+	return CheckEntityContext(context.Background(), user, entity, opts...)
+}
+
+// CheckEntityContext is like CheckEntity. The context is passed to the
+// revocation checker and may carry the reference time (see ContextWithNow).
+func CheckEntityContext(ctx context.Context, user *User, entity Entity, opts ...Option) *Report {
+	cfg := newConfig(opts).withContext(ctx)
+	return cfg.checkEntity(ctx, user, entity)
+}
+
+// ValidateEntity validates an entity of a user and returns the first error
+func ValidateEntity(user *User, entity Entity, opts ...Option) error {
+	return CheckEntity(user, entity, opts...).Err()
+}
+
+// CheckEntity validates an entity of a user using the validator's settings
+func (v *Validator) CheckEntity(user *User, entity Entity) *Report {
+	return v.config().checkEntity(context.Background(), user, entity)
+}
+
+// CheckEntityContext is like CheckEntity, passing ctx to the revocation
+// checker and taking the current time from ctx when it carries one
+func (v *Validator) CheckEntityContext(ctx context.Context, user *User, entity Entity) *Report {
+	cfg := v.config().withContext(ctx)
+	return cfg.checkEntity(ctx, user, entity)
+}
+
+// checkEntity runs the date checks and the entity-level rules
+func (c *config) checkEntity(ctx context.Context, user *User, entity Entity) *Report {
+	report := c.newReport(user, entity.Date, entity.Type)
+	c.evaluateEntity(ctx, user, entity, report)
+	return c.finish(ctx, user, entity, report)
+}
+
+// evaluateEntity records the findings for an entity in report
+func (c *config) evaluateEntity(ctx context.Context, user *User, entity Entity, report *Report) {
+	err := validateStatus(entity)
+	c.explain.check("status", "known status, not revoked", err)
+	if err != nil {
+		report.add(c.localize(err))
+		if !c.fullEvaluation {
+			return
+		}
+	}
+	c = c.withJurisdiction(ctx, user, entity, report)
+	in, err := c.newRuleInput(ctx, user, entity)
+	_, rules := c.rules()
+	if lenient, lenientIn, warning := c.acceptPlaceholder(ctx, user, entity, rules, err); warning != nil {
+		lenient.explain.input(lenient, &lenientIn, warning)
+		report.add(warning)
+		lenient.runRules(rules, &lenientIn, report)
+		return
+	}
+	c.explain.input(c, &in, err)
+	if err != nil {
+		report.add(err)
+		return
+	}
+	c.runRules(rules, &in, report)
+}
+
+// validateStatus rejects revoked entities and unknown statuses
+func validateStatus(entity Entity) error {
+	switch {
+	case !entity.Status.Valid():
+		return atField(newError(msgUnknownStatus, "type", entityTypeArg(entity.Type), "status", entity.Status), fieldStatus)
+	case entity.Status == StatusRevoked:
+		return atField(newError(msgStatusRevoked, "type", entityTypeArg(entity.Type), "date", entity.Date), fieldStatus)
+	}
+	return nil
+}
+
+// validateRenewal checks that a renewal date, if any, is plausible
+func (c *config) validateRenewal(entity Entity) error {
+	if entity.RenewedAt.IsZero() {
+		return nil
+	}
+	renewedAt := c.truncate(entity.RenewedAt)
+	if renewedAt.Before(c.truncate(entity.Date)) {
+		return newError(msgRenewalBeforeIssue, "type", entityTypeArg(entity.Type), "renewed", renewedAt, "date", entity.Date)
+	}
+	if renewedAt.After(c.truncate(c.now())) {
+		return newError(msgFutureRenewal, "type", entityTypeArg(entity.Type), "renewed", renewedAt)
+	}
+	return nil
+}
+
+// defaultMaxDurations are the usual longest durations of placements, past
+// which entities are reported with ErrCodeImplausibleDuration
+var defaultMaxDurations = map[string]ValidityPeriod{
+	"internship":     {Years: 1},
+	"apprenticeship": {Years: 4},
+}
+
+// validateDuration checks that the end date of an entity, if any, is not
+// before its start, and warns when the entity lasts longer than the maximum
+// duration of its type. Ongoing entities last until now.
+func (c *config) validateDuration(entity Entity, report *Report) error {
+	start, end := c.truncate(entity.Date), c.truncate(c.now())
+	if !entity.EndDate.IsZero() {
+		end = c.truncate(entity.EndDate)
+		if end.Before(start) {
+			return newError(msgEndBeforeStart, "type", entityTypeArg(entity.Type), "end", end, "date", start)
+		}
+	}
+	maxDuration, ok := c.maxDurations[entity.Type]
+	if !ok || maxDuration == (ValidityPeriod{}) {
+		return nil
+	}
+	if limit := c.truncate(maxDuration.expiry(entity.Date)); limit.Before(end) {
+		err := newWarning(msgLongDuration, "type", entityTypeArg(entity.Type), "date", start, "end", end, "max", maxDuration)
+		report.add(withConfidence(err, excessConfidence(days(start, end), days(start, limit))))
+	}
+	return nil
+}
+
+// checkExpiry warns when the entity is marked expired, or its expiry or its
+// validity period has elapsed
+func (c *config) checkExpiry(entity Entity, birthDate time.Time, report *Report) {
+	if entity.Status == StatusExpired {
+		report.add(newWarning(msgMarkedExpired, "type", entityTypeArg(entity.Type), "date", entity.Date))
+		return
+	}
+
+	// Documents carry their expiry
+	if documentTypes[entity.Type] && !entity.EndDate.IsZero() {
+		if end := c.truncate(entity.EndDate); !end.After(c.truncate(c.now())) {
+			report.add(newWarning(msgEndDateExpired, "type", entityTypeArg(entity.Type), "date", entity.Date, "end", end))
+		}
+		return
+	}
+
+	period, ok := c.documentValidity(entity, birthDate)
+	if !ok {
+		return
+	}
+
+	validFrom := entity.validFrom()
+	expiry := c.truncate(period.expiry(validFrom))
+	if expiry.After(c.truncate(c.now())) {
+		return
+	}
+
+	report.add(newWarning(msgPeriodExpired,
+		"type", entityTypeArg(entity.Type), "date", validFrom, "expiry", expiry, "period", period))
+}
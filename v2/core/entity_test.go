@@ -0,0 +1,269 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckEntityExpiry(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+
+	tests := []struct {
+		name         string
+		entity       Entity
+		opts         []Option
+		errCode      string
+		warningCodes []string
+	}{
+		{
+			name:   "still valid",
+			entity: Entity{Type: "cpr_certification", Date: mustParseDate("2019-01-01")},
+		},
+		{
+			name:         "expired",
+			entity:       Entity{Type: "cpr_certification", Date: mustParseDate("2018-01-01")},
+			warningCodes: []string{ErrCodeExpired},
+		},
+		{
+			name:         "expires today",
+			entity:       Entity{Type: "cpr_certification", Date: mustParseDate("2018-06-15")},
+			warningCodes: []string{ErrCodeExpired},
+		},
+		{
+			name:   "expires tomorrow",
+			entity: Entity{Type: "cpr_certification", Date: mustParseDate("2018-06-16")},
+		},
+		{
+			name:   "renewed",
+			entity: Entity{Type: "cpr_certification", Date: mustParseDate("2014-01-01"), RenewedAt: mustParseDate("2019-03-01")},
+		},
+		{
+			name:         "renewal also expired",
+			entity:       Entity{Type: "cpr_certification", Date: mustParseDate("2014-01-01"), RenewedAt: mustParseDate("2016-03-01")},
+			warningCodes: []string{ErrCodeExpired},
+		},
+		{
+			name:   "type without validity period",
+			entity: Entity{Type: "certification", Date: mustParseDate("2000-01-01")},
+		},
+		{
+			name:         "custom validity period",
+			entity:       Entity{Type: "certification", Date: mustParseDate("2019-01-01")},
+			opts:         []Option{WithValidityPeriod("certification", ValidityPeriod{Months: 6})},
+			warningCodes: []string{ErrCodeExpired},
+		},
+		{
+			name:   "disabled validity period",
+			entity: Entity{Type: "cpr_certification", Date: mustParseDate("2000-01-01")},
+			opts:   []Option{WithValidityPeriod("cpr_certification", ValidityPeriod{})},
+		},
+		{
+			name:    "renewal before issue",
+			entity:  Entity{Type: "cpr_certification", Date: mustParseDate("2019-01-01"), RenewedAt: mustParseDate("2018-01-01")},
+			errCode: ErrCodeInvalidDate,
+		},
+		{
+			name:    "renewal in the future",
+			entity:  Entity{Type: "cpr_certification", Date: mustParseDate("2019-01-01"), RenewedAt: mustParseDate("2021-01-01")},
+			errCode: ErrCodeFutureDate,
+		},
+		{
+			name:    "date errors come first",
+			entity:  Entity{Type: "cpr_certification", Date: mustParseDate("1980-01-01")},
+			errCode: ErrCodeBeforeBirth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, tt.entity, append([]Option{now}, tt.opts...)...)
+
+			err := report.Err()
+			if tt.errCode == "" {
+				if err != nil {
+					t.Errorf("CheckEntity() unexpected error = %v", err)
+				}
+			} else if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != tt.errCode {
+				t.Errorf("CheckEntity() error = %v, want code %v", err, tt.errCode)
+			}
+
+			var codes []string
+			for _, w := range report.Warnings() {
+				codes = append(codes, w.Code)
+			}
+			if len(codes) != len(tt.warningCodes) || (len(codes) > 0 && codes[0] != tt.warningCodes[0]) {
+				t.Errorf("CheckEntity() warnings = %v, want %v", codes, tt.warningCodes)
+			}
+		})
+	}
+}
+
+func TestDefaultValidityPeriod(t *testing.T) {
+	tests := map[string]ValidityPeriod{
+		"cpr_certification": {Years: 2},
+		"passport":          {Years: 10},
+		"forklift_license":  {Years: 3},
+	}
+	for entityType, want := range tests {
+		if got, ok := DefaultValidityPeriod(entityType); !ok || got != want {
+			t.Errorf("DefaultValidityPeriod(%q) = %v, %v, want %v", entityType, got, ok, want)
+		}
+	}
+	if _, ok := DefaultValidityPeriod("certification"); ok {
+		t.Errorf("DefaultValidityPeriod(%q) ok = true, want false", "certification")
+	}
+
+	if got := (ValidityPeriod{Years: 1, Months: 6}).String(); got != "1y6m" {
+		t.Errorf("ValidityPeriod.String() = %v, want %v", got, "1y6m")
+	}
+}
+
+func TestValidateEntity(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	// Expiry is only a warning
+	if err := ValidateEntity(user, Entity{Type: "cpr_certification", Date: mustParseDate("2010-01-01")}); err != nil {
+		t.Errorf("ValidateEntity() unexpected error = %v", err)
+	}
+
+	err := ValidateEntity(user, Entity{Type: "license", Date: mustParseDate("2000-01-01")})
+	if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeUnrealisticAge {
+		t.Errorf("ValidateEntity() error = %v, want code %v", err, ErrCodeUnrealisticAge)
+	}
+
+	report := NewValidator(WithFixedNow(mustParseDate("2011-01-01"))).
+		CheckEntity(user, Entity{Type: "cpr_certification", Date: mustParseDate("2010-01-01")})
+	if len(report.Findings) != 0 {
+		t.Errorf("Validator.CheckEntity() findings = %v, want none", report.Findings)
+	}
+}
+
+func TestCheckEntityStatus(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	date := mustParseDate("2020-01-01")
+
+	tests := []struct {
+		name        string
+		status      EntityStatus
+		errCode     string
+		warningCode string
+	}{
+		{name: "empty status", status: ""},
+		{name: "claimed", status: StatusClaimed},
+		{name: "verified", status: StatusVerified},
+		{name: "expired", status: StatusExpired, warningCode: ErrCodeExpired},
+		{name: "revoked", status: StatusRevoked, errCode: ErrCodeRevoked},
+		{name: "unknown", status: "pending", errCode: ErrCodeInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, Entity{Type: "certification", Date: date, Status: tt.status})
+
+			err := report.Err()
+			if tt.errCode == "" {
+				if err != nil {
+					t.Errorf("CheckEntity() unexpected error = %v", err)
+				}
+			} else if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != tt.errCode {
+				t.Errorf("CheckEntity() error = %v, want code %v", err, tt.errCode)
+			}
+
+			warnings := report.Warnings()
+			switch {
+			case tt.warningCode == "" && len(warnings) != 0:
+				t.Errorf("CheckEntity() warnings = %v, want none", warnings)
+			case tt.warningCode != "" && (len(warnings) != 1 || warnings[0].Code != tt.warningCode):
+				t.Errorf("CheckEntity() warnings = %v, want %v", warnings, tt.warningCode)
+			}
+		})
+	}
+}
+
+func TestEntityTransition(t *testing.T) {
+	tests := []struct {
+		from    EntityStatus
+		to      EntityStatus
+		allowed bool
+	}{
+		{"", StatusVerified, true},
+		{StatusClaimed, StatusVerified, true},
+		{StatusClaimed, StatusExpired, false},
+		{StatusClaimed, StatusRevoked, true},
+		{StatusVerified, StatusExpired, true},
+		{StatusVerified, StatusRevoked, true},
+		{StatusVerified, StatusClaimed, false},
+		{StatusExpired, StatusVerified, true},
+		{StatusExpired, StatusRevoked, true},
+		{StatusRevoked, StatusVerified, false},
+		{StatusRevoked, StatusClaimed, false},
+		{"pending", StatusVerified, false},
+	}
+
+	for _, tt := range tests {
+		entity := Entity{Type: "certification", Status: tt.from}
+		err := entity.Transition(tt.to)
+		if tt.allowed {
+			if err != nil || entity.Status != tt.to {
+				t.Errorf("Transition(%q -> %q) = %v, status %q", tt.from, tt.to, err, entity.Status)
+			}
+			continue
+		}
+		if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeInvalidStatus {
+			t.Errorf("Transition(%q -> %q) error = %v, want code %v", tt.from, tt.to, err, ErrCodeInvalidStatus)
+		}
+		if entity.Status != tt.from {
+			t.Errorf("Transition(%q -> %q) changed status to %q", tt.from, tt.to, entity.Status)
+		}
+	}
+}
+
+func TestEntityDuration(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-01-01")}
+	now := WithFixedNow(mustParseDate("2025-01-01"))
+
+	tests := []struct {
+		name   string
+		entity Entity
+		opts   []Option
+		codes  []string
+	}{
+		{"internship at 15", Entity{Type: "internship", Date: mustParseDate("2015-06-01"), EndDate: mustParseDate("2015-08-31")}, nil, nil},
+		{"internship at 13", Entity{Type: "internship", Date: mustParseDate("2013-06-01")}, nil, []string{ErrCodeUnrealisticAge}},
+		{"apprenticeship at 15", Entity{Type: "apprenticeship", Date: mustParseDate("2015-09-01"), EndDate: mustParseDate("2018-06-30")}, nil, nil},
+		{"apprenticeship at 15 in GB", Entity{Type: "apprenticeship", Date: mustParseDate("2015-09-01")}, []Option{WithRules(mustRuleConfig(t, "GB"))}, []string{ErrCodeUnrealisticAge}},
+		{"end before start", Entity{Type: "internship", Date: mustParseDate("2020-06-01"), EndDate: mustParseDate("2020-05-01")}, nil, []string{ErrCodeInvalidDate}},
+		{"long internship", Entity{Type: "internship", Date: mustParseDate("2020-01-01"), EndDate: mustParseDate("2021-06-30")}, nil, []string{ErrCodeImplausibleDuration}},
+		{"ongoing long internship", Entity{Type: "internship", Date: mustParseDate("2022-01-01")}, nil, []string{ErrCodeImplausibleDuration}},
+		{"ongoing internship", Entity{Type: "internship", Date: mustParseDate("2024-09-01")}, nil, nil},
+		{"longer maximum", Entity{Type: "internship", Date: mustParseDate("2022-01-01")}, []Option{WithMaxDuration("internship", ValidityPeriod{Years: 5})}, nil},
+		{"check disabled", Entity{Type: "internship", Date: mustParseDate("2022-01-01")}, []Option{WithMaxDuration("internship", ValidityPeriod{})}, nil},
+		{"employment has no maximum", Entity{Type: "employment", Date: mustParseDate("2016-01-01")}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, tt.entity, append(tt.opts, now)...)
+			if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+				t.Errorf("codes = %v, want %v", codes, tt.codes)
+			}
+		})
+	}
+
+	report := CheckEntity(user, Entity{Type: "internship", Date: mustParseDate("2020-06-01"), EndDate: mustParseDate("2020-05-01")}, now)
+	report.AttachPaths(PayloadPaths{Entity: "/internships/0"})
+	if path := report.Findings[0].Path; path != "/internships/0/end_date" {
+		t.Errorf("Path = %q, want /internships/0/end_date", path)
+	}
+}
+
+// mustRuleConfig returns the default rules of a jurisdiction
+func mustRuleConfig(t *testing.T, jurisdiction string) RuleConfig {
+	t.Helper()
+	r, err := NewRuleConfig(jurisdiction, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
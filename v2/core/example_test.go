@@ -1,33 +1,35 @@
-package userdate_test
+package core_test
 
 import (
 	"fmt"
 	"log"
 	"time"
 
-	userdate "github.com/i2sac/user-entity-date-verification"
+	"github.com/i2sac/user-entity-date-verification/v2/core"
 )
 
 func ExampleNewUser() {
 	// Create a new user with validation
 	birthDate, _ := time.Parse("2006-01-02", "1990-05-15")
-	user, err := userdate.NewUser("user123", birthDate, "John Doe")
+	user, err := core.NewUser("user123", birthDate, "John Doe")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("User created: %s (Age: %d)\n", user.Name, user.GetAge())
+	// Pin the clock so the computed age does not change over time
+	now, _ := time.Parse("2006-01-02", "2025-07-18")
+	fmt.Printf("User created: %s (Age: %d)\n", user.Name, user.GetAge(core.WithFixedNow(now)))
 	// Output: User created: John Doe (Age: 35)
 }
 
 func ExampleValidateCertification() {
 	// Create a user
 	birthDate, _ := time.Parse("2006-01-02", "1990-05-15")
-	user, _ := userdate.NewUser("user123", birthDate, "John Doe")
+	user, _ := core.NewUser("user123", birthDate, "John Doe")
 
 	// Valid certification date
 	certDate, _ := time.Parse("2006-01-02", "2020-03-10")
-	err := userdate.ValidateCertification(user, certDate)
+	err := core.ValidateCertification(user, certDate)
 	if err != nil {
 		fmt.Printf("Validation failed: %v\n", err)
 	} else {
@@ -36,7 +38,7 @@ func ExampleValidateCertification() {
 
 	// Invalid certification date (before birth)
 	invalidDate, _ := time.Parse("2006-01-02", "1989-01-01")
-	err = userdate.ValidateCertification(user, invalidDate)
+	err = core.ValidateCertification(user, invalidDate)
 	if err != nil {
 		fmt.Printf("Validation failed: %v\n", err)
 	}
@@ -49,11 +51,11 @@ func ExampleValidateCertification() {
 func ExampleValidateEntityDate() {
 	// Create a user
 	birthDate, _ := time.Parse("2006-01-02", "1990-05-15")
-	user, _ := userdate.NewUser("user123", birthDate, "John Doe")
+	user, _ := core.NewUser("user123", birthDate, "John Doe")
 
 	// Validate different types of entities
 	trainingDate, _ := time.Parse("2006-01-02", "2018-09-01")
-	err := userdate.ValidateEntityDate(user, trainingDate, "training")
+	err := core.ValidateEntityDate(user, trainingDate, "training")
 	if err != nil {
 		fmt.Printf("Training validation failed: %v\n", err)
 	} else {
@@ -62,7 +64,7 @@ func ExampleValidateEntityDate() {
 
 	// Validate employment (user must be at least 14)
 	employmentDate, _ := time.Parse("2006-01-02", "2006-06-01") // User is 16
-	err = userdate.ValidateEntityDate(user, employmentDate, "employment")
+	err = core.ValidateEntityDate(user, employmentDate, "employment")
 	if err != nil {
 		fmt.Printf("Employment validation failed: %v\n", err)
 	} else {
@@ -74,9 +76,39 @@ func ExampleValidateEntityDate() {
 	// Employment date is valid
 }
 
+func ExampleWithFixedNow() {
+	birthDate, _ := time.Parse("2006-01-02", "1990-05-15")
+	user, _ := core.NewUser("user123", birthDate, "John Doe")
+
+	// Evaluate everything as if today were 2019-05-14
+	now, _ := time.Parse("2006-01-02", "2019-05-14")
+	fmt.Printf("Age: %d\n", user.GetAge(core.WithFixedNow(now)))
+
+	certDate, _ := time.Parse("2006-01-02", "2021-01-01")
+	err := core.ValidateCertification(user, certDate, core.WithFixedNow(now))
+	fmt.Println(err)
+
+	// Output:
+	// Age: 28
+	// date validation error [FUTURE_DATE]: certification date (2021-01-01) cannot be in the future
+}
+
+func ExampleNewValidator() {
+	// Active-workforce products do not expect birth dates before 1900
+	workforce := core.NewValidator(
+		core.WithMinBirthDate(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	birthDate, _ := time.Parse("2006-01-02", "1899-12-31")
+	err := workforce.ValidateBirthDate(birthDate)
+	fmt.Println(err)
+
+	// Output: date validation error [DATE_TOO_OLD]: date (1899-12-31) is before the earliest accepted date (1900-01-01)
+}
+
 func ExampleUser_GetAgeAtDate() {
 	birthDate, _ := time.Parse("2006-01-02", "1990-05-15")
-	user, _ := userdate.NewUser("user123", birthDate, "John Doe")
+	user, _ := core.NewUser("user123", birthDate, "John Doe")
 
 	// Get age at a specific date
 	specificDate, _ := time.Parse("2006-01-02", "2020-05-15")
@@ -88,15 +120,16 @@ func ExampleUser_GetAgeAtDate() {
 
 func ExampleDateValidationError() {
 	birthDate, _ := time.Parse("2006-01-02", "1990-05-15")
-	user, _ := userdate.NewUser("user123", birthDate, "John Doe")
+	user, _ := core.NewUser("user123", birthDate, "John Doe")
 
-	// Try to validate a future date
-	futureDate := time.Now().AddDate(1, 0, 0)
-	err := userdate.ValidateCertification(user, futureDate)
+	// Try to validate a future date against a pinned clock
+	now, _ := time.Parse("2006-01-02", "2025-07-18")
+	futureDate := now.AddDate(1, 0, 0)
+	err := core.ValidateCertification(user, futureDate, core.WithFixedNow(now))
 
 	if err != nil {
 		// Check if it's a DateValidationError
-		if dateErr, ok := err.(*userdate.DateValidationError); ok {
+		if dateErr, ok := err.(*core.DateValidationError); ok {
 			fmt.Printf("Error Code: %s\n", dateErr.Code)
 			fmt.Printf("Error Message: %s\n", dateErr.Message)
 		}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// daysPerYear is the average length of a Gregorian year, which converts
+// days of experience to years
+const daysPerYear = 365.2425
+
+// WithIndustries restricts ExperienceYears to the employments of the given
+// industries, see Entity.Industry. Employments without an industry are
+// then left out.
+func WithIndustries(industries ...string) Option {
+	return func(c *config) {
+		filter := maps.Clone(c.industries)
+		if filter == nil {
+			filter = make(map[string]bool, len(industries))
+		}
+		for _, industry := range industries {
+			filter[industry] = true
+		}
+		c.industries = filter
+	}
+}
+
+// ExperienceYears returns the years of experience of the user of a profile
+// as of asOf: the days their employment entities cover up to asOf, counting
+// the days of concurrent employments once, divided by the average length
+// of a year. Ongoing employments run until asOf, or the death of the user.
+// Employments that fail validation with opts as of asOf are left out, so
+// experience before the working age or after death never counts. It
+// returns the error of an invalid user.
+func ExperienceYears(profile Profile, asOf time.Time, opts ...Option) (float64, error) {
+	cfg := newConfig(opts)
+	cfg.now = func() time.Time { return asOf }
+	user := profile.User
+	if err := cfg.validateUser(user); err != nil {
+		return 0, err
+	}
+	until := cfg.truncate(asOf)
+	if !user.DeathDate.IsZero() && user.DeathDate.Before(until) {
+		until = cfg.truncate(user.DeathDate)
+	}
+	var spans []dateSpan
+	for _, entity := range profile.Entities {
+		if entity.Type != employmentType || (cfg.industries != nil && !cfg.industries[entity.Industry]) {
+			continue
+		}
+		report := cfg.newReport(user, entity.Date, entity.Type)
+		cfg.evaluateEntity(context.Background(), user, entity, report)
+		valid := report.Valid()
+		report.Release()
+		if !valid {
+			continue
+		}
+		span := dateSpan{start: cfg.truncate(entity.Date), end: until}
+		if !entity.EndDate.IsZero() && entity.EndDate.Before(until) {
+			span.end = cfg.truncate(entity.EndDate)
+		}
+		if !span.end.Before(span.start) {
+			spans = append(spans, span)
+		}
+	}
+	return float64(mergedDays(spans)) / daysPerYear, nil
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExperienceYears(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	asOf := mustParseDate("2025-01-01")
+	jobs := []Entity{
+		{Type: "employment", Industry: "healthcare", Date: mustParseDate("2010-01-01"), EndDate: mustParseDate("2014-12-31")},
+		{Type: "employment", Industry: "retail", Date: mustParseDate("2013-01-01"), EndDate: mustParseDate("2016-12-31")},
+		{Type: "employment", Industry: "healthcare", Date: mustParseDate("2020-01-01")},
+	}
+
+	tests := []struct {
+		name     string
+		profile  Profile
+		opts     []Option
+		want     float64
+		wantCode string
+	}{
+		{"overlaps count once", Profile{User: user, Entities: jobs}, nil, 12, ""},
+		{"industry filter", Profile{User: user, Entities: jobs}, []Option{WithIndustries("healthcare")}, 10, ""},
+		{"several industries", Profile{User: user, Entities: jobs}, []Option{WithIndustries("healthcare", "retail")}, 12, ""},
+		{"other entity types", Profile{User: user, Entities: []Entity{{Type: "internship", Date: mustParseDate("2009-01-01"), EndDate: mustParseDate("2009-12-31")}}}, nil, 0, ""},
+		{"employment before the working age", Profile{User: user, Entities: []Entity{{Type: "employment", Date: mustParseDate("2000-01-01"), EndDate: mustParseDate("2009-12-31")}}}, nil, 0, ""},
+		{"employment after asOf", Profile{User: user, Entities: []Entity{{Type: "employment", Date: mustParseDate("2025-06-01")}}}, nil, 0, ""},
+		{"ends at death", Profile{User: &User{ID: "late", BirthDate: mustParseDate("1950-01-01"), DeathDate: mustParseDate("2020-01-01")}, Entities: []Entity{{Type: "employment", Date: mustParseDate("2010-01-01")}}}, nil, 10, ""},
+		{"invalid user", Profile{Entities: jobs}, nil, 0, ErrCodeInvalidUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExperienceYears(tt.profile, asOf, tt.opts...)
+			if code := codeOf(err); code != tt.wantCode {
+				t.Fatalf("ExperienceYears() code = %q, want %q: %v", code, tt.wantCode, err)
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ExperienceYears() = %.3f, want %.0f", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Verdict is the outcome of a step of an explanation
+type Verdict string
+
+// Verdicts
+const (
+	VerdictPass          Verdict = "pass"
+	VerdictFail          Verdict = "fail"
+	VerdictWarn          Verdict = "warn"           // Passed with a warning
+	VerdictIndeterminate Verdict = "indeterminate"  // Fails for part of an imprecise date's range only
+	VerdictNotApplicable Verdict = "not_applicable" // The rule does not apply to the entity
+	VerdictSkipped       Verdict = "skipped"        // Not evaluated, after an earlier error
+)
+
+// Step is the evaluation of one check. Threshold is what the rule compares
+// against, such as "at least 16 years", and Value the computed value, such
+// as "14 years".
+type Step struct {
+	Rule      string                 `json:"rule"`
+	Verdict   Verdict                `json:"verdict"`
+	Threshold string                 `json:"threshold,omitempty"`
+	Value     string                 `json:"value,omitempty"`
+	Findings  []*DateValidationError `json:"findings,omitempty"`
+}
+
+// Explanation is the rule-by-rule evaluation of an entity, as returned by
+// Explain. Steps start with the status and input checks, then list the
+// rules in the order they ran.
+type Explanation struct {
+	Report *Report   `json:"report"`
+	Now    time.Time `json:"now"`
+	Age    int       `json:"age"` // Age of the user on the entity date, -1 when the input is invalid
+	Steps  []Step    `json:"steps"`
+}
+
+// Explain validates an entity like CheckEntity and records how each rule
+// decided, with its threshold and the computed values. It answers "why was
+// this rejected?" for support tools.
+func Explain(user *User, entity Entity, opts ...Option) *Explanation {
+	cfg := newConfig(opts)
+	return cfg.explainEntity(context.Background(), user, entity)
+}
+
+// Explain is like the package-level Explain using the validator's settings
+func (v *Validator) Explain(user *User, entity Entity) *Explanation {
+	return v.config().explainEntity(context.Background(), user, entity)
+}
+
+// explainEntity checks an entity while recording an explanation
+func (c *config) explainEntity(ctx context.Context, user *User, entity Entity) *Explanation {
+	e := &Explanation{Now: c.truncate(c.now()), Age: -1}
+	cfg := *c
+	cfg.explain = e
+	cfg.poolReports = false
+	e.Report = cfg.checkEntity(ctx, user, entity)
+
+	if !e.ran("input") {
+		e.Steps = append(e.Steps, Step{Rule: "input", Verdict: VerdictSkipped})
+	}
+	_, rules := c.rules()
+	for _, id := range rules {
+		if !e.ran(ruleInfos[id].name) {
+			e.Steps = append(e.Steps, Step{Rule: ruleInfos[id].name, Verdict: VerdictSkipped})
+		}
+	}
+	return e
+}
+
+// Verdict returns the overall outcome, the verdict of the report
+func (e *Explanation) Verdict() Verdict {
+	if e == nil {
+		return ""
+	}
+	return e.Report.Verdict
+}
+
+// hasCode reports whether one of findings has the code
+func hasCode(findings []*DateValidationError, code string) bool {
+	for _, f := range findings {
+		if f.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// ran reports whether a step of the rule was recorded
+func (e *Explanation) ran(rule string) bool {
+	for _, s := range e.Steps {
+		if s.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// check records a status or input check. It does nothing on nil
+// explanations, so validations only pay for a nil check.
+func (e *Explanation) check(rule, threshold string, err error) {
+	if e == nil {
+		return
+	}
+	step := Step{Rule: rule, Verdict: VerdictPass, Threshold: threshold}
+	if dateErr, ok := err.(*DateValidationError); ok {
+		step.Verdict = VerdictFail
+		if dateErr.Severity == SeverityWarning {
+			step.Verdict = VerdictWarn
+		}
+		step.Findings = []*DateValidationError{dateErr}
+	}
+	e.Steps = append(e.Steps, step)
+}
+
+// input records the input checks and the age of the user
+func (e *Explanation) input(c *config, in *ruleInput, err error) {
+	if e == nil {
+		return
+	}
+	e.check("input", "valid user, birth and entity dates", err)
+	if err == nil {
+		e.Age = c.ageAt(in.birthDate, in.entityDate)
+	}
+}
+
+// rule records the evaluation of a rule whose findings start at index from
+// of report
+func (e *Explanation) rule(c *config, id ruleID, in *ruleInput, report *Report, from int, err error) {
+	if e == nil {
+		return
+	}
+	threshold, value, applies := c.describeRule(id, in)
+	step := Step{Rule: ruleInfos[id].name, Threshold: threshold, Value: value}
+	if report != nil {
+		step.Findings = report.Findings[from:len(report.Findings):len(report.Findings)]
+	}
+	switch {
+	case err != nil:
+		step.Verdict = VerdictFail
+	case hasCode(step.Findings, ErrCodeImpreciseDate):
+		step.Verdict = VerdictIndeterminate
+	case len(step.Findings) > 0:
+		step.Verdict = VerdictWarn
+	case !applies:
+		step.Verdict = VerdictNotApplicable
+	default:
+		step.Verdict = VerdictPass
+	}
+	e.Steps = append(e.Steps, step)
+}
+
+// describeRule returns the threshold a rule checks against, the value it
+// computes from the input, and whether the rule applies to the entity
+func (c *config) describeRule(id ruleID, in *ruleInput) (threshold, value string, applies bool) {
+	const day = "2006-01-02"
+	date := in.entityDate.Format(day)
+	if in.imprecise() {
+		date = in.earliest.Format(day) + ".." + in.latest.Format(day)
+	}
+	switch id {
+	case ruleBeforeBirth:
+		if window, ok := c.prenatal[in.entity.Type]; ok {
+			return fmt.Sprintf("on or after %s, or within %s before", in.birthDate.Format(day), window), date, true
+		}
+		return "on or after " + in.birthDate.Format(day), date, true
+	case ruleSameDayBirth:
+		if c.allowsSameDayBirth(in.entity.Type) {
+			return "birth date allowed", date, false
+		}
+		return "not on " + in.birthDate.Format(day), date, true
+	case ruleFutureDate:
+		return "on or before " + in.now.Format(day), date, true
+	case ruleMinimumAge:
+		minAge, ok := c.minimumAge(in.entity)
+		if !ok {
+			return "no minimum age", "", false
+		}
+		return fmt.Sprintf("at least %d years", minAge), c.explainAge(in), !in.entityDate.Before(in.birthDate)
+	case ruleMaximumAge:
+		maxAge, ok := c.maximumAges[in.entity.Type]
+		if !ok {
+			return "no maximum age", "", false
+		}
+		return fmt.Sprintf("at most %d years", maxAge), c.explainAge(in), !in.entityDate.Before(in.birthDate)
+	case ruleHistory:
+		return "on or after " + c.historyCutoff(in.now).Format(day), date, true
+	case ruleRenewal:
+		if in.entity.RenewedAt.IsZero() {
+			return "not renewed", "", false
+		}
+		return fmt.Sprintf("between %s and %s", date, in.now.Format(day)), c.truncate(in.entity.RenewedAt).Format(day), true
+	case ruleDuration:
+		maxDuration, ok := c.maxDurations[in.entity.Type]
+		end := "ongoing"
+		if !in.entity.EndDate.IsZero() {
+			end = c.truncate(in.entity.EndDate).Format(day)
+		}
+		if !ok || maxDuration == (ValidityPeriod{}) {
+			if in.entity.EndDate.IsZero() {
+				return "no end date or maximum duration", "", false
+			}
+			return "on or after " + date, end, true
+		}
+		return fmt.Sprintf("on or after %s, at most %s", date, maxDuration), end, true
+	case ruleEntry:
+		if in.entity.EntryDate.IsZero() {
+			return "no entry date", "", false
+		}
+		entry := c.truncate(in.entity.EntryDate).Format(day)
+		if in.entity.EndDate.IsZero() {
+			return "on or after " + date, entry, true
+		}
+		return fmt.Sprintf("between %s and %s", date, c.truncate(in.entity.EndDate).Format(day)), entry, true
+	case ruleRevocation:
+		if c.revocation == nil || in.entity.ID == "" {
+			return "no revocation checker or entity ID", "", false
+		}
+		return "not revoked by " + in.entity.Issuer, in.entity.ID, true
+	case ruleDayMonthSwap:
+		if !c.swapDetection {
+			return "swap detection off", "", false
+		}
+		swapped, ok := swapDayMonth(in.entityDate)
+		if !ok {
+			return "day and month not swappable", date, false
+		}
+		return "valid, or " + swapped.Format(day) + " invalid", date, true
+	case ruleDocumentValidity:
+		period, ok := c.documentValidity(in.entity, in.birthDate)
+		if !documentTypes[in.entity.Type] || in.entity.EndDate.IsZero() || !ok {
+			return "no expiry or validity period", "", false
+		}
+		from := in.entity.validFrom()
+		return "on or before " + c.truncate(period.expiry(from)).Format(day), c.truncate(in.entity.EndDate).Format(day), true
+	case rulePolicy:
+		end := "ongoing"
+		if !in.entity.EndDate.IsZero() {
+			end = c.truncate(in.entity.EndDate).Format(day)
+		}
+		endAge, ok := c.endAges[in.entity.Type]
+		switch {
+		case ok:
+			return "ends by " + c.dateAtAge(in.birthDate, endAge).Format(day), end, true
+		case insuranceTypes[in.entity.Type] && !in.deathDate.IsZero():
+			return "on or before " + in.deathDate.Format(day), end, true
+		}
+		return "no end age or death date", "", false
+	case ruleJuvenile:
+		if !recordTypes[in.entity.Type] {
+			return "not a record", "", false
+		}
+		juvenile := "unmarked"
+		if in.entity.Juvenile {
+			juvenile = "marked juvenile"
+		}
+		return fmt.Sprintf("juvenile under %d", c.juvenileAge), fmt.Sprintf("%s at %d", juvenile, c.ageAt(in.birthDate, in.entityDate)), true
+	case ruleAgeCategory:
+		if in.entity.Category == "" {
+			return "no age category", "", false
+		}
+		cutoff := c.cutoffDate(in.entity.Type, in.entityDate)
+		return in.entity.Category + " on " + cutoff.Format(day), fmt.Sprintf("age %d", c.ageAt(in.birthDate, cutoff)), true
+	case ruleConsent:
+		if !c.consentTypes[in.entity.Type] {
+			return "not a consent", "", false
+		}
+		if in.entity.Guardian || c.guardianConsented(in.entityDate) {
+			return "guardian consented", date, false
+		}
+		return fmt.Sprintf("age %d or a guardian", c.ageOfMajority()), fmt.Sprintf("age %d", c.ageAt(in.birthDate, in.entityDate)), true
+	case ruleExpiry:
+		if documentTypes[in.entity.Type] && !in.entity.EndDate.IsZero() {
+			return "after " + in.now.Format(day), "expires " + c.truncate(in.entity.EndDate).Format(day), true
+		}
+		period, ok := c.documentValidity(in.entity, in.birthDate)
+		if !ok {
+			if in.entity.Status == StatusExpired {
+				return "status not expired", string(in.entity.Status), true
+			}
+			return "no validity period", "", false
+		}
+		from := in.entity.validFrom()
+		return "valid for " + period.String(), "expires " + c.truncate(period.expiry(from)).Format(day), true
+	}
+	return "", "", true
+}
+
+// explainAge describes the age of the user on the age cutoff of an entity,
+// a range of ages for imprecise dates
+func (c *config) explainAge(in *ruleInput) string {
+	cutoff := func(date time.Time) int {
+		return c.ageAt(in.birthDate, c.cutoffDate(in.entity.Type, date))
+	}
+	age := fmt.Sprintf("%d years", cutoff(in.entityDate))
+	if in.imprecise() {
+		age = fmt.Sprintf("%d-%d years", cutoff(in.earliest), cutoff(in.latest))
+	}
+	if c.ageCutoff(in.entity.Type) != CutoffEventDate {
+		age += " on " + c.cutoffDate(in.entity.Type, in.entityDate).Format("2006-01-02")
+	}
+	return age
+}
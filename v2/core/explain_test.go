@@ -0,0 +1,129 @@
+package core
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+
+	tests := []struct {
+		name     string
+		entity   Entity
+		opts     []Option
+		valid    bool
+		age      int
+		verdicts map[string]Verdict
+	}{
+		{
+			name:   "too young for a license",
+			entity: Entity{Type: "license", Date: mustParseDate("2005-01-01")},
+			age:    14,
+			verdicts: map[string]Verdict{
+				"status":         VerdictPass,
+				"input":          VerdictPass,
+				"before_birth":   VerdictPass,
+				"same_day_birth": VerdictNotApplicable,
+				"minimum_age":    VerdictFail,
+				"history":        VerdictSkipped,
+				"expiry":         VerdictSkipped,
+			},
+		},
+		{
+			name:   "without short-circuit",
+			entity: Entity{Type: "license", Date: mustParseDate("2005-01-01")},
+			opts:   []Option{WithShortCircuit(false)},
+			age:    14,
+			verdicts: map[string]Verdict{
+				"minimum_age": VerdictFail,
+				"history":     VerdictPass,
+				"renewal":     VerdictNotApplicable,
+			},
+		},
+		{
+			name:   "expired",
+			entity: Entity{Type: "cpr_certification", Date: mustParseDate("2018-01-01")},
+			valid:  true,
+			age:    27,
+			verdicts: map[string]Verdict{
+				"minimum_age": VerdictNotApplicable,
+				"expiry":      VerdictWarn,
+			},
+		},
+		{
+			name:   "invalid input",
+			entity: Entity{Type: "license"},
+			age:    -1,
+			verdicts: map[string]Verdict{
+				"status":       VerdictPass,
+				"input":        VerdictFail,
+				"before_birth": VerdictSkipped,
+			},
+		},
+		{
+			name:   "unknown status",
+			entity: Entity{Type: "license", Date: mustParseDate("2010-01-01"), Status: "lost"},
+			age:    -1,
+			verdicts: map[string]Verdict{
+				"status":       VerdictFail,
+				"input":        VerdictSkipped,
+				"before_birth": VerdictSkipped,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Explain(user, tt.entity, append([]Option{now}, tt.opts...)...)
+			if got := e.Report.Valid(); got != tt.valid {
+				t.Errorf("Report.Valid() = %v, want %v", got, tt.valid)
+			}
+			if e.Age != tt.age {
+				t.Errorf("Age = %d, want %d", e.Age, tt.age)
+			}
+			if len(e.Steps) != 2+len(ruleInfos) {
+				t.Errorf("got %d steps, want %d", len(e.Steps), 2+len(ruleInfos))
+			}
+			verdicts := make(map[string]Verdict, len(e.Steps))
+			for _, step := range e.Steps {
+				verdicts[step.Rule] = step.Verdict
+			}
+			for rule, want := range tt.verdicts {
+				if got := verdicts[rule]; got != want {
+					t.Errorf("verdict of %s = %q, want %q", rule, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExplainThresholds(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	v := NewValidator(WithFixedNow(mustParseDate("2020-06-15")))
+	e := v.Explain(user, Entity{Type: "license", Date: mustParseDate("2005-01-01")})
+
+	for _, step := range e.Steps {
+		if step.Rule != "minimum_age" {
+			continue
+		}
+		if step.Threshold != "at least 16 years" || step.Value != "14 years" {
+			t.Errorf("minimum_age step = %q, %q, want %q, %q", step.Threshold, step.Value, "at least 16 years", "14 years")
+		}
+		if len(step.Findings) != 1 || step.Findings[0].Code != ErrCodeUnrealisticAge {
+			t.Errorf("minimum_age findings = %v, want one %s", step.Findings, ErrCodeUnrealisticAge)
+		}
+		return
+	}
+	t.Error("no minimum_age step")
+}
+
+func TestExplainDoesNotChangeValidation(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	entity := Entity{Type: "license", Date: mustParseDate("2005-01-01")}
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+
+	e := Explain(user, entity, now)
+	report := CheckEntity(user, entity, now)
+	if len(e.Report.Findings) != len(report.Findings) {
+		t.Errorf("Explain found %d findings, CheckEntity %d", len(e.Report.Findings), len(report.Findings))
+	}
+}
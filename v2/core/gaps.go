@@ -0,0 +1,134 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// GapExplanation accounts for a period of a profile without employment or
+// education, such as parental leave, illness or travel
+type GapExplanation struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Reason string    `json:"reason"` // Such as parental_leave, illness or travel
+}
+
+// defaultMaxGap is the longest gap between the employments and studies of
+// a profile that needs no explanation
+var defaultMaxGap = ValidityPeriod{Months: 6}
+
+// WithMaxGap sets the longest gap between the employments and studies of a
+// profile that needs no explanation, 6 months by default. A zero period
+// turns gap detection off.
+func WithMaxGap(period ValidityPeriod) Option {
+	return func(c *config) {
+		c.maxGap = period
+	}
+}
+
+// timelineTypes are the entity types of a career timeline, which may be
+// ongoing, and educationTypes those that only count with an end date, as
+// their date may be the start of a stage that lasts for years
+var (
+	timelineTypes  = map[string]bool{employmentType: true, "internship": true, "apprenticeship": true}
+	educationTypes = map[string]bool{"education": true, "secondary_education": true, "tertiary_education": true}
+)
+
+// indexedSpan is the span of the entity of a profile at index
+type indexedSpan struct {
+	dateSpan
+	index int
+}
+
+// careerGaps finds the gaps longer than the maximum gap between the
+// employments and studies of a profile, until the last of them starts; the
+// time after the last one ends is not a gap. A gap is reported on the
+// entity that ends it: as a CAREER_GAP warning, or as a note when the
+// explanations of the profile leave no stretch of it longer than the
+// maximum gap.
+func (c *config) careerGaps(profile Profile) map[int]*DateValidationError {
+	if c.maxGap == (ValidityPeriod{}) {
+		return nil
+	}
+	now := c.truncate(c.now())
+	var spans []indexedSpan
+	for i, entity := range profile.Entities {
+		ongoing := timelineTypes[entity.Type]
+		if !ongoing && (!educationTypes[entity.Type] || entity.EndDate.IsZero()) {
+			continue
+		}
+		if entity.Date.IsZero() || entity.Status == StatusRevoked {
+			continue
+		}
+		span := indexedSpan{dateSpan{start: c.truncate(entity.Date), end: now}, i}
+		if !entity.EndDate.IsZero() {
+			span.end = c.truncate(entity.EndDate)
+		}
+		if !span.end.Before(span.start) {
+			spans = append(spans, span)
+		}
+	}
+	slices.SortStableFunc(spans, func(a, b indexedSpan) int { return a.start.Compare(b.start) })
+
+	if len(spans) == 0 {
+		return nil
+	}
+
+	gaps := make(map[int]*DateValidationError)
+	covered := spans[0].end // Last day covered so far
+	for _, next := range spans[1:] {
+		if c.longGap(covered, next.start) {
+			entity := profile.Entities[next.index]
+			since := covered.AddDate(0, 0, 1)
+			var gap *DateValidationError
+			if reasons, ok := c.explainGap(covered, next.start, profile.GapExplanations); ok {
+				gap = newNote(msgGapExplained, "type", entityTypeArg(entity.Type), "date", next.start, "since", since, "reasons", strings.Join(reasons, ", "))
+			} else {
+				gap = newWarning(msgGapUnexplained, "type", entityTypeArg(entity.Type), "date", next.start, "since", since)
+			}
+			gap.field = fieldEntityDate
+			gap.Confidence = excessConfidence(days(since, next.start), days(since, c.maxGap.expiry(since)))
+			gaps[next.index] = gap
+		}
+		covered = latest(covered, next.end)
+	}
+	return gaps
+}
+
+// longGap reports whether the days strictly between the covered days last
+// and next last longer than the maximum gap
+func (c *config) longGap(last, next time.Time) bool {
+	return next.After(c.maxGap.expiry(last.AddDate(0, 0, 1)))
+}
+
+// explainGap reports whether explanations leave no stretch of the gap
+// between the covered days last and next longer than the maximum gap, with
+// the reasons of the explanations that overlap it
+func (c *config) explainGap(last, next time.Time, explanations []GapExplanation) ([]string, bool) {
+	var spans []GapExplanation
+	for _, e := range explanations {
+		from, to := c.truncate(e.From), c.truncate(e.To)
+		if to.Before(from) || !from.Before(next) || !to.After(last) {
+			continue
+		}
+		spans = append(spans, GapExplanation{From: from, To: to, Reason: e.Reason})
+	}
+	slices.SortFunc(spans, func(a, b GapExplanation) int { return a.From.Compare(b.From) })
+
+	var reasons []string
+	explained := true
+	for _, e := range spans {
+		if c.longGap(last, e.From) {
+			explained = false
+		}
+		last = latest(last, e.To)
+		if e.Reason != "" && !slices.Contains(reasons, e.Reason) {
+			reasons = append(reasons, e.Reason)
+		}
+	}
+	if c.longGap(last, next) {
+		explained = false
+	}
+	return reasons, explained && len(spans) > 0
+}
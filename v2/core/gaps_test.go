@@ -0,0 +1,76 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCareerGaps(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	leave := GapExplanation{From: mustParseDate("2019-01-01"), To: mustParseDate("2019-12-31"), Reason: "parental_leave"}
+
+	tests := []struct {
+		name         string
+		entities     []Entity
+		explanations []GapExplanation
+		opts         []Option
+		wantCodes    [][]string
+		wantSeverity Severity
+	}{
+		{"no gap", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2018-12-31")},
+			{Type: "employment", Date: mustParseDate("2019-05-01")},
+		}, nil, nil, [][]string{nil, nil}, 0},
+		{"unexplained gap", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2018-12-31")},
+			{Type: "employment", Date: mustParseDate("2020-01-01")},
+		}, nil, nil, [][]string{nil, {ErrCodeCareerGap}}, SeverityWarning},
+		{"explained gap", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2018-12-31")},
+			{Type: "employment", Date: mustParseDate("2020-01-01")},
+		}, []GapExplanation{leave}, nil, [][]string{nil, {ErrCodeCareerGap}}, SeverityInfo},
+		{"partly explained gap", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2018-12-31")},
+			{Type: "employment", Date: mustParseDate("2021-01-01")},
+		}, []GapExplanation{leave}, nil, [][]string{nil, {ErrCodeCareerGap}}, SeverityWarning},
+		{"studies close the gap", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2016-12-31")},
+			{Type: "education", Date: mustParseDate("2017-01-01"), EndDate: mustParseDate("2019-06-30")},
+			{Type: "employment", Date: mustParseDate("2019-09-01")},
+		}, nil, nil, [][]string{nil, nil, nil}, 0},
+		{"studies without an end date", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2016-12-31")},
+			{Type: "education", Date: mustParseDate("2017-01-01")},
+			{Type: "employment", Date: mustParseDate("2019-09-01")},
+		}, nil, nil, [][]string{nil, nil, {ErrCodeCareerGap}}, SeverityWarning},
+		{"after the last employment", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2018-12-31")},
+		}, nil, nil, [][]string{nil}, 0},
+		{"detection off", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2018-12-31")},
+			{Type: "employment", Date: mustParseDate("2020-01-01")},
+		}, nil, []Option{WithMaxGap(ValidityPeriod{})}, [][]string{nil, nil}, 0},
+		{"longer maximum gap", []Entity{
+			{Type: "employment", Date: mustParseDate("2015-01-01"), EndDate: mustParseDate("2018-12-31")},
+			{Type: "employment", Date: mustParseDate("2020-01-01")},
+		}, nil, []Option{WithMaxGap(ValidityPeriod{Years: 1})}, [][]string{nil, nil}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := Profile{User: user, Entities: tt.entities, GapExplanations: tt.explanations}
+			result := ValidateProfile(profile, append(tt.opts, now)...)
+			for i, report := range result.Reports {
+				if got := findingCodes(report); !slices.Equal(got, tt.wantCodes[i]) {
+					t.Errorf("entity %d findings = %v, want %v", i, got, tt.wantCodes[i])
+				}
+				for _, f := range report.Findings {
+					if f.Code == ErrCodeCareerGap && f.Severity != tt.wantSeverity {
+						t.Errorf("gap severity = %v, want %v", f.Severity, tt.wantSeverity)
+					}
+				}
+			}
+		})
+	}
+}
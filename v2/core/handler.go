@@ -0,0 +1,94 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// MaxRequestBytes is the largest request body NewHandler accepts
+const MaxRequestBytes = 10 << 20
+
+// CheckRequest is the body of the check and explain endpoints of NewHandler
+type CheckRequest struct {
+	User   *User  `json:"user"`
+	Entity Entity `json:"entity"`
+}
+
+// BatchRequest is the body of the batch endpoint of NewHandler
+type BatchRequest struct {
+	Items []Item `json:"items"`
+}
+
+// NewHandler returns an HTTP handler serving the validation API:
+//
+//	POST /v1/check    CheckRequest, answers a Report
+//	POST /v1/explain  CheckRequest, answers an Explanation
+//	POST /v1/batch    BatchRequest, answers a BatchResult
+//
+// Check answers with the HTTPStatusForError status of the report error, so
+// clients can branch on 200, 400 and 422 alone; explain and batch answer 200
+// whatever the outcome. Bodies that are not valid JSON get a 400 with an
+// {"error": "..."} body. The reference time of a request can be pinned with
+// ContextWithNow on its context, by middleware for example.
+func NewHandler(opts ...Option) http.Handler {
+	cfg := newConfig(opts)
+	cfg.poolReports = false
+	cfg.setConfigHash()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/check", func(w http.ResponseWriter, r *http.Request) {
+		var req CheckRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		c := cfg.withContext(r.Context())
+		report := c.checkEntity(r.Context(), req.User, req.Entity)
+		writeJSON(w, HTTPStatusForError(report.Err()), report)
+	})
+	mux.HandleFunc("POST /v1/explain", func(w http.ResponseWriter, r *http.Request) {
+		var req CheckRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		c := cfg.withContext(r.Context())
+		writeJSON(w, http.StatusOK, c.explainEntity(r.Context(), req.User, req.Entity))
+	})
+	mux.HandleFunc("POST /v1/batch", func(w http.ResponseWriter, r *http.Request) {
+		var req BatchRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		c := cfg.withContext(r.Context())
+		writeJSON(w, http.StatusOK, c.validateBatch(req.Items))
+	})
+	return mux
+}
+
+// decodeRequest decodes the JSON body of r into v, answering 400 or 413
+// and returning false when it cannot
+func decodeRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestBytes))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err == nil && dec.More() {
+		err = errors.New("unexpected data after the JSON body")
+	}
+	if err == nil {
+		return true
+	}
+	status := http.StatusBadRequest
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf("invalid request body: %v", err)})
+	return false
+}
+
+// writeJSON answers with status and v as JSON
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
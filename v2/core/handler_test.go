@@ -0,0 +1,85 @@
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(WithFixedNow(mustParseDate("2020-06-15")))
+	const user = `"user": {"id": "user123", "birth_date": "1990-05-15T00:00:00Z"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{"valid", "POST", "/v1/check",
+			`{` + user + `, "entity": {"type": "license", "date": "2010-01-01T00:00:00Z"}}`,
+			http.StatusOK, `"findings":null`},
+		{"too young", "POST", "/v1/check",
+			`{` + user + `, "entity": {"type": "license", "date": "2005-01-01T00:00:00Z"}}`,
+			http.StatusUnprocessableEntity, `"code":"UNREALISTIC_AGE"`},
+		{"no user", "POST", "/v1/check",
+			`{"entity": {"type": "license", "date": "2005-01-01T00:00:00Z"}}`,
+			http.StatusBadRequest, `"code":"INVALID_USER"`},
+		{"explain", "POST", "/v1/explain",
+			`{` + user + `, "entity": {"type": "license", "date": "2005-01-01T00:00:00Z"}}`,
+			http.StatusOK, `"threshold":"at least 16 years"`},
+		{"batch", "POST", "/v1/batch",
+			`{"items": [{` + user + `, "entity_date": "2030-01-01T00:00:00Z", "entity_type": "training"}]}`,
+			http.StatusOK, `"FUTURE_DATE":1`},
+		{"invalid JSON", "POST", "/v1/check", `{"user":`, http.StatusBadRequest, `"error":"invalid request body`},
+		{"unknown field", "POST", "/v1/check", `{"usr": {}}`, http.StatusBadRequest, `unknown field`},
+		{"trailing data", "POST", "/v1/check", `{} {}`, http.StatusBadRequest, `unexpected data`},
+		{"wrong method", "GET", "/v1/check", ``, http.StatusMethodNotAllowed, ``},
+		{"unknown path", "POST", "/v1/nothing", `{}`, http.StatusNotFound, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("body %s does not contain %s", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerContextNow(t *testing.T) {
+	h := NewHandler()
+	body := `{"user": {"id": "u", "birth_date": "1990-05-15T00:00:00Z"}, "entity": {"type": "training", "date": "2030-01-01T00:00:00Z"}}`
+	req := httptest.NewRequest("POST", "/v1/check", strings.NewReader(body))
+	req = req.WithContext(ContextWithNow(req.Context(), mustParseDate("2031-01-01")))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 with the context time (body: %s)", rec.Code, rec.Body.String())
+	}
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if !report.CheckedAt.Equal(mustParseDate("2031-01-01")) {
+		t.Errorf("CheckedAt = %v, want the context time", report.CheckedAt)
+	}
+}
+
+func TestHandlerBodyLimit(t *testing.T) {
+	body := `{"items": [` + strings.Repeat(`{},`, MaxRequestBytes/3) + `{}]}`
+	rec := httptest.NewRecorder()
+	NewHandler().ServeHTTP(rec, httptest.NewRequest("POST", "/v1/batch", strings.NewReader(body)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+}
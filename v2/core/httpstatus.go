@@ -0,0 +1,102 @@
+package core
+
+import (
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// defaultHTTPStatuses maps error codes to the HTTP status an API should
+// answer with. Bad input is 400, valid input failing a rule is 422, and
+// failures of the service itself are 5xx.
+var defaultHTTPStatuses = map[string]int{
+	ErrCodeInvalidDate:          http.StatusBadRequest,
+	ErrCodeInvalidUser:          http.StatusBadRequest,
+	ErrCodeInvalidStatus:        http.StatusBadRequest,
+	ErrCodeBeforeBirth:          http.StatusUnprocessableEntity,
+	ErrCodeFutureDate:           http.StatusUnprocessableEntity,
+	ErrCodeUnrealisticAge:       http.StatusUnprocessableEntity,
+	ErrCodeDateTooOld:           http.StatusUnprocessableEntity,
+	ErrCodePrenatal:             http.StatusUnprocessableEntity,
+	ErrCodeExpired:              http.StatusUnprocessableEntity,
+	ErrCodeRevoked:              http.StatusUnprocessableEntity,
+	ErrCodeOutsideSigningWindow: http.StatusUnprocessableEntity,
+	ErrCodeSwappedDate:          http.StatusUnprocessableEntity,
+	ErrCodePlaceholderDate:      http.StatusBadRequest,
+	ErrCodeTwoDigitYear:         http.StatusBadRequest,
+	ErrCodeImpreciseDate:        http.StatusUnprocessableEntity,
+	ErrCodeRevocationUnknown:    http.StatusServiceUnavailable,
+	ErrCodeAuditFailed:          http.StatusInternalServerError,
+	ErrCodeReviewFailed:         http.StatusInternalServerError,
+	ErrCodeJurisdictionUnknown:  http.StatusServiceUnavailable,
+	ErrCodeImplausibleDuration:  http.StatusUnprocessableEntity,
+	ErrCodeOutsideValidity:      http.StatusUnprocessableEntity,
+	ErrCodeDocumentValidity:     http.StatusUnprocessableEntity,
+	ErrCodeRenewalChain:         http.StatusUnprocessableEntity,
+	ErrCodeAfterDeath:           http.StatusUnprocessableEntity,
+	ErrCodeJuvenileRecord:       http.StatusUnprocessableEntity,
+	ErrCodeAgeCategory:          http.StatusUnprocessableEntity,
+	ErrCodeImplausibleTenure:    http.StatusUnprocessableEntity,
+	ErrCodeCareerGap:            http.StatusUnprocessableEntity,
+	ErrCodeWebhookFailed:        http.StatusInternalServerError,
+	ErrCodeKnownBad:             http.StatusUnprocessableEntity,
+	ErrCodeRulePanic:            http.StatusInternalServerError,
+	ErrCodeMinorConsent:         http.StatusUnprocessableEntity,
+	ErrCodeBeforeSignup:         http.StatusUnprocessableEntity,
+}
+
+// httpStatuses holds the statuses registered with RegisterHTTPStatus
+var httpStatuses struct {
+	mu        sync.RWMutex
+	overrides map[string]int
+}
+
+// HTTPStatusFor returns the HTTP status for an error code. Codes registered
+// with RegisterHTTPStatus take precedence over the default table; unknown
+// codes map to 500 Internal Server Error.
+func HTTPStatusFor(code string) int {
+	httpStatuses.mu.RLock()
+	status, ok := httpStatuses.overrides[code]
+	httpStatuses.mu.RUnlock()
+	if ok {
+		return status
+	}
+	if status, ok := defaultHTTPStatuses[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrorCodes returns the codes of the findings of the package, sorted
+func ErrorCodes() []string {
+	return slices.Sorted(maps.Keys(defaultHTTPStatuses))
+}
+
+// HTTPStatusForError returns the HTTP status for a validation error: 200 OK
+// for nil, the status of the code for a DateValidationError, and 500 for
+// any other error
+func HTTPStatusForError(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var dateErr *DateValidationError
+	if !errors.As(err, &dateErr) {
+		return http.StatusInternalServerError
+	}
+	return HTTPStatusFor(dateErr.Code)
+}
+
+// RegisterHTTPStatus makes HTTPStatusFor return status for code, replacing
+// the default for built-in codes. It is meant to be called during program
+// initialization and is safe for concurrent use.
+func RegisterHTTPStatus(code string, status int) {
+	httpStatuses.mu.Lock()
+	defer httpStatuses.mu.Unlock()
+
+	if httpStatuses.overrides == nil {
+		httpStatuses.overrides = make(map[string]int)
+	}
+	httpStatuses.overrides[code] = status
+}
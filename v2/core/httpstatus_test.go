@@ -0,0 +1,106 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHTTPStatusFor(t *testing.T) {
+	tests := []struct {
+		code string
+		want int
+	}{
+		{ErrCodeFutureDate, http.StatusUnprocessableEntity},
+		{ErrCodeBeforeBirth, http.StatusUnprocessableEntity},
+		{ErrCodeInvalidUser, http.StatusBadRequest},
+		{ErrCodeInvalidDate, http.StatusBadRequest},
+		{ErrCodeRevocationUnknown, http.StatusServiceUnavailable},
+		{ErrCodeAuditFailed, http.StatusInternalServerError},
+		{"SOMETHING_NEW", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := HTTPStatusFor(tt.code); got != tt.want {
+				t.Errorf("HTTPStatusFor(%q) = %d, want %d", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusForEveryCode(t *testing.T) {
+	codes := []string{
+		ErrCodeInvalidDate, ErrCodeBeforeBirth, ErrCodeFutureDate, ErrCodeUnrealisticAge,
+		ErrCodeInvalidUser, ErrCodeDateTooOld, ErrCodePrenatal, ErrCodeExpired, ErrCodeRevoked,
+		ErrCodeInvalidStatus, ErrCodeRevocationUnknown, ErrCodeOutsideSigningWindow, ErrCodeAuditFailed,
+	}
+	for _, code := range codes {
+		if _, ok := defaultHTTPStatuses[code]; !ok {
+			t.Errorf("no default HTTP status for %s", code)
+		}
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	codes := ErrorCodes()
+	if !slices.IsSorted(codes) {
+		t.Errorf("ErrorCodes() = %v, want them sorted", codes)
+	}
+	for _, key := range messageKeys {
+		prefix, _, _ := strings.Cut(string(key), ".")
+		// Milestone summaries and narratives are the only messages that are
+		// not findings
+		if prefix != "MILESTONE" && prefix != "NARRATIVE" && !slices.Contains(codes, prefix) {
+			t.Errorf("ErrorCodes() misses %s, the code of message %s", prefix, key)
+		}
+	}
+}
+
+func TestHTTPStatusForError(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	err := ValidateEntityDate(user, mustParseDate("1989-01-01"), "certification")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"validation error", err, http.StatusUnprocessableEntity},
+		{"wrapped", fmt.Errorf("checking payload: %w", err), http.StatusUnprocessableEntity},
+		{"other error", errors.New("database down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatusForError(tt.err); got != tt.want {
+				t.Errorf("HTTPStatusForError() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterHTTPStatus(t *testing.T) {
+	t.Cleanup(func() {
+		httpStatuses.mu.Lock()
+		httpStatuses.overrides = nil
+		httpStatuses.mu.Unlock()
+	})
+
+	RegisterHTTPStatus(ErrCodeRevoked, http.StatusForbidden)
+	RegisterHTTPStatus("CUSTOM_RULE", http.StatusConflict)
+
+	if got := HTTPStatusFor(ErrCodeRevoked); got != http.StatusForbidden {
+		t.Errorf("HTTPStatusFor(REVOKED) = %d, want %d", got, http.StatusForbidden)
+	}
+	if got := HTTPStatusFor("CUSTOM_RULE"); got != http.StatusConflict {
+		t.Errorf("HTTPStatusFor(CUSTOM_RULE) = %d, want %d", got, http.StatusConflict)
+	}
+	if got := HTTPStatusFor(ErrCodeFutureDate); got != http.StatusUnprocessableEntity {
+		t.Errorf("HTTPStatusFor(FUTURE_DATE) = %d, want default %d", got, http.StatusUnprocessableEntity)
+	}
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+)
+
+// IdentityNormalizer turns the names and IDs of users into the forms that
+// identity cross-checks such as FindLikelyDuplicates compare, so that
+// "Zoë Müller" and "ZOE MULLER" match. See DefaultNormalizer.
+type IdentityNormalizer interface {
+	NormalizeName(name string) string
+	NormalizeID(id string) string
+}
+
+// WithIdentityNormalizer replaces the normalization of names and IDs in
+// identity cross-checks. A nil normalizer restores DefaultNormalizer.
+func WithIdentityNormalizer(n IdentityNormalizer) Option {
+	return func(c *config) {
+		if n == nil {
+			n = DefaultNormalizer{}
+		}
+		c.identity = n
+	}
+}
+
+// DefaultNormalizer is the IdentityNormalizer of identity cross-checks. It
+// folds case, strips diacritics and transliterates letters to ASCII, such
+// as ß to ss, æ to ae and the Cyrillic alphabet as in passports.
+type DefaultNormalizer struct{}
+
+// NormalizeName returns the name in lower case ASCII, its words separated
+// by single spaces. Punctuation such as hyphens and apostrophes separates
+// words, so "Jean-Pierre" gives "jean pierre".
+func (DefaultNormalizer) NormalizeName(name string) string {
+	words := strings.FieldsFunc(transliterate(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// NormalizeID returns the letters and digits of the ID in lower case ASCII,
+// so "AB-12 34" gives "ab1234"
+func (DefaultNormalizer) NormalizeID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, transliterate(id))
+}
+
+// transliterate returns s in lower case with its letters transliterated to
+// ASCII when known and its combining marks dropped
+func transliterate(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range strings.ToLower(s) {
+		switch latin, ok := transliterations[r]; {
+		case ok:
+			b.WriteString(latin)
+		case unicode.Is(unicode.Mn, r):
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// transliterations maps lower case letters to ASCII: the Latin letters
+// with diacritics to their base letter, the Latin ligatures and the
+// Cyrillic alphabet as in ICAO passports
+var transliterations = func() map[rune]string {
+	t := map[rune]string{
+		'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th", 'ð': "d", 'ĳ': "ij",
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+		'ж': "zh", 'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m",
+		'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+		'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+		'ъ': "ie", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+		'і': "i", 'ї': "i", 'є': "ie", 'ґ': "g",
+	}
+	for base, letters := range map[string]string{
+		"a": "àáâãäåāăą", "c": "çćĉċč", "d": "ďđ", "e": "èéêëēĕėęě",
+		"g": "ĝğġģ", "h": "ĥħ", "i": "ìíîïĩīĭįı", "j": "ĵ", "k": "ķ",
+		"l": "ĺļľŀł", "n": "ñńņňŉ", "o": "òóôõöøōŏő", "r": "ŕŗř",
+		"s": "śŝşšș", "t": "ţťŧț", "u": "ùúûüũūŭůűų", "w": "ŵ", "y": "ýÿŷ",
+		"z": "źżž",
+	} {
+		for _, r := range letters {
+			t[r] = base
+		}
+	}
+	return t
+}()
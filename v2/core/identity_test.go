@@ -0,0 +1,81 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDefaultNormalizer(t *testing.T) {
+	names := []struct{ in, want string }{
+		{"Zoë Müller", "zoe muller"},
+		{"  JEAN-PIERRE   d'Arçy ", "jean pierre d arcy"},
+		{"Łukasz Żółć", "lukasz zolc"},
+		{"Straße", "strasse"},
+		{"Æsa Øberg", "aesa oberg"},
+		{"Щукин Юрий", "shchukin iurii"},
+		{"José", "jose"},
+		{"", ""},
+	}
+	for _, tt := range names {
+		if got := (DefaultNormalizer{}).NormalizeName(tt.in); got != tt.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	ids := []struct{ in, want string }{
+		{"AB-12 34", "ab1234"},
+		{"ü-001", "u001"},
+		{"--", ""},
+	}
+	for _, tt := range ids {
+		if got := (DefaultNormalizer{}).NormalizeID(tt.in); got != tt.want {
+			t.Errorf("NormalizeID(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// firstNameNormalizer compares users by first name only
+type firstNameNormalizer struct{ DefaultNormalizer }
+
+func (n firstNameNormalizer) NormalizeName(name string) string {
+	first, _, _ := strings.Cut(n.DefaultNormalizer.NormalizeName(name), " ")
+	return first
+}
+
+func TestIdentityNormalization(t *testing.T) {
+	birth := mustParseDate("1990-05-15")
+	users := []User{
+		{ID: "FR-1001", Name: "Zoë Müller-Dubois", BirthDate: birth},
+		{ID: "fr 2002", Name: "ZOE MULLER DUBOIS", BirthDate: birth},
+		{ID: "fr1001 ", Name: "Zoé Martin", BirthDate: birth},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []LikelyDuplicate
+	}{
+		{"default", nil, []LikelyDuplicate{
+			{First: 0, Second: 1, SimilarName: true},
+			{First: 0, Second: 2, SimilarID: true},
+		}},
+		{"custom", []Option{WithIdentityNormalizer(firstNameNormalizer{})}, []LikelyDuplicate{
+			{First: 0, Second: 1, SimilarName: true},
+			{First: 0, Second: 2, SimilarName: true, SimilarID: true},
+			{First: 1, Second: 2, SimilarName: true},
+		}},
+		{"nil restores the default", []Option{WithIdentityNormalizer(nil)}, []LikelyDuplicate{
+			{First: 0, Second: 1, SimilarName: true},
+			{First: 0, Second: 2, SimilarID: true},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindLikelyDuplicates(users, tt.opts...); !slices.Equal(got, tt.want) {
+				t.Errorf("FindLikelyDuplicates() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
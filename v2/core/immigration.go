@@ -0,0 +1,91 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// Entity types of the immigration timeline. A visa or permit is dated on
+// its issue, its EndDate is its expiry and EntryDate the first entry into
+// the country on it.
+const (
+	workPermitType = "work_permit"
+	employmentType = "employment"
+)
+
+// validateEntry checks that the entry date of a visa or permit falls within
+// its validity, from its issue to its expiry
+func (c *config) validateEntry(entity Entity) error {
+	if entity.EntryDate.IsZero() {
+		return nil
+	}
+	entry, issued := c.truncate(entity.EntryDate), c.truncate(entity.Date)
+	if entry.Before(issued) {
+		return newError(msgEntryBeforeIssue, "type", entityTypeArg(entity.Type), "entry", entry, "date", issued)
+	}
+	if !entity.EndDate.IsZero() {
+		if expiry := c.truncate(entity.EndDate); entry.After(expiry) {
+			return newError(msgEntryAfterExpiry, "type", entityTypeArg(entity.Type), "entry", entry, "end", expiry)
+		}
+	}
+	return nil
+}
+
+// dateSpan is a range of days from start to end included, such as the
+// validity of a work permit from its issue to its expiry; a zero end is open
+type dateSpan struct {
+	start, end time.Time
+}
+
+// workPermits returns the validity of the work permits of a profile, sorted
+// by issue. Revoked permits cover nothing. A permit without an expiry is
+// valid for the validity period of its type, or indefinitely.
+func (c *config) workPermits(entities []Entity) []dateSpan {
+	var spans []dateSpan
+	for _, entity := range entities {
+		if entity.Type != workPermitType || entity.Status == StatusRevoked || entity.Date.IsZero() {
+			continue
+		}
+		span := dateSpan{start: c.truncate(entity.Date)}
+		switch period, ok := c.validityPeriod(entity.Type); {
+		case !entity.EndDate.IsZero():
+			span.end = c.truncate(entity.EndDate)
+		case ok && period != (ValidityPeriod{}):
+			span.end = c.truncate(period.expiry(entity.validFrom()))
+		}
+		spans = append(spans, span)
+	}
+	slices.SortFunc(spans, func(a, b dateSpan) int { return a.start.Compare(b.start) })
+	return spans
+}
+
+// checkWorkPermits reports the first day of an employment not covered by
+// the work permits of its profile, from its date to its end date or to now
+// while ongoing. Profiles without work permits are not checked, as most
+// users need none.
+func (c *config) checkWorkPermits(entity Entity, permits []dateSpan, report *Report) {
+	if entity.Type != employmentType || len(permits) == 0 || entity.Date.IsZero() {
+		return
+	}
+	start, end := c.truncate(entity.Date), c.truncate(c.now())
+	if !entity.EndDate.IsZero() {
+		end = c.truncate(entity.EndDate)
+	}
+	covered := start // First day not known to be covered
+	for _, p := range permits {
+		if p.start.After(covered) {
+			break
+		}
+		if p.end.IsZero() {
+			return
+		}
+		if next := p.end.AddDate(0, 0, 1); next.After(covered) {
+			covered = next
+		}
+	}
+	if covered.After(end) {
+		return
+	}
+	err := newError(msgNoWorkPermit, "type", entityTypeArg(entity.Type), "date", start, "gap", covered)
+	report.add(c.localize(atField(err, fieldEntityDate)))
+}
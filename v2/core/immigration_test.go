@@ -0,0 +1,91 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestVisaEntry(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	visa := Entity{Type: "visa", Date: mustParseDate("2024-03-01"), EndDate: mustParseDate("2024-09-01")}
+
+	tests := []struct {
+		name     string
+		entry    string
+		end      string
+		wantCode string
+	}{
+		{"no entry", "", "", ""},
+		{"entry within validity", "2024-04-15", "", ""},
+		{"entry on issue", "2024-03-01", "", ""},
+		{"entry on expiry", "2024-09-01", "", ""},
+		{"entry before issue", "2024-02-28", "", ErrCodeOutsideValidity},
+		{"entry after expiry", "2024-09-02", "", ErrCodeOutsideValidity},
+		{"no expiry", "2025-01-01", "none", ""},
+		{"expiry before issue", "", "2024-01-01", ErrCodeInvalidDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := visa
+			if tt.entry != "" {
+				entity.EntryDate = mustParseDate(tt.entry)
+			}
+			switch tt.end {
+			case "none":
+				entity.EndDate = time.Time{}
+			case "":
+			default:
+				entity.EndDate = mustParseDate(tt.end)
+			}
+			report := CheckEntity(user, entity, now)
+			if code := codeOf(report.Err()); code != tt.wantCode {
+				t.Errorf("CheckEntity() code = %q, want %q: %v", code, tt.wantCode, report.Findings)
+			}
+		})
+	}
+}
+
+func TestWorkPermitCoverage(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	permits := []Entity{
+		{Type: "work_permit", Date: mustParseDate("2020-01-01"), EndDate: mustParseDate("2021-12-31")},
+		{Type: "work_permit", Date: mustParseDate("2022-01-01"), EndDate: mustParseDate("2023-06-30")},
+	}
+
+	tests := []struct {
+		name       string
+		employment Entity
+		permits    []Entity
+		wantCodes  []string
+	}{
+		{"covered by one permit", Entity{Type: "employment", Date: mustParseDate("2020-03-01"), EndDate: mustParseDate("2021-06-30")}, permits, nil},
+		{"covered by consecutive permits", Entity{Type: "employment", Date: mustParseDate("2021-06-01"), EndDate: mustParseDate("2023-06-30")}, permits, nil},
+		{"starts before the permits", Entity{Type: "employment", Date: mustParseDate("2019-06-01"), EndDate: mustParseDate("2020-06-30")}, permits, []string{ErrCodeOutsideValidity}},
+		{"ends after the permits", Entity{Type: "employment", Date: mustParseDate("2022-06-01"), EndDate: mustParseDate("2023-07-01")}, permits, []string{ErrCodeOutsideValidity}},
+		{"ongoing after the permits", Entity{Type: "employment", Date: mustParseDate("2022-06-01")}, permits, []string{ErrCodeOutsideValidity}},
+		{"gap between permits", Entity{Type: "employment", Date: mustParseDate("2021-06-01"), EndDate: mustParseDate("2022-06-30")}, []Entity{permits[0], {Type: "work_permit", Date: mustParseDate("2022-02-01")}}, []string{ErrCodeOutsideValidity}},
+		{"open-ended permit", Entity{Type: "employment", Date: mustParseDate("2022-06-01")}, []Entity{{Type: "work_permit", Date: mustParseDate("2022-01-01")}}, nil},
+		{"revoked permit", Entity{Type: "employment", Date: mustParseDate("2020-03-01"), EndDate: mustParseDate("2020-06-30")}, []Entity{{Type: "work_permit", Date: mustParseDate("2020-01-01"), Status: StatusRevoked}, permits[1]}, []string{ErrCodeOutsideValidity}},
+		{"no permits", Entity{Type: "employment", Date: mustParseDate("2019-06-01")}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := Profile{
+				User:     &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")},
+				Entities: append([]Entity{tt.employment}, tt.permits...),
+			}
+			result := ValidateProfile(profile, now)
+			report := result.Reports[0]
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Fatalf("employment findings = %v, want %v", got, tt.wantCodes)
+			}
+			if len(tt.wantCodes) > 0 && report.Findings[0].Path != "/entities/0/date" {
+				t.Errorf("finding path = %q, want /entities/0/date", report.Findings[0].Path)
+			}
+		})
+	}
+}
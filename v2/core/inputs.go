@@ -0,0 +1,73 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// HashInputs returns the SHA-256 of the canonical form of the inputs of a
+// validation, in hex: the user's ID, birth and death dates, every field of
+// the entity and the rules version, usually RuleVersion. Times are taken in
+// UTC and defaults are spelled out, such as the claimed status of an entity
+// without one, so equal inputs hash the same in every process. The user's
+// name is left out, as no rule reads it. Caches, deduplication and the
+// InputHash of reports all use it, so consumers need not roll their own.
+func HashInputs(user *User, entity Entity, rulesVersion string) string {
+	precision := entity.DatePrecision
+	if precision == "" {
+		precision = DatePrecisionDay
+	}
+	in := canonicalInputs{
+		EntityID:      entity.ID,
+		EntityIssuer:  entity.Issuer,
+		EntityType:    entity.Type,
+		EntityDate:    canonicalTime(entity.Date),
+		RenewedAt:     canonicalTime(entity.RenewedAt),
+		EndDate:       canonicalTime(entity.EndDate),
+		EntryDate:     canonicalTime(entity.EntryDate),
+		Replaces:      entity.Replaces,
+		Guardian:      entity.Guardian,
+		Juvenile:      entity.Juvenile,
+		Category:      entity.Category,
+		Industry:      entity.Industry,
+		Status:        entity.Status.normalize(),
+		DatePrecision: precision,
+		Uncertainty:   entity.Uncertainty,
+		RulesVersion:  rulesVersion,
+		HasUser:       user != nil,
+	}
+	if user != nil {
+		in.UserID = user.ID
+		in.BirthDate = canonicalTime(user.BirthDate)
+		in.DeathDate = canonicalTime(user.DeathDate)
+	}
+	// Encoding plain strings, numbers and booleans cannot fail
+	data, _ := json.Marshal(in)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalInputs is the hashed form of the inputs of a validation
+type canonicalInputs struct {
+	HasUser       bool          `json:"has_user"`
+	UserID        string        `json:"user_id"`
+	BirthDate     string        `json:"birth_date"`
+	DeathDate     string        `json:"death_date"`
+	EntityID      string        `json:"entity_id"`
+	EntityIssuer  string        `json:"entity_issuer"`
+	EntityType    string        `json:"entity_type"`
+	EntityDate    string        `json:"entity_date"`
+	RenewedAt     string        `json:"renewed_at"`
+	EndDate       string        `json:"end_date"`
+	EntryDate     string        `json:"entry_date"`
+	Replaces      string        `json:"replaces"`
+	Guardian      bool          `json:"guardian"`
+	Juvenile      bool          `json:"juvenile"`
+	Category      string        `json:"category"`
+	Industry      string        `json:"industry"`
+	Status        EntityStatus  `json:"status"`
+	DatePrecision DatePrecision `json:"date_precision"`
+	Uncertainty   Uncertainty   `json:"uncertainty"`
+	RulesVersion  string        `json:"rules_version"`
+}
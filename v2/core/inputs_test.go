@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHashInputs(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entity := Entity{ID: "cert-1", Type: "certification", Date: mustParseDate("2020-01-01")}
+	base := HashInputs(user, entity, RuleVersion)
+
+	// The canonical form is part of the API: stored keys must stay valid
+	if want := "5bbd98b6ff812bdd9b70947aaace3247a5ba354beb300146a4f8f76a5d9fc5da"; base != want {
+		t.Errorf("HashInputs() = %s, want %s", base, want)
+	}
+
+	paris, _ := time.LoadLocation("Europe/Paris")
+	same := []struct {
+		name   string
+		user   *User
+		entity Entity
+	}{
+		{"other time zone", &User{ID: "user123", BirthDate: mustParseDate("1990-01-01").In(paris)}, Entity{ID: "cert-1", Type: "certification", Date: mustParseDate("2020-01-01").In(paris)}},
+		{"name", &User{ID: "user123", BirthDate: mustParseDate("1990-01-01"), Name: "Ada"}, entity},
+		{"default status", user, Entity{ID: "cert-1", Type: "certification", Date: mustParseDate("2020-01-01"), Status: StatusClaimed}},
+		{"default precision", user, Entity{ID: "cert-1", Type: "certification", Date: mustParseDate("2020-01-01"), DatePrecision: DatePrecisionDay}},
+	}
+	for _, tt := range same {
+		if got := HashInputs(tt.user, tt.entity, RuleVersion); got != base {
+			t.Errorf("%s: HashInputs() = %s, want %s", tt.name, got, base)
+		}
+	}
+
+	changed := func(f func(u *User, e *Entity)) string {
+		u, e := *user, entity
+		f(&u, &e)
+		return HashInputs(&u, e, RuleVersion)
+	}
+	different := map[string]string{
+		"no user":       HashInputs(nil, entity, RuleVersion),
+		"rules version": HashInputs(user, entity, "2"),
+		"user ID":       changed(func(u *User, e *Entity) { u.ID = "user456" }),
+		"birth date":    changed(func(u *User, e *Entity) { u.BirthDate = u.BirthDate.AddDate(0, 0, 1) }),
+		"death date":    changed(func(u *User, e *Entity) { u.DeathDate = mustParseDate("2024-01-01") }),
+		"entity date":   changed(func(u *User, e *Entity) { e.Date = e.Date.Add(time.Nanosecond) }),
+		"type":          changed(func(u *User, e *Entity) { e.Type = "training" }),
+		"renewal":       changed(func(u *User, e *Entity) { e.RenewedAt = mustParseDate("2022-01-01") }),
+		"status":        changed(func(u *User, e *Entity) { e.Status = StatusVerified }),
+		"uncertainty":   changed(func(u *User, e *Entity) { e.Uncertainty = Uncertainty{Months: 1} }),
+		"juvenile":      changed(func(u *User, e *Entity) { e.Juvenile = true }),
+	}
+	seen := map[string]string{base: "base"}
+	for name, hash := range different {
+		if other, ok := seen[hash]; ok {
+			t.Errorf("%s: HashInputs() = %s, the hash of %s", name, hash, other)
+		}
+		seen[hash] = name
+	}
+}
+
+func TestReportInputHash(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entity := Entity{ID: "cert-1", Type: "certification", Date: mustParseDate("2020-01-01")}
+	report := CheckEntity(user, entity, WithFixedNow(mustParseDate("2025-07-18")))
+	if want := HashInputs(user, entity, RuleVersion); report.InputHash != want {
+		t.Errorf("InputHash = %s, want %s", report.InputHash, want)
+	}
+
+	key := []byte("secret")
+	if err := report.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	report.InputHash = HashInputs(user, Entity{Type: "certification", Date: entity.Date}, RuleVersion)
+	if err := VerifyReport(key, report); err != ErrInvalidSignature {
+		t.Errorf("VerifyReport() after changing InputHash = %v, want ErrInvalidSignature", err)
+	}
+}
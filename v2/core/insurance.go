@@ -0,0 +1,68 @@
+package core
+
+import (
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/agecalc"
+)
+
+// insuranceTypes are the insurance policies, dated on their effective date
+// with their termination in EndDate, which must fall within the lifetime of
+// the user
+var insuranceTypes = map[string]bool{
+	"child_insurance":  true,
+	"senior_insurance": true,
+	"life_insurance":   true,
+	"health_insurance": true,
+}
+
+// endAges defines the age by which entities of a type must end, such as
+// child policies that cover dependents until 26. Ongoing entities must end
+// before the user reaches it.
+var endAges = map[string]int{
+	"child_insurance": 26,
+}
+
+// WithEndAge sets the age of users by which entities of the given type must
+// end, such as 26 for child insurance policies, overriding the built-in end
+// age. Zero removes it.
+func WithEndAge(entityType string, age int) Option {
+	return func(c *config) {
+		c.endAges = withAge(c.endAges, entityType, age)
+	}
+}
+
+// validatePolicy checks that a policy starts and ends within the lifetime
+// of the user, and that entities with an end age end by it, at their end
+// date or, while ongoing, now or at the death of the user
+func (c *config) validatePolicy(in *ruleInput) error {
+	entity := in.entity
+	end := c.truncate(entity.EndDate)
+	if insuranceTypes[entity.Type] && !in.deathDate.IsZero() {
+		switch {
+		case in.entityDate.After(in.deathDate):
+			return newError(msgStartAfterDeath, "type", entityTypeArg(entity.Type), "date", in.entityDate, "death", in.deathDate)
+		case end.After(in.deathDate):
+			return newError(msgEndAfterDeath, "type", entityTypeArg(entity.Type), "end", end, "death", in.deathDate)
+		}
+	}
+	endAge, ok := c.endAges[entity.Type]
+	if !ok {
+		return nil
+	}
+	if end.IsZero() {
+		end = in.now
+		if !in.deathDate.IsZero() {
+			end = in.deathDate
+		}
+	}
+	if end.After(c.dateAtAge(in.birthDate, endAge)) {
+		return newError(msgPastEndAge, "type", entityTypeArg(entity.Type), "end", end, "age", c.ageAt(in.birthDate, end), "max", endAge)
+	}
+	return nil
+}
+
+// dateAtAge returns the date on which a user born on birthDate reaches age
+func (c *config) dateAtAge(birthDate time.Time, age int) time.Time {
+	return c.truncate(agecalc.DateAtAge(birthDate, age))
+}
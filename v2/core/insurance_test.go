@@ -0,0 +1,38 @@
+package core
+
+import "testing"
+
+func TestInsurancePolicies(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2030-01-01"))
+	young := &User{ID: "young", BirthDate: mustParseDate("2000-06-15")}
+	senior := &User{ID: "senior", BirthDate: mustParseDate("1950-03-01"), DeathDate: mustParseDate("2022-08-01")}
+
+	tests := []struct {
+		name     string
+		user     *User
+		entity   Entity
+		opts     []Option
+		wantCode string
+	}{
+		{"child policy ending at 26", young, Entity{Type: "child_insurance", Date: mustParseDate("2000-07-01"), EndDate: mustParseDate("2026-06-15")}, nil, ""},
+		{"child policy ending after 26", young, Entity{Type: "child_insurance", Date: mustParseDate("2000-07-01"), EndDate: mustParseDate("2026-06-16")}, nil, ErrCodeUnrealisticAge},
+		{"ongoing child policy past 26", young, Entity{Type: "child_insurance", Date: mustParseDate("2000-07-01")}, nil, ErrCodeUnrealisticAge},
+		{"end age raised", young, Entity{Type: "child_insurance", Date: mustParseDate("2000-07-01"), EndDate: mustParseDate("2027-01-01")}, []Option{WithEndAge("child_insurance", 27)}, ""},
+		{"end age removed", young, Entity{Type: "child_insurance", Date: mustParseDate("2000-07-01")}, []Option{WithEndAge("child_insurance", 0)}, ""},
+		{"senior policy at 60", senior, Entity{Type: "senior_insurance", Date: mustParseDate("2010-03-01")}, nil, ""},
+		{"senior policy at 55", senior, Entity{Type: "senior_insurance", Date: mustParseDate("2005-06-01")}, nil, ErrCodeUnrealisticAge},
+		{"policy ending at death", senior, Entity{Type: "life_insurance", Date: mustParseDate("1990-01-01"), EndDate: mustParseDate("2022-08-01")}, nil, ""},
+		{"policy ending after death", senior, Entity{Type: "life_insurance", Date: mustParseDate("1990-01-01"), EndDate: mustParseDate("2025-01-01")}, nil, ErrCodeAfterDeath},
+		{"policy starting after death", senior, Entity{Type: "health_insurance", Date: mustParseDate("2023-01-01")}, nil, ErrCodeAfterDeath},
+		{"other types after death", senior, Entity{Type: "certification", Date: mustParseDate("2023-01-01")}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntity(tt.user, tt.entity, append(tt.opts, now)...)
+			if code := codeOf(err); code != tt.wantCode {
+				t.Errorf("ValidateEntity() code = %q, want %q: %v", code, tt.wantCode, err)
+			}
+		})
+	}
+}
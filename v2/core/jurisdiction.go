@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"maps"
+	"strings"
+)
+
+// JurisdictionResolver returns the jurisdiction whose minimum ages apply to
+// an entity of a user, such as the country stored on the caller's user
+// record or the state of the issuer. The code is one accepted by
+// NewRuleConfig, such as FR or US-CA; an empty code keeps the configured
+// minimum ages.
+type JurisdictionResolver interface {
+	ResolveJurisdiction(ctx context.Context, user *User, entity Entity) (string, error)
+}
+
+// StaticJurisdictions is a JurisdictionResolver over fixed mappings. The
+// issuer of the entity is looked up first, as a credential follows the rules
+// of where it was issued, then the ID of the user, then Default.
+type StaticJurisdictions struct {
+	Issuers map[string]string // Jurisdiction by entity issuer
+	Users   map[string]string // Jurisdiction by user ID
+	Default string
+}
+
+// ResolveJurisdiction returns the jurisdiction of the issuer, of the user or
+// the default
+func (s StaticJurisdictions) ResolveJurisdiction(_ context.Context, user *User, entity Entity) (string, error) {
+	if code, ok := s.Issuers[entity.Issuer]; ok && entity.Issuer != "" {
+		return code, nil
+	}
+	if user != nil {
+		if code, ok := s.Users[user.ID]; ok {
+			return code, nil
+		}
+	}
+	return s.Default, nil
+}
+
+// WithJurisdictionResolver applies the minimum and maximum ages and the
+// child validities of the jurisdiction r resolves for each validated entity
+// over the configured ones, except those set with WithMinimumAge,
+// WithMaximumAge and WithChildValidity. A failed
+// lookup, or a jurisdiction without rules, keeps the configured ages and is
+// reported as a JURISDICTION_UNKNOWN warning.
+func WithJurisdictionResolver(r JurisdictionResolver) Option {
+	return func(c *config) {
+		c.jurisdictions = r
+	}
+}
+
+// withJurisdiction returns the settings for an entity of user, with the
+// minimum ages of its resolved jurisdiction. It returns c itself when there
+// is no resolver or the resolver gives no jurisdiction.
+func (c *config) withJurisdiction(ctx context.Context, user *User, entity Entity, report *Report) *config {
+	if c.jurisdictions == nil || user == nil {
+		return c
+	}
+	code, err := c.jurisdictions.ResolveJurisdiction(ctx, user, entity)
+	if err != nil {
+		report.add(c.localize(newWarning(msgJurisdictionLookup, "type", entityTypeArg(entity.Type), "error", err)))
+		return c
+	}
+	if code == "" {
+		return c
+	}
+	ages, ok := jurisdictionRules(strings.ToUpper(code))
+	if !ok {
+		report.add(c.localize(newWarning(msgNoJurisdictionRules, "jurisdiction", code)))
+		return c
+	}
+
+	resolved := *c
+	resolved.jurisdiction = strings.ToUpper(code)
+	resolved.minimumAges = overrideAges(c.minimumAges, ages.minimum, c.pinnedAges)
+	resolved.maximumAges = overrideAges(c.maximumAges, ages.maximum, c.pinnedMaxAges)
+	resolved.childValidity = overrideAges(c.childValidity, ages.childValidity, c.pinnedChildValidity)
+	return &resolved
+}
+
+// overrideAges returns a copy of ages with the jurisdiction's ages applied,
+// except for the pinned entity types
+func overrideAges[V any](ages, jurisdiction map[string]V, pinned map[string]bool) map[string]V {
+	merged := maps.Clone(ages)
+	if merged == nil {
+		merged = make(map[string]V)
+	}
+	for entityType, age := range jurisdiction {
+		if !pinned[entityType] {
+			merged[entityType] = age
+		}
+	}
+	return merged
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// failingResolver is a JurisdictionResolver that always fails
+type failingResolver struct{}
+
+func (failingResolver) ResolveJurisdiction(context.Context, *User, Entity) (string, error) {
+	return "", errors.New("user store unavailable")
+}
+
+func TestStaticJurisdictions(t *testing.T) {
+	r := StaticJurisdictions{
+		Issuers: map[string]string{"dmv.ca.gov": "US-CA"},
+		Users:   map[string]string{"user123": "FR"},
+		Default: "US",
+	}
+	tests := []struct {
+		name   string
+		user   string
+		issuer string
+		want   string
+	}{
+		{"issuer first", "user123", "dmv.ca.gov", "US-CA"},
+		{"user", "user123", "other", "FR"},
+		{"default", "user456", "", "US"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.ResolveJurisdiction(context.Background(), &User{ID: tt.user}, Entity{Issuer: tt.issuer})
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveJurisdiction() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithJurisdictionResolver(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-01-01"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-01-01")}
+	at16 := Entity{Type: "license", Date: mustParseDate("2016-06-01")} // Aged 16
+	resolver := WithJurisdictionResolver(StaticJurisdictions{
+		Users:   map[string]string{"user123": "us-nj", "user456": "XX"},
+		Default: "US",
+	})
+
+	tests := []struct {
+		name  string
+		user  *User
+		opts  []Option
+		codes []string
+	}{
+		{"no resolver", user, nil, nil},
+		{"state license age", user, []Option{resolver}, []string{ErrCodeUnrealisticAge}},
+		{"default jurisdiction", &User{ID: "user789", BirthDate: user.BirthDate}, []Option{resolver}, nil},
+		{"minimum age option kept", user, []Option{WithMinimumAge("license", 16), resolver}, nil},
+		{"no rules", &User{ID: "user456", BirthDate: user.BirthDate}, []Option{resolver}, []string{ErrCodeJurisdictionUnknown}},
+		{"failed lookup", user, []Option{WithJurisdictionResolver(failingResolver{})}, []string{ErrCodeJurisdictionUnknown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(tt.user, at16, append(tt.opts, now)...)
+			if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+				t.Errorf("codes = %v, want %v", codes, tt.codes)
+			}
+			err := ValidateEntityDate(tt.user, at16.Date, at16.Type, append(tt.opts, now)...)
+			if wantErr := slices.Contains(tt.codes, ErrCodeUnrealisticAge); (err != nil) != wantErr {
+				t.Errorf("ValidateEntityDate() error = %v, want error %v", err, wantErr)
+			}
+		})
+	}
+
+	sealed, err := Seal(user, resolver, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sealed.ValidateEntityDate(at16.Date, at16.Type); err == nil {
+		t.Error("SealedUser.ValidateEntityDate() error = nil, want the US-NJ license age")
+	}
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Sources of the settings of a RuleStack that no layer sets
+const (
+	SourceDefault = "default" // The library defaults
+	SourceOptions = "options" // The per-call options given to Settings
+)
+
+// ruleSetting is a setting of a rules file, as read by readRulesYAML
+type ruleSetting struct {
+	section, key, value string
+}
+
+// RuleLayer is one level of a layered rule configuration, such as the
+// rules file of an organization or of a tenant. Unlike ParseRuleConfig,
+// which fills in the defaults, a layer only holds the settings its file
+// sets.
+type RuleLayer struct {
+	Name     string
+	Warnings []ConfigIssue // Deprecated settings of the file, see ParseRuleConfigWarnings
+	settings []ruleSetting
+}
+
+// ParseRuleLayer reads a layer named name, such as "organization" or
+// "tenant acme", from a file in the format of WriteYAML. Mapping entries
+// override the same entries of lower layers one by one; an empty mapping
+// {} drops the entries of lower layers.
+func ParseRuleLayer(name string, data []byte) (RuleLayer, error) {
+	layer := RuleLayer{Name: name}
+	r := EffectiveRules()
+	warnings, err := readRulesYAML(data, func(section, key, value string) error {
+		if err := r.merge(section, key, value); err != nil {
+			return err
+		}
+		layer.settings = append(layer.settings, ruleSetting{section, key, value})
+		return nil
+	})
+	if err != nil {
+		return RuleLayer{}, err
+	}
+	layer.Warnings = warnings
+	return layer, nil
+}
+
+// merge applies a setting of a layer on top of the rules
+func (r *RuleConfig) merge(section, key, value string) error {
+	switch {
+	case section == "":
+		return r.set(key, value)
+	case key == "" && value == "{}":
+		return r.clearSection(section)
+	case key == "":
+		// Check the name of the mapping, keeping its entries
+		var scratch RuleConfig
+		return scratch.clearSection(section)
+	}
+	return r.setEntry(section, key, value)
+}
+
+// RuleStack is a layered rule configuration: the library defaults, then
+// each layer in turn, such as an organization's rules then a tenant's. It
+// records which layer set each setting, to answer questions such as "why
+// is the minimum age 15 here?".
+type RuleStack struct {
+	rules   RuleConfig
+	sources map[string]string // Layer name by setting key
+}
+
+// RuleSetting is the effective value of a setting of a RuleStack and where
+// it came from. Keys are those of WriteYAML, with entries of mappings
+// written section.key, such as minimum_ages.license. Source is the name of
+// the layer that set the value, SourceDefault or SourceOptions.
+type RuleSetting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// NewRuleStack merges the layers over the library defaults, later layers
+// taking precedence
+func NewRuleStack(layers ...RuleLayer) *RuleStack {
+	s := &RuleStack{rules: EffectiveRules(), sources: make(map[string]string)}
+	for _, layer := range layers {
+		for _, setting := range layer.settings {
+			// Layers were checked by ParseRuleLayer
+			s.rules.merge(setting.section, setting.key, setting.value)
+			switch {
+			case setting.section == "":
+				s.sources[setting.key] = layer.Name
+			case setting.key != "":
+				s.sources[setting.section+"."+setting.key] = layer.Name
+			case setting.value == "{}":
+				for key := range s.sources {
+					if strings.HasPrefix(key, setting.section+".") {
+						delete(s.sources, key)
+					}
+				}
+			}
+		}
+	}
+	return s
+}
+
+// Rules returns the merged rules
+func (s *RuleStack) Rules() RuleConfig {
+	if s == nil {
+		s = NewRuleStack()
+	}
+	return s.rules
+}
+
+// Option returns the option applying the merged rules. Per-call options
+// passed after it take precedence.
+func (s *RuleStack) Option() Option {
+	if s == nil {
+		s = NewRuleStack()
+	}
+	return WithRules(s.rules)
+}
+
+// Settings returns every effective setting of validations with Option
+// followed by opts, in the order of WriteYAML
+func (s *RuleStack) Settings(opts ...Option) []RuleSetting {
+	if s == nil {
+		s = NewRuleStack()
+	}
+	effective := EffectiveRules(append([]Option{s.Option()}, opts...)...)
+	effective.Jurisdiction, effective.Preset = s.rules.Jurisdiction, s.rules.Preset
+	stacked := make(map[string]string)
+	for _, setting := range s.rules.settings() {
+		stacked[setting.Key] = setting.Value
+	}
+
+	settings := effective.settings()
+	for i, setting := range settings {
+		value, ok := stacked[setting.Key]
+		switch {
+		case !ok || value != setting.Value:
+			settings[i].Source = SourceOptions
+		case s.sources[setting.Key] != "":
+			settings[i].Source = s.sources[setting.Key]
+		default:
+			settings[i].Source = SourceDefault
+		}
+	}
+	return settings
+}
+
+// Setting returns the effective value of a setting and its source, false
+// when the setting is unknown or its mapping has no such entry
+func (s *RuleStack) Setting(key string, opts ...Option) (RuleSetting, bool) {
+	for _, setting := range s.Settings(opts...) {
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return RuleSetting{}, false
+}
+
+// settings returns the settings of the rules as written by WriteYAML,
+// without sources
+func (r RuleConfig) settings() []RuleSetting {
+	var b bytes.Buffer
+	r.WriteYAML(&b)
+	var settings []RuleSetting
+	readRulesYAML(b.Bytes(), func(section, key, value string) error {
+		switch {
+		case section == "":
+			settings = append(settings, RuleSetting{Key: key, Value: value})
+		case key != "":
+			settings = append(settings, RuleSetting{Key: section + "." + key, Value: value})
+		}
+		return nil
+	})
+	return settings
+}
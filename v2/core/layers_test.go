@@ -0,0 +1,86 @@
+package core
+
+import "testing"
+
+func TestRuleStack(t *testing.T) {
+	org, err := ParseRuleLayer("organization", []byte(`
+jurisdiction: FR
+short_circuit: false
+minimum_ages:
+  license: 17
+  employment: 15
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant, err := ParseRuleLayer("tenant acme", []byte(`
+minimum_ages:
+  employment: 16
+validity_periods: {}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stack := NewRuleStack(org, tenant)
+
+	tests := []struct {
+		key    string
+		opts   []Option
+		value  string
+		source string
+		found  bool
+	}{
+		{"minimum_ages.license", nil, "17", "organization", true},
+		{"minimum_ages.employment", nil, "16", "tenant acme", true},
+		{"minimum_ages.training", nil, "5", SourceDefault, true},
+		{"minimum_ages.employment", []Option{WithMinimumAge("employment", 14)}, "14", SourceOptions, true},
+		{"short_circuit", nil, "false", "organization", true},
+		{"jurisdiction", nil, "FR", "organization", true},
+		{"comparison", nil, "instant", SourceDefault, true},
+		{"validity_periods.passport", nil, "", "", false},
+		{"unknown", nil, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := stack.Setting(tt.key, tt.opts...)
+			if ok != tt.found {
+				t.Fatalf("Setting(%q) found = %v, want %v", tt.key, ok, tt.found)
+			}
+			if got.Value != tt.value || got.Source != tt.source {
+				t.Errorf("Setting(%q) = %+v, want %s from %s", tt.key, got, tt.value, tt.source)
+			}
+		})
+	}
+
+	if rules := stack.Rules(); rules.MinimumAges["license"] != 17 || len(rules.ValidityPeriods) != 0 || rules.ShortCircuit {
+		t.Errorf("Rules() = %+v", rules)
+	}
+}
+
+func TestRuleStackClearedSection(t *testing.T) {
+	org, err := ParseRuleLayer("organization", []byte("minimum_ages:\n  license: 17\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant, err := ParseRuleLayer("tenant", []byte("minimum_ages: {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setting, ok := NewRuleStack(org, tenant).Setting("minimum_ages.license"); ok {
+		t.Errorf("Setting() = %+v, want none after the tenant cleared minimum_ages", setting)
+	}
+}
+
+func TestParseRuleLayerErrors(t *testing.T) {
+	for _, data := range []string{
+		"min_age: 5\n",
+		"minimum_ages:\n  license: old\n",
+		"ages:\n  license: 17\n",
+		"  license: 17\n",
+	} {
+		if _, err := ParseRuleLayer("bad", []byte(data)); err == nil {
+			t.Errorf("ParseRuleLayer(%q) error = nil, want an error", data)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ConfigIssue is a problem ValidateConfig found in a rule configuration.
+// Errors are settings that contradict each other or cannot be right;
+// warnings are settings that are likely mistakes, such as an entity type
+// the library does not know.
+type ConfigIssue struct {
+	Setting  string   `json:"setting"` // Key as in RuleSetting, such as minimum_ages.license
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// String returns the issue as "setting: severity: message"
+func (i ConfigIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Setting, i.Severity, i.Message)
+}
+
+// ValidateConfig checks a rule configuration before it is deployed, for
+// unknown jurisdictions and presets, out of range values, rules that
+// contradict each other and unknown entity types. It returns the errors in
+// the order of the settings in WriteYAML, then the warnings.
+func ValidateConfig(r RuleConfig) []ConfigIssue {
+	var issues []ConfigIssue
+	issue := func(setting string, severity Severity, format string, args ...any) {
+		issues = append(issues, ConfigIssue{Setting: setting, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if _, ok := jurisdictionRules(strings.ToUpper(r.Jurisdiction)); r.Jurisdiction != "" && !ok {
+		issue("jurisdiction", SeverityError, "unknown jurisdiction %q, want one of %s", r.Jurisdiction, strings.Join(Jurisdictions(), ", "))
+	}
+	if _, ok := presets[r.Preset]; r.Preset != "" && !ok {
+		issue("preset", SeverityError, "unknown preset %q, want one of %s", r.Preset, strings.Join(Presets(), ", "))
+	}
+	if r.MaxHistory < 0 {
+		issue("max_history", SeverityError, "negative history limit %s", r.MaxHistory)
+	}
+	now := time.Now()
+	for _, floor := range []struct {
+		setting string
+		date    time.Time
+	}{{"min_birth_date", r.MinBirthDate}, {"min_entity_date", r.MinEntityDate}} {
+		if floor.date.After(now) {
+			issue(floor.setting, SeverityError, "%s is in the future, every date would be too old", floor.date.Format("2006-01-02"))
+		}
+	}
+
+	for _, entityType := range slices.Sorted(maps.Keys(r.SameDayBirthTypes)) {
+		if age := r.MinimumAges[entityType]; r.SameDayBirthTypes[entityType] && age > 0 {
+			issue("same_day_birth."+entityType, SeverityError, "entities dated on the birth date always fail the minimum age of %d", age)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.MinimumAges)) {
+		switch age := r.MinimumAges[entityType]; {
+		case age < 0:
+			issue("minimum_ages."+entityType, SeverityError, "negative minimum age %d", age)
+		case age > MaxHumanAge:
+			issue("minimum_ages."+entityType, SeverityError, "minimum age %d is above the maximum age of %d", age, MaxHumanAge)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.MaximumAges)) {
+		switch age, minAge := r.MaximumAges[entityType], r.MinimumAges[entityType]; {
+		case age < 0:
+			issue("maximum_ages."+entityType, SeverityError, "negative maximum age %d", age)
+		case age > MaxHumanAge:
+			issue("maximum_ages."+entityType, SeverityError, "maximum age %d is above the maximum age of %d", age, MaxHumanAge)
+		case age < minAge:
+			issue("maximum_ages."+entityType, SeverityError, "maximum age %d is below the minimum age of %d, every date would fail", age, minAge)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.EndAges)) {
+		switch age, minAge := r.EndAges[entityType], r.MinimumAges[entityType]; {
+		case age < 0:
+			issue("end_ages."+entityType, SeverityError, "negative end age %d", age)
+		case age > MaxHumanAge:
+			issue("end_ages."+entityType, SeverityError, "end age %d is above the maximum age of %d", age, MaxHumanAge)
+		case age < minAge:
+			issue("end_ages."+entityType, SeverityError, "end age %d is below the minimum age of %d, every entity would fail", age, minAge)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.GuardianAges)) {
+		switch age, minAge := r.GuardianAges[entityType], r.MinimumAges[entityType]; {
+		case age < 0:
+			issue("guardian_ages."+entityType, SeverityError, "negative guardian age %d", age)
+		case age > minAge:
+			issue("guardian_ages."+entityType, SeverityError, "guardian age %d is above the minimum age of %d, a guardian would make the rule stricter", age, minAge)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.PrenatalWindows)) {
+		window := r.PrenatalWindows[entityType]
+		switch age := r.MinimumAges[entityType]; {
+		case window < 0:
+			issue("prenatal_windows."+entityType, SeverityError, "negative prenatal window %s", window)
+		case window > 0 && age > 0:
+			issue("prenatal_windows."+entityType, SeverityError, "prenatal dates always fail the minimum age of %d", age)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.ValidityPeriods)) {
+		if p := r.ValidityPeriods[entityType]; p.Years < 0 || p.Months < 0 {
+			issue("validity_periods."+entityType, SeverityError, "negative validity period %s", p)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.ChildValidity)) {
+		switch v := r.ChildValidity[entityType]; {
+		case v.UnderAge <= 0 || v.UnderAge > MaxHumanAge:
+			issue("child_validity."+entityType, SeverityError, "age %d is out of range", v.UnderAge)
+		case v.Validity.Years < 0 || v.Validity.Months < 0:
+			issue("child_validity."+entityType, SeverityError, "negative validity period %s", v.Validity)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.AgeCategories)) {
+		switch a := r.AgeCategories[name]; {
+		case a.MinAge < 0 || a.MaxAge < 0:
+			issue("age_categories."+name, SeverityError, "negative age in %s", a)
+		case a.MaxAge != 0 && a.MaxAge < a.MinAge:
+			issue("age_categories."+name, SeverityError, "maximum age %d is below the minimum age of %d, every entry would fail", a.MaxAge, a.MinAge)
+		}
+	}
+	for _, entityType := range slices.Sorted(maps.Keys(r.MaxDurations)) {
+		if p := r.MaxDurations[entityType]; p.Years < 0 || p.Months < 0 {
+			issue("max_durations."+entityType, SeverityError, "negative maximum duration %s", p)
+		}
+	}
+
+	for _, section := range []struct {
+		name  string
+		types []string
+	}{
+		{"same_day_birth", slices.Collect(maps.Keys(r.SameDayBirthTypes))},
+		{"minimum_ages", slices.Collect(maps.Keys(r.MinimumAges))},
+		{"maximum_ages", slices.Collect(maps.Keys(r.MaximumAges))},
+		{"guardian_ages", slices.Collect(maps.Keys(r.GuardianAges))},
+		{"end_ages", slices.Collect(maps.Keys(r.EndAges))},
+		{"prenatal_windows", slices.Collect(maps.Keys(r.PrenatalWindows))},
+		{"validity_periods", slices.Collect(maps.Keys(r.ValidityPeriods))},
+		{"child_validity", slices.Collect(maps.Keys(r.ChildValidity))},
+		{"max_durations", slices.Collect(maps.Keys(r.MaxDurations))},
+		{"age_cutoffs", slices.Collect(maps.Keys(r.AgeCutoffs))},
+	} {
+		slices.Sort(section.types)
+		for _, entityType := range section.types {
+			if !knownEntityType(entityType) {
+				issue(section.name+"."+entityType, SeverityWarning, "entity type %q has no built-in rules, check its spelling", entityType)
+			}
+		}
+	}
+	for _, entityType := range r.AgeWarnings {
+		if !knownEntityType(entityType) {
+			issue("age_warnings", SeverityWarning, "entity type %q has no built-in rules, check its spelling", entityType)
+		}
+	}
+	return issues
+}
+
+// knownEntityType reports whether the library has built-in rules for an
+// entity type
+func knownEntityType(entityType string) bool {
+	_, minAge := minimumAges[entityType]
+	_, maxAge := maximumAges[entityType]
+	_, period := defaultValidityPeriods()[entityType]
+	_, duration := defaultMaxDurations[entityType]
+	_, endAge := endAges[entityType]
+	return minAge || maxAge || period || duration || endAge || documentTypes[entityType] || insuranceTypes[entityType]
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		adjust func(r *RuleConfig)
+		want   []string
+	}{
+		{"defaults", func(r *RuleConfig) {}, nil},
+		{"jurisdiction", func(r *RuleConfig) { r.Jurisdiction = "fr" }, nil},
+		{"subdivision", func(r *RuleConfig) { r.Jurisdiction = "US-TX" }, nil},
+		{"unknown jurisdiction", func(r *RuleConfig) { r.Jurisdiction = "XX" }, []string{"jurisdiction: error"}},
+		{"unknown preset", func(r *RuleConfig) { r.Preset = "lax" }, []string{"preset: error"}},
+		{"negative history", func(r *RuleConfig) { r.MaxHistory = -time.Hour }, []string{"max_history: error"}},
+		{"future floor", func(r *RuleConfig) { r.MinEntityDate = time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC) }, []string{"min_entity_date: error"}},
+		{"minimum age above maximum", func(r *RuleConfig) { r.MinimumAges["license"] = 200 }, []string{"minimum_ages.license: error"}},
+		{"negative minimum age", func(r *RuleConfig) { r.MinimumAges["license"] = -1 }, []string{"minimum_ages.license: error"}},
+		{"maximum age below minimum age", func(r *RuleConfig) { r.MaximumAges["kindergarten"] = 2 }, []string{"maximum_ages.kindergarten: error"}},
+		{"negative maximum age", func(r *RuleConfig) { r.MaximumAges["kindergarten"] = -1 }, []string{"maximum_ages.kindergarten: error"}},
+		{"unknown maximum age type", func(r *RuleConfig) { r.MaximumAges["nursery"] = 4 }, []string{"maximum_ages.nursery: warning"}},
+		{"same day birth with minimum age", func(r *RuleConfig) { r.SameDayBirthTypes["license"] = true }, []string{"same_day_birth.license: error"}},
+		{"prenatal with minimum age", func(r *RuleConfig) { r.PrenatalWindows["training"] = time.Hour }, []string{"prenatal_windows.training: error"}},
+		{"negative validity", func(r *RuleConfig) { r.ValidityPeriods["passport"] = ValidityPeriod{Years: -1} }, []string{"validity_periods.passport: error"}},
+		{"negative maximum duration", func(r *RuleConfig) { r.MaxDurations["internship"] = ValidityPeriod{Months: -1} }, []string{"max_durations.internship: error"}},
+		{"unknown type", func(r *RuleConfig) { r.MinimumAges["licence"] = 16 }, []string{"minimum_ages.licence: warning"}},
+		{"end age below minimum age", func(r *RuleConfig) { r.EndAges["senior_insurance"] = 26 }, []string{"end_ages.senior_insurance: error"}},
+		{"guardian age above minimum age", func(r *RuleConfig) { r.GuardianAges["loan"] = 21 }, []string{"guardian_ages.loan: error"}},
+		{"child validity age out of range", func(r *RuleConfig) { r.ChildValidity["passport"] = ChildValidity{Validity: ValidityPeriod{Years: 5}} }, []string{"child_validity.passport: error"}},
+		{"empty age category", func(r *RuleConfig) { r.AgeCategories["junior"] = AgeCategory{MinAge: 20, MaxAge: 19} }, []string{"age_categories.junior: error"}},
+		{"unknown age cutoff type", func(r *RuleConfig) { r.AgeCutoffs["kindergarden"] = CutoffSchoolYearEnd }, []string{"age_cutoffs.kindergarden: warning"}},
+		{"unknown age warning type", func(r *RuleConfig) { r.AgeWarnings = []string{"volunteering"} }, []string{"age_warnings: warning"}},
+		{"unknown prenatal type", func(r *RuleConfig) { r.PrenatalWindows["prenatal_screening"] = time.Hour }, []string{"prenatal_windows.prenatal_screening: warning"}},
+		{"errors before warnings", func(r *RuleConfig) {
+			r.MinimumAges["licence"] = 16
+			r.Preset = "lax"
+		}, []string{"preset: error", "minimum_ages.licence: warning"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := EffectiveRules()
+			tt.adjust(&r)
+			var got []string
+			for _, issue := range ValidateConfig(r) {
+				got = append(got, issue.Setting+": "+issue.Severity.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfigPresets(t *testing.T) {
+	for _, jurisdiction := range append(Jurisdictions(), "") {
+		for _, preset := range Presets() {
+			r, err := NewRuleConfig(jurisdiction, preset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if issues := ValidateConfig(r); len(issues) > 0 {
+				t.Errorf("ValidateConfig(%q, %q) = %v, want no issues", jurisdiction, preset, issues)
+			}
+		}
+	}
+}
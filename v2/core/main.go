@@ -0,0 +1,419 @@
+// Package userdate provides comprehensive date validation for user entities.
+// It ensures that dates associated with user data (certifications, trainings, etc.)
+// are realistic and valid within the context of the user's lifetime.
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// User represents a user entity with basic information for date validation
+type User struct {
+	ID        string    `json:"id"`
+	BirthDate time.Time `json:"birth_date"`
+	Name      string    `json:"name,omitempty"`
+	DeathDate time.Time `json:"death_date,omitzero"` // Zero when the user is alive
+}
+
+// DateValidationError represents an error during date validation
+type DateValidationError struct {
+	Message  string   `json:"message"`
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"` // SeverityError unless the finding is only a warning
+
+	// Path is the JSON pointer of the offending value in the validated
+	// payload, such as /entities/2/date. It is set by nested validations,
+	// see Report.AttachPaths, and empty otherwise.
+	Path string `json:"path,omitempty"`
+
+	// Reasons are the facts the finding derives from, in order: for the
+	// findings of rules, the rule, jurisdiction, dates and entity type of
+	// the input, then the values the rule compared, such as a minimum age.
+	Reasons []Reason `json:"reasons,omitempty"`
+
+	// Confidence is how sure a heuristic rule is of the finding, from 0 to
+	// 1, such as the placeholder, day and month swap, tenure, duration and
+	// career gap rules. It is zero for the findings of the other rules,
+	// which are certain, and is independent of the severity.
+	Confidence float64 `json:"confidence,omitempty"`
+
+	field findingField // The input the finding is about
+	key   messageKey   // Identifies the message in the catalogs
+	args  []any        // Name, value pairs filling the message placeholders
+}
+
+func (e *DateValidationError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("date validation error [%s]: %s", e.Code, e.Message)
+}
+
+// Validation error codes
+const (
+	ErrCodeInvalidDate          = "INVALID_DATE"
+	ErrCodeBeforeBirth          = "BEFORE_BIRTH"
+	ErrCodeFutureDate           = "FUTURE_DATE"
+	ErrCodeUnrealisticAge       = "UNREALISTIC_AGE"
+	ErrCodeInvalidUser          = "INVALID_USER"
+	ErrCodeDateTooOld           = "DATE_TOO_OLD"
+	ErrCodePrenatal             = "PRENATAL_DATE"
+	ErrCodeExpired              = "EXPIRED"
+	ErrCodeRevoked              = "REVOKED"
+	ErrCodeInvalidStatus        = "INVALID_STATUS"
+	ErrCodeRevocationUnknown    = "REVOCATION_UNKNOWN"
+	ErrCodeOutsideSigningWindow = "OUTSIDE_SIGNING_WINDOW"
+	ErrCodeAuditFailed          = "AUDIT_FAILED"
+	ErrCodeSwappedDate          = "SWAPPED_DATE"
+	ErrCodePlaceholderDate      = "PLACEHOLDER_DATE"
+	ErrCodeTwoDigitYear         = "TWO_DIGIT_YEAR"
+	ErrCodeImpreciseDate        = "IMPRECISE_DATE"
+	ErrCodeReviewFailed         = "REVIEW_FAILED"
+	ErrCodeJurisdictionUnknown  = "JURISDICTION_UNKNOWN"
+	ErrCodeImplausibleDuration  = "IMPLAUSIBLE_DURATION"
+	ErrCodeOutsideValidity      = "OUTSIDE_VALIDITY"
+	ErrCodeDocumentValidity     = "DOCUMENT_VALIDITY"
+	ErrCodeRenewalChain         = "RENEWAL_CHAIN"
+	ErrCodeAfterDeath           = "AFTER_DEATH"
+	ErrCodeJuvenileRecord       = "JUVENILE_RECORD"
+	ErrCodeAgeCategory          = "AGE_CATEGORY"
+	ErrCodeImplausibleTenure    = "IMPLAUSIBLE_TENURE"
+	ErrCodeCareerGap            = "CAREER_GAP"
+	ErrCodeWebhookFailed        = "WEBHOOK_FAILED"
+	ErrCodeKnownBad             = "KNOWN_BAD"
+	ErrCodeRulePanic            = "RULE_PANIC"
+	ErrCodeMinorConsent         = "MINOR_CONSENT"
+	ErrCodeBeforeSignup         = "BEFORE_SIGNUP"
+)
+
+// Constants for validation limits
+const (
+	MaxHumanAge     = 150 // Maximum realistic human age
+	MinCertAge      = 5   // Minimum age for certifications
+	MaxHistoryYears = 200 // Maximum years back in history to consider valid
+)
+
+// ValidateEntityDate validates a date for a user entity (certification, training, etc.)
+func ValidateEntityDate(user *User, entityDate time.Time, entityType string, opts ...Option) error {
+	cfg := newConfig(opts)
+	return cfg.validateEntityDate(context.Background(), user, entityDate, entityType)
+}
+
+// validateEntityDate runs all entity date checks using the given settings
+func (c *config) validateEntityDate(ctx context.Context, user *User, entityDate time.Time, entityType string) error {
+	if c.needsReport() {
+		// Audited and counted validations need the full report
+		report := c.checkEntityDate(ctx, user, entityDate, entityType)
+		err := report.Err()
+		report.Release()
+		return err
+	}
+	return c.evaluateEntityDate(ctx, user, entityDate, entityType, nil)
+}
+
+// evaluateEntityDate runs all entity date checks, recording the findings in
+// report when it is not nil, and returns the first error found
+func (c *config) evaluateEntityDate(ctx context.Context, user *User, entityDate time.Time, entityType string, report *Report) error {
+	entity := Entity{Type: entityType, Date: entityDate}
+	c = c.withJurisdiction(ctx, user, entity, report)
+	in, err := c.newRuleInput(ctx, user, entity)
+	rules, _ := c.rules()
+	if err != nil {
+		lenient, lenientIn, warning := c.acceptPlaceholder(ctx, user, entity, rules, err)
+		if warning == nil {
+			report.add(err)
+			return err
+		}
+		report.add(warning)
+		c, in = lenient, lenientIn
+	}
+	return c.runRules(rules, &in, report)
+}
+
+// sameDay reports whether a and b fall on the same calendar date
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// validateDate performs basic date validation against the placeholder dates
+// and the earliest accepted date
+func (c *config) validateDate(date, floor time.Time) error {
+	// Check if date is zero value
+	if date.IsZero() {
+		return newError(msgZeroDate)
+	}
+
+	// Placeholders break other rules by accident, report them first
+	if c.isPlaceholder(date) {
+		return withConfidence(newError(msgPlaceholder, "date", date), placeholderConfidence(date, floor))
+	}
+
+	// Check if date is too far in the past (before year 1800 by default)
+	if date.Before(floor) {
+		return newError(msgBeforeFloor, "date", date, "floor", floor)
+	}
+
+	return nil
+}
+
+// validateBirthDate validates a user's birth date
+func (c *config) validateBirthDate(birthDate time.Time) error {
+	return c.validateLifetimeLeniently(birthDate, time.Time{})
+}
+
+// validateLifetime validates a user's birth date and, when it is set, their
+// death date. The age limit is measured at the death date for deceased users.
+func (c *config) validateLifetime(birthDate, deathDate time.Time) error {
+	if err := c.validateDate(birthDate, c.minBirthDate); err != nil {
+		return atField(err, fieldBirthDate)
+	}
+
+	now := c.truncate(c.now())
+	birthDate = c.truncate(birthDate)
+
+	// Check if birth date is in the future
+	if birthDate.After(now) {
+		return atField(newError(msgFutureBirth), fieldBirthDate)
+	}
+
+	end := now
+	if !deathDate.IsZero() {
+		if err := c.validateDate(deathDate, c.minBirthDate); err != nil {
+			return atField(err, fieldDeathDate)
+		}
+		deathDate = c.truncate(deathDate)
+
+		if deathDate.Before(birthDate) {
+			return atField(newError(msgDeathBeforeBirth, "death", deathDate, "birth", birthDate), fieldDeathDate)
+		}
+		if deathDate.After(now) {
+			return atField(newError(msgFutureDeath), fieldDeathDate)
+		}
+		end = deathDate
+	}
+
+	// Check if age is unrealistic
+	age := c.ageAt(birthDate, end)
+	if age > MaxHumanAge {
+		return atField(newError(msgMaxAge, "age", age, "max", MaxHumanAge), fieldBirthDate)
+	}
+
+	return nil
+}
+
+// minimumAges defines minimum ages for different entity types
+var minimumAges = map[string]int{
+	"certification": MinCertAge,
+	"training":      MinCertAge,
+	"education":     MinCertAge,
+	"employment":    14, // Minimum working age in many countries
+	"license":       16, // Typical minimum age for licenses
+
+	// Placements open to school pupils, younger than regular employment
+	"internship":     14,
+	"apprenticeship": 15,
+
+	// Lenient floors for youth programs, which take young children along
+	// with a parent; see WithAgeWarnings to only warn about younger users
+	"volunteer":       6,
+	"extracurricular": 3,
+
+	// Academic records. Publications are rare before 10, which only gives a
+	// warning, see defaultAgeWarnings; patents need the legal capacity of
+	// an adult, and jurisdictions with a later age of majority raise it.
+	"award":       MinCertAge,
+	"publication": 10,
+	"patent":      18,
+
+	// Financial products need the contractual capacity of an adult, except
+	// accounts held jointly with a guardian, see guardianAges
+	"bank_account":       18,
+	"credit_application": 18,
+	"loan":               18,
+
+	// Minimum age of criminal responsibility, which jurisdictions set
+	// between 10 and 14; younger users only give a warning, see
+	// defaultAgeWarnings
+	"criminal_record": 12,
+	"court_date":      12,
+
+	// Age of digital consent to online services under COPPA and GDPR
+	// article 8, which jurisdictions raise up to 16; younger users sign up
+	// with a guardian, see guardianAges
+	"signup": 13,
+
+	// Age-banded insurance products
+	"senior_insurance": 60,
+
+	// Entry ages of the stages of education, the entity date being the
+	// start of the stage, across common school systems
+	"kindergarten":        3,
+	"primary_education":   4,
+	"secondary_education": 9,
+	"tertiary_education":  15,
+}
+
+// guardianAges defines the minimum ages of entity types held jointly with a
+// parent or guardian, such as a child's savings account; zero allows any
+// age. Credit and loans have no such allowance.
+var guardianAges = map[string]int{
+	"bank_account": 0,
+	"signup":       0,
+}
+
+// maximumAges defines the maximum ages for entity types that users only
+// start young, the upper bound of the entry-age windows of minimumAges
+var maximumAges = map[string]int{
+	"kindergarten":        7,
+	"primary_education":   8,
+	"secondary_education": 15,
+}
+
+// validateMinimumAge checks if user meets minimum age requirements for certain entity types
+func (c *config) validateMinimumAge(birthDate, entityDate time.Time, entity Entity) error {
+	if minAge, exists := c.minimumAge(entity); exists {
+		age := c.ageAt(birthDate, c.cutoffDate(entity.Type, entityDate))
+		if age < minAge {
+			return newError(msgTooYoung, "age", age, "type", entityTypeArg(entity.Type), "date", entityDate, "min", minAge)
+		}
+	}
+
+	return nil
+}
+
+// minimumAge returns the minimum age of the user on the date of an entity,
+// the guardian age of its type for entities held with a guardian
+func (c *config) minimumAge(entity Entity) (int, bool) {
+	if guardianAge, ok := c.guardianAges[entity.Type]; ok && entity.Guardian {
+		return guardianAge, guardianAge > 0
+	}
+	minAge, ok := c.minimumAges[entity.Type]
+	return minAge, ok
+}
+
+// validateMaximumAge checks that the user was not older than the maximum
+// age of the entity type on the entity date
+func (c *config) validateMaximumAge(birthDate, entityDate time.Time, entityType string) error {
+	if maxAge, exists := c.maximumAges[entityType]; exists {
+		age := c.ageAt(birthDate, c.cutoffDate(entityType, entityDate))
+		if age > maxAge {
+			return newError(msgTooOldForType, "age", age, "type", entityTypeArg(entityType), "date", entityDate, "max", maxAge)
+		}
+	}
+	return nil
+}
+
+// validateHistoricalRealism checks if the date is historically realistic.
+// A date is too old when it falls strictly before the history cutoff; a date
+// exactly on the cutoff is accepted.
+func (c *config) validateHistoricalRealism(date time.Time) error {
+	now := c.truncate(c.now())
+	cutoff := c.historyCutoff(now)
+
+	if date.Before(cutoff) {
+		return newError(msgTooOld, "date", date, "years", c.ageAt(date, now), "cutoff", cutoff)
+	}
+
+	return nil
+}
+
+// HistoryCutoff returns the earliest date accepted by the historical realism
+// check at the current time. Dates strictly before the cutoff are reported
+// with ErrCodeDateTooOld; the cutoff itself is valid.
+func HistoryCutoff(opts ...Option) time.Time {
+	cfg := newConfig(opts)
+	return cfg.historyCutoff(cfg.truncate(cfg.now()))
+}
+
+// ValidateCertification validates a certification date for a user
+func ValidateCertification(user *User, certDate time.Time, opts ...Option) error {
+	return ValidateEntityDate(user, certDate, "certification", opts...)
+}
+
+// ValidateTraining validates a training date for a user
+func ValidateTraining(user *User, trainingDate time.Time, opts ...Option) error {
+	return ValidateEntityDate(user, trainingDate, "training", opts...)
+}
+
+// ValidateEducation validates an education date for a user
+func ValidateEducation(user *User, educationDate time.Time, opts ...Option) error {
+	return ValidateEntityDate(user, educationDate, "education", opts...)
+}
+
+// ValidateEmployment validates an employment date for a user
+func ValidateEmployment(user *User, employmentDate time.Time, opts ...Option) error {
+	return ValidateEntityDate(user, employmentDate, "employment", opts...)
+}
+
+// ValidateLicense validates a license date for a user
+func ValidateLicense(user *User, licenseDate time.Time, opts ...Option) error {
+	return ValidateEntityDate(user, licenseDate, "license", opts...)
+}
+
+// NewUser creates a new User with validation
+func NewUser(id string, birthDate time.Time, name string, opts ...Option) (*User, error) {
+	cfg := newConfig(opts)
+	return cfg.newUser(id, birthDate, name)
+}
+
+// newUser creates a new User whose birth date is validated with the given settings
+func (c *config) newUser(id string, birthDate time.Time, name string) (*User, error) {
+	if id == "" {
+		return nil, c.localize(newError(msgEmptyUserID))
+	}
+
+	user := &User{
+		ID:        id,
+		BirthDate: birthDate,
+		Name:      name,
+	}
+
+	// Validate birth date
+	if err := c.validateBirthDate(birthDate); err != nil {
+		return nil, c.localize(err)
+	}
+
+	return user, nil
+}
+
+// Validate checks that the user is complete and consistent: the ID is set,
+// the birth date is valid and, if present, the death date falls between the
+// birth date and now. Use it for users that bypass NewUser, such as users
+// decoded from JSON or loaded from a database.
+func (u *User) Validate(opts ...Option) error {
+	cfg := newConfig(opts)
+	return cfg.validateUser(u)
+}
+
+// validateUser checks a user's identity and lifetime
+func (c *config) validateUser(u *User) error {
+	if u == nil {
+		return c.localize(newError(msgNilUser))
+	}
+	if u.ID == "" {
+		return c.localize(newError(msgEmptyUserID))
+	}
+	return c.localize(c.validateLifetimeLeniently(u.BirthDate, u.DeathDate))
+}
+
+// GetAge returns the current age of the user
+func (u *User) GetAge(opts ...Option) int {
+	if u == nil {
+		return 0
+	}
+	cfg := newConfig(opts)
+	return cfg.ageAt(u.BirthDate, cfg.now())
+}
+
+// GetAgeAtDate returns the user's age at a specific date
+func (u *User) GetAgeAtDate(date time.Time, opts ...Option) int {
+	if u == nil {
+		return 0
+	}
+	cfg := newConfig(opts)
+	return cfg.ageAt(u.BirthDate, date)
+}
@@ -0,0 +1,429 @@
+package core
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/internal/msgformat"
+)
+
+//go:generate go run ../internal/catalogcheck
+
+// messageKey identifies the message of a validation error. Keys are the
+// error code followed by a dot and the variant of the message, so that the
+// catalogs can be checked against the error codes.
+type messageKey string
+
+// Message keys
+const (
+	msgZeroDate            messageKey = ErrCodeInvalidDate + ".zero"
+	msgRenewalBeforeIssue  messageKey = ErrCodeInvalidDate + ".renewal_before_issue"
+	msgEndBeforeStart      messageKey = ErrCodeInvalidDate + ".end_before_start"
+	msgBeforeFloor         messageKey = ErrCodeDateTooOld + ".floor"
+	msgTooOld              messageKey = ErrCodeDateTooOld + ".history"
+	msgBeforeBirth         messageKey = ErrCodeBeforeBirth + ".entity"
+	msgSameDayBirth        messageKey = ErrCodeBeforeBirth + ".same_day"
+	msgDeathBeforeBirth    messageKey = ErrCodeBeforeBirth + ".death"
+	msgFutureEntity        messageKey = ErrCodeFutureDate + ".entity"
+	msgFutureBirth         messageKey = ErrCodeFutureDate + ".birth"
+	msgFutureDeath         messageKey = ErrCodeFutureDate + ".death"
+	msgFutureRenewal       messageKey = ErrCodeFutureDate + ".renewal"
+	msgMaxAge              messageKey = ErrCodeUnrealisticAge + ".max_age"
+	msgTooYoung            messageKey = ErrCodeUnrealisticAge + ".too_young"
+	msgTooOldForType       messageKey = ErrCodeUnrealisticAge + ".too_old"
+	msgNilUser             messageKey = ErrCodeInvalidUser + ".nil"
+	msgEmptyUserID         messageKey = ErrCodeInvalidUser + ".empty_id"
+	msgPrenatal            messageKey = ErrCodePrenatal + ".window"
+	msgMarkedExpired       messageKey = ErrCodeExpired + ".marked"
+	msgPeriodExpired       messageKey = ErrCodeExpired + ".period"
+	msgStatusRevoked       messageKey = ErrCodeRevoked + ".status"
+	msgIssuerRevoked       messageKey = ErrCodeRevoked + ".issuer"
+	msgUnknownStatus       messageKey = ErrCodeInvalidStatus + ".unknown"
+	msgStatusTransition    messageKey = ErrCodeInvalidStatus + ".transition"
+	msgRevocationLookup    messageKey = ErrCodeRevocationUnknown + ".lookup"
+	msgSigningWindow       messageKey = ErrCodeOutsideSigningWindow + ".window"
+	msgNilCertificate      messageKey = ErrCodeOutsideSigningWindow + ".nil_certificate"
+	msgAuditFailed         messageKey = ErrCodeAuditFailed + ".append"
+	msgReviewFailed        messageKey = ErrCodeReviewFailed + ".send"
+	msgJurisdictionLookup  messageKey = ErrCodeJurisdictionUnknown + ".lookup"
+	msgNoJurisdictionRules messageKey = ErrCodeJurisdictionUnknown + ".no_rules"
+	msgSwappedDate         messageKey = ErrCodeSwappedDate + ".day_month"
+	msgPlaceholder         messageKey = ErrCodePlaceholderDate + ".default"
+	msgUnknownFormat       messageKey = ErrCodeInvalidDate + ".format"
+	msgExcelLeapDay        messageKey = ErrCodeInvalidDate + ".excel_leap_day"
+	msgTwoDigitYear        messageKey = ErrCodeTwoDigitYear + ".century"
+	msgUnknownPrecision    messageKey = ErrCodeInvalidDate + ".precision"
+	msgNegativeUncertainty messageKey = ErrCodeInvalidDate + ".uncertainty"
+	msgImpreciseDate       messageKey = ErrCodeImpreciseDate + ".straddle"
+	msgLongDuration        messageKey = ErrCodeImplausibleDuration + ".max"
+	msgEntryBeforeIssue    messageKey = ErrCodeOutsideValidity + ".before_issue"
+	msgEntryAfterExpiry    messageKey = ErrCodeOutsideValidity + ".after_expiry"
+	msgNoWorkPermit        messageKey = ErrCodeOutsideValidity + ".no_permit"
+	msgEndDateExpired      messageKey = ErrCodeExpired + ".end_date"
+	msgValidityTooLong     messageKey = ErrCodeDocumentValidity + ".too_long"
+	msgReplacedMissing     messageKey = ErrCodeRenewalChain + ".missing"
+	msgReplacedType        messageKey = ErrCodeRenewalChain + ".type"
+	msgReplacedOrder       messageKey = ErrCodeRenewalChain + ".order"
+	msgReplacedCycle       messageKey = ErrCodeRenewalChain + ".cycle"
+	msgPastEndAge          messageKey = ErrCodeUnrealisticAge + ".end_age"
+	msgStartAfterDeath     messageKey = ErrCodeAfterDeath + ".start"
+	msgEndAfterDeath       messageKey = ErrCodeAfterDeath + ".end"
+	msgJuvenileUnmarked    messageKey = ErrCodeJuvenileRecord + ".unmarked"
+	msgJuvenileAdult       messageKey = ErrCodeJuvenileRecord + ".adult"
+	msgCategoryTooYoung    messageKey = ErrCodeAgeCategory + ".too_young"
+	msgCategoryTooOld      messageKey = ErrCodeAgeCategory + ".too_old"
+	msgUnknownCategory     messageKey = ErrCodeAgeCategory + ".unknown"
+	msgLongTenure          messageKey = ErrCodeImplausibleTenure + ".total"
+	msgGapUnexplained      messageKey = ErrCodeCareerGap + ".unexplained"
+	msgGapExplained        messageKey = ErrCodeCareerGap + ".explained"
+	msgWebhookFailed       messageKey = ErrCodeWebhookFailed + ".send"
+	msgKnownBad            messageKey = ErrCodeKnownBad + ".prescreen"
+	msgRulePanic           messageKey = ErrCodeRulePanic + ".rule"
+	msgValidationPanic     messageKey = ErrCodeRulePanic + ".validation"
+	msgMinorConsent        messageKey = ErrCodeMinorConsent + ".majority"
+	msgBeforeSignup        messageKey = ErrCodeBeforeSignup + ".signup"
+)
+
+// Message keys of the summaries of milestones, which are not errors
+const (
+	msgMilestoneAge     messageKey = "MILESTONE.age"
+	msgMilestoneRenewal messageKey = "MILESTONE.renewal_due"
+	msgMilestoneExpiry  messageKey = "MILESTONE.expiry"
+)
+
+// Message keys of the sentences of narratives, which are not errors
+const (
+	msgNarrativePass          messageKey = "NARRATIVE.pass"
+	msgNarrativeWarnings      messageKey = "NARRATIVE.pass_warnings"
+	msgNarrativeFail          messageKey = "NARRATIVE.fail"
+	msgNarrativeIndeterminate messageKey = "NARRATIVE.indeterminate"
+	msgNarrativeProfilePass   messageKey = "NARRATIVE.profile_pass"
+	msgNarrativeProfileFail   messageKey = "NARRATIVE.profile_fail"
+	msgNarrativeIncomplete    messageKey = "NARRATIVE.profile_incomplete"
+)
+
+// code returns the error code of the message
+func (k messageKey) code() string {
+	code, _, _ := strings.Cut(string(k), ".")
+	return code
+}
+
+// entityTypeArg is a message argument naming an entity type, which catalogs
+// may translate
+type entityTypeArg string
+
+// instantArg is a message argument rendered with its time of day
+type instantArg time.Time
+
+// newError returns a validation error with the English message of key.
+// Arguments are given as name, value pairs and fill the {name} placeholders
+// of the message.
+func newError(key messageKey, args ...any) *DateValidationError {
+	return &DateValidationError{
+		Message: english().format(key, args, ""),
+		Code:    key.code(),
+		Reasons: argReasons(args),
+		key:     key,
+		args:    args,
+	}
+}
+
+// newWarning is like newError for findings that do not make a date invalid
+func newWarning(key messageKey, args ...any) *DateValidationError {
+	err := newError(key, args...)
+	err.Severity = SeverityWarning
+	return err
+}
+
+// newNote is like newError for informational findings
+func newNote(key messageKey, args ...any) *DateValidationError {
+	err := newError(key, args...)
+	err.Severity = SeverityInfo
+	return err
+}
+
+// Localize returns the message of the error in the given locale, such as
+// "fr" or "pt-BR", falling back to the language without its region and then
+// to English. Dates are written in the conventions of the locale. Errors
+// decoded from JSON keep their stored message.
+func (e *DateValidationError) Localize(locale string) string {
+	if e == nil {
+		return ""
+	}
+	if e.key == "" {
+		return e.Message
+	}
+	return lookupCatalog(locale).format(e.key, e.args, locale)
+}
+
+// WithLocale writes the messages of validation errors in the given locale,
+// see Locales, with dates in the conventions of the locale, such as
+// "5 mars 2024" for fr or "05.03.2024" for de. Codes and structured fields
+// are not affected and keep ISO dates.
+func WithLocale(locale string) Option {
+	return func(c *config) {
+		c.messages = lookupCatalog(locale)
+		c.locale = locale
+	}
+}
+
+// localize rewrites the message of a validation error in the configured
+// locale, if any
+func (c *config) localize(err error) error {
+	if c.messages == nil {
+		return err
+	}
+	if dateErr, ok := err.(*DateValidationError); ok && dateErr.key != "" {
+		dateErr.Message = c.messages.format(dateErr.key, dateErr.args, c.locale)
+	}
+	return err
+}
+
+// localizeFindings rewrites the messages of the findings of report from
+// index from on
+func (c *config) localizeFindings(report *Report, from int) {
+	if c.messages == nil || report == nil {
+		return
+	}
+	for _, f := range report.Findings[from:] {
+		c.localize(f)
+	}
+}
+
+// MessageCatalog holds the messages of a locale. Its JSON form is the
+// format of the built-in catalogs in the locales directory:
+//
+//	{
+//	  "locale": "fr",
+//	  "entity_types": {"training": "formation"},
+//	  "messages": {"FUTURE_DATE.entity": "{type} : la date ({date}) ne peut pas être dans le futur"}
+//	}
+//
+// Message keys are an error code and a variant. Messages use the ICU
+// MessageFormat syntax: {name} is replaced with an argument of the error,
+// and numbers such as ages can select plural forms with the plural rules of
+// the locale:
+//
+//	"({age, plural, one {# an} other {# ans}})"
+//
+// Dates use the 2006-01-02 format by default and the conventions of the
+// locale asked for with WithLocale or Localize, which {date, date, long}
+// arguments can refine. Entity types missing from entity_types and messages
+// missing from messages are written in English.
+type MessageCatalog struct {
+	Locale      string            `json:"locale"`
+	EntityTypes map[string]string `json:"entity_types,omitempty"`
+	Messages    map[string]string `json:"messages"`
+
+	compiled map[string]*msgformat.Message
+	fallback *MessageCatalog
+}
+
+//go:embed locales/*.json
+var builtinCatalogs embed.FS
+
+// catalogs holds the message catalogs by lower-case locale
+var catalogs struct {
+	once sync.Once
+	mu   sync.RWMutex
+	byID map[string]*MessageCatalog
+}
+
+// loadCatalogs parses the built-in catalogs once
+func loadCatalogs() {
+	catalogs.once.Do(func() {
+		catalogs.byID = make(map[string]*MessageCatalog)
+		files, _ := builtinCatalogs.ReadDir("locales")
+		for _, file := range files {
+			data, err := builtinCatalogs.ReadFile(path.Join("locales", file.Name()))
+			if err != nil {
+				panic(err)
+			}
+			cat, err := parseMessageCatalog(data)
+			if err != nil {
+				panic(fmt.Sprintf("userdate: built-in catalog %s: %v", file.Name(), err))
+			}
+			catalogs.byID[normalizeLocale(cat.Locale)] = cat
+		}
+		for id, cat := range catalogs.byID {
+			if id != "en" {
+				cat.fallback = catalogs.byID["en"]
+			}
+		}
+	})
+}
+
+// english returns the English catalog, which holds every message
+func english() *MessageCatalog {
+	loadCatalogs()
+	catalogs.mu.RLock()
+	defer catalogs.mu.RUnlock()
+	return catalogs.byID["en"]
+}
+
+// lookupCatalog returns the catalog of a locale, of its language, or the
+// English one
+func lookupCatalog(locale string) *MessageCatalog {
+	loadCatalogs()
+	catalogs.mu.RLock()
+	defer catalogs.mu.RUnlock()
+
+	id := normalizeLocale(locale)
+	if cat, ok := catalogs.byID[id]; ok {
+		return cat
+	}
+	if lang, _, ok := strings.Cut(id, "-"); ok {
+		if cat, ok := catalogs.byID[lang]; ok {
+			return cat
+		}
+	}
+	return catalogs.byID["en"]
+}
+
+// language returns the language of a locale, such as "pt" for "pt-BR"
+func language(locale string) string {
+	lang, _, _ := strings.Cut(normalizeLocale(locale), "-")
+	return lang
+}
+
+// normalizeLocale lowercases a locale and separates its parts with dashes
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// parseMessageCatalog decodes a catalog in its JSON form and parses its
+// messages
+func parseMessageCatalog(data []byte) (*MessageCatalog, error) {
+	var cat MessageCatalog
+	if err := json.Unmarshal(data, &cat); err != nil {
+		return nil, err
+	}
+	if cat.Locale == "" {
+		return nil, fmt.Errorf("catalog has no locale")
+	}
+	cat.compiled = make(map[string]*msgformat.Message, len(cat.Messages))
+	for key, text := range cat.Messages {
+		msg, err := msgformat.Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", key, err)
+		}
+		cat.compiled[key] = msg
+	}
+	return &cat, nil
+}
+
+// RegisterMessageCatalog adds a catalog in the JSON format described on
+// MessageCatalog, replacing any catalog of the same locale. It is meant to
+// be called during program initialization and is safe for concurrent use.
+func RegisterMessageCatalog(data []byte) error {
+	cat, err := parseMessageCatalog(data)
+	if err != nil {
+		return err
+	}
+	loadCatalogs()
+	catalogs.mu.Lock()
+	defer catalogs.mu.Unlock()
+
+	id := normalizeLocale(cat.Locale)
+	catalogs.byID[id] = cat
+	for other, c := range catalogs.byID {
+		if other != "en" {
+			c.fallback = catalogs.byID["en"]
+		}
+	}
+	return nil
+}
+
+// Locales returns the locales that have a message catalog, sorted
+func Locales() []string {
+	loadCatalogs()
+	catalogs.mu.RLock()
+	defer catalogs.mu.RUnlock()
+
+	locales := make([]string, 0, len(catalogs.byID))
+	for _, cat := range catalogs.byID {
+		locales = append(locales, cat.Locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// MissingMessages returns the keys of the English catalog that the catalog
+// of the locale does not translate, sorted. It is nil for complete catalogs
+// and for locales without a catalog.
+func MissingMessages(locale string) []string {
+	cat := lookupCatalog(locale)
+	if cat.fallback == nil {
+		return nil
+	}
+	var missing []string
+	for key := range cat.fallback.Messages {
+		if _, ok := cat.Messages[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// format renders the message of key with the name, value pairs of args.
+// Dates follow the conventions of locale when the message is in its
+// language, and are ISO dates otherwise.
+func (m *MessageCatalog) format(key messageKey, args []any, locale string) string {
+	cat := m
+	msg, ok := cat.compiled[string(key)]
+	if !ok && cat.fallback != nil {
+		cat = cat.fallback
+		msg, ok = cat.compiled[string(key)]
+	}
+	if !ok {
+		return string(key)
+	}
+
+	conventions, localDates := cat.Locale, false
+	if locale != "" && language(locale) == language(cat.Locale) {
+		conventions, localDates = locale, true
+	}
+	return msg.Format(conventions, func(name string) (any, bool) {
+		value, ok := lookupArg(args, name)
+		if t, isTime := value.(time.Time); isTime && !localDates {
+			return t.Format("2006-01-02"), ok
+		}
+		return value, ok
+	}, cat.formatArg)
+}
+
+// lookupArg returns the value of the named argument
+func lookupArg(args []any, name string) (any, bool) {
+	for i := 0; i+1 < len(args); i += 2 {
+		if args[i] == name {
+			return args[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// formatArg renders an argument value
+func (m *MessageCatalog) formatArg(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case instantArg:
+		return time.Time(v).Format(time.RFC3339)
+	case entityTypeArg:
+		if name, ok := m.EntityTypes[string(v)]; ok {
+			return name
+		}
+		return string(v)
+	case error:
+		return v.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// messageKeys lists every message key used by the package
+var messageKeys = []messageKey{
+	msgZeroDate, msgRenewalBeforeIssue, msgBeforeFloor, msgTooOld, msgBeforeBirth, msgSameDayBirth,
+	msgDeathBeforeBirth, msgFutureEntity, msgFutureBirth, msgFutureDeath, msgFutureRenewal, msgMaxAge,
+	msgTooYoung, msgNilUser, msgEmptyUserID, msgPrenatal, msgMarkedExpired, msgPeriodExpired,
+	msgStatusRevoked, msgIssuerRevoked, msgUnknownStatus, msgStatusTransition, msgRevocationLookup,
+	msgSigningWindow, msgNilCertificate, msgAuditFailed, msgSwappedDate, msgPlaceholder,
+	msgUnknownFormat, msgExcelLeapDay, msgTwoDigitYear, msgUnknownPrecision, msgImpreciseDate,
+	msgNegativeUncertainty, msgReviewFailed, msgJurisdictionLookup, msgNoJurisdictionRules,
+	msgTooOldForType, msgEndBeforeStart, msgLongDuration, msgEntryBeforeIssue, msgEntryAfterExpiry,
+	msgNoWorkPermit, msgEndDateExpired, msgValidityTooLong, msgReplacedMissing, msgReplacedType,
+	msgReplacedOrder, msgReplacedCycle, msgPastEndAge, msgStartAfterDeath, msgEndAfterDeath,
+	msgJuvenileUnmarked, msgJuvenileAdult, msgCategoryTooYoung, msgCategoryTooOld, msgUnknownCategory,
+	msgLongTenure, msgGapUnexplained, msgGapExplained, msgMilestoneAge, msgMilestoneRenewal, msgMilestoneExpiry,
+	msgWebhookFailed, msgKnownBad, msgRulePanic, msgValidationPanic, msgNarrativePass, msgNarrativeWarnings,
+	msgNarrativeFail, msgNarrativeIndeterminate, msgNarrativeProfilePass, msgNarrativeProfileFail,
+	msgNarrativeIncomplete, msgMinorConsent, msgBeforeSignup,
+}
+
+func TestBuiltinCatalogsComplete(t *testing.T) {
+	for _, key := range messageKeys {
+		if _, ok := english().Messages[string(key)]; !ok {
+			t.Errorf("no English message for %s", key)
+		}
+	}
+	for _, locale := range []string{"fr", "es", "de", "pt"} {
+		if missing := MissingMessages(locale); len(missing) > 0 {
+			t.Errorf("MissingMessages(%q) = %v", locale, missing)
+		}
+	}
+	if got := strings.Join(Locales(), ","); got != "de,en,es,fr,pt" {
+		t.Errorf("Locales() = %s, want de,en,es,fr,pt", got)
+	}
+}
+
+func TestWithLocale(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"fr", "formation : la date (1 janv. 1989) ne peut pas précéder la date de naissance de l'utilisateur (1 janv. 1990)"},
+		{"es", "formación: la fecha (1 ene 1989) no puede ser anterior a la fecha de nacimiento del usuario (1 ene 1990)"},
+		{"de", "Schulung: das Datum (01.01.1989) darf nicht vor dem Geburtsdatum des Benutzers (01.01.1990) liegen"},
+		{"pt-BR", "formação: a data (1 de jan. de 1989) não pode ser anterior à data de nascimento do usuário (1 de jan. de 1990)"},
+		{"fr_CA", "formation : la date (1 janv. 1989) ne peut pas précéder la date de naissance de l'utilisateur (1 janv. 1990)"},
+		{"ja", "training date (1989-01-01) cannot be before user's birth date (1990-01-01)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			err := ValidateEntityDate(user, mustParseDate("1989-01-01"), "training", now, WithLocale(tt.locale))
+			var dateErr *DateValidationError
+			if !errors.As(err, &dateErr) {
+				t.Fatalf("ValidateEntityDate() error = %v, want a DateValidationError", err)
+			}
+			if dateErr.Message != tt.want || dateErr.Code != ErrCodeBeforeBirth {
+				t.Errorf("Message = %q, want %q", dateErr.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithLocaleReports(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	tests := []struct {
+		name string
+		run  func(opts ...Option) []*DateValidationError
+	}{
+		{"input check", func(opts ...Option) []*DateValidationError {
+			return CheckEntity(nil, Entity{Type: "training", Date: mustParseDate("2020-01-01")}, opts...).Findings
+		}},
+		{"status", func(opts ...Option) []*DateValidationError {
+			user := &User{ID: "u", BirthDate: mustParseDate("1990-01-01")}
+			return CheckEntity(user, Entity{Type: "training", Date: mustParseDate("2020-01-01"), Status: "lost"}, opts...).Findings
+		}},
+		{"warning", func(opts ...Option) []*DateValidationError {
+			user := &User{ID: "u", BirthDate: mustParseDate("1990-01-01")}
+			return CheckEntity(user, Entity{Type: "training", Date: mustParseDate("2020-01-01"), Status: StatusExpired}, opts...).Findings
+		}},
+		{"user", func(opts ...Option) []*DateValidationError {
+			err := (&User{ID: "u", BirthDate: mustParseDate("2030-01-01")}).Validate(opts...)
+			return []*DateValidationError{err.(*DateValidationError)}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			english := tt.run(now)
+			french := tt.run(now, WithLocale("fr"))
+			if len(french) == 0 || len(french) != len(english) {
+				t.Fatalf("findings = %v, want %d", french, len(english))
+			}
+			for i := range french {
+				if french[i].Message == english[i].Message || french[i].Code != english[i].Code {
+					t.Errorf("finding %d = %q, want a French message for %s", i, french[i].Message, english[i].Code)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	err := ValidateEntityDate(user, mustParseDate("1993-01-01"), "license", WithFixedNow(mustParseDate("2025-07-18")))
+	var dateErr *DateValidationError
+	if !errors.As(err, &dateErr) {
+		t.Fatalf("ValidateEntityDate() error = %v", err)
+	}
+
+	want := "der Benutzer war am 01.01.1993 zu jung (3 Jahre) für Lizenz (Mindestalter: 16 Jahre)"
+	if got := dateErr.Localize("de"); got != want {
+		t.Errorf("Localize(de) = %q, want %q", got, want)
+	}
+	if !strings.Contains(dateErr.Message, "at date 1993-01-01") {
+		t.Errorf("Message = %q, want an ISO date without a locale", dateErr.Message)
+	}
+	if got := dateErr.Localize("en-GB"); !strings.Contains(got, "at date 1 Jan 1993") {
+		t.Errorf("Localize(en-GB) = %q, want a British date", got)
+	}
+
+	decoded := &DateValidationError{Message: "stored message", Code: ErrCodeUnrealisticAge}
+	if got := decoded.Localize("fr"); got != "stored message" {
+		t.Errorf("Localize() of a decoded error = %q, want the stored message", got)
+	}
+}
+
+func TestRegisterMessageCatalog(t *testing.T) {
+	t.Cleanup(func() {
+		catalogs.mu.Lock()
+		delete(catalogs.byID, "it")
+		catalogs.mu.Unlock()
+	})
+
+	err := RegisterMessageCatalog([]byte(`{
+		"locale": "it",
+		"entity_types": {"training": "formazione"},
+		"messages": {"FUTURE_DATE.entity": "{type}: la data ({date}) non può essere nel futuro"}
+	}`))
+	if err != nil {
+		t.Fatalf("RegisterMessageCatalog() error = %v", err)
+	}
+
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	err = ValidateEntityDate(user, mustParseDate("2026-01-01"), "training", now, WithLocale("it"))
+	if want := "formazione: la data (2026-01-01) non può essere nel futuro"; !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("error = %v, want message %q", err, want)
+	}
+
+	// Missing messages fall back to English
+	err = ValidateEntityDate(user, mustParseDate("1989-01-01"), "training", now, WithLocale("it"))
+	if want := "training date (1989-01-01) cannot be before"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %v, want the English message", err)
+	}
+	if missing := MissingMessages("it"); len(missing) != len(messageKeys)-1 {
+		t.Errorf("MissingMessages(it) = %d keys, want %d", len(missing), len(messageKeys)-1)
+	}
+
+	if err := RegisterMessageCatalog([]byte(`{"messages": {}}`)); err == nil {
+		t.Error("RegisterMessageCatalog() without a locale succeeded")
+	}
+}
+
+func TestPluralMessages(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+
+	tests := []struct {
+		name   string
+		date   string
+		locale string
+		want   string
+	}{
+		{"one", "1991-06-01", "en", "user was too young (1 year old) for license"},
+		{"other", "1993-01-01", "en", "user was too young (3 years old) for license"},
+		{"french zero", "1990-06-01", "fr", "l'utilisateur était trop jeune (0 an) pour permis"},
+		{"french other", "1993-01-01", "fr", "l'utilisateur était trop jeune (3 ans) pour permis"},
+		{"spanish zero", "1990-06-01", "es", "el usuario era demasiado joven (0 años) para licencia"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntityDate(user, mustParseDate(tt.date), "license", now, WithLocale(tt.locale))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want message containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterMessageCatalogSyntax(t *testing.T) {
+	err := RegisterMessageCatalog([]byte(`{
+		"locale": "it",
+		"messages": {"UNREALISTIC_AGE.too_young": "troppo giovane ({age, plural, one {# anno}})"}
+	}`))
+	if err == nil || !strings.Contains(err.Error(), "UNREALISTIC_AGE.too_young") {
+		t.Errorf("RegisterMessageCatalog() error = %v, want a syntax error for UNREALISTIC_AGE.too_young", err)
+	}
+	if slices.Contains(Locales(), "it") {
+		t.Error("invalid catalog was registered")
+	}
+}
+
+func TestLocalizedReportKeepsISODates(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	report := CheckEntityDate(user, mustParseDate("1989-03-05"), "training",
+		WithFixedNow(mustParseDate("2025-07-18")), WithLocale("de"))
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"entity_date":"1989-03-05T00:00:00Z"`) {
+		t.Errorf("report = %s, want an ISO entity date", data)
+	}
+	if !strings.Contains(string(data), "das Datum (05.03.1989)") {
+		t.Errorf("report = %s, want a German message", data)
+	}
+}
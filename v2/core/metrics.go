@@ -0,0 +1,98 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WritePrometheus writes the snapshot in the Prometheus text exposition
+// format, for a /metrics endpoint:
+//
+//	userdate_validations_total                  validations counted
+//	userdate_validations_failed_total           validations with an error
+//	userdate_warnings_total                     warnings found
+//	userdate_findings_total{code}               findings by code
+//	userdate_rule_evaluations_total{rule}       rule evaluations, when profiled
+//	userdate_rule_duration_seconds_total{rule}  time spent in rules, when profiled
+//	userdate_result_cache_hits_total            result cache hits, when cached
+//	userdate_result_cache_misses_total          result cache misses, when cached
+func (s StatsSnapshot) WritePrometheus(w io.Writer) error {
+	return writePrometheus(w, "", map[string]StatsSnapshot{"": s})
+}
+
+// writePrometheus writes the snapshots of WritePrometheus, each series
+// labeled with label set to the key of its snapshot when label is not empty
+func writePrometheus(w io.Writer, label string, snaps map[string]StatsSnapshot) error {
+	bw := bufio.NewWriter(w)
+	keys := slices.Sorted(maps.Keys(snaps))
+	counter := func(name, help string) {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	}
+	// labels formats the label set of a series of the snapshot of key
+	labels := func(key string, pairs ...string) string {
+		var set []string
+		if label != "" {
+			set = append(set, fmt.Sprintf("%s=%q", label, key))
+		}
+		for i := 0; i < len(pairs); i += 2 {
+			set = append(set, fmt.Sprintf("%s=%q", pairs[i], pairs[i+1]))
+		}
+		if len(set) == 0 {
+			return ""
+		}
+		return "{" + strings.Join(set, ",") + "}"
+	}
+
+	counter("userdate_validations_total", "Validations counted.")
+	for _, key := range keys {
+		fmt.Fprintf(bw, "userdate_validations_total%s %d\n", labels(key), snaps[key].Total)
+	}
+	counter("userdate_validations_failed_total", "Validations with an error-level finding.")
+	for _, key := range keys {
+		fmt.Fprintf(bw, "userdate_validations_failed_total%s %d\n", labels(key), snaps[key].Failed)
+	}
+	counter("userdate_warnings_total", "Warnings found.")
+	for _, key := range keys {
+		fmt.Fprintf(bw, "userdate_warnings_total%s %d\n", labels(key), snaps[key].Warnings)
+	}
+	counter("userdate_findings_total", "Findings by error code.")
+	for _, key := range keys {
+		s := snaps[key]
+		for _, code := range slices.Sorted(maps.Keys(s.ByCode)) {
+			fmt.Fprintf(bw, "userdate_findings_total%s %d\n", labels(key, "code", code), s.ByCode[code])
+		}
+	}
+
+	profiled := slices.ContainsFunc(keys, func(key string) bool { return len(snaps[key].Rules) > 0 })
+	if profiled {
+		counter("userdate_rule_evaluations_total", "Rule evaluations.")
+		for _, key := range keys {
+			for _, t := range snaps[key].Rules {
+				fmt.Fprintf(bw, "userdate_rule_evaluations_total%s %d\n", labels(key, "rule", t.Rule), t.Count)
+			}
+		}
+		counter("userdate_rule_duration_seconds_total", "Time spent evaluating rules.")
+		for _, key := range keys {
+			for _, t := range snaps[key].Rules {
+				fmt.Fprintf(bw, "userdate_rule_duration_seconds_total%s %g\n", labels(key, "rule", t.Rule), t.Total.Seconds())
+			}
+		}
+	}
+
+	cached := slices.ContainsFunc(keys, func(key string) bool { return snaps[key].CacheHits+snaps[key].CacheMisses > 0 })
+	if cached {
+		counter("userdate_result_cache_hits_total", "Validations answered from the result cache.")
+		for _, key := range keys {
+			fmt.Fprintf(bw, "userdate_result_cache_hits_total%s %d\n", labels(key), snaps[key].CacheHits)
+		}
+		counter("userdate_result_cache_misses_total", "Validations missing the result cache.")
+		for _, key := range keys {
+			fmt.Fprintf(bw, "userdate_result_cache_misses_total%s %d\n", labels(key), snaps[key].CacheMisses)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	snap := StatsSnapshot{
+		Total:    10,
+		Failed:   3,
+		Warnings: 1,
+		ByCode:   map[string]uint64{ErrCodeFutureDate: 2, ErrCodeBeforeBirth: 1},
+		Rules:    []RuleTiming{{Rule: "future_date", Count: 10, Total: 1500 * time.Microsecond}},
+	}
+	var b strings.Builder
+	if err := snap.WritePrometheus(&b); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	for _, want := range []string{
+		"# TYPE userdate_validations_total counter\nuserdate_validations_total 10\n",
+		"userdate_validations_failed_total 3\n",
+		"userdate_warnings_total 1\n",
+		"userdate_findings_total{code=\"BEFORE_BIRTH\"} 1\nuserdate_findings_total{code=\"FUTURE_DATE\"} 2\n",
+		"userdate_rule_evaluations_total{rule=\"future_date\"} 10\n",
+		"userdate_rule_duration_seconds_total{rule=\"future_date\"} 0.0015\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, b.String())
+		}
+	}
+
+	b.Reset()
+	if err := (StatsSnapshot{}).WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "userdate_rule_") {
+		t.Errorf("rule metrics written without rule profiling:\n%s", b.String())
+	}
+}
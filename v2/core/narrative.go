@@ -0,0 +1,102 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Narrative returns a short paragraph in the given locale, see Locales,
+// telling why the entity date passed or failed, such as for a rejection
+// email. It is templated from the catalogs, not generated: a sentence on
+// the verdict, then the messages of the errors of a failed date, or of the
+// warnings otherwise. Notes are left out.
+func (r *Report) Narrative(locale string) string {
+	if r == nil {
+		return ""
+	}
+	var key messageKey
+	var findings []*DateValidationError
+	switch r.verdict() {
+	case VerdictFail:
+		key = msgNarrativeFail
+		findings = r.withSeverity(SeverityError)
+	case VerdictIndeterminate:
+		key = msgNarrativeIndeterminate
+		findings = r.withSeverity(SeverityWarning)
+	default:
+		key = msgNarrativePass
+		if findings = r.withSeverity(SeverityWarning); len(findings) > 0 {
+			key = msgNarrativeWarnings
+		}
+	}
+	sentences := []string{lookupCatalog(locale).format(key, []any{"type", entityTypeArg(r.EntityType), "date", r.EntityDate}, locale)}
+	for _, f := range findings {
+		sentences = append(sentences, f.Localize(locale))
+	}
+	return paragraph(sentences)
+}
+
+// Narrative returns a short paragraph in the given locale telling why the
+// profile passed or failed: a sentence on the entities that failed, or on
+// all of them when none did, then the Narrative of each entity that failed,
+// or that has warnings when none failed
+func (p *ProfileReport) Narrative(locale string) string {
+	if p == nil {
+		return ""
+	}
+	cat := lookupCatalog(locale)
+	var failed, remarked []string
+	for _, r := range p.Reports {
+		switch {
+		case !r.Valid():
+			failed = append(failed, r.Narrative(locale))
+		case r.verdict() == VerdictIndeterminate || len(r.Warnings()) > 0:
+			remarked = append(remarked, r.Narrative(locale))
+		}
+	}
+	var sentences []string
+	if len(failed) > 0 {
+		sentences = append(sentences, cat.format(msgNarrativeProfileFail, []any{"failed", len(failed), "count", len(p.Reports)}, locale))
+	} else {
+		sentences = append(sentences, cat.format(msgNarrativeProfilePass, []any{"count", len(p.Reports)}, locale))
+	}
+	if p.Incomplete {
+		sentences = append(sentences, cat.format(msgNarrativeIncomplete, nil, locale))
+	}
+	details := remarked
+	if len(failed) > 0 {
+		details = failed
+	}
+	return strings.Join(append([]string{paragraph(sentences)}, details...), " ")
+}
+
+// withSeverity returns the findings of the given severity
+func (r *Report) withSeverity(severity Severity) []*DateValidationError {
+	var found []*DateValidationError
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+// paragraph joins messages as sentences, capitalized and ending with a
+// period
+func paragraph(messages []string) string {
+	sentences := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		msg = strings.TrimSpace(msg)
+		if msg == "" {
+			continue
+		}
+		first, size := utf8.DecodeRuneInString(msg)
+		msg = string(unicode.ToUpper(first)) + msg[size:]
+		if !strings.HasSuffix(msg, ".") {
+			msg += "."
+		}
+		sentences = append(sentences, msg)
+	}
+	return strings.Join(sentences, " ")
+}
@@ -0,0 +1,82 @@
+package core
+
+import "testing"
+
+func TestReportNarrative(t *testing.T) {
+	user := &User{ID: "u1", BirthDate: mustParseDate("1990-05-15")}
+	now := WithFixedNow(mustParseDate("2024-06-15"))
+
+	tests := []struct {
+		name   string
+		entity Entity
+		locale string
+		want   string
+	}{
+		{
+			name:   "pass",
+			entity: Entity{Type: "employment", Date: mustParseDate("2010-01-01")},
+			locale: "en",
+			want:   "The employment dated Jan 1, 2010 passed all date checks.",
+		},
+		{
+			name:   "warnings",
+			entity: Entity{Type: "certification", Date: mustParseDate("2015-01-01"), Status: StatusExpired},
+			locale: "en",
+			want:   "The certification dated Jan 1, 2015 passed the date checks, with remarks. Certification dated Jan 1, 2015 is marked as expired.",
+		},
+		{
+			name:   "fail",
+			entity: Entity{Type: "certification", Date: mustParseDate("1989-01-01")},
+			locale: "en",
+			want:   "The certification dated Jan 1, 1989 failed the date checks. Certification date (Jan 1, 1989) cannot be before user's birth date (May 15, 1990).",
+		},
+		{
+			name:   "localized",
+			entity: Entity{Type: "employment", Date: mustParseDate("2010-01-01")},
+			locale: "fr",
+			want:   "Emploi du 1 janv. 2010 : tous les contrôles de date sont réussis.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckEntity(user, tt.entity, now).Narrative(tt.locale); got != tt.want {
+				t.Errorf("Narrative(%q) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileNarrative(t *testing.T) {
+	user := &User{ID: "u1", BirthDate: mustParseDate("1990-05-15")}
+	now := WithFixedNow(mustParseDate("2024-06-15"))
+	employment := Entity{Type: "employment", Date: mustParseDate("2010-01-01")}
+	expired := Entity{Type: "certification", Date: mustParseDate("2015-01-01"), Status: StatusExpired}
+	early := Entity{Type: "certification", Date: mustParseDate("1989-01-01")}
+
+	tests := []struct {
+		name     string
+		entities []Entity
+		want     string
+	}{
+		{"one entity", []Entity{employment}, "The only entity of the profile passed the date checks."},
+		{"warnings", []Entity{employment, expired}, "All 2 entities of the profile passed the date checks. " +
+			"The certification dated Jan 1, 2015 passed the date checks, with remarks. Certification dated Jan 1, 2015 is marked as expired."},
+		{"fail", []Entity{employment, expired, early}, "1 of 3 entities in the profile failed the date checks. " +
+			"The certification dated Jan 1, 1989 failed the date checks. Certification date (Jan 1, 1989) cannot be before user's birth date (May 15, 1990)."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateProfile(Profile{User: user, Entities: tt.entities}, now)
+			if got := report.Narrative("en"); got != tt.want {
+				t.Errorf("Narrative() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	var nilReport *ProfileReport
+	if got := nilReport.Narrative("en"); got != "" {
+		t.Errorf("nil Narrative() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build userdate_nodata
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain loads the datasets of the data directory, which builds with the
+// userdate_nodata tag leave out
+func TestMain(m *testing.M) {
+	testRuleData = os.DirFS("data")
+	if err := LoadRuleData(testRuleData); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,551 @@
+package core
+
+import (
+	"maps"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/agecalc"
+)
+
+// Option configures how dates are validated. Options can be passed to any
+// validation function to override the package defaults for that call.
+type Option func(*config)
+
+// config holds the effective settings for a validation call.
+type config struct {
+	now       func() time.Time
+	legacyAge bool
+	precision Precision
+
+	// maxHistory overrides the MaxHistoryYears calendar limit when non-zero
+	maxHistory time.Duration
+
+	minBirthDate  time.Time
+	minEntityDate time.Time
+
+	// placeholders are the dates that stand for a missing date
+	placeholders []time.Time
+
+	// yearPivot completes the two-digit years of ParseDate, or yearsAhead
+	// the current year when rollingPivot is set
+	yearPivot    int
+	yearsAhead   int
+	rollingPivot bool
+
+	// sameDayBirth records whether an entity type may fall on the birth
+	// date; the "" key holds the default for unlisted types
+	sameDayBirth map[string]bool
+
+	// prenatal maps entity types to how long before birth they may be dated
+	prenatal map[string]time.Duration
+
+	// validityPeriods maps credential types to how long they stay valid; a
+	// zero period disables expiry checks for the type
+	validityPeriods map[string]ValidityPeriod
+
+	// minimumAges and maximumAges map entity types to the minimum and
+	// maximum age of the user on the entity date, and pinnedAges and
+	// pinnedMaxAges the types set with WithMinimumAge and WithMaximumAge,
+	// which resolved jurisdictions keep
+	minimumAges   map[string]int
+	maximumAges   map[string]int
+	pinnedAges    map[string]bool
+	pinnedMaxAges map[string]bool
+
+	// scopeAge is the age from which records count in background checks
+	scopeAge int
+
+	// juvenileAge is the age under which records are juvenile records
+	juvenileAge int
+
+	// consentTypes are the entity types that are legal consents, majority
+	// the age of majority when pinnedMajority is set, and guardianConsents
+	// the dates of the guardian consents of the profile being validated
+	consentTypes     map[string]bool
+	majority         int
+	pinnedMajority   bool
+	guardianConsents []time.Time
+
+	// maxGap is the longest gap in a profile that needs no explanation
+	maxGap ValidityPeriod
+
+	// renewalNotice is how long before an expiry its renewal is due
+	renewalNotice ValidityPeriod
+
+	// industries holds the industries ExperienceYears counts, nil for all
+	industries map[string]bool
+
+	// ageCategories maps the names of age categories to their age range
+	ageCategories map[string]AgeCategory
+
+	// ageCutoffs maps entity types to the date their age rules take ages
+	// on, the empty type holding the default, and schoolYearEnd holds the
+	// month and day the school year ends on
+	ageCutoffs    map[string]AgeCutoff
+	schoolYearEnd time.Time
+
+	// endAges maps entity types to the age by which they must end
+	endAges map[string]int
+
+	// guardianAges maps entity types to their minimum age when held with a
+	// guardian, see Entity.Guardian
+	guardianAges map[string]int
+
+	// childValidity maps document types to their validity for young users,
+	// and pinnedChildValidity holds the types set with WithChildValidity,
+	// which resolved jurisdictions keep
+	childValidity       map[string]ChildValidity
+	pinnedChildValidity map[string]bool
+
+	// ageWarnings holds the entity types whose minimum and maximum age
+	// findings are warnings rather than errors
+	ageWarnings map[string]bool
+
+	// maxDurations maps entity types to their usual longest duration; a
+	// zero period disables the check for the type
+	maxDurations map[string]ValidityPeriod
+
+	// jurisdictions resolves the jurisdiction of each entity
+	jurisdictions JurisdictionResolver
+
+	// jurisdiction is the code of the jurisdiction whose rules apply, as
+	// set by WithRules or resolved for an entity, for the reason chains
+	jurisdiction string
+
+	// catalog holds the validity periods of types missing from
+	// validityPeriods
+	catalog *Catalog
+
+	ruleOrder      RuleOrder
+	fullEvaluation bool // Keep evaluating after the first error
+
+	// swapDetection enables the day_month_swap rule
+	swapDetection bool
+
+	revocation RevocationChecker
+	identity   IdentityNormalizer
+	audit      *AuditLog
+	stats      *Stats
+	rejects    RejectWriter
+	review     ReviewSink
+	webhook    *WebhookSink
+
+	// resultCache keeps the reports of entity date validations for
+	// resultTTL
+	resultCache ResultCache
+	resultTTL   time.Duration
+
+	// sampledRules holds the percent of validations running each sampled
+	// rule
+	sampledRules map[ruleID]float64
+
+	// skipRules names the rules CallOptions skip, and uncached is set when
+	// call options make reports unfit for the result cache
+	skipRules []string
+	uncached  bool
+
+	// configHash is the ConfigSnapshot hash of a Validator, which its
+	// reports carry, and unscreenedHash the hash of the same options
+	// without WithPrescreen, which the reports of earlier runs carry
+	configHash     string
+	unscreenedHash string
+
+	// recoverPanics turns panics into RULE_PANIC errors, see SafeValidator
+	recoverPanics bool
+
+	// prescreen holds the input hashes of records known to fail
+	prescreen *BloomFilter
+
+	// profileRules records rule timings in stats
+	profileRules bool
+
+	// poolReports takes reports from reportPool
+	poolReports bool
+
+	// shard restricts batch and bulk validation to some users
+	shard Shard
+
+	// messages is the catalog validation messages are written from, nil
+	// for the English messages, and locale the locale asked for
+	messages *MessageCatalog
+	locale   string
+
+	// explain records the evaluation of each rule for Explain
+	explain *Explanation
+}
+
+// MinYear is the earliest year accepted for birth and entity dates by default
+const MinYear = 1800
+
+// defaultConfig returns the package default settings
+func defaultConfig() config {
+	floor := time.Date(MinYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return config{
+		now:             time.Now,
+		minBirthDate:    floor,
+		minEntityDate:   floor,
+		placeholders:    defaultPlaceholderDates,
+		yearPivot:       DefaultTwoDigitYearPivot,
+		validityPeriods: defaultValidityPeriods(),
+		minimumAges:     minimumAges,
+		maximumAges:     maximumAges,
+		maxDurations:    defaultMaxDurations,
+		ageWarnings:     defaultAgeWarnings,
+		childValidity:   defaultChildValidity,
+		guardianAges:    guardianAges,
+		endAges:         endAges,
+		scopeAge:        DefaultScopeAge,
+		juvenileAge:     DefaultJuvenileAge,
+		consentTypes:    defaultConsentTypes,
+		ageCategories:   ageCategories,
+		schoolYearEnd:   defaultSchoolYearEnd,
+		maxGap:          defaultMaxGap,
+		renewalNotice:   defaultRenewalNotice,
+		identity:        DefaultNormalizer{},
+	}
+}
+
+// defaultAgeWarnings are the entity types whose age floors are plausibility
+// hints rather than rules, see WithAgeWarnings
+var defaultAgeWarnings = map[string]bool{
+	"publication": true,
+
+	// Records below the age of criminal responsibility need a review, not
+	// a rejection
+	"criminal_record": true,
+	"court_date":      true,
+}
+
+// newConfig builds a config from the defaults and the given options
+func newConfig(opts []Option) config {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// Precision controls how finely dates are compared with each other.
+type Precision int
+
+const (
+	// PrecisionInstant compares exact instants, including time of day and
+	// zone offset. It is the default.
+	PrecisionInstant Precision = iota
+	// PrecisionDate compares calendar dates as written in their own
+	// location, ignoring time of day and zone offset. A timestamp of
+	// 2020-03-10T23:59+14:00 is treated as March 10, and "today" is the
+	// calendar date of the clock's current time.
+	PrecisionDate
+)
+
+// WithClock sets the function used to obtain the current time. It is the
+// reference for future-date checks, current ages and historical limits.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		if now != nil {
+			c.now = now
+		}
+	}
+}
+
+// WithFixedNow pins the current time to t. It is mainly intended for tests
+// and examples whose expectations would otherwise drift as time passes.
+func WithFixedNow(t time.Time) Option {
+	return WithClock(func() time.Time { return t })
+}
+
+// WithLegacyAgeCalc restores the day-of-year age calculation used by earlier
+// releases. It is off by default because it is off by one day around leap
+// years and for people born on February 29; use it only when results must
+// match records produced by those releases.
+func WithLegacyAgeCalc() Option {
+	return func(c *config) {
+		c.legacyAge = true
+	}
+}
+
+// ageAt returns the number of full years between birth and date
+func (c *config) ageAt(birth, date time.Time) int {
+	if c.legacyAge {
+		age := date.Year() - birth.Year()
+		if date.YearDay() < birth.YearDay() {
+			age--
+		}
+		return age
+	}
+	return agecalc.Years(birth, date)
+}
+
+// WithPrecision sets how dates are compared. The precision applies uniformly
+// to every rule: birth date checks, before-birth and future checks, and ages.
+func WithPrecision(p Precision) Option {
+	return func(c *config) {
+		c.precision = p
+	}
+}
+
+// truncate reduces t to the precision used for comparisons
+func (c *config) truncate(t time.Time) time.Time {
+	if c.precision != PrecisionDate {
+		return t
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// WithMaxHistory sets how far back in time a date may be, as an exact
+// duration before now. By default the limit is MaxHistoryYears calendar
+// years, so the cutoff falls on the same month and day as today.
+func WithMaxHistory(d time.Duration) Option {
+	return func(c *config) {
+		if d > 0 {
+			c.maxHistory = d
+		}
+	}
+}
+
+// historyCutoff returns the earliest acceptable date relative to now
+func (c *config) historyCutoff(now time.Time) time.Time {
+	if c.maxHistory > 0 {
+		return now.Add(-c.maxHistory)
+	}
+	return now.AddDate(-MaxHistoryYears, 0, 0)
+}
+
+// WithMinBirthDate sets the earliest accepted birth date. Earlier birth dates
+// are reported with ErrCodeDateTooOld. The default is January 1 of MinYear;
+// a workforce product might use 1900 while genealogy data needs 1850 or less.
+func WithMinBirthDate(t time.Time) Option {
+	return func(c *config) {
+		c.minBirthDate = t
+	}
+}
+
+// WithMinEntityDate sets the earliest accepted entity date, independently of
+// the birth date floor. Earlier entity dates are reported with
+// ErrCodeDateTooOld. The default is January 1 of MinYear.
+func WithMinEntityDate(t time.Time) Option {
+	return func(c *config) {
+		c.minEntityDate = t
+	}
+}
+
+// WithSameDayBirth sets whether entities of the given types may be dated on
+// the user's birth date, as birth certificates or newborn screenings are.
+// When no types are given it sets the default for all types not configured
+// explicitly. Same-day entities are allowed by default; disallowed ones are
+// reported with ErrCodeBeforeBirth.
+func WithSameDayBirth(allowed bool, entityTypes ...string) Option {
+	return func(c *config) {
+		sameDay := make(map[string]bool, len(c.sameDayBirth)+len(entityTypes)+1)
+		for k, v := range c.sameDayBirth {
+			sameDay[k] = v
+		}
+		if len(entityTypes) == 0 {
+			sameDay[""] = allowed
+		}
+		for _, entityType := range entityTypes {
+			sameDay[entityType] = allowed
+		}
+		c.sameDayBirth = sameDay
+	}
+}
+
+// allowsSameDayBirth reports whether entityType may be dated on the birth date
+func (c *config) allowsSameDayBirth(entityType string) bool {
+	if allowed, ok := c.sameDayBirth[entityType]; ok {
+		return allowed
+	}
+	if allowed, ok := c.sameDayBirth[""]; ok {
+		return allowed
+	}
+	return true
+}
+
+// WithPrenatalWindow lets entities of the given types, such as prenatal
+// screening records, be dated up to window before the user's birth. Such
+// dates produce an ErrCodePrenatal warning instead of ErrCodeBeforeBirth;
+// earlier dates are still rejected.
+func WithPrenatalWindow(window time.Duration, entityTypes ...string) Option {
+	return func(c *config) {
+		prenatal := make(map[string]time.Duration, len(c.prenatal)+len(entityTypes))
+		for k, v := range c.prenatal {
+			prenatal[k] = v
+		}
+		for _, entityType := range entityTypes {
+			prenatal[entityType] = window
+		}
+		c.prenatal = prenatal
+	}
+}
+
+// WithValidityPeriod sets how long entities of the given type stay valid
+// after issuance or renewal, overriding the built-in catalog. A zero period
+// disables expiry checks for the type.
+func WithValidityPeriod(entityType string, period ValidityPeriod) Option {
+	return func(c *config) {
+		periods := make(map[string]ValidityPeriod, len(c.validityPeriods)+1)
+		for k, v := range c.validityPeriods {
+			periods[k] = v
+		}
+		periods[entityType] = period
+		c.validityPeriods = periods
+	}
+}
+
+// WithMinimumAge sets the minimum age of users on the date of entities of
+// the given type, overriding the built-in minimum. Zero removes the minimum.
+func WithMinimumAge(entityType string, age int) Option {
+	return func(c *config) {
+		c.minimumAges = withAge(c.minimumAges, entityType, age)
+		c.pinnedAges = withPin(c.pinnedAges, entityType)
+	}
+}
+
+// WithMaximumAge sets the maximum age of users on the date of entities of
+// the given type, such as the latest age to start kindergarten, overriding
+// the built-in maximum. Zero removes the maximum.
+func WithMaximumAge(entityType string, age int) Option {
+	return func(c *config) {
+		c.maximumAges = withAge(c.maximumAges, entityType, age)
+		c.pinnedMaxAges = withPin(c.pinnedMaxAges, entityType)
+	}
+}
+
+// WithAgeWarnings reports users younger than the minimum age, or older than
+// the maximum age, of entities of the given types as UNREALISTIC_AGE
+// warnings instead of errors, for types such as volunteer work where the
+// age floors are guidance rather than rules.
+func WithAgeWarnings(entityTypes ...string) Option {
+	return func(c *config) {
+		warnings := maps.Clone(c.ageWarnings)
+		if warnings == nil {
+			warnings = make(map[string]bool, len(entityTypes))
+		}
+		for _, entityType := range entityTypes {
+			warnings[entityType] = true
+		}
+		c.ageWarnings = warnings
+	}
+}
+
+// ageFinding records the error of an age rule as a warning in report when
+// the entity type only warns about ages, see WithAgeWarnings
+func (c *config) ageFinding(err error, entityType string, report *Report) error {
+	dateErr, ok := err.(*DateValidationError)
+	if !ok || !c.ageWarnings[entityType] {
+		return err
+	}
+	dateErr.Severity = SeverityWarning
+	report.add(dateErr)
+	return nil
+}
+
+// WithGuardianAge sets the minimum age of users on the date of entities of
+// the given type held jointly with a guardian, see Entity.Guardian, such as
+// 7 for children's bank accounts. Zero allows any age, and a negative age
+// removes the allowance so the minimum age of the type applies.
+func WithGuardianAge(entityType string, age int) Option {
+	return func(c *config) {
+		ages := maps.Clone(c.guardianAges)
+		if ages == nil {
+			ages = make(map[string]int)
+		}
+		if age >= 0 {
+			ages[entityType] = age
+		} else {
+			delete(ages, entityType)
+		}
+		c.guardianAges = ages
+	}
+}
+
+// WithChildValidity sets the validity of documents of the given type, such
+// as passports, issued to users younger than underAge, overriding the
+// built-in rules. A zero underAge removes the shorter validity, so the
+// validity period of the type applies at every age.
+func WithChildValidity(entityType string, underAge int, validity ValidityPeriod) Option {
+	return func(c *config) {
+		children := maps.Clone(c.childValidity)
+		if children == nil {
+			children = make(map[string]ChildValidity)
+		}
+		if underAge > 0 {
+			children[entityType] = ChildValidity{UnderAge: underAge, Validity: validity}
+		} else {
+			delete(children, entityType)
+		}
+		c.childValidity = children
+		c.pinnedChildValidity = withPin(c.pinnedChildValidity, entityType)
+	}
+}
+
+// WithMaxDuration sets the usual longest duration of entities of the given
+// type, from their date to their end date or to now while ongoing. Longer
+// entities get an IMPLAUSIBLE_DURATION warning. A zero period disables the
+// check for the type.
+func WithMaxDuration(entityType string, maxDuration ValidityPeriod) Option {
+	return func(c *config) {
+		durations := maps.Clone(c.maxDurations)
+		if durations == nil {
+			durations = make(map[string]ValidityPeriod)
+		}
+		durations[entityType] = maxDuration
+		c.maxDurations = durations
+	}
+}
+
+// withAge returns a copy of ages with the age of an entity type set, or
+// removed when zero
+func withAge(ages map[string]int, entityType string, age int) map[string]int {
+	ages = maps.Clone(ages)
+	if ages == nil {
+		ages = make(map[string]int)
+	}
+	if age > 0 {
+		ages[entityType] = age
+	} else {
+		delete(ages, entityType)
+	}
+	return ages
+}
+
+// withPin returns a copy of pinned with an entity type added
+func withPin(pinned map[string]bool, entityType string) map[string]bool {
+	pinned = maps.Clone(pinned)
+	if pinned == nil {
+		pinned = make(map[string]bool)
+	}
+	pinned[entityType] = true
+	return pinned
+}
+
+// WithCatalog looks up the validity periods of entity types that have no
+// built-in or WithValidityPeriod period in cat
+func WithCatalog(cat *Catalog) Option {
+	return func(c *config) {
+		c.catalog = cat
+	}
+}
+
+// validityPeriod returns the validity period of an entity type, if it has one
+func (c *config) validityPeriod(entityType string) (ValidityPeriod, bool) {
+	if period, ok := c.validityPeriods[entityType]; ok {
+		return period, period != ValidityPeriod{}
+	}
+	if c.catalog != nil {
+		if e, ok := c.catalog.Lookup(entityType); ok {
+			return e.Validity, e.Validity != ValidityPeriod{}
+		}
+	}
+	return ValidityPeriod{}, false
+}
+
+// needsReport reports whether validations must build a full report even
+// when the caller only wants an error
+func (c *config) needsReport() bool {
+	return c.audit != nil || c.stats != nil || c.webhook != nil || c.resultCache != nil || c.prescreen != nil
+}
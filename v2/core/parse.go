@@ -0,0 +1,185 @@
+package core
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTwoDigitYearPivot is the default of WithTwoDigitYearPivot, as in
+// POSIX strptime and the time package: 69 to 99 are in the 1900s, 00 to 68
+// in the 2000s
+const DefaultTwoDigitYearPivot = 69
+
+// Excel serial dates in the 1900 date system. Serial 1 is 1900-01-01 and
+// serial 60 is 1900-02-29, a day that never existed but that Excel keeps for
+// compatibility with Lotus 1-2-3; serials from 61 on are one day ahead.
+const (
+	excelLeapDay   = 60
+	maxExcelSerial = 2958465 // 9999-12-31
+)
+
+var (
+	excelEpoch     = time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC) // Serial 0, as serials below 60 count
+	excelLeapEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC) // Serial 0, as serials above 60 count
+)
+
+// WithTwoDigitYearPivot sets how ParseDate completes two-digit years: years
+// below pivot are in the 2000s, the others in the 1900s. With a pivot of 30,
+// "15-05-29" is 2029 and "15-05-90" is 1990. The default is
+// DefaultTwoDigitYearPivot.
+func WithTwoDigitYearPivot(pivot int) Option {
+	return func(c *config) {
+		c.yearPivot, c.rollingPivot = pivot, false
+	}
+}
+
+// WithRollingYearPivot completes two-digit years relative to the current
+// year instead of a fixed pivot: a two-digit year is the latest year ending
+// with its digits that is at most ahead years after the current year. With
+// ahead 1 in 2026, "15-05-27" is 2027 and "15-05-28" is 1928. Unlike a fixed
+// pivot, it does not age as the years pass.
+func WithRollingYearPivot(ahead int) Option {
+	return func(c *config) {
+		c.yearsAhead, c.rollingPivot = ahead, true
+	}
+}
+
+// ParseDate parses a date in one of the encodings of spreadsheets and legacy
+// exports, so that it can be validated:
+//   - 2006-01-02 and RFC 3339 timestamps
+//   - YYYYMMDD integers, such as 20060102
+//   - Excel serial numbers of the 1900 date system, such as 38719 or
+//     38719.5 for noon, rejecting the nonexistent 1900-02-29 (serial 60)
+//   - day-first dates with -, / or . separators and four or two-digit
+//     years, such as 02/01/2006 or 02.01.06; see WithTwoDigitYearPivot
+//
+// Dates without a zone are in UTC. Other input is reported with
+// ErrCodeInvalidDate.
+func ParseDate(s string, opts ...Option) (time.Time, error) {
+	t, _, err := ParseDateWarnings(s, opts...)
+	return t, err
+}
+
+// ParseDateWarnings is like ParseDate, but also returns the assumptions made
+// as warnings, so that ingestion of old records is explicit: a two-digit
+// year gives an ErrCodeTwoDigitYear warning naming the year it was read as.
+func ParseDateWarnings(s string, opts ...Option) (time.Time, []*DateValidationError, error) {
+	cfg := newConfig(opts)
+	t, warning, err := cfg.parseDate(strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, nil, cfg.localize(err)
+	}
+	if warning != nil {
+		cfg.localize(warning)
+		return t, []*DateValidationError{warning}, nil
+	}
+	return t, nil, nil
+}
+
+// parseDate parses a trimmed date of ParseDate, with the warning of a
+// two-digit year
+func (c *config) parseDate(s string) (time.Time, *DateValidationError, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil, nil
+	}
+	if len(s) == 8 && isDigits(s) {
+		if t, err := time.Parse("20060102", s); err == nil {
+			return t, nil, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(s, 64); err == nil && isDigits(strings.Replace(s, ".", "", 1)) {
+		t, err := excelSerialDate(s, serial)
+		return t, nil, err
+	}
+	if t, twoDigit, ok := c.parseDayFirst(s); ok {
+		if !twoDigit {
+			return t, nil, nil
+		}
+		// Years are strings, numbers would get digit grouping
+		last := c.lastTwoDigitYear()
+		return t, newWarning(msgTwoDigitYear, "input", s, "year", strconv.Itoa(t.Year()),
+			"first", strconv.Itoa(last-99), "last", strconv.Itoa(last)), nil
+	}
+	return time.Time{}, nil, newError(msgUnknownFormat, "input", s)
+}
+
+// excelSerialDate converts an Excel serial number, written s, to a date
+func excelSerialDate(s string, serial float64) (time.Time, error) {
+	days := math.Floor(serial)
+	switch {
+	case days < 1 || days > maxExcelSerial:
+		return time.Time{}, newError(msgUnknownFormat, "input", s)
+	case days == excelLeapDay:
+		return time.Time{}, newError(msgExcelLeapDay, "input", s)
+	}
+	epoch := excelLeapEpoch
+	if days < excelLeapDay {
+		epoch = excelEpoch
+	}
+	seconds := math.Round((serial - days) * 24 * 60 * 60)
+	return epoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second), nil
+}
+
+// parseDayFirst parses DD-MM-YYYY and DD-MM-YY dates, with -, / or .
+// separators, and reports whether the year had two digits
+func (c *config) parseDayFirst(s string) (t time.Time, twoDigit, ok bool) {
+	i := strings.IndexAny(s, "-/.")
+	if i < 0 {
+		return time.Time{}, false, false
+	}
+	parts := strings.Split(s, s[i:i+1])
+	if len(parts) != 3 || len(parts[0]) > 2 || len(parts[1]) > 2 || len(parts[2]) != 2 && len(parts[2]) != 4 {
+		return time.Time{}, false, false
+	}
+	var n [3]int
+	for i, part := range parts {
+		if part == "" || !isDigits(part) {
+			return time.Time{}, false, false
+		}
+		n[i], _ = strconv.Atoi(part)
+	}
+	day, month, year := n[0], time.Month(n[1]), n[2]
+	twoDigit = len(parts[2]) == 2
+	if twoDigit {
+		year = c.fullYear(year)
+	}
+	t = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day || t.Month() != month {
+		return time.Time{}, false, false
+	}
+	return t, twoDigit, true
+}
+
+// lastTwoDigitYear returns the latest year a two-digit year can stand for;
+// two-digit years cover the 100 years up to it
+func (c *config) lastTwoDigitYear() int {
+	if c.rollingPivot {
+		return c.now().Year() + c.yearsAhead
+	}
+	return 1999 + c.yearPivot
+}
+
+// fullYear completes a two-digit year with the pivot
+func (c *config) fullYear(yy int) int {
+	last := c.lastTwoDigitYear()
+	year := last - last%100 + yy
+	if year > last {
+		year -= 100
+	}
+	return year
+}
+
+// isDigits reports whether s is made of ASCII digits only
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	now2026 := WithFixedNow(mustParseDate("2026-10-18"))
+	tests := []struct {
+		input string
+		opts  []Option
+		want  string // RFC 3339, empty for an error
+	}{
+		{input: "2006-01-02", want: "2006-01-02T00:00:00Z"},
+		{input: "2006-01-02T15:04:05+02:00", want: "2006-01-02T15:04:05+02:00"},
+		{input: " 20060102 ", want: "2006-01-02T00:00:00Z"},
+		{input: "20061302"},
+		{input: "38719", want: "2006-01-02T00:00:00Z"},
+		{input: "38719.5", want: "2006-01-02T12:00:00Z"},
+		{input: "1", want: "1900-01-01T00:00:00Z"},
+		{input: "59", want: "1900-02-28T00:00:00Z"},
+		{input: "61", want: "1900-03-01T00:00:00Z"},
+		{input: "0"},
+		{input: "-5"},
+		{input: "3000000"},
+		{input: "02/01/2006", want: "2006-01-02T00:00:00Z"},
+		{input: "2.1.2006", want: "2006-01-02T00:00:00Z"},
+		{input: "15-05-90", want: "1990-05-15T00:00:00Z"},
+		{input: "15-05-29", want: "2029-05-15T00:00:00Z"},
+		{input: "15-05-29", opts: []Option{WithTwoDigitYearPivot(20)}, want: "1929-05-15T00:00:00Z"},
+		{input: "15-05-27", opts: []Option{now2026, WithRollingYearPivot(1)}, want: "2027-05-15T00:00:00Z"},
+		{input: "15-05-28", opts: []Option{now2026, WithRollingYearPivot(1)}, want: "1928-05-15T00:00:00Z"},
+		{input: "15-05-28", opts: []Option{now2026, WithRollingYearPivot(1), WithTwoDigitYearPivot(30)}, want: "2028-05-15T00:00:00Z"},
+		{input: "31-02-90"},
+		{input: "15-05/90"},
+		{input: "2006/01/02"},
+		{input: "15-05-990"},
+		{input: "yesterday"},
+		{input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input, tt.opts...)
+			if tt.want == "" {
+				var dateErr *DateValidationError
+				if !errors.As(err, &dateErr) || dateErr.Code != ErrCodeInvalidDate {
+					t.Errorf("ParseDate(%q) = %v, %v, want an INVALID_DATE error", tt.input, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDate(%q) unexpected error = %v", tt.input, err)
+			}
+			if s := got.Format(time.RFC3339); s != tt.want {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.input, s, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateExcelLeapDay(t *testing.T) {
+	_, err := ParseDate("60")
+	if err == nil || !strings.Contains(err.Error(), "February 29, 1900") {
+		t.Errorf("ParseDate(60) error = %v, want the nonexistent February 29, 1900", err)
+	}
+	_, err = ParseDate("60", WithLocale("fr"))
+	if err == nil || !strings.Contains(err.Error(), "29 février 1900") {
+		t.Errorf("ParseDate(60) in French error = %v, want a French message", err)
+	}
+}
+
+func TestParseDateWarnings(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2026-10-18"))
+
+	tests := []struct {
+		input string
+		opts  []Option
+		want  string // Warning message, empty for none
+	}{
+		{input: "15-05-90", want: `"15-05-90" has a two-digit year, read as 1990 (two-digit years stand for 1969 to 2068)`},
+		{input: "15-05-90", opts: []Option{WithRollingYearPivot(1)}, want: `"15-05-90" has a two-digit year, read as 1990 (two-digit years stand for 1928 to 2027)`},
+		{input: "15-05-1990"},
+		{input: "19900515"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, warnings, err := ParseDateWarnings(tt.input, append([]Option{now}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("ParseDateWarnings(%q) unexpected error = %v", tt.input, err)
+			}
+			if tt.want == "" {
+				if len(warnings) != 0 {
+					t.Errorf("warnings = %v, want none", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0].Code != ErrCodeTwoDigitYear || warnings[0].Severity != SeverityWarning || warnings[0].Message != tt.want {
+				t.Errorf("warnings = %v, want a TWO_DIGIT_YEAR warning %q", warnings, tt.want)
+			}
+		})
+	}
+
+	if _, warnings, err := ParseDateWarnings("someday"); err == nil || warnings != nil {
+		t.Errorf("ParseDateWarnings(someday) = %v, %v, want only an error", warnings, err)
+	}
+}
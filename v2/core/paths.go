@@ -0,0 +1,104 @@
+package core
+
+// findingField identifies the input a finding is about
+type findingField uint8
+
+// Finding fields
+const (
+	fieldNone       findingField = iota // Not about an input, such as audit failures
+	fieldUser                           // The user as a whole
+	fieldBirthDate                      // User.BirthDate
+	fieldDeathDate                      // User.DeathDate
+	fieldEntity                         // The entity as a whole
+	fieldEntityDate                     // Entity.Date
+	fieldRenewedAt                      // Entity.RenewedAt
+	fieldStatus                         // Entity.Status
+	fieldEndDate                        // Entity.EndDate
+	fieldEntryDate                      // Entity.EntryDate
+	fieldReplaces                       // Entity.Replaces
+)
+
+// atField marks a validation error as being about the given input
+func atField(err error, field findingField) error {
+	if dateErr, ok := err.(*DateValidationError); ok && dateErr.field == fieldNone {
+		dateErr.field = field
+	}
+	return err
+}
+
+// PayloadPaths locates the user and the entity of a validation in the
+// caller's payload, as JSON pointers
+type PayloadPaths struct {
+	User   string // Such as "/user"; findings about the birth date get "/user/birth_date"
+	Entity string // Such as "/educations/2"; status findings get "/educations/2/status"
+
+	// EntityDate is the pointer of the entity date, such as
+	// "/educations/2/end_date". Defaults to Entity followed by "/date".
+	EntityDate string
+}
+
+// path returns the JSON pointer of an input, or "" for findings that are
+// not about an input
+func (p PayloadPaths) path(field findingField) string {
+	switch field {
+	case fieldUser:
+		return p.User
+	case fieldBirthDate:
+		return p.User + "/birth_date"
+	case fieldDeathDate:
+		return p.User + "/death_date"
+	case fieldEntity:
+		return p.Entity
+	case fieldEntityDate:
+		if p.EntityDate != "" {
+			return p.EntityDate
+		}
+		return p.Entity + "/date"
+	case fieldRenewedAt:
+		return p.Entity + "/renewed_at"
+	case fieldStatus:
+		return p.Entity + "/status"
+	case fieldEndDate:
+		return p.Entity + "/end_date"
+	case fieldEntryDate:
+		return p.Entity + "/entry_date"
+	case fieldReplaces:
+		return p.Entity + "/replaces"
+	default:
+		return ""
+	}
+}
+
+// AttachPaths sets the Path of every finding to the JSON pointer of the
+// value it is about, so API consumers can map failures back to their
+// request payload. Findings that are not about an input, such as audit
+// failures, keep an empty path. ValidateProfile attaches paths itself.
+func (r *Report) AttachPaths(paths PayloadPaths) {
+	if r == nil {
+		return
+	}
+	for _, f := range r.Findings {
+		f.Path = paths.path(f.field)
+	}
+}
+
+// len returns the number of findings. A nil report has none.
+func (r *Report) len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.Findings)
+}
+
+// tagFindings marks the findings from index from on as being about field,
+// unless they already name their input
+func (r *Report) tagFindings(from int, field findingField) {
+	if r == nil {
+		return
+	}
+	for _, f := range r.Findings[from:] {
+		if f.field == fieldNone {
+			f.field = field
+		}
+	}
+}
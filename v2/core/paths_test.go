@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// findingPaths returns the code and path of every finding
+func findingPaths(report *Report) []string {
+	var paths []string
+	for _, f := range report.Findings {
+		paths = append(paths, f.Code+" "+f.Path)
+	}
+	return paths
+}
+
+func TestProfileFindingPaths(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	profile := Profile{User: user, Entities: []Entity{
+		{Type: "certification", Date: mustParseDate("2020-01-01")},
+		{Type: "certification", Date: mustParseDate("2020-01-01"), RenewedAt: mustParseDate("2019-01-01")},
+		{Type: "education", Date: mustParseDate("1989-01-01")},
+		{Type: "training", Date: mustParseDate("2020-01-01"), Status: "lost"},
+		{Type: "prenatal_screening", Date: mustParseDate("1989-12-01")},
+	}}
+
+	report := ValidateProfile(profile,
+		WithFixedNow(mustParseDate("2025-07-18")),
+		WithPrenatalWindow(60*24*time.Hour, "prenatal_screening"))
+
+	want := [][]string{
+		nil,
+		{ErrCodeInvalidDate + " /entities/1/renewed_at"},
+		{ErrCodeBeforeBirth + " /entities/2/date"},
+		{ErrCodeInvalidStatus + " /entities/3/status"},
+		{ErrCodePrenatal + " /entities/4/date"},
+	}
+	for i, r := range report.Reports {
+		if got := findingPaths(r); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("entity %d findings = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestProfileUserFindingPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		user *User
+		want string
+	}{
+		{"nil user", nil, ErrCodeInvalidUser + " /user"},
+		{"future birth", &User{ID: "u", BirthDate: mustParseDate("2030-01-01")}, ErrCodeFutureDate + " /user/birth_date"},
+		{"death before birth", &User{ID: "u", BirthDate: mustParseDate("1990-01-01"), DeathDate: mustParseDate("1980-01-01")},
+			ErrCodeBeforeBirth + " /user/death_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := Profile{User: tt.user, Entities: []Entity{{Type: "training", Date: mustParseDate("2020-01-01")}}}
+			report := ValidateProfile(profile, WithFixedNow(mustParseDate("2025-07-18")))
+			if got := findingPaths(report.Reports[0]); !reflect.DeepEqual(got, []string{tt.want}) {
+				t.Errorf("findings = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachPaths(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	report := CheckEntityDate(user, mustParseDate("1989-01-01"), "education", WithShortCircuit(false))
+	if report.Findings[0].Path != "" {
+		t.Fatalf("Path = %q before AttachPaths, want empty", report.Findings[0].Path)
+	}
+
+	report.AttachPaths(PayloadPaths{User: "/applicant", Entity: "/educations/2", EntityDate: "/educations/2/end_date"})
+	for _, f := range report.Findings {
+		if f.Path != "/educations/2/end_date" {
+			t.Errorf("%s Path = %q, want /educations/2/end_date", f.Code, f.Path)
+		}
+	}
+}
+
+func TestAttachPathsSkipsNonInputFindings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "audit.jsonl")
+	log, err := OpenAuditLog(context.Background(), NewFileAuditStore(path))
+	if err != nil {
+		t.Fatalf("OpenAuditLog() error = %v", err)
+	}
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	report := CheckEntityDate(user, mustParseDate("2020-01-01"), "training", WithAuditLog(log))
+
+	report.AttachPaths(PayloadPaths{User: "/user", Entity: "/entities/0"})
+	if len(report.Findings) != 1 || report.Findings[0].Code != ErrCodeAuditFailed || report.Findings[0].Path != "" {
+		t.Errorf("findings = %v, want an AUDIT_FAILED finding without a path", findingPaths(report))
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// defaultPlaceholderDates are the dates systems write for a missing date:
+// the first day of the Go and SQL zero year, the Excel epoch and the Unix
+// epoch
+var defaultPlaceholderDates = []time.Time{
+	time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// DefaultPlaceholderDates returns the dates reported with
+// ErrCodePlaceholderDate by default: 0001-01-01, 1900-01-01 and 1970-01-01
+func DefaultPlaceholderDates() []time.Time {
+	return slices.Clone(defaultPlaceholderDates)
+}
+
+// WithPlaceholderDates replaces the dates that stand for a missing date,
+// such as the default of a form or of a database column. Birth, death and
+// entity dates on them are reported with ErrCodePlaceholderDate instead of
+// the rule they would happen to break. Dates that pass every rule are
+// still valid, with an ErrCodePlaceholderDate warning in reports. Call it
+// without dates to accept them like any other date.
+func WithPlaceholderDates(dates ...time.Time) Option {
+	return func(c *config) {
+		c.placeholders = slices.Clone(dates)
+	}
+}
+
+// isPlaceholder reports whether date is a placeholder date: the same
+// instant, such as the Unix epoch written in a local zone, or midnight of
+// the same calendar date as written
+func (c *config) isPlaceholder(date time.Time) bool {
+	for _, p := range c.placeholders {
+		if date.Equal(p) {
+			return true
+		}
+		h, m, s := date.Clock()
+		if sameDay(date, p) && h == 0 && m == 0 && s == 0 && date.Nanosecond() == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholderError returns err as a placeholder date error, if it is one
+func placeholderError(err error) (*DateValidationError, bool) {
+	dateErr, ok := err.(*DateValidationError)
+	return dateErr, ok && dateErr.Code == ErrCodePlaceholderDate
+}
+
+// withoutPlaceholders returns a copy of c that accepts placeholder dates
+func (c *config) withoutPlaceholders() *config {
+	lenient := *c
+	lenient.placeholders = nil
+	return &lenient
+}
+
+// acceptPlaceholder returns the settings and input to evaluate the rules
+// with when err, the input error, only reports a placeholder date of inputs
+// that pass every rule otherwise. It returns err as a warning then, and
+// nil when the inputs fail otherwise too, which err reports instead.
+func (c *config) acceptPlaceholder(ctx context.Context, user *User, entity Entity, rules []ruleID, err error) (*config, ruleInput, *DateValidationError) {
+	warning, ok := placeholderError(err)
+	if !ok {
+		return nil, ruleInput{}, nil
+	}
+	lenient := c.withoutPlaceholders()
+	in, err := lenient.newRuleInput(ctx, user, entity)
+	if err != nil {
+		return nil, ruleInput{}, nil
+	}
+	dry := *lenient
+	dry.explain, dry.stats, dry.fullEvaluation = nil, nil, false
+	if dry.runRules(rules, &in, nil) != nil {
+		return nil, ruleInput{}, nil
+	}
+	warning.Severity = SeverityWarning
+	return lenient, in, warning
+}
+
+// validateLifetimeLeniently is validateLifetime, accepting the placeholder
+// dates of a lifetime that passes otherwise
+func (c *config) validateLifetimeLeniently(birthDate, deathDate time.Time) error {
+	err := c.validateLifetime(birthDate, deathDate)
+	if _, ok := placeholderError(err); ok && c.withoutPlaceholders().validateLifetime(birthDate, deathDate) == nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlaceholderDates(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	eastern := time.FixedZone("EST", -5*3600)
+
+	tests := []struct {
+		name  string
+		user  *User
+		date  time.Time
+		opts  []Option
+		codes []string
+		valid bool // The placeholder is only a warning
+	}{
+		{
+			name:  "unix epoch instead of before birth",
+			user:  user,
+			date:  mustParseDate("1970-01-01"),
+			codes: []string{ErrCodePlaceholderDate},
+		},
+		{
+			name:  "unix epoch in a local zone",
+			user:  user,
+			date:  time.Unix(0, 0).In(eastern),
+			codes: []string{ErrCodePlaceholderDate},
+		},
+		{
+			name:  "zero year with a location instead of too old",
+			user:  user,
+			date:  time.Date(1, time.January, 1, 0, 0, 0, 0, eastern),
+			codes: []string{ErrCodePlaceholderDate},
+		},
+		{
+			name:  "placeholder birth date passing otherwise",
+			user:  &User{ID: "user123", BirthDate: mustParseDate("1900-01-01")},
+			date:  mustParseDate("2015-03-04"),
+			codes: []string{ErrCodePlaceholderDate},
+			valid: true,
+		},
+		{
+			name:  "placeholder birth date instead of before birth",
+			user:  &User{ID: "user123", BirthDate: mustParseDate("1970-01-01")},
+			date:  mustParseDate("1969-03-04"),
+			codes: []string{ErrCodePlaceholderDate},
+		},
+		{
+			name:  "unix epoch passing otherwise",
+			user:  &User{ID: "user123", BirthDate: mustParseDate("1950-05-15")},
+			date:  mustParseDate("1970-01-01"),
+			codes: []string{ErrCodePlaceholderDate},
+			valid: true,
+		},
+		{
+			name:  "go zero time stays invalid",
+			user:  user,
+			date:  time.Time{},
+			codes: []string{ErrCodeInvalidDate},
+		},
+		{
+			name:  "time of day on a placeholder date",
+			user:  user,
+			date:  time.Date(1970, time.January, 1, 15, 42, 0, 0, time.UTC).In(eastern),
+			codes: []string{ErrCodeBeforeBirth},
+		},
+		{
+			name:  "detection off",
+			user:  user,
+			date:  mustParseDate("1970-01-01"),
+			opts:  []Option{WithPlaceholderDates()},
+			codes: []string{ErrCodeBeforeBirth},
+		},
+		{
+			name:  "custom list",
+			user:  user,
+			date:  mustParseDate("2011-11-11"),
+			opts:  []Option{WithPlaceholderDates(mustParseDate("2011-11-11"))},
+			codes: []string{ErrCodePlaceholderDate},
+			valid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{now}, tt.opts...)
+			report := CheckEntity(tt.user, Entity{Type: "training", Date: tt.date}, opts...)
+			if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+				t.Errorf("codes = %v, want %v", codes, tt.codes)
+			}
+			if report.Valid() != tt.valid {
+				t.Errorf("Valid() = %v, want %v", report.Valid(), tt.valid)
+			}
+			err := ValidateEntityDate(tt.user, tt.date, "training", opts...)
+			if (err == nil) != tt.valid {
+				t.Errorf("ValidateEntityDate() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestPlaceholderDatesRuleConfig(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithPlaceholderDates()}, {WithPlaceholderDates(mustParseDate("2000-02-02"))}} {
+		r := EffectiveRules(opts...)
+		var b strings.Builder
+		if err := r.WriteYAML(&b); err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := ParseRuleConfig([]byte(b.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(parsed.PlaceholderDates, r.PlaceholderDates) {
+			t.Errorf("parsed PlaceholderDates = %v, want %v", parsed.PlaceholderDates, r.PlaceholderDates)
+		}
+	}
+	if _, err := ParseRuleConfig([]byte("placeholder_dates: 1970-01-01, someday\n")); err == nil {
+		t.Error("ParseRuleConfig() accepted an invalid placeholder date")
+	}
+}
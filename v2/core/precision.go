@@ -0,0 +1,166 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatePrecision is how precisely an entity date is known. Dates of month
+// or year precision hold the first day of their month or year.
+type DatePrecision string
+
+// Date precisions
+const (
+	DatePrecisionDay         DatePrecision = "day"         // Known to the day (default)
+	DatePrecisionMonth       DatePrecision = "month"       // Known to the month
+	DatePrecisionYear        DatePrecision = "year"        // Known to the year
+	DatePrecisionApproximate DatePrecision = "approximate" // Within Entity.Uncertainty either way
+)
+
+// DefaultUncertainty is the radius of approximate dates without an
+// Entity.Uncertainty
+var DefaultUncertainty = Uncertainty{Years: 1}
+
+// Uncertainty is the radius of an approximate date, such as a year for
+// "circa 1890", in calendar years, months and days
+type Uncertainty struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ParseUncertainty parses an uncertainty written as by String, such as
+// "1y", "6m" or "1y6m15d"
+func ParseUncertainty(s string) (Uncertainty, error) {
+	var u Uncertainty
+	rest := s
+	for _, unit := range []struct {
+		suffix string
+		n      *int
+	}{{"y", &u.Years}, {"m", &u.Months}, {"d", &u.Days}} {
+		value, after, ok := strings.Cut(rest, unit.suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return Uncertainty{}, fmt.Errorf("invalid uncertainty %q", s)
+		}
+		*unit.n, rest = n, after
+	}
+	if rest != "" || s == "" {
+		return Uncertainty{}, fmt.Errorf("invalid uncertainty %q", s)
+	}
+	return u, nil
+}
+
+// String returns the uncertainty in a compact form such as "1y" or "1y6m",
+// "0d" when it is zero
+func (u Uncertainty) String() string {
+	var b strings.Builder
+	for _, unit := range []struct {
+		n      int
+		suffix string
+	}{{u.Years, "y"}, {u.Months, "m"}, {u.Days, "d"}} {
+		if unit.n != 0 {
+			fmt.Fprintf(&b, "%d%s", unit.n, unit.suffix)
+		}
+	}
+	if b.Len() == 0 {
+		return "0d"
+	}
+	return b.String()
+}
+
+// MarshalText encodes the uncertainty as its String form
+func (u Uncertainty) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText decodes an uncertainty written as by String
+func (u *Uncertainty) UnmarshalText(text []byte) error {
+	parsed, err := ParseUncertainty(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// valid reports whether no part of the uncertainty is negative
+func (u Uncertainty) valid() bool {
+	return u.Years >= 0 && u.Months >= 0 && u.Days >= 0
+}
+
+// Valid reports whether p is a known precision. The empty precision is
+// valid and means DatePrecisionDay.
+func (p DatePrecision) Valid() bool {
+	switch p {
+	case "", DatePrecisionDay, DatePrecisionMonth, DatePrecisionYear, DatePrecisionApproximate:
+		return true
+	}
+	return false
+}
+
+// bounds returns the earliest and the latest day the date of an entity may
+// stand for: its month or year, widened by its uncertainty
+func (e Entity) bounds() (earliest, latest time.Time) {
+	year, month, _ := e.Date.Date()
+	earliest, latest = e.Date, e.Date
+	switch e.DatePrecision {
+	case DatePrecisionMonth:
+		earliest = time.Date(year, month, 1, 0, 0, 0, 0, e.Date.Location())
+		latest = earliest.AddDate(0, 1, -1)
+	case DatePrecisionYear:
+		earliest = time.Date(year, time.January, 1, 0, 0, 0, 0, e.Date.Location())
+		latest = earliest.AddDate(1, 0, -1)
+	}
+	u := e.Uncertainty
+	if e.DatePrecision == DatePrecisionApproximate && u == (Uncertainty{}) {
+		u = DefaultUncertainty
+	}
+	return earliest.AddDate(-u.Years, -u.Months, -u.Days), latest.AddDate(u.Years, u.Months, u.Days)
+}
+
+// comparesEntityDate reports whether a rule compares the entity date with
+// a threshold, and so can be undecided for an imprecise date
+func (id ruleID) comparesEntityDate() bool {
+	switch id {
+	case ruleBeforeBirth, ruleSameDayBirth, ruleFutureDate, ruleMinimumAge, ruleMaximumAge, ruleHistory, ruleConsent:
+		return true
+	}
+	return false
+}
+
+// applyRangeRule applies a rule to the earliest and the latest day an
+// imprecise entity date stands for. The rule fails only when both fail;
+// when only one does, the date may or may not break the rule, which is
+// reported with an ErrCodeImpreciseDate warning.
+func (c *config) applyRangeRule(id ruleID, in *ruleInput, report *Report) error {
+	if !in.imprecise() || !id.comparesEntityDate() {
+		return c.applyRule(id, in, report)
+	}
+	early, late := *in, *in
+	early.entityDate, late.entityDate = in.earliest, in.latest
+	errEarly := c.applyRule(id, &early, report)
+	errLate := c.applyRule(id, &late, nil)
+	if (errEarly == nil) == (errLate == nil) {
+		return errEarly
+	}
+	code := ErrCodeInvalidDate
+	for _, err := range []error{errEarly, errLate} {
+		if dateErr, ok := err.(*DateValidationError); ok {
+			code = dateErr.Code
+		}
+	}
+	report.add(newWarning(msgImpreciseDate, "type", entityTypeArg(in.entity.Type),
+		"earliest", in.earliest, "latest", in.latest, "code", code))
+	return nil
+}
+
+// imprecise reports whether the entity date stands for more than one day
+func (in *ruleInput) imprecise() bool {
+	return !in.earliest.Equal(in.latest)
+}
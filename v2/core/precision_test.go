@@ -0,0 +1,139 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDatePrecision(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+
+	tests := []struct {
+		name      string
+		typ       string
+		date      string
+		precision DatePrecision
+		radius    Uncertainty
+		codes     []string
+		valid     bool
+	}{
+		{"year straddling birth", "account", "1990-01-01", DatePrecisionYear, Uncertainty{}, []string{ErrCodeImpreciseDate}, true},
+		{"year before birth", "account", "1989-01-01", DatePrecisionYear, Uncertainty{}, []string{ErrCodeBeforeBirth}, false},
+		{"month straddling birth", "account", "1990-05-01", DatePrecisionMonth, Uncertainty{}, []string{ErrCodeImpreciseDate}, true},
+		{"month after birth", "account", "1990-06-01", DatePrecisionMonth, Uncertainty{}, nil, true},
+		{"day before birth", "account", "1990-01-01", DatePrecisionDay, Uncertainty{}, []string{ErrCodeBeforeBirth}, false},
+		{"empty precision is day", "account", "1990-01-01", "", Uncertainty{}, []string{ErrCodeBeforeBirth}, false},
+		{"approximate straddling birth", "account", "1991-01-01", DatePrecisionApproximate, Uncertainty{}, []string{ErrCodeImpreciseDate}, true},
+		{"year straddling minimum age", "license", "2006-01-01", DatePrecisionYear, Uncertainty{}, []string{ErrCodeImpreciseDate}, true},
+		{"year straddling now", "account", "2020-01-01", DatePrecisionYear, Uncertainty{}, []string{ErrCodeImpreciseDate}, true},
+		{"year in the future", "account", "2021-01-01", DatePrecisionYear, Uncertainty{}, []string{ErrCodeFutureDate}, false},
+		{"unknown precision", "account", "2010-01-01", "week", Uncertainty{}, []string{ErrCodeInvalidDate}, false},
+		{"approximate within radius", "account", "1991-01-01", DatePrecisionApproximate, Uncertainty{Months: 6}, nil, true},
+		{"radius straddling birth", "account", "1990-06-01", DatePrecisionDay, Uncertainty{Days: 20}, []string{ErrCodeImpreciseDate}, true},
+		{"radius widens a year", "account", "1991-01-01", DatePrecisionYear, Uncertainty{Years: 1}, []string{ErrCodeImpreciseDate}, true},
+		{"radius before birth", "account", "1980-01-01", DatePrecisionApproximate, Uncertainty{Years: 2}, []string{ErrCodeBeforeBirth}, false},
+		{"negative radius", "account", "2010-01-01", DatePrecisionApproximate, Uncertainty{Years: -1}, []string{ErrCodeInvalidDate}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := Entity{Type: tt.typ, Date: mustParseDate(tt.date), DatePrecision: tt.precision, Uncertainty: tt.radius}
+			report := CheckEntity(user, entity, now)
+			if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+				t.Errorf("codes = %v, want %v", codes, tt.codes)
+			}
+			if report.Valid() != tt.valid {
+				t.Errorf("Valid() = %v, want %v", report.Valid(), tt.valid)
+			}
+		})
+	}
+}
+
+func TestDatePrecisionMessage(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	entity := Entity{Type: "license", Date: mustParseDate("2006-01-01"), DatePrecision: DatePrecisionYear}
+	report := CheckEntity(user, entity, WithFixedNow(mustParseDate("2020-06-15")))
+	if len(report.Findings) != 1 {
+		t.Fatalf("findings = %v, want one warning", report.Findings)
+	}
+	want := "license date is only known to be between 2006-01-01 and 2006-12-31, and part of that range fails UNREALISTIC_AGE"
+	if msg := report.Findings[0].Message; msg != want {
+		t.Errorf("message = %q, want %q", msg, want)
+	}
+}
+
+func TestExplainVerdict(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+
+	tests := []struct {
+		name   string
+		entity Entity
+		want   Verdict
+	}{
+		{"definite pass", Entity{Type: "license", Date: mustParseDate("2010-01-01"), DatePrecision: DatePrecisionYear}, VerdictPass},
+		{"definite fail", Entity{Type: "license", Date: mustParseDate("2003-01-01"), DatePrecision: DatePrecisionYear}, VerdictFail},
+		{"indeterminate", Entity{Type: "license", Date: mustParseDate("2006-06-01"), DatePrecision: DatePrecisionApproximate}, VerdictIndeterminate},
+		{"precise pass", Entity{Type: "license", Date: mustParseDate("2006-06-01")}, VerdictPass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Explain(user, tt.entity, now)
+			if got := e.Verdict(); got != tt.want {
+				t.Errorf("Verdict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUncertainty(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Uncertainty
+		wantErr bool
+	}{
+		{"1y", Uncertainty{Years: 1}, false},
+		{"6m", Uncertainty{Months: 6}, false},
+		{"1y6m15d", Uncertainty{Years: 1, Months: 6, Days: 15}, false},
+		{"0d", Uncertainty{}, false},
+		{"", Uncertainty{}, true},
+		{"1w", Uncertainty{}, true},
+		{"6m1y", Uncertainty{}, true},
+		{"-1y", Uncertainty{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseUncertainty(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseUncertainty(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseUncertainty(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.in {
+				t.Errorf("String() = %q, want %q", got.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestUncertaintyJSON(t *testing.T) {
+	var entity Entity
+	if err := json.Unmarshal([]byte(`{"type":"census","date":"1890-01-01T00:00:00Z","date_precision":"approximate","uncertainty":"2y"}`), &entity); err != nil {
+		t.Fatal(err)
+	}
+	if entity.Uncertainty != (Uncertainty{Years: 2}) {
+		t.Errorf("Uncertainty = %+v, want 2 years", entity.Uncertainty)
+	}
+	data, err := json.Marshal(Entity{Type: "census", Date: mustParseDate("1890-01-01")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"census","date":"1890-01-01T00:00:00Z"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+}
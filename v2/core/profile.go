@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Profile is a user together with their dated entities
+type Profile struct {
+	User     *User    `json:"user"`
+	Entities []Entity `json:"entities"`
+
+	// GapExplanations account for gaps between the employments and studies
+	// of the profile, which then only give notes
+	GapExplanations []GapExplanation `json:"gap_explanations,omitempty"`
+}
+
+// ProfileReport holds the reports of a profile validation. The findings
+// carry the JSON pointer of the offending value in the profile, such as
+// /entities/2/date or /user/birth_date.
+type ProfileReport struct {
+	UserID string `json:"user_id"`
+
+	// Reports holds one report per evaluated entity, in the order of the
+	// profile's entities. When Incomplete is set, the last report may be
+	// partial and the entities after it were not evaluated.
+	Reports    []*Report `json:"reports"`
+	Incomplete bool      `json:"incomplete,omitempty"`
+}
+
+// Valid reports whether no evaluated entity has an error-level finding
+func (p *ProfileReport) Valid() bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Reports {
+		if !r.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns the errors of every evaluated entity joined with errors.Join,
+// or nil
+func (p *ProfileReport) Err() error {
+	if p == nil {
+		return ErrNilValue
+	}
+	var errs []error
+	for _, r := range p.Reports {
+		errs = append(errs, r.Err())
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateProfile checks every entity of a profile
+func ValidateProfile(profile Profile, opts ...Option) *ProfileReport {
+	return ValidateProfileWithDeadline(context.Background(), profile, opts...)
+}
+
+// ValidateProfileWithDeadline checks the entities of a profile in order
+// until ctx is done. Instead of blocking past the deadline or returning
+// nothing, it then returns the reports gathered so far with Incomplete set.
+// Rules run in the configured order, so combine it with
+// WithRuleOrder(OrderSeverityFirst) or OrderCheapestFirst to decide which
+// rules get the time budget first.
+func ValidateProfileWithDeadline(ctx context.Context, profile Profile, opts ...Option) *ProfileReport {
+	cfg := newConfig(opts).withContext(ctx)
+	return cfg.validateProfile(ctx, profile)
+}
+
+// ValidateProfile checks every entity of a profile using the validator's
+// settings
+func (v *Validator) ValidateProfile(profile Profile) *ProfileReport {
+	return v.config().validateProfile(context.Background(), profile)
+}
+
+// ValidateProfileWithDeadline is like the package function, using the
+// validator's settings
+func (v *Validator) ValidateProfileWithDeadline(ctx context.Context, profile Profile) *ProfileReport {
+	cfg := v.config().withContext(ctx)
+	return cfg.validateProfile(ctx, profile)
+}
+
+// validateProfile checks the entities of a profile until ctx is done
+func (c *config) validateProfile(ctx context.Context, profile Profile) *ProfileReport {
+	result := &ProfileReport{Reports: make([]*Report, 0, len(profile.Entities))}
+	if profile.User != nil {
+		result.UserID = profile.User.ID
+	}
+	c = c.withGuardianConsents(profile.Entities)
+	signup := c.accountSignup(profile.Entities)
+	permits, byID := c.workPermits(profile.Entities), entitiesByID(profile.Entities)
+	tenure, gaps := c.checkTenure(profile.User, profile.Entities), c.careerGaps(profile)
+	for i, entity := range profile.Entities {
+		if ctx.Err() != nil {
+			result.Incomplete = true
+			break
+		}
+		report := c.newReport(profile.User, entity.Date, entity.Type)
+		c.evaluateEntity(ctx, profile.User, entity, report)
+		if report.Valid() || c.fullEvaluation {
+			c.checkWorkPermits(entity, permits, report)
+			c.checkRenewalChain(entity, byID, report)
+			c.checkAccountEvent(entity, signup, report)
+			if i == tenure.index {
+				report.add(c.localize(tenure.err))
+			}
+			if gap, ok := gaps[i]; ok {
+				report.add(c.localize(gap))
+			}
+		}
+		report = c.finish(ctx, profile.User, entity, report)
+		report.AttachPaths(PayloadPaths{User: "/user", Entity: fmt.Sprintf("/entities/%d", i)})
+		result.Reports = append(result.Reports, report)
+		if report.Incomplete {
+			result.Incomplete = true
+			break
+		}
+	}
+	return result
+}
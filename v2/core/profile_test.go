@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func testProfile() Profile {
+	return Profile{
+		User: &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")},
+		Entities: []Entity{
+			{ID: "cert-1", Type: "certification", Date: mustParseDate("2015-01-01")},
+			{ID: "lic-1", Type: "license", Date: mustParseDate("1989-01-01")},
+			{ID: "cert-2", Type: "training", Date: mustParseDate("2018-01-01")},
+		},
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	result := ValidateProfile(testProfile(), WithFixedNow(mustParseDate("2025-07-18")))
+	if result.Incomplete || len(result.Reports) != 3 {
+		t.Fatalf("ValidateProfile() = %d reports, incomplete %v, want 3 complete reports", len(result.Reports), result.Incomplete)
+	}
+	if result.UserID != "user123" || result.Valid() {
+		t.Errorf("ValidateProfile() = %+v, want an invalid report for user123", result)
+	}
+	if code := codeOf(result.Reports[1].Err()); code != ErrCodeBeforeBirth {
+		t.Errorf("second entity code = %s, want %s", code, ErrCodeBeforeBirth)
+	}
+	if result.Err() == nil {
+		t.Error("Err() = nil, want the joined entity errors")
+	}
+}
+
+func TestValidateProfileWithDeadline(t *testing.T) {
+	// The revocation lookup of the first entity outlives the deadline
+	blocking := checkerFunc(func(ctx context.Context) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	})
+	v := NewValidator(WithFixedNow(mustParseDate("2025-07-18")), WithRevocationChecker(blocking))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result := v.ValidateProfileWithDeadline(ctx, testProfile())
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ValidateProfileWithDeadline() took %v after a 20ms deadline", elapsed)
+	}
+	if !result.Incomplete || len(result.Reports) != 1 {
+		t.Fatalf("ValidateProfileWithDeadline() = %d reports, incomplete %v, want 1 partial report", len(result.Reports), result.Incomplete)
+	}
+	report := result.Reports[0]
+	if !report.Incomplete || !report.Valid() || report.Verdict != VerdictIndeterminate {
+		t.Errorf("partial report = %+v, want a valid incomplete report with an indeterminate verdict", report)
+	}
+}
+
+func TestValidateProfileExpiredContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := ValidateProfileWithDeadline(ctx, testProfile())
+	if !result.Incomplete || len(result.Reports) != 0 {
+		t.Errorf("ValidateProfileWithDeadline() = %d reports, incomplete %v, want no reports, incomplete", len(result.Reports), result.Incomplete)
+	}
+	if !result.Valid() || result.Err() != nil {
+		t.Error("a profile with no evaluated entity has no errors")
+	}
+}
+
+func TestIncompleteReportSignature(t *testing.T) {
+	key := []byte("secret")
+	report := &Report{UserID: "user123", EntityType: "training", Incomplete: true}
+	if err := report.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	report.Incomplete = false
+	if err := VerifyReport(key, report); err == nil {
+		t.Error("VerifyReport() accepted a report whose Incomplete flag was cleared")
+	}
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Reason is a fact a finding derives from, such as the birth date of the
+// user, the date of the entity or the minimum age of its type. Values are
+// not localized and keep ISO dates.
+type Reason struct {
+	Fact  string `json:"fact"`
+	Value string `json:"value"`
+}
+
+// reasonAliases name the context fact a message argument repeats
+var reasonAliases = map[string]string{
+	"birth": "birth_date",
+	"death": "death_date",
+	"type":  "entity_type",
+	"date":  "entity_date",
+}
+
+// argReasons returns the reasons of the name, value pairs of a message
+func argReasons(args []any) []Reason {
+	var reasons []Reason
+	for i := 0; i+1 < len(args); i += 2 {
+		name, ok := args[i].(string)
+		if !ok {
+			continue
+		}
+		reasons = append(reasons, Reason{Fact: name, Value: reasonValue(args[i+1])})
+	}
+	return reasons
+}
+
+// reasonValue renders a message argument as the value of a reason
+func reasonValue(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		if h, m, sec := v.Clock(); h == 0 && m == 0 && sec == 0 && v.Nanosecond() == 0 {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339)
+	case instantArg:
+		return time.Time(v).Format(time.RFC3339)
+	case entityTypeArg:
+		return string(v)
+	case error:
+		return v.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ruleReasons returns the facts of the input of a rule, in the order review
+// UIs show them: the rule, the jurisdiction whose rules apply, the dates of
+// the user, the entity and the reference date
+func (c *config) ruleReasons(id ruleID, in *ruleInput) []Reason {
+	reasons := []Reason{{Fact: "rule", Value: ruleInfos[id].name}}
+	if c.jurisdiction != "" {
+		reasons = append(reasons, Reason{Fact: "jurisdiction", Value: c.jurisdiction})
+	}
+	for _, fact := range []struct {
+		name  string
+		value time.Time
+	}{{"birth_date", in.birthDate}, {"death_date", in.deathDate}} {
+		if !fact.value.IsZero() {
+			reasons = append(reasons, Reason{Fact: fact.name, Value: reasonValue(fact.value)})
+		}
+	}
+	if in.entity.Type != "" {
+		reasons = append(reasons, Reason{Fact: "entity_type", Value: in.entity.Type})
+	}
+	return append(reasons,
+		Reason{Fact: "entity_date", Value: reasonValue(in.entityDate)},
+		Reason{Fact: "now", Value: reasonValue(in.now)})
+}
+
+// chainReasons prepends the facts of the input of a rule to the reasons of
+// a finding, dropping the arguments that repeat them
+func chainReasons(err *DateValidationError, facts []Reason) {
+	chain := slices.Clone(facts)
+	for _, r := range err.Reasons {
+		if fact, ok := reasonAliases[r.Fact]; ok && slices.Contains(facts, Reason{Fact: fact, Value: r.Value}) {
+			continue
+		}
+		if slices.Contains(facts, r) {
+			continue
+		}
+		chain = append(chain, r)
+	}
+	err.Reasons = chain
+}
+
+// reasonFindings chains the reasons of the findings of a rule from index
+// from on, and of err when there is no report
+func (c *config) reasonFindings(id ruleID, in *ruleInput, report *Report, from int, err error) {
+	if report == nil {
+		if dateErr, ok := err.(*DateValidationError); ok {
+			chainReasons(dateErr, c.ruleReasons(id, in))
+		}
+		return
+	}
+	if len(report.Findings) == from {
+		return
+	}
+	facts := c.ruleReasons(id, in)
+	for _, f := range report.Findings[from:] {
+		chainReasons(f, facts)
+	}
+}
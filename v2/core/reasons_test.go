@@ -0,0 +1,99 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReasons(t *testing.T) {
+	fr, err := NewRuleConfig("FR", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &User{ID: "u1", BirthDate: mustParseDate("1990-05-15")}
+	facts := func(pairs ...string) []Reason {
+		var reasons []Reason
+		for i := 0; i+1 < len(pairs); i += 2 {
+			reasons = append(reasons, Reason{Fact: pairs[i], Value: pairs[i+1]})
+		}
+		return reasons
+	}
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		entityDate string
+		entityType string
+		want       []Reason
+	}{
+		{
+			name:       "minimum age under a jurisdiction",
+			opts:       []Option{WithRules(fr), WithLocale("fr")},
+			entityDate: "2005-01-01",
+			entityType: "license",
+			want: facts("rule", "minimum_age", "jurisdiction", "FR", "birth_date", "1990-05-15",
+				"entity_type", "license", "entity_date", "2005-01-01", "now", "2024-06-15", "age", "14", "min", "17"),
+		},
+		{
+			name:       "before birth",
+			entityDate: "1989-01-01",
+			entityType: "certification",
+			want: facts("rule", "before_birth", "birth_date", "1990-05-15",
+				"entity_type", "certification", "entity_date", "1989-01-01", "now", "2024-06-15"),
+		},
+		{
+			name:       "resolved jurisdiction",
+			opts:       []Option{WithJurisdictionResolver(StaticJurisdictions{Users: map[string]string{"u1": "fr"}})},
+			entityDate: "2005-01-01",
+			entityType: "license",
+			want: facts("rule", "minimum_age", "jurisdiction", "FR", "birth_date", "1990-05-15",
+				"entity_type", "license", "entity_date", "2005-01-01", "now", "2024-06-15", "age", "14", "min", "17"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithFixedNow(mustParseDate("2024-06-15"))}, tt.opts...)
+			v := NewValidator(opts...)
+			date := mustParseDate(tt.entityDate)
+
+			report := v.CheckEntityDate(user, date, tt.entityType)
+			if len(report.Findings) != 1 {
+				t.Fatalf("CheckEntityDate() findings = %v, want one", report.Findings)
+			}
+			if got := report.Findings[0].Reasons; !slices.Equal(got, tt.want) {
+				t.Errorf("CheckEntityDate() reasons = %v, want %v", got, tt.want)
+			}
+
+			var dateErr *DateValidationError
+			if err := v.ValidateEntityDate(user, date, tt.entityType); !errors.As(err, &dateErr) {
+				t.Fatalf("ValidateEntityDate() = %v, want a DateValidationError", err)
+			}
+			if !slices.Equal(dateErr.Reasons, tt.want) {
+				t.Errorf("ValidateEntityDate() reasons = %v, want %v", dateErr.Reasons, tt.want)
+			}
+		})
+	}
+}
+
+func TestReasonValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{mustParseDate("2024-03-05"), "2024-03-05"},
+		{time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC), "2024-03-05T14:30:00Z"},
+		{instantArg(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)), "2024-03-05T00:00:00Z"},
+		{entityTypeArg("license"), "license"},
+		{errors.New("lookup failed"), "lookup failed"},
+		{17, "17"},
+	}
+
+	for _, tt := range tests {
+		if got := reasonValue(tt.value); got != tt.want {
+			t.Errorf("reasonValue(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
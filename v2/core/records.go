@@ -0,0 +1,41 @@
+package core
+
+// recordTypes are the criminal justice records, dated on the offence or
+// the hearing. Their findings call for a review rather than a rejection:
+// the age rules only warn, see defaultAgeWarnings, and records of minors
+// are flagged for the handling juvenile records need.
+var recordTypes = map[string]bool{
+	"criminal_record": true,
+	"court_date":      true,
+}
+
+// DefaultJuvenileAge is the age under which records are juvenile records,
+// see WithJuvenileAge
+const DefaultJuvenileAge = 18
+
+// WithJuvenileAge sets the age of the user under which criminal records
+// and court dates are juvenile records, DefaultJuvenileAge by default, for
+// jurisdictions that try older minors as adults.
+func WithJuvenileAge(age int) Option {
+	return func(c *config) {
+		c.juvenileAge = max(age, 0)
+	}
+}
+
+// checkJuvenileRecord warns about records dated while the user was a minor
+// that are not marked as juvenile records, which are often sealed and must
+// not be used like adult records, and about records marked as juvenile
+// while the user was an adult
+func (c *config) checkJuvenileRecord(in *ruleInput, report *Report) {
+	entity := in.entity
+	if !recordTypes[entity.Type] || in.entityDate.Before(in.birthDate) {
+		return
+	}
+	age := c.ageAt(in.birthDate, in.entityDate)
+	switch {
+	case age < c.juvenileAge && !entity.Juvenile:
+		report.add(newWarning(msgJuvenileUnmarked, "type", entityTypeArg(entity.Type), "date", in.entityDate, "age", age, "juvenile", c.juvenileAge))
+	case age >= c.juvenileAge && entity.Juvenile:
+		report.add(newWarning(msgJuvenileAdult, "type", entityTypeArg(entity.Type), "date", in.entityDate, "age", age, "juvenile", c.juvenileAge))
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCriminalRecords(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-06-15")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	england := WithJurisdictionResolver(StaticJurisdictions{Default: "GB"})
+	germany := WithJurisdictionResolver(StaticJurisdictions{Default: "DE"})
+
+	tests := []struct {
+		name      string
+		entity    Entity
+		opts      []Option
+		wantCodes []string
+	}{
+		{"adult record", Entity{Type: "criminal_record", Date: mustParseDate("2020-03-01")}, nil, nil},
+		{"unmarked juvenile record", Entity{Type: "criminal_record", Date: mustParseDate("2015-03-01")}, nil, []string{ErrCodeJuvenileRecord}},
+		{"marked juvenile record", Entity{Type: "criminal_record", Date: mustParseDate("2015-03-01"), Juvenile: true}, nil, nil},
+		{"adult record marked juvenile", Entity{Type: "court_date", Date: mustParseDate("2020-03-01"), Juvenile: true}, nil, []string{ErrCodeJuvenileRecord}},
+		{"under the age of responsibility", Entity{Type: "criminal_record", Date: mustParseDate("2011-03-01"), Juvenile: true}, nil, []string{ErrCodeUnrealisticAge}},
+		{"under the age of responsibility unmarked", Entity{Type: "court_date", Date: mustParseDate("2011-03-01")}, nil, []string{ErrCodeUnrealisticAge, ErrCodeJuvenileRecord}},
+		{"responsible from 10 in England", Entity{Type: "criminal_record", Date: mustParseDate("2011-03-01"), Juvenile: true}, []Option{england}, nil},
+		{"responsible from 14 in Germany", Entity{Type: "criminal_record", Date: mustParseDate("2014-03-01"), Juvenile: true}, []Option{germany}, []string{ErrCodeUnrealisticAge}},
+		{"juvenile age lowered", Entity{Type: "criminal_record", Date: mustParseDate("2017-03-01")}, []Option{WithJuvenileAge(16)}, nil},
+		{"other types", Entity{Type: "certification", Date: mustParseDate("2015-03-01"), Juvenile: true}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, tt.entity, append(tt.opts, now)...)
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Fatalf("findings = %v, want %v", got, tt.wantCodes)
+			}
+			if !report.Valid() {
+				t.Errorf("CheckEntity() error = %v, want only warnings", report.Err())
+			}
+		})
+	}
+}
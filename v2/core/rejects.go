@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Reject is a batch item that failed validation, stored with its report so
+// it can be reprocessed once the cause is fixed
+type Reject struct {
+	Item   Item    `json:"item"`
+	Report *Report `json:"report"`
+}
+
+// RejectWriter persists rejected batch items
+type RejectWriter interface {
+	WriteReject(ctx context.Context, reject Reject) error
+}
+
+// WithRejectWriter makes batch validation write every item that fails to w
+func WithRejectWriter(w RejectWriter) Option {
+	return func(c *config) {
+		c.rejects = w
+	}
+}
+
+// FileRejectWriter writes rejects to a JSON Lines file, one reject per line,
+// which ReadRejects and Replay read back. It is safe for concurrent use
+// within a process.
+type FileRejectWriter struct {
+	file jsonlFile
+}
+
+// NewFileRejectWriter returns a writer appending to the file at path, which
+// is created on the first write if it does not exist
+func NewFileRejectWriter(path string) *FileRejectWriter {
+	return &FileRejectWriter{file: jsonlFile{path: path}}
+}
+
+// WriteReject appends the reject as a line of the file
+func (w *FileRejectWriter) WriteReject(_ context.Context, reject Reject) error {
+	return w.file.append(reject)
+}
+
+// ReadRejects reads the rejects stored in a file written by FileRejectWriter
+func ReadRejects(path string) ([]Reject, error) {
+	file := jsonlFile{path: path}
+	var rejects []Reject
+	err := file.read(func(line []byte) error {
+		var reject Reject
+		if err := json.Unmarshal(line, &reject); err != nil {
+			return err
+		}
+		rejects = append(rejects, reject)
+		return nil
+	})
+	return rejects, err
+}
+
+// Replay validates again the items of a rejects file, typically after the
+// data or the rules were fixed. Items that still fail are written to the
+// reject writer of opts, if any.
+func Replay(path string, opts ...Option) (*BatchResult, error) {
+	rejects, err := ReadRejects(path)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Item, len(rejects))
+	for i, reject := range rejects {
+		items[i] = reject.Item
+	}
+	return ValidateBatch(items, opts...), nil
+}
+
+// writeRejects writes the failed items of a batch to the reject writer
+func (c *config) writeRejects(result *BatchResult) {
+	if c.rejects == nil {
+		return
+	}
+	var errs []error
+	for i, report := range result.Reports {
+		if report.Valid() {
+			continue
+		}
+		reject := Reject{Item: result.Items[i], Report: report}
+		if err := c.rejects.WriteReject(context.Background(), reject); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	result.RejectErr = errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRejectWriterAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rejects.jsonl")
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	items := []Item{
+		{User: user, EntityDate: mustParseDate("2020-01-01"), EntityType: "certification"},
+		{User: user, EntityDate: mustParseDate("1989-01-01"), EntityType: "certification"},
+		{User: user, EntityDate: mustParseDate("2021-01-01"), EntityType: "training"},
+	}
+
+	result := ValidateBatch(items, WithFixedNow(mustParseDate("2020-06-15")), WithRejectWriter(NewFileRejectWriter(path)))
+	if result.RejectErr != nil {
+		t.Fatalf("RejectErr = %v", result.RejectErr)
+	}
+
+	rejects, err := ReadRejects(path)
+	if err != nil {
+		t.Fatalf("ReadRejects() error = %v", err)
+	}
+	if len(rejects) != 2 {
+		t.Fatalf("ReadRejects() returned %d rejects, want 2", len(rejects))
+	}
+	wantCodes := []string{ErrCodeBeforeBirth, ErrCodeFutureDate}
+	for i, reject := range rejects {
+		if reject.Item.User.ID != user.ID || reject.Report.Err() == nil {
+			t.Errorf("reject %d = %+v, want the failed item and its report", i, reject)
+			continue
+		}
+		if code := reject.Report.Findings[0].Code; code != wantCodes[i] {
+			t.Errorf("reject %d code = %s, want %s", i, code, wantCodes[i])
+		}
+	}
+
+	// A year later the future-dated training is valid; the item dated
+	// before birth still fails and is written to the next rejects file
+	next := filepath.Join(t.TempDir(), "still-failing.jsonl")
+	replayed, err := Replay(path, WithFixedNow(mustParseDate("2021-06-15")), WithRejectWriter(NewFileRejectWriter(next)))
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if failed := replayed.Failed(); len(failed) != 1 || !failed[0].EntityDate.Equal(items[1].EntityDate) {
+		t.Errorf("Replay() failed = %v, want only the item dated before birth", failed)
+	}
+	if still, _ := ReadRejects(next); len(still) != 1 {
+		t.Errorf("still-failing rejects = %d, want 1", len(still))
+	}
+}
+
+func TestReplayMissingFile(t *testing.T) {
+	result, err := Replay(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if len(result.Reports) != 0 {
+		t.Errorf("Replay() returned %d reports, want 0", len(result.Reports))
+	}
+}
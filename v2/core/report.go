@@ -0,0 +1,245 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Severity ranks how serious a validation finding is
+type Severity int
+
+const (
+	// SeverityError marks a finding that makes a date invalid. It is the zero
+	// value, so a DateValidationError without an explicit severity is a hard
+	// failure.
+	SeverityError Severity = iota
+	// SeverityWarning marks a date that is unusual but still accepted
+	SeverityWarning
+	// SeverityInfo marks an informational note that needs no action, such
+	// as a gap in a profile the caller explained
+	SeverityInfo
+
+	// SeverityNone means there is no finding at all. It only appears in
+	// summaries such as BatchResult.Worst.
+	SeverityNone Severity = -1
+)
+
+// severityNames maps severities to their text form
+var severityNames = map[Severity]string{
+	SeverityNone:    "none",
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "info",
+}
+
+// String returns the lower-case name of the severity
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText encodes the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity from its name
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if name == string(text) {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q", text)
+}
+
+// rank orders severities from least to most severe
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Report collects the findings of a validation, including warnings that do
+// not make the date invalid
+type Report struct {
+	UserID      string                 `json:"user_id"`
+	BirthDate   time.Time              `json:"birth_date,omitzero"`
+	EntityType  string                 `json:"entity_type"`
+	EntityDate  time.Time              `json:"entity_date"`
+	Findings    []*DateValidationError `json:"findings"`
+	RuleVersion string                 `json:"rule_version"`
+	CheckedAt   time.Time              `json:"checked_at"`
+	Incomplete  bool                   `json:"incomplete,omitempty"`  // Set when a deadline stopped the checks
+	Verdict     Verdict                `json:"verdict"`               // Pass, Fail or Indeterminate, set once the checks ran
+	InputHash   string                 `json:"input_hash,omitempty"`  // HashInputs of the user, entity and rule version
+	Sampled     []string               `json:"sampled,omitempty"`     // Rules skipped by WithSampledRule
+	ConfigHash  string                 `json:"config_hash,omitempty"` // ConfigSnapshot hash of the Validator
+	Signature   string                 `json:"signature,omitempty"`   // Set by Sign
+
+	pooled bool // Taken from reportPool; returned by Release
+}
+
+// RuleVersion identifies the revision of the validation rules. It changes
+// whenever a rule change can alter the outcome for the same inputs.
+const RuleVersion = "1"
+
+// CheckEntityDate validates a date for a user entity like ValidateEntityDate,
+// but returns a report holding warnings as well as the error, if any
+func CheckEntityDate(user *User, entityDate time.Time, entityType string, opts ...Option) *Report {
+	cfg := newConfig(opts)
+	return cfg.checkEntityDate(context.Background(), user, entityDate, entityType)
+}
+
+// checkEntityDate runs all entity date checks and records them in a report
+func (c *config) checkEntityDate(ctx context.Context, user *User, entityDate time.Time, entityType string) *Report {
+	if report := c.prescreened(user, entityDate, entityType); report != nil {
+		return c.finish(ctx, user, Entity{Type: entityType, Date: entityDate}, report)
+	}
+	report, key, cached := c.cachedReport(user, entityDate, entityType)
+	if !cached {
+		report = c.newReport(user, entityDate, entityType)
+		c.evaluateEntityDate(ctx, user, entityDate, entityType, report)
+		c.cacheReport(key, report)
+	}
+	return c.finish(ctx, user, Entity{Type: entityType, Date: entityDate}, report)
+}
+
+// finish completes a report of an entity of user, setting its verdict and
+// sending it to the review sink, webhook sink, audit log and stats if they
+// are set. The review and webhook sinks get ctx. Review and audit storage
+// failures are recorded as a warning.
+func (c *config) finish(ctx context.Context, user *User, entity Entity, report *Report) *Report {
+	report.Verdict = report.verdict()
+	report.InputHash = HashInputs(user, entity, report.RuleVersion)
+	report.ConfigHash = c.configHash
+	c.sendReview(ctx, user, entity, report)
+	c.sendWebhooks(ctx, user, entity, report)
+	if c.audit != nil {
+		if _, err := c.audit.Append(report); err != nil {
+			report.add(c.localize(newWarning(msgAuditFailed, "error", err)))
+		}
+	}
+	if c.stats != nil {
+		c.stats.Record(report)
+	}
+	return report
+}
+
+// newReport returns an empty report for the given inputs
+func (c *config) newReport(user *User, entityDate time.Time, entityType string) *Report {
+	var report *Report
+	if c.poolReports {
+		report = reportPool.Get().(*Report)
+		*report = Report{Findings: report.Findings[:0], pooled: true}
+	} else {
+		report = &Report{}
+	}
+	report.EntityType = entityType
+	report.EntityDate = entityDate
+	report.RuleVersion = RuleVersion
+	report.CheckedAt = c.now()
+	if user != nil {
+		report.UserID = user.ID
+		report.BirthDate = user.BirthDate
+	}
+	return report
+}
+
+// reportPool recycles reports in WithReportPool mode
+var reportPool = sync.Pool{
+	New: func() any { return new(Report) },
+}
+
+// WithReportPool takes reports from a sync.Pool instead of allocating them,
+// for services running enough validations that report garbage matters. The
+// caller must call Release on every report it receives once done with it,
+// and must not use the report afterwards. The findings themselves are not
+// recycled, so errors returned by Err stay valid after Release. Reports
+// kept by the audit log are copies and are not affected.
+func WithReportPool() Option {
+	return func(c *config) {
+		c.poolReports = true
+	}
+}
+
+// Release returns a report obtained in WithReportPool mode to the pool. It
+// does nothing for other reports, so it is always safe to call once.
+func (r *Report) Release() {
+	if r == nil || !r.pooled {
+		return
+	}
+	clear(r.Findings)
+	*r = Report{Findings: r.Findings[:0]}
+	reportPool.Put(r)
+}
+
+// Err returns the first error-level finding, or nil if the date is valid
+func (r *Report) Err() error {
+	if r == nil {
+		return ErrNilValue
+	}
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return f
+		}
+	}
+	return nil
+}
+
+// Valid reports whether the report holds no error-level findings
+func (r *Report) Valid() bool {
+	return r.Err() == nil
+}
+
+// verdict returns VerdictFail when the report holds an error, and
+// VerdictIndeterminate when the checks could not decide: a deadline
+// stopped them, or an imprecise date passes some rule on part of its range
+// only. Otherwise it returns VerdictPass, warnings included.
+func (r *Report) verdict() Verdict {
+	switch {
+	case !r.Valid():
+		return VerdictFail
+	case r.Incomplete || hasCode(r.Findings, ErrCodeImpreciseDate):
+		return VerdictIndeterminate
+	}
+	return VerdictPass
+}
+
+// Warnings returns the warning-level findings
+func (r *Report) Warnings() []*DateValidationError {
+	if r == nil {
+		return nil
+	}
+	var warnings []*DateValidationError
+	for _, f := range r.Findings {
+		if f.Severity == SeverityWarning {
+			warnings = append(warnings, f)
+		}
+	}
+	return warnings
+}
+
+// add records a finding in the report. A nil report discards it.
+func (r *Report) add(err error) {
+	if r == nil || err == nil {
+		return
+	}
+	if dateErr, ok := err.(*DateValidationError); ok {
+		r.Findings = append(r.Findings, dateErr)
+		return
+	}
+	r.Findings = append(r.Findings, &DateValidationError{Message: err.Error(), Code: ErrCodeInvalidDate})
+}
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckEntityDatePrenatal(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2020-06-15")}
+	prenatal := WithPrenatalWindow(280*24*time.Hour, "prenatal_screening")
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		entityType   string
+		date         time.Time
+		errCode      string
+		warningCodes []string
+	}{
+		{
+			name:         "within window",
+			opts:         []Option{prenatal},
+			entityType:   "prenatal_screening",
+			date:         mustParseDate("2020-01-10"),
+			warningCodes: []string{ErrCodePrenatal},
+		},
+		{
+			name:         "window boundary",
+			opts:         []Option{prenatal},
+			entityType:   "prenatal_screening",
+			date:         mustParseDate("2019-09-09"),
+			warningCodes: []string{ErrCodePrenatal},
+		},
+		{
+			name:       "before window",
+			opts:       []Option{prenatal},
+			entityType: "prenatal_screening",
+			date:       mustParseDate("2019-09-08"),
+			errCode:    ErrCodeBeforeBirth,
+		},
+		{
+			name:       "other entity types",
+			opts:       []Option{prenatal},
+			entityType: "vaccination",
+			date:       mustParseDate("2020-01-10"),
+			errCode:    ErrCodeBeforeBirth,
+		},
+		{
+			name:       "no window configured",
+			entityType: "prenatal_screening",
+			date:       mustParseDate("2020-01-10"),
+			errCode:    ErrCodeBeforeBirth,
+		},
+		{
+			name:       "after birth",
+			opts:       []Option{prenatal},
+			entityType: "prenatal_screening",
+			date:       mustParseDate("2020-07-01"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntityDate(user, tt.date, tt.entityType, tt.opts...)
+
+			err := report.Err()
+			if tt.errCode == "" {
+				if err != nil {
+					t.Errorf("Report.Err() unexpected error = %v", err)
+				}
+			} else if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != tt.errCode {
+				t.Errorf("Report.Err() = %v, want code %v", err, tt.errCode)
+			}
+			if report.Valid() != (tt.errCode == "") {
+				t.Errorf("Report.Valid() = %v, want %v", report.Valid(), tt.errCode == "")
+			}
+
+			warnings := report.Warnings()
+			if len(warnings) != len(tt.warningCodes) {
+				t.Fatalf("Report.Warnings() = %v, want codes %v", warnings, tt.warningCodes)
+			}
+			for i, w := range warnings {
+				if w.Code != tt.warningCodes[i] || w.Severity != SeverityWarning {
+					t.Errorf("Report.Warnings()[%d] = %v (%v), want code %v", i, w.Code, w.Severity, tt.warningCodes[i])
+				}
+			}
+
+			// ValidateEntityDate agrees with the report's error
+			if got := ValidateEntityDate(user, tt.date, tt.entityType, tt.opts...); (got == nil) != (err == nil) {
+				t.Errorf("ValidateEntityDate() = %v, want %v", got, err)
+			}
+		})
+	}
+}
+
+func TestReportFields(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	date := mustParseDate("2020-01-01")
+
+	report := NewValidator().CheckEntityDate(user, date, "certification")
+	if report.UserID != "user123" || report.EntityType != "certification" || !report.EntityDate.Equal(date) {
+		t.Errorf("CheckEntityDate() report = %+v", report)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("CheckEntityDate() findings = %v, want none", report.Findings)
+	}
+
+	report = CheckEntityDate(nil, date, "certification")
+	if report.UserID != "" || report.Valid() {
+		t.Errorf("CheckEntityDate(nil) report = %+v, want invalid", report)
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	tests := map[Severity]string{
+		SeverityNone:    "none",
+		SeverityError:   "error",
+		SeverityWarning: "warning",
+		Severity(42):    "Severity(42)",
+	}
+	for s, want := range tests {
+		if got := s.String(); got != want {
+			t.Errorf("Severity.String() = %v, want %v", got, want)
+		}
+	}
+
+	var s Severity
+	if err := s.UnmarshalText([]byte("warning")); err != nil || s != SeverityWarning {
+		t.Errorf("Severity.UnmarshalText() = %v, %v, want %v", s, err, SeverityWarning)
+	}
+	if err := s.UnmarshalText([]byte("fatal")); err == nil {
+		t.Errorf("Severity.UnmarshalText() expected error but got none")
+	}
+}
+
+func TestWithReportPool(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	audit := NewAuditLog()
+	v := NewValidator(WithReportPool(), WithAuditLog(audit))
+
+	report := v.CheckEntityDate(user, mustParseDate("1989-01-01"), "certification")
+	err := report.Err()
+	if codeOf(err) != ErrCodeBeforeBirth {
+		t.Fatalf("Err() = %v, want %s", err, ErrCodeBeforeBirth)
+	}
+	report.Release()
+
+	// Released reports are emptied; errors and audit copies survive
+	if report.UserID != "" || len(report.Findings) != 0 {
+		t.Errorf("released report = %+v, want it reset", report)
+	}
+	if codeOf(err) != ErrCodeBeforeBirth {
+		t.Errorf("error after Release = %v, want %s", err, ErrCodeBeforeBirth)
+	}
+	records := audit.Records()
+	if len(records) != 1 || records[0].Report.UserID != "user123" || len(records[0].Report.Findings) != 1 {
+		t.Errorf("audit record after Release = %+v", records)
+	}
+	records[0].Report.Release()
+	if records[0].Report.UserID != "user123" {
+		t.Error("Release emptied an audit copy")
+	}
+
+	// A second release and releasing unpooled reports are no-ops
+	report.Release()
+	unpooled := CheckEntityDate(user, mustParseDate("2020-01-01"), "certification")
+	unpooled.Release()
+	if unpooled.UserID != "user123" {
+		t.Error("Release emptied a report outside pool mode")
+	}
+	var nilReport *Report
+	nilReport.Release()
+}
+
+func BenchmarkCheckEntityDatePooled(b *testing.B) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entityDate := mustParseDate("2020-01-01")
+	v := NewValidator(WithReportPool())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		v.CheckEntityDate(user, entityDate, "certification").Release()
+	}
+}
+
+func TestReportVerdict(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+
+	tests := []struct {
+		name   string
+		entity Entity
+		want   Verdict
+	}{
+		{"pass", Entity{Type: "account", Date: mustParseDate("2010-01-01")}, VerdictPass},
+		{"pass with warning", Entity{Type: "account", Date: mustParseDate("2010-01-01"), Status: StatusExpired}, VerdictPass},
+		{"fail", Entity{Type: "account", Date: mustParseDate("1989-01-01")}, VerdictFail},
+		{"imprecise", Entity{Type: "account", Date: mustParseDate("1990-01-01"), DatePrecision: DatePrecisionYear}, VerdictIndeterminate},
+		{"imprecise and failing", Entity{Type: "account", Date: mustParseDate("1990-01-01"), DatePrecision: DatePrecisionYear, Status: StatusRevoked}, VerdictFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckEntity(user, tt.entity, now).Verdict; got != tt.want {
+				t.Errorf("Verdict = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Errors returned by remote calls guarded by a ResiliencePolicy
+var (
+	ErrCircuitOpen = errors.New("userdate: circuit breaker open")
+	ErrRateLimited = errors.New("userdate: rate limit exceeded")
+)
+
+// ResiliencePolicy bounds the cost of calls to remote providers so that a
+// slow or failing service cannot stall validation. Zero fields disable the
+// corresponding protection.
+type ResiliencePolicy struct {
+	// Timeout limits each attempt
+	Timeout time.Duration
+
+	// MaxRetries is the number of additional attempts after a failure.
+	// Backoff is the wait before the first retry; it doubles after each
+	// retry, up to MaxBackoff when that is set.
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// RateLimit is the sustained number of calls per second, with bursts of
+	// up to Burst calls. Calls over the limit fail immediately with
+	// ErrRateLimited rather than waiting.
+	RateLimit float64
+	Burst     int
+
+	// FailureThreshold consecutive failed calls open the circuit: calls then
+	// fail immediately with ErrCircuitOpen until Cooldown has elapsed, after
+	// which one trial call is let through.
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+// guard applies a ResiliencePolicy to calls. It is safe for concurrent use.
+type guard struct {
+	policy ResiliencePolicy
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+
+	mu        sync.Mutex
+	tokens    float64
+	refilled  time.Time
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// newGuard returns a guard enforcing policy
+func newGuard(policy ResiliencePolicy) *guard {
+	return &guard{
+		policy: policy,
+		now:    time.Now,
+		sleep:  sleepContext,
+		tokens: float64(max(policy.Burst, 1)),
+	}
+}
+
+// do runs fn under the policy
+func (g *guard) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := g.admit(); err != nil {
+		return err
+	}
+
+	backoff := g.policy.Backoff
+	var err error
+	for attempt := 0; attempt <= g.policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if sleepErr := g.sleep(ctx, backoff); sleepErr != nil {
+				break
+			}
+			backoff *= 2
+			if g.policy.MaxBackoff > 0 && backoff > g.policy.MaxBackoff {
+				backoff = g.policy.MaxBackoff
+			}
+		}
+		if err = g.attempt(ctx, fn); err == nil || ctx.Err() != nil {
+			break
+		}
+		var p permanentError
+		if errors.As(err, &p) {
+			err = p.err
+			break
+		}
+	}
+
+	g.done(err)
+	return err
+}
+
+// attempt runs fn once within the timeout
+func (g *guard) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if g.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.policy.Timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+// admit checks the circuit breaker and takes a rate limit token
+func (g *guard) admit() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+
+	if g.policy.FailureThreshold > 0 && g.failures >= g.policy.FailureThreshold {
+		if now.Before(g.openUntil) || g.trial {
+			return ErrCircuitOpen
+		}
+		// Half-open: let a single trial call through
+		g.trial = true
+	}
+
+	if g.policy.RateLimit > 0 {
+		if !g.refilled.IsZero() {
+			g.tokens += now.Sub(g.refilled).Seconds() * g.policy.RateLimit
+		}
+		burst := float64(max(g.policy.Burst, 1))
+		g.tokens = min(g.tokens, burst)
+		g.refilled = now
+		if g.tokens < 1 {
+			g.trial = false
+			return ErrRateLimited
+		}
+		g.tokens--
+	}
+	return nil
+}
+
+// done records the outcome of a call for the circuit breaker
+func (g *guard) done(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.trial = false
+	if err == nil {
+		g.failures = 0
+		return
+	}
+	g.failures++
+	if g.policy.FailureThreshold > 0 && g.failures >= g.policy.FailureThreshold {
+		g.openUntil = g.now().Add(g.policy.Cooldown)
+	}
+}
+
+// permanentError is a failure that retrying cannot fix, such as a request
+// the remote service rejects
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+// permanent marks err as not worth retrying
+func permanent(err error) error {
+	return permanentError{err}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ResilientRevocationChecker guards a remote RevocationChecker with a
+// ResiliencePolicy. When a lookup fails, times out, is rate limited or the
+// circuit is open, it falls back to the local Fallback checker if one is
+// set, and otherwise returns the error (reported as REVOCATION_UNKNOWN).
+type ResilientRevocationChecker struct {
+	next     RevocationChecker
+	fallback RevocationChecker
+	guard    *guard
+}
+
+// NewResilientRevocationChecker wraps next with policy. fallback may be nil.
+func NewResilientRevocationChecker(next RevocationChecker, policy ResiliencePolicy, fallback RevocationChecker) *ResilientRevocationChecker {
+	return &ResilientRevocationChecker{
+		next:     next,
+		fallback: fallback,
+		guard:    newGuard(policy),
+	}
+}
+
+// IsRevoked asks the wrapped checker under the policy, falling back to the
+// local checker on failure
+func (r *ResilientRevocationChecker) IsRevoked(ctx context.Context, issuer, credentialID string, asOf time.Time) (bool, error) {
+	if r == nil || r.next == nil {
+		return false, ErrNilValue
+	}
+	var revoked bool
+	err := r.guard.do(ctx, func(ctx context.Context) error {
+		var err error
+		revoked, err = r.next.IsRevoked(ctx, issuer, credentialID, asOf)
+		return err
+	})
+	if err != nil && r.fallback != nil {
+		return r.fallback.IsRevoked(ctx, issuer, credentialID, asOf)
+	}
+	return revoked, err
+}
+
+// ResilientJurisdictionResolver guards a remote JurisdictionResolver, the
+// provider of the rules of each entity, with a ResiliencePolicy. When a
+// lookup fails it falls back to the local Fallback resolver if one is set,
+// such as StaticJurisdictions, and otherwise returns the error, which
+// keeps the configured ages and is reported as JURISDICTION_UNKNOWN.
+type ResilientJurisdictionResolver struct {
+	next     JurisdictionResolver
+	fallback JurisdictionResolver
+	guard    *guard
+}
+
+// NewResilientJurisdictionResolver wraps next with policy. fallback may be
+// nil.
+func NewResilientJurisdictionResolver(next JurisdictionResolver, policy ResiliencePolicy, fallback JurisdictionResolver) *ResilientJurisdictionResolver {
+	return &ResilientJurisdictionResolver{
+		next:     next,
+		fallback: fallback,
+		guard:    newGuard(policy),
+	}
+}
+
+// ResolveJurisdiction asks the wrapped resolver under the policy, falling
+// back to the local resolver on failure
+func (r *ResilientJurisdictionResolver) ResolveJurisdiction(ctx context.Context, user *User, entity Entity) (string, error) {
+	if r == nil || r.next == nil {
+		return "", ErrNilValue
+	}
+	var code string
+	err := r.guard.do(ctx, func(ctx context.Context) error {
+		var err error
+		code, err = r.next.ResolveJurisdiction(ctx, user, entity)
+		return err
+	})
+	if err != nil && r.fallback != nil {
+		return r.fallback.ResolveJurisdiction(ctx, user, entity)
+	}
+	return code, err
+}
+
+// ResilientReviewSink guards a remote ReviewSink, such as the adapter of a
+// ticketing system, with a ResiliencePolicy. When a review cannot be sent
+// it goes to the local Fallback sink if one is set, such as a
+// ChanReviewSink drained later, and otherwise the error is reported as
+// REVIEW_FAILED. A closed sink is not retried.
+type ResilientReviewSink struct {
+	next     ReviewSink
+	fallback ReviewSink
+	guard    *guard
+}
+
+// NewResilientReviewSink wraps next with policy. fallback may be nil.
+func NewResilientReviewSink(next ReviewSink, policy ResiliencePolicy, fallback ReviewSink) *ResilientReviewSink {
+	return &ResilientReviewSink{
+		next:     next,
+		fallback: fallback,
+		guard:    newGuard(policy),
+	}
+}
+
+// Review sends the review to the wrapped sink under the policy, falling
+// back to the local sink on failure
+func (r *ResilientReviewSink) Review(ctx context.Context, review Review) error {
+	if r == nil || r.next == nil {
+		return ErrNilValue
+	}
+	err := r.guard.do(ctx, func(ctx context.Context) error {
+		err := r.next.Review(ctx, review)
+		if errors.Is(err, ErrReviewSinkClosed) {
+			return permanent(err)
+		}
+		return err
+	})
+	if err != nil && r.fallback != nil {
+		return r.fallback.Review(ctx, review)
+	}
+	return err
+}
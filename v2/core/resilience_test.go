@@ -0,0 +1,300 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for guard tests
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(_ context.Context, d time.Duration) error {
+	c.t = c.t.Add(d)
+	return nil
+}
+
+// newTestChecker wraps next with policy on a fake clock
+func newTestChecker(next RevocationChecker, policy ResiliencePolicy, fallback RevocationChecker) (*ResilientRevocationChecker, *fakeClock) {
+	clock := &fakeClock{t: mustParseDate("2020-06-15")}
+	r := NewResilientRevocationChecker(next, policy, fallback)
+	r.guard.now = clock.now
+	r.guard.sleep = clock.sleep
+	return r, clock
+}
+
+// flakyChecker fails a fixed number of times before answering
+type flakyChecker struct {
+	calls    int
+	failures int
+}
+
+func (f *flakyChecker) IsRevoked(context.Context, string, string, time.Time) (bool, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return false, errors.New("unavailable")
+	}
+	return true, nil
+}
+
+func TestResilientRevocationCheckerRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		failures    int
+		maxRetries  int
+		wantRevoked bool
+		wantErr     bool
+		wantCalls   int
+		wantElapsed time.Duration
+	}{
+		{name: "first attempt", failures: 0, maxRetries: 2, wantRevoked: true, wantCalls: 1},
+		{name: "recovers on retry", failures: 2, maxRetries: 2, wantRevoked: true, wantCalls: 3, wantElapsed: 300 * time.Millisecond},
+		{name: "retries exhausted", failures: 5, maxRetries: 2, wantErr: true, wantCalls: 3, wantElapsed: 300 * time.Millisecond},
+		{name: "no retries", failures: 1, maxRetries: 0, wantErr: true, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &flakyChecker{failures: tt.failures}
+			r, clock := newTestChecker(next, ResiliencePolicy{MaxRetries: tt.maxRetries, Backoff: 100 * time.Millisecond}, nil)
+			start := clock.t
+
+			revoked, err := r.IsRevoked(context.Background(), "acme", "cert-1", start)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsRevoked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if revoked != tt.wantRevoked {
+				t.Errorf("IsRevoked() = %v, want %v", revoked, tt.wantRevoked)
+			}
+			if next.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", next.calls, tt.wantCalls)
+			}
+			if elapsed := clock.t.Sub(start); elapsed != tt.wantElapsed {
+				t.Errorf("backoff = %v, want %v", elapsed, tt.wantElapsed)
+			}
+		})
+	}
+}
+
+func TestResilientRevocationCheckerTimeout(t *testing.T) {
+	slow := checkerFunc(func(ctx context.Context) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	})
+	r := NewResilientRevocationChecker(slow, ResiliencePolicy{Timeout: 10 * time.Millisecond}, nil)
+
+	_, err := r.IsRevoked(context.Background(), "acme", "cert-1", time.Now())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("IsRevoked() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestResilientRevocationCheckerCircuitBreaker(t *testing.T) {
+	next := &countingChecker{err: errors.New("unavailable")}
+	r, clock := newTestChecker(next, ResiliencePolicy{FailureThreshold: 2, Cooldown: time.Minute}, nil)
+	ctx := context.Background()
+
+	for range 2 {
+		r.IsRevoked(ctx, "acme", "cert-1", clock.t)
+	}
+	if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("IsRevoked() with open circuit error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if next.calls != 2 {
+		t.Errorf("calls while open = %d, want 2", next.calls)
+	}
+
+	// After the cooldown a trial call goes through and reopens the circuit
+	clock.t = clock.t.Add(time.Minute)
+	if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("trial call rejected: %v", err)
+	}
+	if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("IsRevoked() after failed trial error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	// A successful trial closes the circuit
+	next.err = nil
+	clock.t = clock.t.Add(time.Minute)
+	for i := range 3 {
+		if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); err != nil {
+			t.Errorf("call %d after recovery: %v", i, err)
+		}
+	}
+}
+
+func TestResilientRevocationCheckerRateLimit(t *testing.T) {
+	next := &countingChecker{}
+	r, clock := newTestChecker(next, ResiliencePolicy{RateLimit: 2, Burst: 2}, nil)
+	ctx := context.Background()
+
+	for i := range 2 {
+		if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); err != nil {
+			t.Fatalf("call %d within burst: %v", i, err)
+		}
+	}
+	if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("IsRevoked() over limit error = %v, want %v", err, ErrRateLimited)
+	}
+
+	clock.t = clock.t.Add(500 * time.Millisecond)
+	if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); err != nil {
+		t.Errorf("IsRevoked() after refill: %v", err)
+	}
+	if next.calls != 3 {
+		t.Errorf("calls = %d, want 3", next.calls)
+	}
+
+	// Without a burst, one call is let through at a time
+	r, _ = newTestChecker(next, ResiliencePolicy{RateLimit: 10}, nil)
+	if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); err != nil {
+		t.Errorf("IsRevoked() first call without burst: %v", err)
+	}
+	if _, err := r.IsRevoked(ctx, "acme", "cert-1", clock.t); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("IsRevoked() second call without burst error = %v, want %v", err, ErrRateLimited)
+	}
+}
+
+func TestResilientRevocationCheckerFallback(t *testing.T) {
+	local := NewMemoryRevocationList()
+	local.Revoke("acme", "cert-1", mustParseDate("2020-03-01"))
+	remote := &countingChecker{err: errors.New("unavailable")}
+	r, clock := newTestChecker(remote, ResiliencePolicy{MaxRetries: 1}, local)
+
+	revoked, err := r.IsRevoked(context.Background(), "acme", "cert-1", clock.t)
+	if err != nil || !revoked {
+		t.Errorf("IsRevoked() = %v, %v, want true from fallback", revoked, err)
+	}
+
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entity := Entity{ID: "cert-1", Issuer: "acme", Type: "certification", Date: mustParseDate("2019-01-01")}
+	report := CheckEntity(user, entity, WithFixedNow(clock.t), WithRevocationChecker(r))
+	if err := report.Err(); err == nil || err.(*DateValidationError).Code != ErrCodeRevoked {
+		t.Errorf("CheckEntity() error = %v, want %s", err, ErrCodeRevoked)
+	}
+}
+
+// checkerFunc adapts a function to RevocationChecker
+type checkerFunc func(ctx context.Context) (bool, error)
+
+func (f checkerFunc) IsRevoked(ctx context.Context, _, _ string, _ time.Time) (bool, error) {
+	return f(ctx)
+}
+
+// resolverFunc adapts a function to JurisdictionResolver
+type resolverFunc func(ctx context.Context) (string, error)
+
+func (f resolverFunc) ResolveJurisdiction(ctx context.Context, _ *User, _ Entity) (string, error) {
+	return f(ctx)
+}
+
+func TestResilientJurisdictionResolver(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2004-06-15")}
+	entity := Entity{Type: "consent", Date: mustParseDate("2023-03-01")}
+	fallback := StaticJurisdictions{Default: "US-MS"}
+
+	tests := []struct {
+		name      string
+		remote    func(calls *int) resolverFunc
+		fallback  JurisdictionResolver
+		wantCalls int
+		wantCodes []string
+	}{
+		{"recovers on retry", func(calls *int) resolverFunc {
+			return func(context.Context) (string, error) {
+				if *calls++; *calls == 1 {
+					return "", errors.New("unavailable")
+				}
+				return "US-MS", nil
+			}
+		}, nil, 2, []string{ErrCodeMinorConsent}},
+		{"falls back", func(calls *int) resolverFunc {
+			return func(context.Context) (string, error) { *calls++; return "", errors.New("unavailable") }
+		}, fallback, 2, []string{ErrCodeMinorConsent}},
+		{"no fallback", func(calls *int) resolverFunc {
+			return func(context.Context) (string, error) { *calls++; return "", errors.New("unavailable") }
+		}, nil, 2, []string{ErrCodeJurisdictionUnknown}},
+		{"timeout", func(calls *int) resolverFunc {
+			return func(ctx context.Context) (string, error) { *calls++; <-ctx.Done(); return "", ctx.Err() }
+		}, fallback, 2, []string{ErrCodeMinorConsent}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			r := NewResilientJurisdictionResolver(tt.remote(&calls), ResiliencePolicy{Timeout: time.Millisecond, MaxRetries: 1}, tt.fallback)
+			r.guard.sleep = (&fakeClock{}).sleep
+			report := CheckEntity(user, entity, WithFixedNow(mustParseDate("2025-07-18")), WithJurisdictionResolver(r))
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("findings = %v, want %v", got, tt.wantCodes)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// reviewSinkFunc adapts a function to ReviewSink
+type reviewSinkFunc func() error
+
+func (f reviewSinkFunc) Review(context.Context, Review) error { return f() }
+
+func TestResilientReviewSink(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entity := Entity{Type: "certification", Date: mustParseDate("2015-01-01"), Status: StatusExpired}
+	closed := NewChanReviewSink(1)
+	closed.Close()
+
+	tests := []struct {
+		name      string
+		remote    func(calls *int) ReviewSink
+		fallback  bool
+		wantCalls int
+		wantCodes []string
+	}{
+		{"recovers on retry", func(calls *int) ReviewSink {
+			return reviewSinkFunc(func() error {
+				if *calls++; *calls == 1 {
+					return errors.New("unavailable")
+				}
+				return nil
+			})
+		}, false, 2, []string{ErrCodeExpired}},
+		{"falls back", func(calls *int) ReviewSink {
+			return reviewSinkFunc(func() error { *calls++; return errors.New("unavailable") })
+		}, true, 2, []string{ErrCodeExpired}},
+		{"no fallback", func(calls *int) ReviewSink {
+			return reviewSinkFunc(func() error { *calls++; return errors.New("unavailable") })
+		}, false, 2, []string{ErrCodeExpired, ErrCodeReviewFailed}},
+		{"closed sink is not retried", func(calls *int) ReviewSink {
+			return reviewSinkFunc(func() error { *calls++; return closed.Review(context.Background(), Review{}) })
+		}, false, 1, []string{ErrCodeExpired, ErrCodeReviewFailed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			local := NewChanReviewSink(1)
+			var fallback ReviewSink
+			if tt.fallback {
+				fallback = local
+			}
+			r := NewResilientReviewSink(tt.remote(&calls), ResiliencePolicy{MaxRetries: 1}, fallback)
+			report := CheckEntity(user, entity, WithFixedNow(mustParseDate("2024-06-15")), WithReviewSink(r))
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("findings = %v, want %v", got, tt.wantCodes)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if got, want := len(local.Reviews()), map[bool]int{true: 1}[tt.fallback]; got != want {
+				t.Errorf("fallback reviews = %d, want %d", got, want)
+			}
+		})
+	}
+}
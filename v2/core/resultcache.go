@@ -0,0 +1,137 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// ResultCache stores the reports of entity date validations by key. Caches
+// keep reports for a time and may evict them earlier. Implementations must
+// be safe for concurrent use.
+type ResultCache interface {
+	Get(key string) (*Report, bool)
+	Set(key string, report *Report, ttl time.Duration)
+}
+
+// WithResultCache reuses the reports of earlier validations of the same
+// entity date for ttl instead of evaluating the rules again, as historical
+// records seldom change. Reports are keyed by the HashInputs of the user
+// ID, birth and death dates, entity date and type and RuleVersion, and by
+// the ConfigSnapshot hash of the options, so a change of any of them misses
+// the cache. Cached reports keep the CheckedAt of their evaluation, and
+// still go to the review sink, webhook, audit log and stats. Reports whose
+// jurisdiction or revocation lookup failed are not cached, nor are the
+// reports of calls with a reference time or call options from their
+// context. Entity checks such as CheckEntity, which depend on more than
+// the date, are not cached.
+func WithResultCache(cache ResultCache, ttl time.Duration) Option {
+	return func(c *config) {
+		c.resultCache = cache
+		c.resultTTL = ttl
+	}
+}
+
+// cachedReport returns a copy of the cached report of an entity date, with
+// its cache key
+func (c *config) cachedReport(user *User, entityDate time.Time, entityType string) (*Report, string, bool) {
+	if c.resultCache == nil || c.uncached {
+		return nil, "", false
+	}
+	hash := c.configHash
+	if hash == "" {
+		hash = c.snapshot().Hash
+	}
+	key := HashInputs(user, Entity{Type: entityType, Date: entityDate}, RuleVersion) + ":" + hash
+	report, ok := c.resultCache.Get(key)
+	if c.stats != nil {
+		c.stats.recordCache(ok)
+	}
+	if !ok {
+		return nil, key, false
+	}
+	return copyReport(report), key, true
+}
+
+// cacheReport stores a copy of an evaluated report under key, unless a
+// lookup failure that may not recur made it
+func (c *config) cacheReport(key string, report *Report) {
+	if c.resultCache == nil || key == "" {
+		return
+	}
+	for _, f := range report.Findings {
+		if f.Code == ErrCodeJurisdictionUnknown || f.Code == ErrCodeRevocationUnknown {
+			return
+		}
+	}
+	c.resultCache.Set(key, copyReport(report), c.resultTTL)
+}
+
+// MemoryResultCache is an in-process ResultCache. Expired reports are
+// dropped when they are looked up.
+type MemoryResultCache struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedResult
+	hits    uint64
+	misses  uint64
+}
+
+// cachedResult is a report of a MemoryResultCache
+type cachedResult struct {
+	report  *Report
+	expires time.Time
+}
+
+// ResultCacheStats counts the lookups of a MemoryResultCache
+type ResultCacheStats struct {
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Entries int    `json:"entries"`
+}
+
+// NewMemoryResultCache returns an empty cache
+func NewMemoryResultCache() *MemoryResultCache {
+	return &MemoryResultCache{now: time.Now, entries: make(map[string]cachedResult)}
+}
+
+// Get returns the report stored under key, unless it has expired
+func (c *MemoryResultCache) Get(key string) (*Report, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if ok && !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return entry.report, true
+}
+
+// Set stores report under key for ttl
+func (c *MemoryResultCache) Set(key string, report *Report, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedResult{report: report, expires: c.now().Add(ttl)}
+}
+
+// Stats returns the hits and misses of the cache since it was created, and
+// the number of reports it holds, expired ones included
+func (c *MemoryResultCache) Stats() ResultCacheStats {
+	if c == nil {
+		return ResultCacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ResultCacheStats{Hits: c.hits, Misses: c.misses, Entries: len(c.entries)}
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithResultCache(t *testing.T) {
+	cache := NewMemoryResultCache()
+	stats := NewStats()
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	v := NewValidator(now, WithResultCache(cache, time.Hour), WithStats(stats), WithRuleProfiling())
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	evaluations := func() uint64 {
+		for _, r := range stats.Snapshot().Rules {
+			if r.Rule == "before_birth" {
+				return r.Count
+			}
+		}
+		return 0
+	}
+
+	first := v.CheckEntityDate(user, mustParseDate("1989-01-01"), "certification")
+	second := v.CheckEntityDate(user, mustParseDate("1989-01-01"), "certification")
+	if evaluations() != 1 {
+		t.Errorf("before_birth evaluated %d times, want once", evaluations())
+	}
+	if codeOf(second.Err()) != ErrCodeBeforeBirth || !second.CheckedAt.Equal(first.CheckedAt) {
+		t.Errorf("cached report = %+v, want the report of the first validation", second)
+	}
+
+	// Reports are copies of the cached one
+	second.Findings[0].Code = "CHANGED"
+	if err := v.ValidateEntityDate(user, mustParseDate("1989-01-01"), "certification"); codeOf(err) != ErrCodeBeforeBirth {
+		t.Errorf("ValidateEntityDate() error = %v, want the cached %s", err, ErrCodeBeforeBirth)
+	}
+
+	// A change of any part of the key misses the cache
+	for _, tt := range []struct {
+		user       *User
+		date, kind string
+	}{
+		{&User{ID: "user456", BirthDate: user.BirthDate}, "1989-01-01", "certification"},
+		{&User{ID: user.ID, BirthDate: mustParseDate("1988-01-01")}, "1989-01-01", "certification"},
+		{&User{ID: user.ID, BirthDate: user.BirthDate, DeathDate: mustParseDate("2020-01-01")}, "1989-01-01", "certification"},
+		{user, "1989-01-02", "certification"},
+		{user, "1989-01-01", "training"},
+	} {
+		v.CheckEntityDate(tt.user, mustParseDate(tt.date), tt.kind)
+	}
+	if got := evaluations(); got != 6 {
+		t.Errorf("before_birth evaluated %d times, want 6", got)
+	}
+
+	if got, want := cache.Stats(), (ResultCacheStats{Hits: 2, Misses: 6, Entries: 6}); got != want {
+		t.Errorf("cache.Stats() = %+v, want %+v", got, want)
+	}
+	snap := stats.Snapshot()
+	if snap.CacheHits != 2 || snap.CacheMisses != 6 || snap.Total != 8 {
+		t.Errorf("Snapshot() = %+v, want 2 hits and 6 misses of 8 validations", snap)
+	}
+	var buf bytes.Buffer
+	if err := snap.WritePrometheus(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"userdate_result_cache_hits_total 2\n", "userdate_result_cache_misses_total 6\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("WritePrometheus() misses %q", want)
+		}
+	}
+}
+
+func TestResultCacheBypass(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	date := mustParseDate("2020-01-01")
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+
+	t.Run("reference time", func(t *testing.T) {
+		cache := NewMemoryResultCache()
+		v := NewValidator(now, WithResultCache(cache, time.Hour))
+		v.CheckEntityDate(user, date, "certification")
+
+		// The entity date is in the future of the reference time
+		ctx := ContextWithNow(context.Background(), mustParseDate("2019-06-15"))
+		if err := v.CheckEntityDateContext(ctx, user, date, "certification").Err(); codeOf(err) != ErrCodeFutureDate {
+			t.Errorf("CheckEntityDateContext() error = %v, want %s", err, ErrCodeFutureDate)
+		}
+		if got := cache.Stats(); got.Hits != 0 || got.Entries != 1 {
+			t.Errorf("cache.Stats() = %+v, want the call to bypass the cache", got)
+		}
+	})
+
+	t.Run("failed lookup", func(t *testing.T) {
+		cache := NewMemoryResultCache()
+		v := NewValidator(now, WithResultCache(cache, time.Hour), WithJurisdictionResolver(failingResolver{}))
+		if codes := findingCodes(v.CheckEntityDate(user, date, "certification")); !slices.Contains(codes, ErrCodeJurisdictionUnknown) {
+			t.Fatalf("findings = %v, want %s", codes, ErrCodeJurisdictionUnknown)
+		}
+		if got := cache.Stats().Entries; got != 0 {
+			t.Errorf("cache holds %d reports, want the failed lookup left out", got)
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		cache := NewMemoryResultCache()
+		english := NewValidator(now, WithResultCache(cache, time.Hour))
+		french := NewValidator(now, WithResultCache(cache, time.Hour), WithLocale("fr"))
+		want := french.CheckEntityDate(user, mustParseDate("1989-01-01"), "certification").Err().Error()
+		english.CheckEntityDate(user, mustParseDate("1989-01-01"), "certification")
+		if got := french.CheckEntityDate(user, mustParseDate("1989-01-01"), "certification").Err().Error(); got != want {
+			t.Errorf("French message = %q, want %q", got, want)
+		}
+		if got := cache.Stats(); got.Hits != 1 || got.Entries != 2 {
+			t.Errorf("cache.Stats() = %+v, want one report per validator", got)
+		}
+	})
+}
+
+func TestMemoryResultCacheTTL(t *testing.T) {
+	cache := NewMemoryResultCache()
+	clock := mustParseDate("2025-07-18")
+	cache.now = func() time.Time { return clock }
+
+	cache.Set("key", &Report{UserID: "user123"}, time.Hour)
+	if report, ok := cache.Get("key"); !ok || report.UserID != "user123" {
+		t.Errorf("Get() = %v, %v, want the report", report, ok)
+	}
+	clock = clock.Add(time.Hour)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Get() found an expired report")
+	}
+	if got := cache.Stats(); got != (ResultCacheStats{Hits: 1, Misses: 1}) {
+		t.Errorf("Stats() = %+v, want 1 hit, 1 miss and no entries", got)
+	}
+}
@@ -0,0 +1,277 @@
+package core
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultFormat is the file format of a ResultWriter
+type ResultFormat int
+
+// Result formats
+const (
+	FormatJSONL ResultFormat = iota // One JSON report per line (default)
+	FormatCSV                       // One row per report, with a header row in every file
+)
+
+// String returns the name of the format, which is also its file extension
+func (f ResultFormat) String() string {
+	if f == FormatCSV {
+		return "csv"
+	}
+	return "jsonl"
+}
+
+// ParseResultFormat returns the format named by s, "jsonl" or "csv"
+func ParseResultFormat(s string) (ResultFormat, error) {
+	switch s {
+	case "jsonl":
+		return FormatJSONL, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return 0, fmt.Errorf("unknown result format %q", s)
+	}
+}
+
+// resultColumns is the CSV header row
+var resultColumns = []string{"seq", "user_id", "entity_type", "entity_date", "valid", "code", "message", "warnings"}
+
+// ResultWriterConfig tunes a ResultWriter
+type ResultWriterConfig struct {
+	Format ResultFormat
+
+	// MaxBytes starts a new file once the current one holds this many bytes
+	// before compression. Zero disables size-based rotation.
+	MaxBytes int64
+
+	// MaxAge starts a new file on the first write after the current one has
+	// been open this long. Zero disables time-based rotation.
+	MaxAge time.Duration
+
+	// Gzip compresses the files
+	Gzip bool
+}
+
+// ResultWriter streams validation results to a series of files, starting a
+// new file when the current one grows too large or too old, so that long
+// running jobs do not produce one huge file. Files are named after a prefix
+// and a sequence number, such as results-000001.jsonl.gz. A ResultWriter is
+// safe for concurrent use.
+type ResultWriter struct {
+	prefix string
+	cfg    ResultWriterConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	files   []string
+	file    *os.File
+	gz      *gzip.Writer
+	buf     *bufio.Writer
+	written int64 // Bytes written to the current file before compression
+	opened  time.Time
+	err     error // First error of Handle
+}
+
+// NewResultWriter returns a writer creating its files at prefix followed by
+// a sequence number and the format extension. The first file is created on
+// the first write. Existing files are never overwritten; writing fails
+// instead.
+func NewResultWriter(prefix string, cfg ResultWriterConfig) *ResultWriter {
+	return &ResultWriter{prefix: prefix, cfg: cfg, now: time.Now}
+}
+
+// Write appends the report of an item, with its submission sequence number
+// or 0 when there is none
+func (w *ResultWriter) Write(seq uint64, report *Report) error {
+	if w == nil || report == nil {
+		return ErrNilValue
+	}
+	line, err := w.encode(seq, report)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.rotate(); err != nil {
+		return err
+	}
+	n, err := w.buf.Write(line)
+	w.written += int64(n)
+	return err
+}
+
+// WriteBatch appends the reports of a batch in order
+func (w *ResultWriter) WriteBatch(result *BatchResult) error {
+	if w == nil || result == nil {
+		return ErrNilValue
+	}
+	for i, report := range result.Reports {
+		if err := w.Write(uint64(i+1), report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handle writes a bulk result. It has the signature of a BulkValidator
+// handler; the first error is kept and returned by Close.
+func (w *ResultWriter) Handle(result BulkResult) {
+	if w == nil {
+		return
+	}
+	if err := w.Write(result.Seq, result.Report); err != nil {
+		w.mu.Lock()
+		if w.err == nil {
+			w.err = err
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Files returns the names of the files created so far, in order
+func (w *ResultWriter) Files() []string {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.files...)
+}
+
+// Flush writes the buffered results to the current file. Compressed files
+// are only complete once closed.
+func (w *ResultWriter) Flush() error {
+	if w == nil {
+		return ErrNilValue
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf == nil {
+		return nil
+	}
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if w.gz != nil {
+		return w.gz.Flush()
+	}
+	return nil
+}
+
+// Close flushes and closes the current file. It returns the first error
+// of Handle, if any.
+func (w *ResultWriter) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return errors.Join(w.closeFile(), w.err)
+}
+
+// encode formats a report as a line of the output format
+func (w *ResultWriter) encode(seq uint64, report *Report) ([]byte, error) {
+	if w.cfg.Format != FormatCSV {
+		line, err := json.Marshal(struct {
+			Seq uint64 `json:"seq,omitempty"`
+			*Report
+		}{seq, report})
+		return append(line, '\n'), err
+	}
+
+	var code, message string
+	var warnings []string
+	for _, f := range report.Findings {
+		switch {
+		case f.Severity == SeverityWarning:
+			warnings = append(warnings, f.Code)
+		case f.Severity == SeverityError && code == "":
+			code, message = f.Code, f.Message
+		}
+	}
+	var sb strings.Builder
+	cw := csv.NewWriter(&sb)
+	cw.Write([]string{
+		strconv.FormatUint(seq, 10),
+		report.UserID,
+		report.EntityType,
+		report.EntityDate.Format("2006-01-02"),
+		strconv.FormatBool(report.Valid()),
+		code,
+		message,
+		strings.Join(warnings, ";"),
+	})
+	cw.Flush()
+	return []byte(sb.String()), cw.Error()
+}
+
+// rotate opens a new file when there is none or the current one is full or
+// too old
+func (w *ResultWriter) rotate() error {
+	if w.file != nil {
+		full := w.cfg.MaxBytes > 0 && w.written >= w.cfg.MaxBytes
+		old := w.cfg.MaxAge > 0 && w.now().Sub(w.opened) >= w.cfg.MaxAge
+		if !full && !old {
+			return nil
+		}
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+
+	name := fmt.Sprintf("%s-%06d.%s", w.prefix, len(w.files)+1, w.cfg.Format)
+	if w.cfg.Gzip {
+		name += ".gz"
+	}
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = file
+	if w.cfg.Gzip {
+		w.gz = gzip.NewWriter(file)
+		out = w.gz
+	}
+	w.files = append(w.files, name)
+	w.file = file
+	w.buf = bufio.NewWriter(out)
+	w.written = 0
+	w.opened = w.now()
+
+	if w.cfg.Format == FormatCSV {
+		cw := csv.NewWriter(w.buf)
+		cw.Write(resultColumns)
+		cw.Flush()
+		return cw.Error()
+	}
+	return nil
+}
+
+// closeFile flushes and closes the current file, if any
+func (w *ResultWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.buf.Flush()
+	if w.gz != nil {
+		err = errors.Join(err, w.gz.Close())
+	}
+	err = errors.Join(err, w.file.Sync(), w.file.Close())
+	w.file, w.gz, w.buf = nil, nil, nil
+	return err
+}
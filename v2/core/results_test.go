@@ -0,0 +1,166 @@
+package core
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readResultFile returns the contents of a result file, decompressed
+func readResultFile(t *testing.T, name string) string {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = gz
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func resultItems(n int) []Item {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	items := make([]Item, n)
+	for i := range items {
+		date := mustParseDate("2020-01-01")
+		if i%2 == 1 {
+			date = mustParseDate("1989-01-01")
+		}
+		items[i] = Item{User: user, EntityDate: date, EntityType: "training"}
+	}
+	return items
+}
+
+func TestResultWriterRotatesBySize(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "results")
+	w := NewResultWriter(prefix, ResultWriterConfig{MaxBytes: 1})
+	batch := ValidateBatch(resultItems(3), WithFixedNow(mustParseDate("2025-07-18")))
+
+	if err := w.WriteBatch(batch); err != nil {
+		t.Fatalf("WriteBatch() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	files := w.Files()
+	if len(files) != 3 || filepath.Base(files[2]) != "results-000003.jsonl" {
+		t.Fatalf("Files() = %v, want 3 files ending with results-000003.jsonl", files)
+	}
+	var line struct {
+		Seq      uint64                 `json:"seq"`
+		UserID   string                 `json:"user_id"`
+		Findings []*DateValidationError `json:"findings"`
+	}
+	if err := json.Unmarshal([]byte(readResultFile(t, files[1])), &line); err != nil {
+		t.Fatalf("decoding line: %v", err)
+	}
+	if line.Seq != 2 || line.UserID != "user123" || line.Findings[0].Code != ErrCodeBeforeBirth {
+		t.Errorf("second line = %+v, want seq 2 with BEFORE_BIRTH", line)
+	}
+}
+
+func TestResultWriterRotatesByAge(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "results")
+	w := NewResultWriter(prefix, ResultWriterConfig{MaxAge: time.Hour})
+	clock := mustParseDate("2025-07-18")
+	w.now = func() time.Time { return clock }
+	report := CheckEntityDate(resultItems(1)[0].User, mustParseDate("2020-01-01"), "training")
+
+	for _, step := range []time.Duration{0, 30 * time.Minute, 30 * time.Minute, time.Minute} {
+		clock = clock.Add(step)
+		if err := w.Write(1, report); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	w.Close()
+
+	files := w.Files()
+	if len(files) != 2 {
+		t.Fatalf("Files() = %v, want 2 files", files)
+	}
+	if got := strings.Count(readResultFile(t, files[0]), "\n"); got != 2 {
+		t.Errorf("first file has %d lines, want 2", got)
+	}
+}
+
+func TestResultWriterCSVGzip(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "results")
+	w := NewResultWriter(prefix, ResultWriterConfig{Format: FormatCSV, Gzip: true, MaxBytes: 200})
+	b := NewBulkValidator(BulkConfig{Workers: 2}, w.Handle, WithFixedNow(mustParseDate("2025-07-18")))
+	for _, item := range resultItems(10) {
+		b.Submit(context.Background(), item)
+	}
+	b.Close()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	files := w.Files()
+	if len(files) < 2 || !strings.HasSuffix(files[0], ".csv.gz") {
+		t.Fatalf("Files() = %v, want several .csv.gz files", files)
+	}
+	rows, failed := 0, 0
+	for _, name := range files {
+		records, err := csv.NewReader(strings.NewReader(readResultFile(t, name))).ReadAll()
+		if err != nil {
+			t.Fatalf("reading %s: %v", name, err)
+		}
+		if strings.Join(records[0], ",") != strings.Join(resultColumns, ",") {
+			t.Errorf("%s header = %v, want %v", name, records[0], resultColumns)
+		}
+		for _, r := range records[1:] {
+			rows++
+			if r[4] == "false" {
+				failed++
+				if r[5] != ErrCodeBeforeBirth {
+					t.Errorf("failed row code = %q, want %q", r[5], ErrCodeBeforeBirth)
+				}
+			}
+		}
+	}
+	if rows != 10 || failed != 5 {
+		t.Errorf("read %d rows with %d failures, want 10 with 5", rows, failed)
+	}
+}
+
+func TestResultWriterDoesNotOverwrite(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "results")
+	if err := os.WriteFile(prefix+"-000001.jsonl", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w := NewResultWriter(prefix, ResultWriterConfig{})
+	if err := w.Write(1, &Report{}); err == nil {
+		t.Error("Write() over an existing file succeeded")
+	}
+}
+
+func TestParseResultFormat(t *testing.T) {
+	for _, f := range []ResultFormat{FormatJSONL, FormatCSV} {
+		if got, err := ParseResultFormat(f.String()); err != nil || got != f {
+			t.Errorf("ParseResultFormat(%q) = %v, %v", f, got, err)
+		}
+	}
+	if _, err := ParseResultFormat("xml"); err == nil {
+		t.Error("ParseResultFormat(xml) succeeded")
+	}
+}
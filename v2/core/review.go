@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Errors of ChanReviewSink
+var (
+	ErrReviewQueueFull  = errors.New("userdate: review queue is full")
+	ErrReviewSinkClosed = errors.New("userdate: review sink is closed")
+)
+
+// Review is a validation that needs a human decision, as sent to a
+// ReviewSink
+type Review struct {
+	User   *User   `json:"user"`
+	Entity Entity  `json:"entity"`
+	Report *Report `json:"report"`
+}
+
+// ReviewSink receives the validations to review manually: reports that
+// pass with warnings and reports with VerdictIndeterminate. Failed reports
+// are not sent, their verdict is definite.
+type ReviewSink interface {
+	Review(ctx context.Context, review Review) error
+}
+
+// WithReviewSink sends the validations needing manual review to s, such as
+// a ChanReviewSink or an adapter for a ticketing system. Reviews hold a
+// copy of the report. When s fails, the report gets a REVIEW_FAILED
+// warning.
+func WithReviewSink(s ReviewSink) Option {
+	return func(c *config) {
+		c.review = s
+	}
+}
+
+// needsReview reports whether a finished report is sent to the review sink
+func (r *Report) needsReview() bool {
+	switch r.Verdict {
+	case VerdictIndeterminate:
+		return true
+	case VerdictPass:
+		return len(r.Warnings()) > 0
+	}
+	return false
+}
+
+// sendReview sends the report to the review sink if it needs a review,
+// passing it the ctx of the validation
+func (c *config) sendReview(ctx context.Context, user *User, entity Entity, report *Report) {
+	if c.review == nil || !report.needsReview() {
+		return
+	}
+	review := Review{User: user, Entity: entity, Report: copyReport(report)}
+	if err := c.review.Review(ctx, review); err != nil {
+		report.add(c.localize(newWarning(msgReviewFailed, "error", err)))
+	}
+}
+
+// ChanReviewSink is an in-process ReviewSink that queues reviews on a
+// buffered channel, for review tooling running in the same process. It
+// never blocks validation: reviews sent while the buffer is full fail with
+// ErrReviewQueueFull.
+type ChanReviewSink struct {
+	mu     sync.RWMutex
+	closed bool
+	c      chan Review
+}
+
+// NewChanReviewSink returns a sink queuing up to size reviews
+func NewChanReviewSink(size int) *ChanReviewSink {
+	return &ChanReviewSink{c: make(chan Review, size)}
+}
+
+// Reviews returns the channel of the queued reviews. It is closed by Close.
+func (s *ChanReviewSink) Reviews() <-chan Review {
+	if s == nil {
+		return nil
+	}
+	return s.c
+}
+
+// Review queues a review
+func (s *ChanReviewSink) Review(_ context.Context, review Review) error {
+	if s == nil {
+		return ErrNilValue
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return ErrReviewSinkClosed
+	}
+	select {
+	case s.c <- review:
+		return nil
+	default:
+		return ErrReviewQueueFull
+	}
+}
+
+// Close closes the channel of Reviews once the queued reviews are read.
+// Later reviews fail with ErrReviewSinkClosed.
+func (s *ChanReviewSink) Close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.c)
+	}
+}
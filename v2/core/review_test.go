@@ -0,0 +1,100 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWithReviewSink(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+
+	tests := []struct {
+		name   string
+		entity Entity
+		want   bool
+	}{
+		{"pass", Entity{Type: "account", Date: mustParseDate("2010-01-01")}, false},
+		{"pass with warning", Entity{Type: "account", Date: mustParseDate("2010-01-01"), Status: StatusExpired}, true},
+		{"indeterminate", Entity{Type: "account", Date: mustParseDate("1990-01-01"), DatePrecision: DatePrecisionYear}, true},
+		{"fail", Entity{Type: "account", Date: mustParseDate("1989-01-01")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := NewChanReviewSink(1)
+			report := CheckEntity(user, tt.entity, WithFixedNow(mustParseDate("2020-06-15")), WithReviewSink(sink))
+			sink.Close()
+
+			review, ok := <-sink.Reviews()
+			if ok != tt.want {
+				t.Fatalf("review sent = %v, want %v", ok, tt.want)
+			}
+			if !ok {
+				return
+			}
+			if review.User != user || review.Entity != tt.entity {
+				t.Errorf("review = %+v, want the user and entity checked", review)
+			}
+			if review.Report == report || !reflect.DeepEqual(review.Report, report) {
+				t.Errorf("review report = %+v, want a copy of %+v", review.Report, report)
+			}
+		})
+	}
+}
+
+func TestChanReviewSinkFull(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	entity := Entity{Type: "account", Date: mustParseDate("2010-01-01"), Status: StatusExpired}
+	sink := NewChanReviewSink(1)
+	v := NewValidator(WithReviewSink(sink))
+
+	if codes := findingCodes(v.CheckEntity(user, entity)); !reflect.DeepEqual(codes, []string{ErrCodeExpired}) {
+		t.Errorf("first codes = %v, want [%s]", codes, ErrCodeExpired)
+	}
+	report := v.CheckEntity(user, entity)
+	if codes := findingCodes(report); !reflect.DeepEqual(codes, []string{ErrCodeExpired, ErrCodeReviewFailed}) {
+		t.Errorf("codes with a full queue = %v, want [%s %s]", codes, ErrCodeExpired, ErrCodeReviewFailed)
+	}
+	if !report.Valid() {
+		t.Errorf("Valid() = false, want a review failure to be a warning")
+	}
+
+	sink.Close()
+	if err := sink.Review(t.Context(), Review{}); !errors.Is(err, ErrReviewSinkClosed) {
+		t.Errorf("Review() after Close error = %v, want %v", err, ErrReviewSinkClosed)
+	}
+	if n := len(sink.Reviews()); n != 1 {
+		t.Errorf("queued reviews = %d, want 1", n)
+	}
+}
+
+// contextSink records the context of the reviews it receives
+type contextSink struct{ ctx context.Context }
+
+func (s *contextSink) Review(ctx context.Context, _ Review) error {
+	s.ctx = ctx
+	return ctx.Err()
+}
+
+func TestReviewSinkContext(t *testing.T) {
+	type key struct{}
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	entity := Entity{Type: "account", Date: mustParseDate("2010-01-01"), Status: StatusExpired}
+	sink := &contextSink{}
+	ctx := context.WithValue(context.Background(), key{}, "caller")
+
+	CheckEntityContext(ctx, user, entity, WithReviewSink(sink))
+	if sink.ctx == nil || sink.ctx.Value(key{}) != "caller" {
+		t.Errorf("review context = %v, want the validation context", sink.ctx)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	report := CheckEntityContext(cancelled, user, entity, WithReviewSink(sink))
+	if codes := findingCodes(report); !slices.Contains(codes, ErrCodeReviewFailed) {
+		t.Errorf("codes with a cancelled context = %v, want %s", codes, ErrCodeReviewFailed)
+	}
+}
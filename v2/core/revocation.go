@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RevocationChecker reports whether an issuer has revoked a credential. It is
+// consulted for entities that carry an ID, so that revoked credentials fail
+// even when their dates are plausible. asOf is the validation's reference
+// time; revocations after it are not taken into account.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, issuer, credentialID string, asOf time.Time) (bool, error)
+}
+
+// revocationKey identifies a credential across issuers
+type revocationKey struct {
+	issuer       string
+	credentialID string
+}
+
+// MemoryRevocationList is an in-memory RevocationChecker. It is safe for
+// concurrent use.
+type MemoryRevocationList struct {
+	mu      sync.RWMutex
+	revoked map[revocationKey]time.Time
+}
+
+// NewMemoryRevocationList creates an empty revocation list
+func NewMemoryRevocationList() *MemoryRevocationList {
+	return &MemoryRevocationList{revoked: make(map[revocationKey]time.Time)}
+}
+
+// Revoke records that the issuer revoked the credential at the given time
+func (l *MemoryRevocationList) Revoke(issuer, credentialID string, at time.Time) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.revoked[revocationKey{issuer, credentialID}] = at
+}
+
+// IsRevoked reports whether the credential was revoked at or before asOf
+func (l *MemoryRevocationList) IsRevoked(_ context.Context, issuer, credentialID string, asOf time.Time) (bool, error) {
+	if l == nil {
+		return false, ErrNilValue
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	at, ok := l.revoked[revocationKey{issuer, credentialID}]
+	return ok && !at.After(asOf), nil
+}
+
+// cachedRevocation is a cached revocation answer for the reference time
+// asOf
+type cachedRevocation struct {
+	revoked bool
+	asOf    time.Time
+	expires time.Time
+}
+
+// answers reports whether the cached answer holds for the reference time
+// asOf: a revocation holds for later times, as revocations are final, and
+// a credential not revoked is taken as such for up to ttl later
+func (e cachedRevocation) answers(asOf time.Time, ttl time.Duration) bool {
+	if e.revoked {
+		return !asOf.Before(e.asOf)
+	}
+	return asOf.Before(e.asOf.Add(ttl))
+}
+
+// CachingRevocationChecker caches the answers of another RevocationChecker
+// for a fixed time. An answer is reused for later reference times only as
+// long as it holds: a revocation for any later time, and a credential not
+// revoked for a reference time up to the same fixed time later, so that an
+// answer cached for an old reference time is not given for a recent one.
+// Failed lookups are not cached. It is safe for concurrent use.
+type CachingRevocationChecker struct {
+	next RevocationChecker
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu    sync.Mutex
+	cache map[revocationKey]cachedRevocation
+}
+
+// NewCachingRevocationChecker wraps next with a cache keeping answers for ttl
+func NewCachingRevocationChecker(next RevocationChecker, ttl time.Duration) *CachingRevocationChecker {
+	return &CachingRevocationChecker{
+		next:  next,
+		ttl:   ttl,
+		now:   time.Now,
+		cache: make(map[revocationKey]cachedRevocation),
+	}
+}
+
+// IsRevoked returns the cached answer for the credential, asking the wrapped
+// checker when there is none or it has expired
+func (c *CachingRevocationChecker) IsRevoked(ctx context.Context, issuer, credentialID string, asOf time.Time) (bool, error) {
+	if c == nil || c.next == nil {
+		return false, ErrNilValue
+	}
+	key := revocationKey{issuer, credentialID}
+
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) && entry.answers(asOf, c.ttl) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := c.next.IsRevoked(ctx, issuer, credentialID, asOf)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.cache[key] = cachedRevocation{revoked: revoked, asOf: asOf, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return revoked, nil
+}
+
+// WithRevocationChecker sets the checker consulted for entities with an ID
+func WithRevocationChecker(rc RevocationChecker) Option {
+	return func(c *config) {
+		c.revocation = rc
+	}
+}
+
+// checkRevocation asks the revocation checker about the entity. Revoked
+// credentials are errors; a failed lookup is reported as a warning so that
+// an unavailable checker does not reject valid credentials.
+func (c *config) checkRevocation(ctx context.Context, entity Entity, report *Report) error {
+	if c.revocation == nil || entity.ID == "" {
+		return nil
+	}
+
+	revoked, err := c.revocation.IsRevoked(ctx, entity.Issuer, entity.ID, c.now())
+	switch {
+	case err != nil:
+		report.add(newWarning(msgRevocationLookup, "type", entityTypeArg(entity.Type), "id", entity.ID, "error", err))
+	case revoked:
+		return newError(msgIssuerRevoked, "type", entityTypeArg(entity.Type), "id", entity.ID)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingChecker counts lookups and returns a fixed answer
+type countingChecker struct {
+	calls   int
+	revoked bool
+	err     error
+}
+
+func (c *countingChecker) IsRevoked(context.Context, string, string, time.Time) (bool, error) {
+	c.calls++
+	return c.revoked, c.err
+}
+
+func TestCheckEntityRevocation(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	list := NewMemoryRevocationList()
+	list.Revoke("acme", "cert-1", mustParseDate("2020-03-01"))
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+
+	tests := []struct {
+		name        string
+		entity      Entity
+		opts        []Option
+		errCode     string
+		warningCode string
+	}{
+		{
+			name:    "revoked credential",
+			entity:  Entity{ID: "cert-1", Issuer: "acme", Type: "certification", Date: mustParseDate("2019-01-01")},
+			opts:    []Option{now, WithRevocationChecker(list)},
+			errCode: ErrCodeRevoked,
+		},
+		{
+			name:   "revoked after reference time",
+			entity: Entity{ID: "cert-1", Issuer: "acme", Type: "certification", Date: mustParseDate("2019-01-01")},
+			opts:   []Option{WithFixedNow(mustParseDate("2020-02-01")), WithRevocationChecker(list)},
+		},
+		{
+			name:   "other issuer",
+			entity: Entity{ID: "cert-1", Issuer: "globex", Type: "certification", Date: mustParseDate("2019-01-01")},
+			opts:   []Option{now, WithRevocationChecker(list)},
+		},
+		{
+			name:   "no checker",
+			entity: Entity{ID: "cert-1", Issuer: "acme", Type: "certification", Date: mustParseDate("2019-01-01")},
+			opts:   []Option{now},
+		},
+		{
+			name:        "checker failure",
+			entity:      Entity{ID: "cert-2", Issuer: "acme", Type: "certification", Date: mustParseDate("2019-01-01")},
+			opts:        []Option{now, WithRevocationChecker(&countingChecker{err: errors.New("timeout")})},
+			warningCode: ErrCodeRevocationUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, tt.entity, tt.opts...)
+
+			err := report.Err()
+			if tt.errCode == "" {
+				if err != nil {
+					t.Errorf("CheckEntity() unexpected error = %v", err)
+				}
+			} else if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != tt.errCode {
+				t.Errorf("CheckEntity() error = %v, want code %v", err, tt.errCode)
+			}
+
+			warnings := report.Warnings()
+			if tt.warningCode != "" && (len(warnings) != 1 || warnings[0].Code != tt.warningCode) {
+				t.Errorf("CheckEntity() warnings = %v, want %v", warnings, tt.warningCode)
+			}
+		})
+	}
+}
+
+func TestRevocationSkippedWithoutID(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	checker := &countingChecker{revoked: true}
+
+	report := NewValidator(WithRevocationChecker(checker)).
+		CheckEntityContext(context.Background(), user, Entity{Type: "certification", Date: mustParseDate("2019-01-01")})
+	if !report.Valid() || checker.calls != 0 {
+		t.Errorf("CheckEntityContext() valid = %v, calls = %d, want valid without lookup", report.Valid(), checker.calls)
+	}
+}
+
+func TestCachingRevocationChecker(t *testing.T) {
+	next := &countingChecker{revoked: true}
+	cache := NewCachingRevocationChecker(next, time.Minute)
+	now := mustParseDate("2020-06-15")
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		revoked, err := cache.IsRevoked(ctx, "acme", "cert-1", now)
+		if !revoked || err != nil {
+			t.Fatalf("IsRevoked() = %v, %v, want true, nil", revoked, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Errorf("wrapped checker called %d times, want 1", next.calls)
+	}
+
+	// Other credentials are looked up separately
+	_, _ = cache.IsRevoked(ctx, "acme", "cert-2", now)
+	if next.calls != 2 {
+		t.Errorf("wrapped checker called %d times, want 2", next.calls)
+	}
+
+	// Entries expire after the TTL
+	now = now.Add(time.Minute)
+	_, _ = cache.IsRevoked(ctx, "acme", "cert-1", now)
+	if next.calls != 3 {
+		t.Errorf("wrapped checker called %d times, want 3", next.calls)
+	}
+
+	// Errors are not cached
+	next.err = errors.New("unavailable")
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if _, err := cache.IsRevoked(ctx, "acme", "cert-1", now); err == nil {
+			t.Errorf("IsRevoked() expected error but got none")
+		}
+	}
+	if next.calls != 5 {
+		t.Errorf("wrapped checker called %d times, want 5", next.calls)
+	}
+}
+
+func TestCachingRevocationCheckerReferenceTime(t *testing.T) {
+	list := NewMemoryRevocationList()
+	list.Revoke("acme", "cert-1", mustParseDate("2022-01-01"))
+	cache := NewCachingRevocationChecker(list, time.Hour)
+	ctx := context.Background()
+
+	tests := []struct {
+		asOf string
+		want bool
+	}{
+		{"2020-06-15", false},
+		{"2024-06-15", true},  // Not the answer cached for 2020
+		{"2025-01-01", true},  // Revoked for any later time
+		{"2021-06-15", false}, // Not revoked yet
+	}
+	for _, tt := range tests {
+		if revoked, err := cache.IsRevoked(ctx, "acme", "cert-1", mustParseDate(tt.asOf)); revoked != tt.want || err != nil {
+			t.Errorf("IsRevoked(%s) = %v, %v, want %v, nil", tt.asOf, revoked, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// RuleOrder is the order in which validation rules are evaluated
+type RuleOrder int
+
+// Rule orders
+const (
+	OrderDeclaration   RuleOrder = iota // The documented order of the rules (default)
+	OrderCheapestFirst                  // Cheap comparisons first, remote lookups last
+	OrderSeverityFirst                  // Rules that reject before rules that only warn
+)
+
+// String returns the name of the order
+func (o RuleOrder) String() string {
+	switch o {
+	case OrderCheapestFirst:
+		return "cheapest-first"
+	case OrderSeverityFirst:
+		return "severity-first"
+	default:
+		return "declaration"
+	}
+}
+
+// WithRuleOrder sets the order in which rules are evaluated. The input
+// checks (nil user, invalid birth, death or entity date) always run first
+// and stop evaluation, since no other rule is meaningful without them.
+func WithRuleOrder(order RuleOrder) Option {
+	return func(c *config) {
+		c.ruleOrder = order
+	}
+}
+
+// WithShortCircuit sets whether evaluation stops at the first error, which
+// is the default. With short-circuiting disabled, reports hold every error
+// found; the functions returning an error still return the first one.
+func WithShortCircuit(enabled bool) Option {
+	return func(c *config) {
+		c.fullEvaluation = !enabled
+	}
+}
+
+// ruleID identifies a validation rule
+type ruleID int
+
+// Validation rules, in declaration order
+const (
+	ruleBeforeBirth ruleID = iota
+	ruleSameDayBirth
+	ruleFutureDate
+	ruleMinimumAge
+	ruleHistory
+	ruleRenewal
+	ruleRevocation
+	ruleExpiry
+	ruleDayMonthSwap
+	ruleMaximumAge
+	ruleDuration
+	ruleEntry
+	ruleDocumentValidity
+	rulePolicy
+	ruleJuvenile
+	ruleAgeCategory
+	ruleConsent
+)
+
+// ruleInfo describes a rule for ordering
+type ruleInfo struct {
+	name     string
+	cost     int          // Relative evaluation cost
+	severity Severity     // Most severe finding the rule produces
+	field    findingField // The input the rule's findings are about
+}
+
+// ruleInfos describes each rule, indexed by ruleID
+var ruleInfos = [...]ruleInfo{
+	ruleBeforeBirth:  {name: "before_birth", cost: 1, severity: SeverityError, field: fieldEntityDate},
+	ruleSameDayBirth: {name: "same_day_birth", cost: 1, severity: SeverityError, field: fieldEntityDate},
+	ruleFutureDate:   {name: "future_date", cost: 1, severity: SeverityError, field: fieldEntityDate},
+	ruleMinimumAge:   {name: "minimum_age", cost: 3, severity: SeverityError, field: fieldEntityDate},
+	ruleHistory:      {name: "history", cost: 2, severity: SeverityError, field: fieldEntityDate},
+	ruleRenewal:      {name: "renewal", cost: 2, severity: SeverityError, field: fieldRenewedAt},
+	ruleRevocation:   {name: "revocation", cost: 100, severity: SeverityError, field: fieldEntity},
+	ruleExpiry:       {name: "expiry", cost: 3, severity: SeverityWarning, field: fieldEntityDate},
+
+	// The swap rule only warns, but it must run before the errors it
+	// explains stop the evaluation, so it ranks with them
+	ruleDayMonthSwap: {name: "day_month_swap", cost: 1, severity: SeverityError, field: fieldEntityDate},
+
+	ruleMaximumAge: {name: "maximum_age", cost: 3, severity: SeverityError, field: fieldEntityDate},
+	ruleDuration:   {name: "duration", cost: 2, severity: SeverityError, field: fieldEndDate},
+	ruleEntry:      {name: "entry", cost: 1, severity: SeverityError, field: fieldEntryDate},
+
+	ruleDocumentValidity: {name: "validity", cost: 2, severity: SeverityError, field: fieldEndDate},
+	rulePolicy:           {name: "policy", cost: 2, severity: SeverityError, field: fieldEndDate},
+	ruleJuvenile:         {name: "juvenile", cost: 1, severity: SeverityWarning, field: fieldEntityDate},
+	ruleAgeCategory:      {name: "age_category", cost: 3, severity: SeverityError, field: fieldEntityDate},
+	ruleConsent:          {name: "consent", cost: 3, severity: SeverityError, field: fieldEntityDate},
+}
+
+// Rule sets in declaration order
+var (
+	dateRules   = []ruleID{ruleDayMonthSwap, ruleBeforeBirth, ruleSameDayBirth, ruleFutureDate, ruleMinimumAge, ruleMaximumAge, ruleHistory}
+	entityRules = append(slices.Clone(dateRules), ruleRenewal, ruleDuration, ruleEntry, ruleDocumentValidity, rulePolicy, ruleJuvenile, ruleAgeCategory, ruleConsent, ruleRevocation, ruleExpiry)
+)
+
+// orderedRules holds each rule set sorted in every order, so evaluation
+// does not sort
+var orderedRules = [...]struct{ date, entity []ruleID }{
+	OrderDeclaration:   {dateRules, entityRules},
+	OrderCheapestFirst: {sortRules(dateRules, OrderCheapestFirst), sortRules(entityRules, OrderCheapestFirst)},
+	OrderSeverityFirst: {sortRules(dateRules, OrderSeverityFirst), sortRules(entityRules, OrderSeverityFirst)},
+}
+
+// sortRules returns the rules sorted in the given order, keeping the
+// declaration order among equals
+func sortRules(rules []ruleID, order RuleOrder) []ruleID {
+	sorted := slices.Clone(rules)
+	slices.SortStableFunc(sorted, func(a, b ruleID) int {
+		if order == OrderSeverityFirst {
+			return ruleInfos[b].severity.rank() - ruleInfos[a].severity.rank()
+		}
+		return ruleInfos[a].cost - ruleInfos[b].cost
+	})
+	return sorted
+}
+
+// ruleInput holds the values the rules check. Dates are truncated to the
+// configured precision.
+type ruleInput struct {
+	ctx        context.Context
+	entity     Entity
+	birthDate  time.Time
+	deathDate  time.Time // Zero while the user is alive
+	entityDate time.Time
+	now        time.Time
+
+	// earliest and latest bound the days an imprecise entity date stands
+	// for; both are entityDate for dates known to the day
+	earliest time.Time
+	latest   time.Time
+}
+
+// newRuleInput runs the input checks and prepares the rule input
+func (c *config) newRuleInput(ctx context.Context, user *User, entity Entity) (ruleInput, error) {
+	if user == nil {
+		return ruleInput{}, c.localize(atField(newError(msgNilUser), fieldUser))
+	}
+
+	// Validate the user's birth date first
+	if err := c.validateLifetime(user.BirthDate, user.DeathDate); err != nil {
+		return ruleInput{}, c.localize(err)
+	}
+
+	// Validate the entity date
+	if err := c.validateDate(entity.Date, c.minEntityDate); err != nil {
+		return ruleInput{}, c.localize(atField(err, fieldEntityDate))
+	}
+	if !entity.DatePrecision.Valid() {
+		return ruleInput{}, c.localize(atField(newError(msgUnknownPrecision, "precision", entity.DatePrecision), fieldEntityDate))
+	}
+	if !entity.Uncertainty.valid() {
+		return ruleInput{}, c.localize(atField(newError(msgNegativeUncertainty, "uncertainty", entity.Uncertainty), fieldEntityDate))
+	}
+
+	// Compare dates at the configured precision from here on
+	earliest, latest := entity.bounds()
+	return ruleInput{
+		ctx:        ctx,
+		entity:     entity,
+		birthDate:  c.truncate(user.BirthDate),
+		deathDate:  c.truncate(user.DeathDate),
+		entityDate: c.truncate(entity.Date),
+		now:        c.truncate(c.now()),
+		earliest:   c.truncate(earliest),
+		latest:     c.truncate(latest),
+	}, nil
+}
+
+// runRules evaluates the rules in order, recording findings in report when
+// it is not nil, and returns the first error. When the context of the input
+// is done, the remaining rules are skipped and the report is marked
+// incomplete.
+func (c *config) runRules(rules []ruleID, in *ruleInput, report *Report) error {
+	var first error
+	for _, id := range rules {
+		if in.ctx.Err() != nil {
+			if report != nil {
+				report.Incomplete = true
+			}
+			break
+		}
+		if slices.Contains(c.skipRules, ruleInfos[id].name) || c.sampledOut(id, in, report) {
+			continue
+		}
+		found := report.len()
+		err := c.timeRule(id, in, report)
+		if err == nil {
+			report.tagFindings(found, ruleInfos[id].field)
+			c.reasonFindings(id, in, report, found, nil)
+			c.localizeFindings(report, found)
+			c.explain.rule(c, id, in, report, found, nil)
+			continue
+		}
+		if report == nil {
+			c.localize(err)
+		}
+		report.add(err)
+		report.tagFindings(found, ruleInfos[id].field)
+		c.reasonFindings(id, in, report, found, err)
+		c.localizeFindings(report, found)
+		c.explain.rule(c, id, in, report, found, err)
+		if first == nil {
+			first = err
+		}
+		if !c.fullEvaluation || report == nil {
+			break
+		}
+	}
+	return first
+}
+
+// timeRule applies a rule, recording its duration when rule profiling is on
+func (c *config) timeRule(id ruleID, in *ruleInput, report *Report) error {
+	if !c.profileRules || c.stats == nil {
+		return c.applySafely(id, in, report)
+	}
+	start := time.Now()
+	err := c.applySafely(id, in, report)
+	c.stats.recordRule(id, time.Since(start))
+	return err
+}
+
+// rules returns the date and entity rule sets in the configured order
+func (c *config) rules() (date, entity []ruleID) {
+	order := c.ruleOrder
+	if order < 0 || int(order) >= len(orderedRules) {
+		order = OrderDeclaration
+	}
+	return orderedRules[order].date, orderedRules[order].entity
+}
+
+// applyRule evaluates a single rule. Rules record their warnings in report
+// and return their error.
+func (c *config) applyRule(id ruleID, in *ruleInput, report *Report) error {
+	switch id {
+	case ruleBeforeBirth:
+		return c.checkBeforeBirth(in, report)
+	case ruleSameDayBirth:
+		return c.checkSameDayBirth(in)
+	case ruleFutureDate:
+		return checkFutureDate(in)
+	case ruleMinimumAge:
+		// Prenatal entities are covered by the before-birth rule
+		if in.entityDate.Before(in.birthDate) {
+			return nil
+		}
+		return c.ageFinding(c.validateMinimumAge(in.birthDate, in.entityDate, in.entity), in.entity.Type, report)
+	case ruleMaximumAge:
+		if in.entityDate.Before(in.birthDate) {
+			return nil
+		}
+		return c.ageFinding(c.validateMaximumAge(in.birthDate, in.entityDate, in.entity.Type), in.entity.Type, report)
+	case ruleHistory:
+		return c.validateHistoricalRealism(in.entityDate)
+	case ruleRenewal:
+		return c.validateRenewal(in.entity)
+	case ruleDuration:
+		return c.validateDuration(in.entity, report)
+	case ruleEntry:
+		return c.validateEntry(in.entity)
+	case ruleDocumentValidity:
+		return c.validateDocumentValidity(in.entity, in.birthDate)
+	case rulePolicy:
+		return c.validatePolicy(in)
+	case ruleJuvenile:
+		c.checkJuvenileRecord(in, report)
+		return nil
+	case ruleAgeCategory:
+		return c.validateAgeCategory(in)
+	case ruleConsent:
+		return c.validateConsent(in)
+	case ruleRevocation:
+		return c.checkRevocation(in.ctx, in.entity, report)
+	case ruleExpiry:
+		// Expiry is only worth a warning for otherwise valid entities
+		if report.Valid() {
+			c.checkExpiry(in.entity, in.birthDate, report)
+		}
+		return nil
+	case ruleDayMonthSwap:
+		c.checkDayMonthSwap(in, report)
+		return nil
+	}
+	return nil
+}
+
+// checkBeforeBirth rejects entity dates before the user's birth, allowing
+// the prenatal window of the entity type with a warning
+func (c *config) checkBeforeBirth(in *ruleInput, report *Report) error {
+	if !in.entityDate.Before(in.birthDate) {
+		return nil
+	}
+	entityType := in.entity.Type
+	window, ok := c.prenatal[entityType]
+	if !ok || in.entityDate.Before(in.birthDate.Add(-window)) {
+		return newError(msgBeforeBirth, "type", entityTypeArg(entityType), "date", in.entityDate, "birth", in.birthDate)
+	}
+	report.add(newWarning(msgPrenatal, "type", entityTypeArg(entityType), "date", in.entityDate, "birth", in.birthDate))
+	return nil
+}
+
+// checkSameDayBirth rejects entities dated on the birth date when the
+// entity type does not allow it
+func (c *config) checkSameDayBirth(in *ruleInput) error {
+	if sameDay(in.entityDate, in.birthDate) && !c.allowsSameDayBirth(in.entity.Type) {
+		return newError(msgSameDayBirth, "type", entityTypeArg(in.entity.Type), "date", in.entityDate)
+	}
+	return nil
+}
+
+// checkFutureDate rejects entity dates in the future
+func checkFutureDate(in *ruleInput) error {
+	if in.entityDate.After(in.now) {
+		return newError(msgFutureEntity, "type", entityTypeArg(in.entity.Type), "date", in.entityDate)
+	}
+	return nil
+}
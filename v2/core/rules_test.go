@@ -0,0 +1,155 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// findingCodes returns the codes of a report's findings in order
+func findingCodes(r *Report) []string {
+	var codes []string
+	for _, f := range r.Findings {
+		codes = append(codes, f.Code)
+	}
+	return codes
+}
+
+func TestRuleOrderAndShortCircuit(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	child := &User{ID: "child", BirthDate: mustParseDate("2015-01-01")}
+	adult := &User{ID: "adult", BirthDate: mustParseDate("1990-01-01")}
+	tenYears := WithMaxHistory(10 * 365 * 24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		user  *User
+		date  string
+		opts  []Option
+		codes []string
+	}{
+		{
+			name:  "declaration order stops at first error",
+			user:  child,
+			date:  "2026-01-01",
+			codes: []string{ErrCodeFutureDate},
+		},
+		{
+			name:  "full evaluation",
+			user:  child,
+			date:  "2026-01-01",
+			opts:  []Option{WithShortCircuit(false)},
+			codes: []string{ErrCodeFutureDate, ErrCodeUnrealisticAge},
+		},
+		{
+			name:  "declaration order checks minimum age before history",
+			user:  adult,
+			date:  "2000-01-01",
+			opts:  []Option{tenYears},
+			codes: []string{ErrCodeUnrealisticAge},
+		},
+		{
+			name:  "cheapest first checks history before minimum age",
+			user:  adult,
+			date:  "2000-01-01",
+			opts:  []Option{tenYears, WithRuleOrder(OrderCheapestFirst)},
+			codes: []string{ErrCodeDateTooOld},
+		},
+		{
+			name:  "cheapest first full evaluation",
+			user:  adult,
+			date:  "2000-01-01",
+			opts:  []Option{tenYears, WithRuleOrder(OrderCheapestFirst), WithShortCircuit(false)},
+			codes: []string{ErrCodeDateTooOld, ErrCodeUnrealisticAge},
+		},
+		{
+			name:  "input checks always stop",
+			user:  &User{ID: "unborn", BirthDate: mustParseDate("2030-01-01")},
+			date:  "2026-01-01",
+			opts:  []Option{WithShortCircuit(false)},
+			codes: []string{ErrCodeFutureDate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{now}, tt.opts...)
+			report := CheckEntityDate(tt.user, mustParseDate(tt.date), "license", opts...)
+			if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+				t.Errorf("findings = %v, want %v", codes, tt.codes)
+			}
+
+			// The error is the first finding of the report
+			err := ValidateEntityDate(tt.user, mustParseDate(tt.date), "license", opts...)
+			if code := codeOf(err); code != tt.codes[0] {
+				t.Errorf("ValidateEntityDate() code = %s, want %s", code, tt.codes[0])
+			}
+		})
+	}
+}
+
+func TestEntityRuleOrder(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	list := NewMemoryRevocationList()
+	list.Revoke("acme", "cpr-1", mustParseDate("2020-01-01"))
+	expiredCPR := Entity{ID: "cpr-1", Issuer: "acme", Type: "cpr_certification", Date: mustParseDate("2018-01-01")}
+	base := []Option{WithFixedNow(mustParseDate("2021-06-15")), WithRevocationChecker(list)}
+
+	tests := []struct {
+		name   string
+		entity Entity
+		opts   []Option
+		codes  []string
+	}{
+		{
+			name:   "declaration order",
+			entity: expiredCPR,
+			codes:  []string{ErrCodeRevoked},
+		},
+		{
+			name:   "cheapest first defers the revocation lookup",
+			entity: expiredCPR,
+			opts:   []Option{WithRuleOrder(OrderCheapestFirst), WithShortCircuit(false)},
+			codes:  []string{ErrCodeExpired, ErrCodeRevoked},
+		},
+		{
+			name:   "severity first checks expiry last",
+			entity: expiredCPR,
+			opts:   []Option{WithRuleOrder(OrderSeverityFirst), WithShortCircuit(false)},
+			codes:  []string{ErrCodeRevoked},
+		},
+		{
+			name:   "revoked status stops by default",
+			entity: Entity{Type: "license", Date: mustParseDate("2022-01-01"), Status: StatusRevoked},
+			codes:  []string{ErrCodeRevoked},
+		},
+		{
+			name:   "revoked status with full evaluation",
+			entity: Entity{Type: "license", Date: mustParseDate("2022-01-01"), Status: StatusRevoked},
+			opts:   []Option{WithShortCircuit(false)},
+			codes:  []string{ErrCodeRevoked, ErrCodeFutureDate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntity(user, tt.entity, append(base, tt.opts...)...)
+			if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+				t.Errorf("findings = %v, want %v", codes, tt.codes)
+			}
+		})
+	}
+}
+
+func TestRuleOrderString(t *testing.T) {
+	tests := map[RuleOrder]string{
+		OrderDeclaration:   "declaration",
+		OrderCheapestFirst: "cheapest-first",
+		OrderSeverityFirst: "severity-first",
+	}
+	for order, want := range tests {
+		if got := order.String(); got != want {
+			t.Errorf("RuleOrder(%d).String() = %q, want %q", order, got, want)
+		}
+	}
+}
@@ -0,0 +1,715 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RuleConfig describes the validation rules in a form that can be written
+// to and read from a configuration file, see WriteYAML and ParseRuleConfig.
+// Apply it with WithRules.
+type RuleConfig struct {
+	Jurisdiction string // Jurisdiction the rules were made for, informational
+	Preset       string // Preset the rules were made from, informational
+
+	Precision    Precision
+	RuleOrder    RuleOrder
+	ShortCircuit bool
+
+	// SwapDetection enables the day_month_swap rule, see WithSwapDetection
+	SwapDetection bool
+
+	// MaxHistory is the exact history limit, see WithMaxHistory. Zero keeps
+	// the calendar limit of MaxHistoryYears.
+	MaxHistory    time.Duration
+	MinBirthDate  time.Time
+	MinEntityDate time.Time
+
+	// PlaceholderDates are the dates reported as missing, see
+	// WithPlaceholderDates
+	PlaceholderDates []time.Time
+
+	// SameDayBirth is whether entities may be dated on the birth date, and
+	// SameDayBirthTypes the types that differ from it
+	SameDayBirth      bool
+	SameDayBirthTypes map[string]bool
+
+	// MinimumAges and MaximumAges bound the age of the user on the entity
+	// date, by entity type
+	MinimumAges     map[string]int
+	MaximumAges     map[string]int
+	PrenatalWindows map[string]time.Duration
+	ValidityPeriods map[string]ValidityPeriod
+
+	// ChildValidity is the validity of documents issued to young users, by
+	// entity type, see WithChildValidity
+	ChildValidity map[string]ChildValidity
+
+	// MaxDurations are the usual longest durations by entity type, see
+	// WithMaxDuration
+	MaxDurations map[string]ValidityPeriod
+
+	// EndAges are the ages by which entities must end, by entity type, see
+	// WithEndAge
+	EndAges map[string]int
+
+	// GuardianAges are the minimum ages of entities held with a guardian,
+	// see WithGuardianAge
+	GuardianAges map[string]int
+
+	// AgeWarnings are the entity types whose age findings are warnings,
+	// sorted, see WithAgeWarnings
+	AgeWarnings []string
+
+	// AgeCutoff is the date the age rules take ages on, AgeCutoffs the
+	// types that differ from it and SchoolYearEnd the month and day of
+	// CutoffSchoolYearEnd, see WithAgeCutoff and WithSchoolYearEnd
+	AgeCutoff     AgeCutoff
+	AgeCutoffs    map[string]AgeCutoff
+	SchoolYearEnd time.Time
+
+	// AgeCategories are the named age categories of competition entries,
+	// see WithAgeCategory
+	AgeCategories map[string]AgeCategory
+}
+
+// EffectiveRules returns the rules that validations with opts apply
+func EffectiveRules(opts ...Option) RuleConfig {
+	cfg := newConfig(opts)
+	return cfg.effectiveRules()
+}
+
+// effectiveRules returns the rules of the configuration
+func (cfg *config) effectiveRules() RuleConfig {
+	r := RuleConfig{
+		Preset:            "default",
+		Precision:         cfg.precision,
+		RuleOrder:         cfg.ruleOrder,
+		ShortCircuit:      !cfg.fullEvaluation,
+		SwapDetection:     cfg.swapDetection,
+		MaxHistory:        cfg.maxHistory,
+		MinBirthDate:      cfg.minBirthDate,
+		MinEntityDate:     cfg.minEntityDate,
+		PlaceholderDates:  slices.Clone(cfg.placeholders),
+		SameDayBirth:      cfg.allowsSameDayBirth(""),
+		SameDayBirthTypes: make(map[string]bool),
+		MinimumAges:       maps.Clone(cfg.minimumAges),
+		MaximumAges:       maps.Clone(cfg.maximumAges),
+		PrenatalWindows:   maps.Clone(cfg.prenatal),
+		ValidityPeriods:   maps.Clone(cfg.validityPeriods),
+		MaxDurations:      maps.Clone(cfg.maxDurations),
+		ChildValidity:     maps.Clone(cfg.childValidity),
+		GuardianAges:      maps.Clone(cfg.guardianAges),
+		EndAges:           maps.Clone(cfg.endAges),
+		AgeCutoff:         cfg.ageCutoff(""),
+		AgeCutoffs:        make(map[string]AgeCutoff),
+		SchoolYearEnd:     cfg.schoolYearEnd,
+		AgeCategories:     maps.Clone(cfg.ageCategories),
+	}
+	for entityType, warn := range cfg.ageWarnings {
+		if warn {
+			r.AgeWarnings = append(r.AgeWarnings, entityType)
+		}
+	}
+	slices.Sort(r.AgeWarnings)
+	for entityType, cutoff := range cfg.ageCutoffs {
+		if entityType != "" {
+			r.AgeCutoffs[entityType] = cutoff
+		}
+	}
+	for entityType, allowed := range cfg.sameDayBirth {
+		if entityType != "" {
+			r.SameDayBirthTypes[entityType] = allowed
+		}
+	}
+	if r.MaximumAges == nil {
+		r.MaximumAges = make(map[string]int)
+	}
+	if r.EndAges == nil {
+		r.EndAges = make(map[string]int)
+	}
+	if r.AgeCategories == nil {
+		r.AgeCategories = make(map[string]AgeCategory)
+	}
+	if r.GuardianAges == nil {
+		r.GuardianAges = make(map[string]int)
+	}
+	if r.ChildValidity == nil {
+		r.ChildValidity = make(map[string]ChildValidity)
+	}
+	if r.MaxDurations == nil {
+		r.MaxDurations = make(map[string]ValidityPeriod)
+	}
+	if r.PrenatalWindows == nil {
+		r.PrenatalWindows = make(map[string]time.Duration)
+	}
+	return r
+}
+
+// WithRules applies a rule configuration, replacing the rule settings of
+// earlier options
+func WithRules(r RuleConfig) Option {
+	return func(c *config) {
+		c.precision = r.Precision
+		c.ruleOrder = r.RuleOrder
+		c.fullEvaluation = !r.ShortCircuit
+		c.swapDetection = r.SwapDetection
+		c.maxHistory = r.MaxHistory
+		c.minBirthDate = r.MinBirthDate
+		c.minEntityDate = r.MinEntityDate
+		c.placeholders = slices.Clone(r.PlaceholderDates)
+		c.sameDayBirth = maps.Clone(r.SameDayBirthTypes)
+		if c.sameDayBirth == nil {
+			c.sameDayBirth = make(map[string]bool)
+		}
+		c.sameDayBirth[""] = r.SameDayBirth
+		c.minimumAges = maps.Clone(r.MinimumAges)
+		c.maximumAges = maps.Clone(r.MaximumAges)
+		c.pinnedAges, c.pinnedMaxAges = nil, nil
+		c.prenatal = maps.Clone(r.PrenatalWindows)
+		c.validityPeriods = maps.Clone(r.ValidityPeriods)
+		c.maxDurations = maps.Clone(r.MaxDurations)
+		c.childValidity = maps.Clone(r.ChildValidity)
+		c.guardianAges = maps.Clone(r.GuardianAges)
+		c.endAges = maps.Clone(r.EndAges)
+		c.pinnedChildValidity = nil
+		c.ageWarnings = nil
+		WithAgeWarnings(r.AgeWarnings...)(c)
+		c.ageCutoffs = maps.Clone(r.AgeCutoffs)
+		if c.ageCutoffs == nil {
+			c.ageCutoffs = make(map[string]AgeCutoff)
+		}
+		c.ageCutoffs[""] = r.AgeCutoff
+		c.schoolYearEnd = r.SchoolYearEnd
+		c.ageCategories = maps.Clone(r.AgeCategories)
+		c.jurisdiction = r.Jurisdiction
+	}
+}
+
+// presets adjust the default rules. Strict rejects entities dated on the
+// birth date and reports every error, most severe rules first.
+var presets = map[string]func(*RuleConfig){
+	"default": func(*RuleConfig) {},
+	"strict": func(r *RuleConfig) {
+		r.SameDayBirth = false
+		clear(r.SameDayBirthTypes)
+		clear(r.PrenatalWindows)
+		r.ShortCircuit = false
+		r.RuleOrder = OrderSeverityFirst
+	},
+}
+
+// Presets returns the names of the rule presets, sorted
+func Presets() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
+
+// Jurisdictions returns the codes of the countries with built-in rules,
+// sorted
+func Jurisdictions() []string {
+	return jurisdictionCodes("")
+}
+
+// Subdivisions returns the ISO 3166-2 codes of the subdivisions of a
+// country with rules of their own, such as US-CA, sorted. Other
+// subdivisions of the country have the country's rules.
+func Subdivisions(country string) []string {
+	return jurisdictionCodes(strings.ToUpper(country))
+}
+
+// NewRuleConfig returns the default rules adjusted by a preset, see Presets,
+// and by the rules of a jurisdiction, see Jurisdictions. The jurisdiction
+// may be a subdivision such as US-CA, see Subdivisions; subdivisions without
+// rules of their own fall back to those of their country. An empty preset is
+// the default one and an empty jurisdiction keeps the default rules.
+func NewRuleConfig(jurisdiction, preset string) (RuleConfig, error) {
+	r := EffectiveRules()
+	if preset == "" {
+		preset = "default"
+	}
+	adjust, ok := presets[preset]
+	if !ok {
+		return RuleConfig{}, fmt.Errorf("userdate: unknown preset %q, want one of %s", preset, strings.Join(Presets(), ", "))
+	}
+	r.Preset = preset
+	adjust(&r)
+
+	if jurisdiction != "" {
+		code := strings.ToUpper(jurisdiction)
+		ages, ok := jurisdictionRules(code)
+		if !ok {
+			return RuleConfig{}, fmt.Errorf("userdate: unknown jurisdiction %q, want one of %s",
+				jurisdiction, strings.Join(Jurisdictions(), ", "))
+		}
+		r.Jurisdiction = code
+		maps.Copy(r.MinimumAges, ages.minimum)
+		maps.Copy(r.MaximumAges, ages.maximum)
+		maps.Copy(r.ChildValidity, ages.childValidity)
+	}
+	return r, nil
+}
+
+// precisionNames names the precisions in configuration files
+var precisionNames = map[Precision]string{
+	PrecisionInstant: "instant",
+	PrecisionDate:    "date",
+}
+
+// WriteYAML writes the rules as a YAML file with a comment on every
+// setting. ParseRuleConfig reads it back.
+func (r RuleConfig) WriteYAML(w io.Writer) error {
+	var b bytes.Buffer
+	b.WriteString("# userdate validation rules. Settings left out keep the library defaults.\n\n")
+
+	b.WriteString("# Version of this file format. Older files are migrated when read.\n")
+	fmt.Fprintf(&b, "schema_version: %d\n\n", RuleSchemaVersion)
+
+	b.WriteString("# Jurisdiction and preset the rules were generated for. Informational only.\n")
+	fmt.Fprintf(&b, "jurisdiction: %s\n", quoteYAML(r.Jurisdiction))
+	fmt.Fprintf(&b, "preset: %s\n\n", quoteYAML(r.Preset))
+
+	b.WriteString("# How dates are compared: instant compares exact instants, date compares\n")
+	b.WriteString("# calendar dates as written, ignoring time of day and zone offset.\n")
+	fmt.Fprintf(&b, "comparison: %s\n\n", precisionNames[r.Precision])
+
+	b.WriteString("# Order of the rules: declaration, cheapest-first or severity-first.\n")
+	fmt.Fprintf(&b, "rule_order: %s\n\n", r.RuleOrder)
+
+	b.WriteString("# Stop at the first error (true) or report every error (false).\n")
+	fmt.Fprintf(&b, "short_circuit: %t\n\n", r.ShortCircuit)
+
+	b.WriteString("# Warn about rejected entity dates that would be valid with day and month\n")
+	b.WriteString("# swapped, as when DD/MM dates are read as MM/DD (SWAPPED_DATE).\n")
+	fmt.Fprintf(&b, "swap_detection: %t\n\n", r.SwapDetection)
+
+	b.WriteString("# Dates further back are too old (DATE_TOO_OLD), as a Go duration such as\n")
+	fmt.Fprintf(&b, "# 87600h. 0s keeps the calendar limit of %d years.\n", MaxHistoryYears)
+	fmt.Fprintf(&b, "max_history: %s\n\n", r.MaxHistory)
+
+	b.WriteString("# Earliest accepted birth and entity dates (DATE_TOO_OLD).\n")
+	fmt.Fprintf(&b, "min_birth_date: %s\n", r.MinBirthDate.Format("2006-01-02"))
+	fmt.Fprintf(&b, "min_entity_date: %s\n\n", r.MinEntityDate.Format("2006-01-02"))
+
+	b.WriteString("# Dates that stand for a missing date (PLACEHOLDER_DATE), separated by\n")
+	b.WriteString("# commas. \"\" accepts them like any other date.\n")
+	placeholders := make([]string, len(r.PlaceholderDates))
+	for i, t := range r.PlaceholderDates {
+		placeholders[i] = t.Format("2006-01-02")
+	}
+	fmt.Fprintf(&b, "placeholder_dates: %s\n\n", quoteYAML(strings.Join(placeholders, ", ")))
+
+	b.WriteString("# Whether entities may be dated on the user's birth date, otherwise\n")
+	b.WriteString("# BEFORE_BIRTH. default applies to the types not listed, such as\n")
+	b.WriteString("# birth_certificate: true.\n")
+	b.WriteString("same_day_birth:\n")
+	fmt.Fprintf(&b, "  default: %t\n", r.SameDayBirth)
+	for _, entityType := range slices.Sorted(maps.Keys(r.SameDayBirthTypes)) {
+		fmt.Fprintf(&b, "  %s: %t\n", entityType, r.SameDayBirthTypes[entityType])
+	}
+	b.WriteString("\n")
+
+	b.WriteString("# Minimum age of the user on the entity date, by entity type\n")
+	b.WriteString("# (UNREALISTIC_AGE).\n")
+	writeYAMLMap(&b, "minimum_ages", r.MinimumAges, strconv.Itoa)
+
+	b.WriteString("# Maximum age of the user on the entity date, by entity type, such as the\n")
+	b.WriteString("# latest age to start kindergarten (UNREALISTIC_AGE).\n")
+	writeYAMLMap(&b, "maximum_ages", r.MaximumAges, strconv.Itoa)
+	b.WriteString("# Age of the user by which entities must end, such as child_insurance: 26,\n")
+	b.WriteString("# at their end date or now while ongoing (UNREALISTIC_AGE).\n")
+	writeYAMLMap(&b, "end_ages", r.EndAges, strconv.Itoa)
+
+	b.WriteString("# Minimum age of the user on the date of entities held jointly with a\n")
+	b.WriteString("# guardian, such as bank_account: 0 for any age (UNREALISTIC_AGE).\n")
+	writeYAMLMap(&b, "guardian_ages", r.GuardianAges, strconv.Itoa)
+
+	b.WriteString("# Entity types whose minimum and maximum ages only give UNREALISTIC_AGE\n")
+	b.WriteString("# warnings, separated by commas, such as volunteer, extracurricular.\n")
+	fmt.Fprintf(&b, "age_warnings: %s\n\n", quoteYAML(strings.Join(r.AgeWarnings, ", ")))
+
+	b.WriteString("# How long before birth entities of these types may be dated, as Go\n")
+	b.WriteString("# durations such as prenatal_screening: 6720h. Such dates are PRENATAL_DATE\n")
+	b.WriteString("# warnings instead of BEFORE_BIRTH errors.\n")
+	writeYAMLMap(&b, "prenatal_windows", r.PrenatalWindows, time.Duration.String)
+
+	b.WriteString("# How long credentials stay valid after issue or renewal, such as 2y or\n")
+	b.WriteString("# 1y6m, before EXPIRED warnings. 0y disables expiry for a type.\n")
+	writeYAMLMap(&b, "validity_periods", r.ValidityPeriods, ValidityPeriod.String)
+
+	b.WriteString("# Validity of documents issued to users younger than an age, such as\n")
+	b.WriteString("# passport: 5y under 16, instead of their validity period.\n")
+	writeYAMLMap(&b, "child_validity", r.ChildValidity, ChildValidity.String)
+
+	b.WriteString("# Usual longest duration of entities with an end date, such as 1y for\n")
+	b.WriteString("# internships, before IMPLAUSIBLE_DURATION warnings. 0y disables the check.\n")
+	writeYAMLMap(&b, "max_durations", r.MaxDurations, ValidityPeriod.String)
+
+	b.WriteString("# Date on which users must be of the minimum, maximum or category age of\n")
+	b.WriteString("# entities: event-date, year-start or year-end of the entity's year, or\n")
+	b.WriteString("# school-year-end, the first school_year_end (MM-DD) on or after it.\n")
+	fmt.Fprintf(&b, "age_cutoff: %s\n", r.AgeCutoff)
+	fmt.Fprintf(&b, "school_year_end: %s\n\n", r.SchoolYearEnd.Format("01-02"))
+
+	b.WriteString("# Age cutoffs of the entity types that differ from age_cutoff, such as\n")
+	b.WriteString("# kindergarten: school-year-end.\n")
+	writeYAMLMap(&b, "age_cutoffs", r.AgeCutoffs, AgeCutoff.String)
+
+	b.WriteString("# Named age categories of competition entries, such as masters: 40+.\n")
+	b.WriteString("# Entries may also use categories such as U18, 40+ or 18-34 directly.\n")
+	writeYAMLMap(&b, "age_categories", r.AgeCategories, AgeCategory.String)
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// writeYAMLMap writes a mapping sorted by key, or {} when it is empty
+func writeYAMLMap[V any](b *bytes.Buffer, name string, m map[string]V, format func(V) string) {
+	if len(m) == 0 {
+		fmt.Fprintf(b, "%s: {}\n\n", name)
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", name)
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		fmt.Fprintf(b, "  %s: %s\n", key, format(m[key]))
+	}
+	b.WriteString("\n")
+}
+
+// quoteYAML writes a string scalar, quoted when empty
+func quoteYAML(s string) string {
+	if s == "" {
+		return `""`
+	}
+	return s
+}
+
+// RuleSchemaVersion is the version of the rules file format that WriteYAML
+// writes as schema_version. Files without one are version 1.
+const RuleSchemaVersion = 2
+
+// ruleRenames are the settings renamed since version 1 of the rules file
+// format, with the version that renamed them. Files using an old name are
+// read as if they used the new one, with a warning.
+var ruleRenames = []struct {
+	version  int
+	old, new string
+}{
+	{2, "precision", "comparison"}, // Not to be confused with Entity.DatePrecision
+}
+
+// ParseRuleConfig reads rules written by WriteYAML. It accepts the subset of
+// YAML that WriteYAML uses: comments, scalars and one level of mappings.
+// Settings left out keep the library defaults; mappings replace the
+// defaults as a whole. Files of older schema versions are migrated, see
+// ParseRuleConfigWarnings.
+func ParseRuleConfig(data []byte) (RuleConfig, error) {
+	r, _, err := ParseRuleConfigWarnings(data)
+	return r, err
+}
+
+// ParseRuleConfigWarnings is like ParseRuleConfig, and also returns a
+// warning for each deprecated setting the file uses, such as a setting
+// renamed since the file's schema version
+func ParseRuleConfigWarnings(data []byte) (RuleConfig, []ConfigIssue, error) {
+	r := EffectiveRules()
+	r.Preset = ""
+	warnings, err := readRulesYAML(data, func(section, key, value string) error {
+		switch {
+		case section == "":
+			return r.set(key, value)
+		case key == "":
+			return r.clearSection(section)
+		}
+		return r.setEntry(section, key, value)
+	})
+	if err != nil {
+		return RuleConfig{}, nil, err
+	}
+	return r, warnings, nil
+}
+
+// rulesLine is a setting of a rules file and the line it is on
+type rulesLine struct {
+	line                int
+	section, key, value string
+}
+
+// readRulesYAML calls fn for each setting of a rules file: with an empty
+// section for top-level scalars, with an empty key when a mapping starts,
+// and with both for the entries of a mapping. Values are unquoted. The
+// empty mapping {} starts a mapping with the value "{}" and no entries.
+// Renamed settings are passed under their current name and returned as
+// warnings. The schema_version is checked and not passed to fn.
+func readRulesYAML(data []byte, fn func(section, key, value string) error) ([]ConfigIssue, error) {
+	lines, err := scanRulesYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []ConfigIssue
+	for _, l := range lines {
+		if l.section == "" && l.key == "schema_version" {
+			version, err := strconv.Atoi(l.value)
+			if err != nil || version < 1 || version > RuleSchemaVersion {
+				return nil, fmt.Errorf("userdate: rules line %d: schema_version: unsupported version %q, want 1 to %d", l.line, l.value, RuleSchemaVersion)
+			}
+			continue
+		}
+		for _, rename := range ruleRenames {
+			if l.section == "" && l.key == rename.old {
+				warnings = append(warnings, ConfigIssue{Setting: rename.old, Severity: SeverityWarning,
+					Message: fmt.Sprintf("line %d: renamed to %s in schema version %d", l.line, rename.new, rename.version)})
+				l.key = rename.new
+			}
+		}
+		if err := fn(l.section, l.key, l.value); err != nil {
+			return nil, fmt.Errorf("userdate: rules line %d: %s: %w", l.line, l.key, err)
+		}
+	}
+	return warnings, nil
+}
+
+// scanRulesYAML splits a rules file into settings
+func scanRulesYAML(data []byte) ([]rulesLine, error) {
+	var lines []rulesLine
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		indented := text[0] == ' ' || text[0] == '\t'
+		key, value, ok := strings.Cut(strings.TrimSpace(text), ":")
+		if !ok {
+			return nil, fmt.Errorf("userdate: rules line %d: expected key: value", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch {
+		case indented && section == "":
+			return nil, fmt.Errorf("userdate: rules line %d: %s: unexpected indentation", line, key)
+		case indented:
+			lines = append(lines, rulesLine{line, section, key, unquoteYAML(value)})
+		case value == "" || value == "{}":
+			lines = append(lines, rulesLine{line, key, "", value})
+			section = key
+			if value == "{}" {
+				section = ""
+			}
+		default:
+			lines = append(lines, rulesLine{line, "", key, unquoteYAML(value)})
+			section = ""
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// unquoteYAML removes the double quotes around a scalar
+func unquoteYAML(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	}
+	return s
+}
+
+// set sets a top-level setting
+func (r *RuleConfig) set(key, value string) error {
+	var err error
+	switch key {
+	case "jurisdiction":
+		r.Jurisdiction = value
+	case "preset":
+		r.Preset = value
+	case "comparison":
+		found := false
+		for p, name := range precisionNames {
+			if name == value {
+				r.Precision, found = p, true
+			}
+		}
+		if !found {
+			err = fmt.Errorf("unknown precision %q", value)
+		}
+	case "rule_order":
+		r.RuleOrder, err = parseRuleOrder(value)
+	case "short_circuit":
+		r.ShortCircuit, err = strconv.ParseBool(value)
+	case "swap_detection":
+		r.SwapDetection, err = strconv.ParseBool(value)
+	case "max_history":
+		r.MaxHistory, err = time.ParseDuration(value)
+	case "min_birth_date":
+		r.MinBirthDate, err = time.Parse("2006-01-02", value)
+	case "min_entity_date":
+		r.MinEntityDate, err = time.Parse("2006-01-02", value)
+	case "placeholder_dates":
+		r.PlaceholderDates = nil
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			var t time.Time
+			if t, err = time.Parse("2006-01-02", s); err != nil {
+				break
+			}
+			r.PlaceholderDates = append(r.PlaceholderDates, t)
+		}
+	case "age_cutoff":
+		r.AgeCutoff, err = parseAgeCutoff(value)
+	case "school_year_end":
+		r.SchoolYearEnd, err = time.Parse("01-02", value)
+	case "age_warnings":
+		r.AgeWarnings = nil
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				r.AgeWarnings = append(r.AgeWarnings, s)
+			}
+		}
+		slices.Sort(r.AgeWarnings)
+	default:
+		err = fmt.Errorf("unknown setting")
+	}
+	return err
+}
+
+// clearSection starts a mapping, dropping its defaults
+func (r *RuleConfig) clearSection(key string) error {
+	switch key {
+	case "same_day_birth":
+		r.SameDayBirthTypes = make(map[string]bool)
+	case "minimum_ages":
+		r.MinimumAges = make(map[string]int)
+	case "maximum_ages":
+		r.MaximumAges = make(map[string]int)
+	case "guardian_ages":
+		r.GuardianAges = make(map[string]int)
+	case "end_ages":
+		r.EndAges = make(map[string]int)
+	case "prenatal_windows":
+		r.PrenatalWindows = make(map[string]time.Duration)
+	case "validity_periods":
+		r.ValidityPeriods = make(map[string]ValidityPeriod)
+	case "max_durations":
+		r.MaxDurations = make(map[string]ValidityPeriod)
+	case "child_validity":
+		r.ChildValidity = make(map[string]ChildValidity)
+	case "age_categories":
+		r.AgeCategories = make(map[string]AgeCategory)
+	case "age_cutoffs":
+		r.AgeCutoffs = make(map[string]AgeCutoff)
+	default:
+		return fmt.Errorf("unknown setting")
+	}
+	return nil
+}
+
+// setEntry sets an entry of a mapping
+func (r *RuleConfig) setEntry(section, key, value string) error {
+	var err error
+	switch section {
+	case "same_day_birth":
+		var allowed bool
+		allowed, err = strconv.ParseBool(value)
+		if key == "default" {
+			r.SameDayBirth = allowed
+		} else {
+			r.SameDayBirthTypes[key] = allowed
+		}
+	case "minimum_ages":
+		r.MinimumAges[key], err = strconv.Atoi(value)
+	case "maximum_ages":
+		r.MaximumAges[key], err = strconv.Atoi(value)
+	case "guardian_ages":
+		r.GuardianAges[key], err = strconv.Atoi(value)
+	case "end_ages":
+		r.EndAges[key], err = strconv.Atoi(value)
+	case "prenatal_windows":
+		r.PrenatalWindows[key], err = time.ParseDuration(value)
+	case "validity_periods":
+		r.ValidityPeriods[key], err = parseValidityPeriod(value)
+	case "max_durations":
+		r.MaxDurations[key], err = parseValidityPeriod(value)
+	case "child_validity":
+		r.ChildValidity[key], err = parseChildValidity(value)
+	case "age_categories":
+		r.AgeCategories[key], err = ParseAgeCategory(value)
+	case "age_cutoffs":
+		r.AgeCutoffs[key], err = parseAgeCutoff(value)
+	}
+	return err
+}
+
+// parseRuleOrder parses the name of a rule order
+func parseRuleOrder(s string) (RuleOrder, error) {
+	for _, o := range []RuleOrder{OrderDeclaration, OrderCheapestFirst, OrderSeverityFirst} {
+		if o.String() == s {
+			return o, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rule order %q", s)
+}
+
+// parseAgeCutoff parses the name of an age cutoff
+func parseAgeCutoff(s string) (AgeCutoff, error) {
+	for _, a := range []AgeCutoff{CutoffEventDate, CutoffYearStart, CutoffYearEnd, CutoffSchoolYearEnd} {
+		if a.String() == s {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown age cutoff %q", s)
+}
+
+// parseChildValidity parses the form written by ChildValidity.String, such
+// as "5y under 16"
+func parseChildValidity(s string) (ChildValidity, error) {
+	period, age, ok := strings.Cut(s, " under ")
+	if !ok {
+		return ChildValidity{}, fmt.Errorf("invalid child validity %q, want such as 5y under 16", s)
+	}
+	var v ChildValidity
+	var err error
+	if v.Validity, err = parseValidityPeriod(strings.TrimSpace(period)); err != nil {
+		return ChildValidity{}, err
+	}
+	if v.UnderAge, err = strconv.Atoi(strings.TrimSpace(age)); err != nil {
+		return ChildValidity{}, fmt.Errorf("invalid child validity %q: %v", s, err)
+	}
+	return v, nil
+}
+
+// parseValidityPeriod parses the form written by ValidityPeriod.String,
+// such as "2y", "6m" or "1y6m"
+func parseValidityPeriod(s string) (ValidityPeriod, error) {
+	var p ValidityPeriod
+	rest := s
+	if years, after, ok := strings.Cut(rest, "y"); ok {
+		n, err := strconv.Atoi(years)
+		if err != nil {
+			return p, fmt.Errorf("invalid validity period %q", s)
+		}
+		p.Years, rest = n, after
+	}
+	if months, ok := strings.CutSuffix(rest, "m"); ok {
+		n, err := strconv.Atoi(months)
+		if err != nil {
+			return p, fmt.Errorf("invalid validity period %q", s)
+		}
+		p.Months, rest = n, ""
+	}
+	if rest != "" || s == "" {
+		return p, fmt.Errorf("invalid validity period %q", s)
+	}
+	return p, nil
+}
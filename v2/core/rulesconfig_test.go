@@ -0,0 +1,238 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewRuleConfig(t *testing.T) {
+	r, err := NewRuleConfig("fr", "strict")
+	if err != nil {
+		t.Fatalf("NewRuleConfig() error = %v", err)
+	}
+	if r.Jurisdiction != "FR" || r.Preset != "strict" {
+		t.Errorf("Jurisdiction, Preset = %q, %q, want FR, strict", r.Jurisdiction, r.Preset)
+	}
+	if r.MinimumAges["license"] != 17 || r.MinimumAges["certification"] != MinCertAge {
+		t.Errorf("MinimumAges = %v, want license 17 and the default certification age", r.MinimumAges)
+	}
+	if r.SameDayBirth || r.ShortCircuit || r.RuleOrder != OrderSeverityFirst {
+		t.Errorf("strict preset not applied: %+v", r)
+	}
+	if minimumAges["license"] != 16 {
+		t.Errorf("NewRuleConfig() changed the built-in minimum ages: %v", minimumAges)
+	}
+
+	for _, tt := range []struct{ jurisdiction, preset string }{{"XX", ""}, {"", "lax"}} {
+		if _, err := NewRuleConfig(tt.jurisdiction, tt.preset); err == nil {
+			t.Errorf("NewRuleConfig(%q, %q) succeeded", tt.jurisdiction, tt.preset)
+		}
+	}
+}
+
+func TestEffectiveRules(t *testing.T) {
+	r := EffectiveRules(
+		WithMinimumAge("employment", 16),
+		WithMinimumAge("license", 0),
+		WithSameDayBirth(false, "vaccination"),
+		WithPrenatalWindow(280*24*time.Hour, "prenatal_screening"),
+		WithShortCircuit(false))
+
+	if r.MinimumAges["employment"] != 16 {
+		t.Errorf("MinimumAges[employment] = %d, want 16", r.MinimumAges["employment"])
+	}
+	if _, ok := r.MinimumAges["license"]; ok {
+		t.Error("MinimumAges has license after WithMinimumAge(license, 0)")
+	}
+	if !r.SameDayBirth || r.SameDayBirthTypes["vaccination"] {
+		t.Errorf("SameDayBirth = %t, %v, want true with vaccination false", r.SameDayBirth, r.SameDayBirthTypes)
+	}
+	if r.PrenatalWindows["prenatal_screening"] != 280*24*time.Hour || r.ShortCircuit {
+		t.Errorf("rules = %+v", r)
+	}
+}
+
+func TestRuleConfigRoundTrip(t *testing.T) {
+	r, err := NewRuleConfig("DE", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Precision = PrecisionDate
+	r.MaxHistory = 100 * 365 * 24 * time.Hour
+	r.SameDayBirthTypes["vaccination"] = false
+	r.PrenatalWindows["prenatal_screening"] = 6720 * time.Hour
+	r.ValidityPeriods["visa"] = ValidityPeriod{Years: 1, Months: 6}
+	r.AgeWarnings = []string{"extracurricular", "volunteer"}
+	r.GuardianAges["bank_account"] = 7
+	r.EndAges["student_insurance"] = 28
+	r.ChildValidity["national_id"] = ChildValidity{UnderAge: 18, Validity: ValidityPeriod{Years: 5}}
+	r.AgeCutoff = CutoffYearEnd
+	r.AgeCutoffs["kindergarten"] = CutoffSchoolYearEnd
+	r.SchoolYearEnd = time.Date(0, time.July, 31, 0, 0, 0, 0, time.UTC)
+	r.AgeCategories["junior"] = AgeCategory{MaxAge: 19}
+	r.AgeCategories["senior"] = AgeCategory{MinAge: 20, MaxAge: 34}
+
+	var buf bytes.Buffer
+	if err := r.WriteYAML(&buf); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+	got, err := ParseRuleConfig(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ParseRuleConfig() error = %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("ParseRuleConfig(WriteYAML()) = %+v, want %+v", got, r)
+	}
+
+	defaults := EffectiveRules()
+	buf.Reset()
+	if err := defaults.WriteYAML(&buf); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+	if got, err := ParseRuleConfig(buf.Bytes()); err != nil || !reflect.DeepEqual(got, defaults) {
+		t.Errorf("ParseRuleConfig() = %+v, %v, want the defaults", got, err)
+	}
+}
+
+func TestParseRuleConfig(t *testing.T) {
+	r, err := ParseRuleConfig([]byte(`
+# Only employment has a minimum age
+minimum_ages:
+  employment: 16   # holiday jobs are not recorded
+short_circuit: false
+`))
+	if err != nil {
+		t.Fatalf("ParseRuleConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(r.MinimumAges, map[string]int{"employment": 16}) || r.ShortCircuit {
+		t.Errorf("rules = %+v", r)
+	}
+	if !reflect.DeepEqual(r.ValidityPeriods, defaultValidityPeriods()) {
+		t.Errorf("ValidityPeriods = %v, want the defaults", r.ValidityPeriods)
+	}
+
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-01-01")}
+	err = ValidateEntityDate(user, mustParseDate("2015-06-01"), "employment", WithRules(r))
+	if code := errorCode(err); code != ErrCodeUnrealisticAge {
+		t.Errorf("ValidateEntityDate() code = %q, want %s", code, ErrCodeUnrealisticAge)
+	}
+	if err := ValidateEntityDate(user, mustParseDate("2002-01-01"), "license", WithRules(r)); err != nil {
+		t.Errorf("ValidateEntityDate() error = %v, want no minimum age for license", err)
+	}
+}
+
+func TestParseRuleConfigErrors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"colour: red", "line 1: colour: unknown setting"},
+		{"precision: nanosecond", `unknown precision "nanosecond"`},
+		{"rule_order: random", `unknown rule order "random"`},
+		{"\n  employment: 16", "line 2: employment: unexpected indentation"},
+		{"minimum_ages:\n  employment: sixteen", "line 2: employment"},
+		{"validity_periods:\n  passport: 10 years", `invalid validity period "10 years"`},
+		{"short_circuit", "expected key: value"},
+		{"schema_version: 3", `line 1: schema_version: unsupported version "3"`},
+		{"schema_version: two", "unsupported version"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseRuleConfig([]byte(tt.data))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseRuleConfig(%q) error = %v, want %q", tt.data, err, tt.want)
+		}
+	}
+}
+
+func TestParseRuleConfigMigration(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		warnings []string
+	}{
+		{"version 1", "precision: date\n", []string{"precision: warning: line 1: renamed to comparison in schema version 2"}},
+		{"version 2", "schema_version: 2\ncomparison: date\n", nil},
+		{"deprecated name in version 2", "schema_version: 2\nprecision: date\n", []string{"precision: warning: line 2: renamed to comparison in schema version 2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, warnings, err := ParseRuleConfigWarnings([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseRuleConfigWarnings() error = %v", err)
+			}
+			if r.Precision != PrecisionDate {
+				t.Errorf("Precision = %v, want PrecisionDate", r.Precision)
+			}
+			var got []string
+			for _, w := range warnings {
+				got = append(got, w.String())
+			}
+			if !reflect.DeepEqual(got, tt.warnings) {
+				t.Errorf("warnings = %q, want %q", got, tt.warnings)
+			}
+		})
+	}
+
+	var b strings.Builder
+	if err := EffectiveRules().WriteYAML(&b); err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("schema_version: %d\n", RuleSchemaVersion); !strings.Contains(b.String(), want) {
+		t.Errorf("WriteYAML() does not contain %q", want)
+	}
+	if _, warnings, err := ParseRuleConfigWarnings([]byte(b.String())); err != nil || len(warnings) > 0 {
+		t.Errorf("ParseRuleConfigWarnings(WriteYAML()) = %v, %v, want no warnings", warnings, err)
+	}
+}
+
+func TestNewRuleConfigSubdivisions(t *testing.T) {
+	tests := []struct {
+		jurisdiction string
+		license      int
+		employment   int
+	}{
+		{"us-nj", 17, 14},  // State license age, federal working age
+		{"US-SD", 14, 14},  // Youngest state license
+		{"US-PR", 16, 14},  // No rules of its own, falls back to US
+		{"FR-IDF", 17, 14}, // No subdivisions in France, falls back to FR
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jurisdiction, func(t *testing.T) {
+			r, err := NewRuleConfig(tt.jurisdiction, "")
+			if err != nil {
+				t.Fatalf("NewRuleConfig() error = %v", err)
+			}
+			if r.Jurisdiction != strings.ToUpper(tt.jurisdiction) {
+				t.Errorf("Jurisdiction = %q", r.Jurisdiction)
+			}
+			if r.MinimumAges["license"] != tt.license || r.MinimumAges["employment"] != tt.employment {
+				t.Errorf("MinimumAges = %v, want license %d, employment %d", r.MinimumAges, tt.license, tt.employment)
+			}
+		})
+	}
+
+	for _, jurisdiction := range []string{"XX-CA", "US-CALIF", "US-"} {
+		if _, err := NewRuleConfig(jurisdiction, ""); err == nil {
+			t.Errorf("NewRuleConfig(%q) error = nil, want an error", jurisdiction)
+		}
+	}
+
+	states := Subdivisions("us")
+	if len(states) != 51 || !slices.Contains(states, "US-CA") || !slices.Contains(states, "US-DC") {
+		t.Errorf("Subdivisions(us) = %v, want the 50 states and DC", states)
+	}
+	if got := Subdivisions("FR"); len(got) != 0 {
+		t.Errorf("Subdivisions(FR) = %v, want none", got)
+	}
+	if slices.Contains(Jurisdictions(), "US-CA") {
+		t.Error("Jurisdictions() lists subdivisions")
+	}
+}
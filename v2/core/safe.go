@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNilValue is returned by methods called on a nil value, or given a nil
+// value they cannot do without, instead of panicking
+var ErrNilValue = errors.New("userdate: nil receiver or argument")
+
+// SafeValidator is a Validator that never panics. A panic in a rule, such
+// as one in a RevocationChecker, gives the report a RULE_PANIC error
+// naming the rule, and evaluation goes on with the next rule as after any
+// error. A panic elsewhere, such as in a JurisdictionResolver or a sink,
+// gives a report holding a single RULE_PANIC error. A SafeValidator is
+// safe for concurrent use.
+type SafeValidator struct {
+	cfg config
+}
+
+// NewSafeValidator returns a SafeValidator with the settings of v, or the
+// package defaults when v is nil
+func NewSafeValidator(v *Validator) *SafeValidator {
+	cfg := *v.config()
+	cfg.recoverPanics = true
+	return &SafeValidator{cfg: cfg}
+}
+
+// config returns the settings of the validator
+func (s *SafeValidator) config() *config {
+	if s == nil {
+		return &NewSafeValidator(nil).cfg
+	}
+	return &s.cfg
+}
+
+// ValidateEntityDate validates a date for a user entity like
+// Validator.ValidateEntityDate
+func (s *SafeValidator) ValidateEntityDate(user *User, entityDate time.Time, entityType string) (err error) {
+	cfg := s.config()
+	defer func() {
+		if v := recover(); v != nil {
+			err = cfg.localize(newError(msgValidationPanic, "panic", fmt.Sprint(v)))
+		}
+	}()
+	return cfg.validateEntityDate(context.Background(), user, entityDate, entityType)
+}
+
+// CheckEntityDate returns the report of a date for a user entity like
+// Validator.CheckEntityDate
+func (s *SafeValidator) CheckEntityDate(user *User, entityDate time.Time, entityType string) (report *Report) {
+	cfg := s.config()
+	defer cfg.recoverReport(user, Entity{Type: entityType, Date: entityDate}, &report)
+	return cfg.checkEntityDate(context.Background(), user, entityDate, entityType)
+}
+
+// CheckEntity validates an entity of a user like Validator.CheckEntity
+func (s *SafeValidator) CheckEntity(user *User, entity Entity) *Report {
+	return s.CheckEntityContext(context.Background(), user, entity)
+}
+
+// CheckEntityContext is like CheckEntity, passing ctx to the revocation
+// checker and taking the current time from ctx when it carries one
+func (s *SafeValidator) CheckEntityContext(ctx context.Context, user *User, entity Entity) (report *Report) {
+	cfg := s.config().withContext(ctx)
+	defer cfg.recoverReport(user, entity, &report)
+	return cfg.checkEntity(ctx, user, entity)
+}
+
+// recoverReport replaces *report with a report of the panic in progress,
+// if any. It must be deferred.
+func (c *config) recoverReport(user *User, entity Entity, report **Report) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	r := &Report{EntityType: entity.Type, EntityDate: entity.Date, RuleVersion: RuleVersion, CheckedAt: c.now()}
+	if user != nil {
+		r.UserID, r.BirthDate = user.ID, user.BirthDate
+	}
+	r.add(c.localize(newError(msgValidationPanic, "panic", fmt.Sprint(v))))
+	r.Verdict = r.verdict()
+	r.InputHash = HashInputs(user, entity, RuleVersion)
+	r.ConfigHash = c.configHash
+	*report = r
+}
+
+// applySafely applies a rule, turning a panic into a RULE_PANIC error when
+// panics are recovered
+func (c *config) applySafely(id ruleID, in *ruleInput, report *Report) (err error) {
+	if !c.recoverPanics {
+		return c.applyRangeRule(id, in, report)
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err = newError(msgRulePanic, "rule", ruleInfos[id].name, "panic", fmt.Sprint(v))
+		}
+	}()
+	return c.applyRangeRule(id, in, report)
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// panickingChecker is a RevocationChecker that panics
+type panickingChecker struct{}
+
+func (panickingChecker) IsRevoked(context.Context, string, string, time.Time) (bool, error) {
+	panic("checker bug")
+}
+
+// panickingResolver is a JurisdictionResolver that panics
+type panickingResolver struct{}
+
+func (panickingResolver) ResolveJurisdiction(context.Context, *User, Entity) (string, error) {
+	panic("resolver bug")
+}
+
+func TestNilReceivers(t *testing.T) {
+	var (
+		v      *Validator
+		batch  *BatchResult
+		report *Report
+		dErr   *DateValidationError
+		user   *User
+		stats  *Stats
+		log    *AuditLog
+		cache  *MemoryResultCache
+		list   *MemoryRevocationList
+		filter *BloomFilter
+		sink   *WebhookSink
+		safe   *SafeValidator
+	)
+	date := mustParseDate("2020-01-01")
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "Validator.ValidateEntityDate", call: func() error {
+			if err := v.ValidateEntityDate(nil, date, "certification"); err == nil {
+				return errors.New("no error for a nil user")
+			}
+			return nil
+		}},
+		{name: "Validator.CheckEntity", call: func() error { v.CheckEntity(user, Entity{Date: date}); return nil }},
+		{name: "BatchResult", call: func() error {
+			batch.Failed()
+			batch.Worst()
+			_, err := batch.MarshalJSON()
+			return err
+		}},
+		{name: "Report.Err", call: func() error { return report.Err() }},
+		{name: "Report.Warnings", call: func() error { report.Warnings(); return nil }},
+		{name: "DateValidationError.Error", call: func() error {
+			if got := dErr.Error(); got != "<nil>" {
+				return errors.New(got)
+			}
+			return nil
+		}},
+		{name: "User.GetAge", call: func() error { user.GetAge(); return nil }},
+		{name: "Stats", call: func() error { stats.Record(nil); stats.Snapshot(); return nil }},
+		{name: "AuditLog", call: func() error { log.Records(); return log.Verify() }},
+		{name: "MemoryResultCache", call: func() error {
+			cache.Set("key", nil, time.Hour)
+			if _, ok := cache.Get("key"); ok {
+				return errors.New("nil cache hit")
+			}
+			return nil
+		}},
+		{name: "MemoryRevocationList", call: func() error {
+			_, err := list.IsRevoked(context.Background(), "acme", "cert-1", date)
+			return err
+		}},
+		{name: "BloomFilter", call: func() error {
+			filter.Add("key")
+			if filter.MayContain("key") {
+				return errors.New("nil filter contains a key")
+			}
+			return nil
+		}},
+		{name: "WebhookSink.Send", call: func() error { return sink.Send(context.Background(), WebhookPayload{}) }},
+		{name: "SafeValidator.CheckEntity", call: func() error { safe.CheckEntity(nil, Entity{Date: date}); return nil }},
+		{name: "StaticJurisdictions", call: func() error {
+			resolver := StaticJurisdictions{Users: map[string]string{"user123": "FR"}, Default: "DE"}
+			if code, err := resolver.ResolveJurisdiction(context.Background(), user, Entity{}); code != "DE" || err != nil {
+				return fmt.Errorf("ResolveJurisdiction() = %q, %v for a nil user", code, err)
+			}
+			return nil
+		}},
+		{name: "ValidateColumns", call: func() error {
+			if _, err := ValidateColumns(make([]time.Time, 2), nil, nil); !errors.Is(err, ErrColumnLengths) {
+				return fmt.Errorf("ValidateColumns() error = %v, want %v", err, ErrColumnLengths)
+			}
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("panic: %v", r)
+				}
+			}()
+			if err := tt.call(); err != nil && !errors.Is(err, ErrNilValue) {
+				t.Errorf("unexpected error = %v, want nil or ErrNilValue", err)
+			}
+		})
+	}
+}
+
+func TestSafeValidator(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entity := Entity{ID: "cert-1", Issuer: "acme", Type: "certification", Date: mustParseDate("2019-01-01")}
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		message string
+	}{
+		{name: "panicking rule", opts: []Option{now, WithRevocationChecker(panickingChecker{})}, message: "revocation"},
+		{name: "panicking resolver", opts: []Option{now, WithJurisdictionResolver(panickingResolver{})}, message: "resolver bug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewSafeValidator(NewValidator(tt.opts...)).CheckEntity(user, entity)
+
+			var dateErr *DateValidationError
+			if !errors.As(report.Err(), &dateErr) || dateErr.Code != ErrCodeRulePanic {
+				t.Fatalf("CheckEntity() error = %v, want code %v", report.Err(), ErrCodeRulePanic)
+			}
+			if !strings.Contains(dateErr.Error(), tt.message) {
+				t.Errorf("CheckEntity() error = %v, want it to mention %q", dateErr, tt.message)
+			}
+			if report.Verdict != VerdictFail {
+				t.Errorf("CheckEntity() verdict = %v, want %v", report.Verdict, VerdictFail)
+			}
+		})
+	}
+
+	t.Run("unsafe validator panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("CheckEntity() did not panic")
+			}
+		}()
+		NewValidator(now, WithRevocationChecker(panickingChecker{})).CheckEntity(user, entity)
+	})
+}
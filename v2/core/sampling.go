@@ -0,0 +1,71 @@
+package core
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+)
+
+// WithSampledRule runs an expensive rule, such as the remote "revocation"
+// lookup, for only percent of the validations, so that it does not drive
+// the tail latency. The rule is named as in the steps of Explain. It always
+// runs when an earlier rule found a warning or an error, where the signal
+// matters most. Whether a validation is sampled depends on its inputs, so
+// the same record is always sampled or always skipped, and cached or
+// replayed reports agree. Skipped rules are listed in Report.Sampled and
+// count as passed in the verdict. Percents of 100 or more run the rule
+// every time; unknown rule names are ignored.
+func WithSampledRule(rule string, percent float64) Option {
+	return func(c *config) {
+		if c.sampledRules == nil {
+			c.sampledRules = make(map[ruleID]float64)
+		}
+		for id, info := range ruleInfos {
+			if info.name == rule {
+				c.sampledRules[ruleID(id)] = percent
+			}
+		}
+	}
+}
+
+// sampledOut reports whether sampling skips a rule, recording it in report
+func (c *config) sampledOut(id ruleID, in *ruleInput, report *Report) bool {
+	percent, ok := c.sampledRules[id]
+	if !ok || percent >= 100 || report.hasFinding() {
+		return false
+	}
+	if sample(id, in) < percent {
+		return false
+	}
+	if report != nil && !slices.Contains(report.Sampled, ruleInfos[id].name) {
+		report.Sampled = append(report.Sampled, ruleInfos[id].name)
+	}
+	return true
+}
+
+// sample returns a stable pseudo-random number in [0, 100) for a rule and
+// its input
+func sample(id ruleID, in *ruleInput) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(ruleInfos[id].name))
+	h.Write([]byte(in.entity.Type))
+	h.Write([]byte(in.entity.ID))
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(in.entity.Date.Unix()))
+	binary.BigEndian.PutUint64(buf[8:], uint64(in.birthDate.Unix()))
+	h.Write(buf[:])
+	return float64(h.Sum64()%10000) / 100
+}
+
+// hasFinding reports whether the report holds a warning or an error
+func (r *Report) hasFinding() bool {
+	if r == nil {
+		return false
+	}
+	for _, f := range r.Findings {
+		if f.Severity != SeverityInfo {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestWithSampledRule(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entity := func(i int) Entity {
+		return Entity{ID: fmt.Sprint("cert-", i), Issuer: "acme", Type: "certification", Date: mustParseDate("2019-01-01")}
+	}
+
+	tests := []struct {
+		name     string
+		percent  float64
+		min, max int
+	}{
+		{"never", 0, 0, 0},
+		{"quarter", 25, 30, 70},
+		{"always", 100, 200, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &countingChecker{}
+			v := NewValidator(WithRevocationChecker(checker), WithSampledRule("revocation", tt.percent))
+			skipped := 0
+			for i := range 200 {
+				report := v.CheckEntityContext(context.Background(), user, entity(i))
+				if !report.Valid() {
+					t.Fatalf("entity %d: findings = %v, want valid", i, findingCodes(report))
+				}
+				if slices.Equal(report.Sampled, []string{"revocation"}) {
+					skipped++
+				}
+			}
+			if checker.calls < tt.min || checker.calls > tt.max {
+				t.Errorf("lookups = %d, want %d to %d", checker.calls, tt.min, tt.max)
+			}
+			if checker.calls+skipped != 200 {
+				t.Errorf("lookups = %d, skipped = %d, want 200 in all", checker.calls, skipped)
+			}
+		})
+	}
+
+	// The decision depends only on the inputs
+	checker := &countingChecker{}
+	v := NewValidator(WithRevocationChecker(checker), WithSampledRule("revocation", 50))
+	for i := range 20 {
+		first := v.CheckEntityContext(context.Background(), user, entity(i)).Sampled
+		if again := v.CheckEntityContext(context.Background(), user, entity(i)).Sampled; !slices.Equal(first, again) {
+			t.Errorf("entity %d: Sampled = %v, then %v", i, first, again)
+		}
+	}
+}
+
+func TestSampledRuleRunsOnWarnings(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	checker := &countingChecker{revoked: true}
+	v := NewValidator(WithRevocationChecker(checker), WithSampledRule("revocation", 0), WithShortCircuit(false))
+
+	// An adult record marked juvenile warns before the revocation lookup
+	report := v.CheckEntityContext(context.Background(), user, Entity{ID: "case-1", Issuer: "court", Type: "criminal_record", Date: mustParseDate("2019-01-01"), Juvenile: true})
+	if checker.calls != 1 || len(report.Sampled) != 0 {
+		t.Errorf("lookups = %d, Sampled = %v, want the rule to run", checker.calls, report.Sampled)
+	}
+	if !slices.Contains(findingCodes(report), ErrCodeRevoked) {
+		t.Errorf("findings = %v, want %s", findingCodes(report), ErrCodeRevoked)
+	}
+}
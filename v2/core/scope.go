@@ -0,0 +1,78 @@
+package core
+
+import "time"
+
+// DefaultScopeAge is the age from which records count in a background
+// check, see ScopeWindow
+const DefaultScopeAge = 18
+
+// WithScopeAge sets the age of the user from which records count in a
+// background check, DefaultScopeAge by default. Zero counts records from
+// birth.
+func WithScopeAge(age int) Option {
+	return func(c *config) {
+		c.scopeAge = max(age, 0)
+	}
+}
+
+// ScopeWindow returns the lookback window of a background check of user:
+// from the later of the user's birthday at the scope age, see WithScopeAge,
+// and years before now, to now or the user's death. Years of zero or less
+// do not cap the window. The window is empty, from after to, when the user
+// has not reached the scope age.
+func ScopeWindow(user *User, years int, opts ...Option) (from, to time.Time) {
+	cfg := newConfig(opts)
+	return cfg.scopeWindow(user, years)
+}
+
+// scopeWindow returns the lookback window of a background check of user
+func (c *config) scopeWindow(user *User, years int) (from, to time.Time) {
+	if user == nil {
+		// The empty window of users below the scope age
+		to = c.truncate(c.now())
+		return to.AddDate(0, 0, 1), to
+	}
+	to = c.truncate(c.now())
+	if !user.DeathDate.IsZero() && user.DeathDate.Before(to) {
+		to = c.truncate(user.DeathDate)
+	}
+	from = c.dateAtAge(c.truncate(user.BirthDate), c.scopeAge)
+	if years > 0 {
+		from = latest(from, to.AddDate(-years, 0, 0))
+	}
+	return from, to
+}
+
+// FilterScope splits the entities of user between those a background check
+// of the last years may consider, see ScopeWindow, and those outside its
+// scope, which must be left out rather than reported as invalid. An entity
+// is in scope when any day from its date to its end date falls within the
+// window; imprecise dates count every day they stand for. Entities without
+// a date stay in scope, so their validation reports them.
+func FilterScope(user *User, entities []Entity, years int, opts ...Option) (inScope, outOfScope []Entity) {
+	// Without a user, validation reports every entity
+	if user == nil {
+		return entities, nil
+	}
+	cfg := newConfig(opts)
+	from, to := cfg.scopeWindow(user, years)
+	for _, entity := range entities {
+		if entity.Date.IsZero() || cfg.overlaps(entity, from, to) {
+			inScope = append(inScope, entity)
+		} else {
+			outOfScope = append(outOfScope, entity)
+		}
+	}
+	return inScope, outOfScope
+}
+
+// overlaps reports whether the span of an entity, from the earliest day its
+// date stands for to its end date or the latest day, meets [from, to]
+func (c *config) overlaps(entity Entity, from, to time.Time) bool {
+	first, last := entity.bounds()
+	start, end := c.truncate(first), c.truncate(last)
+	if !entity.EndDate.IsZero() {
+		end = latest(end, c.truncate(entity.EndDate))
+	}
+	return !start.After(to) && !end.Before(from)
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScopeWindow(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+
+	tests := []struct {
+		name     string
+		user     *User
+		years    int
+		opts     []Option
+		wantFrom string
+		wantTo   string
+	}{
+		{"capped at 7 years", &User{BirthDate: mustParseDate("1980-01-01")}, 7, nil, "2018-07-18", "2025-07-18"},
+		{"from age 18", &User{BirthDate: mustParseDate("2003-03-01")}, 7, nil, "2021-03-01", "2025-07-18"},
+		{"uncapped", &User{BirthDate: mustParseDate("1980-01-01")}, 0, nil, "1998-01-01", "2025-07-18"},
+		{"scope age 16", &User{BirthDate: mustParseDate("2003-03-01")}, 7, []Option{WithScopeAge(16)}, "2019-03-01", "2025-07-18"},
+		{"until death", &User{BirthDate: mustParseDate("1950-01-01"), DeathDate: mustParseDate("2020-05-01")}, 7, nil, "2013-05-01", "2020-05-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := ScopeWindow(tt.user, tt.years, append(tt.opts, now)...)
+			if !from.Equal(mustParseDate(tt.wantFrom)) || !to.Equal(mustParseDate(tt.wantTo)) {
+				t.Errorf("ScopeWindow() = %s, %s, want %s, %s", from.Format(time.DateOnly), to.Format(time.DateOnly), tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+
+	minor := &User{BirthDate: mustParseDate("2010-01-01")}
+	if from, to := ScopeWindow(minor, 7, now); !from.After(to) {
+		t.Errorf("ScopeWindow(minor) = %s, %s, want an empty window", from, to)
+	}
+}
+
+func TestFilterScope(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	entities := []Entity{
+		{ID: "recent", Type: "certification", Date: mustParseDate("2022-01-01")},
+		{ID: "old", Type: "certification", Date: mustParseDate("2010-01-01")},
+		{ID: "minor", Type: "employment", Date: mustParseDate("2005-06-01"), EndDate: mustParseDate("2006-06-01")},
+		{ID: "long", Type: "employment", Date: mustParseDate("2012-01-01"), EndDate: mustParseDate("2020-01-01")},
+		{ID: "approximate", Type: "training", Date: mustParseDate("2018-01-01"), DatePrecision: DatePrecisionYear},
+		{ID: "undated", Type: "training"},
+		{ID: "invalid", Type: "license", Date: mustParseDate("1985-01-01")},
+	}
+
+	inScope, outOfScope := FilterScope(user, entities, 7, WithFixedNow(mustParseDate("2025-07-18")))
+	if got, want := entityIDs(inScope), "recent long approximate undated"; got != want {
+		t.Errorf("in scope = %s, want %s", got, want)
+	}
+	if got, want := entityIDs(outOfScope), "old minor invalid"; got != want {
+		t.Errorf("out of scope = %s, want %s", got, want)
+	}
+}
+
+// entityIDs returns the IDs of entities separated by spaces
+func entityIDs(entities []Entity) string {
+	ids := make([]string, len(entities))
+	for i, e := range entities {
+		ids[i] = e.ID
+	}
+	return strings.Join(ids, " ")
+}
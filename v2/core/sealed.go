@@ -0,0 +1,194 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/agecalc"
+)
+
+// windowMargin keeps eligibility windows clear of day boundaries, where
+// time zones and the legacy age calculation can move a rule's verdict by a
+// day. Dates within the margin are checked in full.
+const windowMargin = 48 * time.Hour
+
+// SealedUser is a validated user bound to a validator's settings and to the
+// reference time at sealing. It caches, per entity type, the eligibility
+// window of dates that pass every entity date rule, so checking a date
+// inside the window takes two comparisons. Dates outside the window and
+// placeholder dates are checked in full, so results match
+// ValidateEntityDate at the sealing time.
+// Seal again to move the reference time. A SealedUser is safe for
+// concurrent use.
+type SealedUser struct {
+	user User
+	cfg  config
+
+	mu      sync.RWMutex
+	windows map[string]eligibilityWindow
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evaluated atomic.Uint64
+}
+
+// eligibilityWindow is an inclusive range of dates that pass every rule
+type eligibilityWindow struct {
+	from, to time.Time
+}
+
+// WindowCacheStats describes the use of a sealed user's window cache
+type WindowCacheStats struct {
+	Hits      uint64 `json:"hits"`      // Checks that found the window cached
+	Misses    uint64 `json:"misses"`    // Checks that computed the window
+	Evaluated uint64 `json:"evaluated"` // Checks outside the window, run in full
+}
+
+// Seal validates a user and binds it to the given options and the current
+// time for repeated entity date checks
+func Seal(user *User, opts ...Option) (*SealedUser, error) {
+	cfg := newConfig(opts)
+	return cfg.seal(user)
+}
+
+// Seal validates a user and binds it to the validator's settings and the
+// current time
+func (v *Validator) Seal(user *User) (*SealedUser, error) {
+	return v.config().seal(user)
+}
+
+// seal validates the user and pins the reference time
+func (c config) seal(user *User) (*SealedUser, error) {
+	if err := c.validateUser(user); err != nil {
+		return nil, err
+	}
+	now := c.now()
+	c.now = func() time.Time { return now }
+	return &SealedUser{user: *user, cfg: c, windows: make(map[string]eligibilityWindow)}, nil
+}
+
+// User returns a copy of the sealed user
+func (s *SealedUser) User() User {
+	if s == nil {
+		return User{}
+	}
+	return s.user
+}
+
+// ValidateEntityDate validates a date for an entity of the sealed user
+func (s *SealedUser) ValidateEntityDate(entityDate time.Time, entityType string) error {
+	return s.ValidateEntityDateContext(context.Background(), entityDate, entityType)
+}
+
+// ValidateEntityDateContext is like ValidateEntityDate, passing ctx to the
+// jurisdiction resolver and revocation checker. The reference time stays
+// the sealing time, whatever the time carried by ctx.
+func (s *SealedUser) ValidateEntityDateContext(ctx context.Context, entityDate time.Time, entityType string) error {
+	if s == nil {
+		return ErrNilValue
+	}
+	if !s.cfg.needsReport() && !entityDate.IsZero() && !s.cfg.isPlaceholder(entityDate) {
+		w := s.window(ctx, entityType)
+		date := s.cfg.truncate(entityDate)
+		if !date.Before(w.from) && !date.After(w.to) {
+			return nil
+		}
+	}
+	s.evaluated.Add(1)
+	return s.cfg.validateEntityDate(ctx, &s.user, entityDate, entityType)
+}
+
+// EligibilityWindow returns the range of dates that an entity of the given
+// type is known to pass. Dates just inside the earliest accepted date may
+// be left out of the window; they are still validated correctly. The window
+// is empty, from and to being zero, when the ages of the type are taken on
+// a cutoff date other than the entity date, see WithAgeCutoff.
+func (s *SealedUser) EligibilityWindow(entityType string) (from, to time.Time) {
+	if s == nil {
+		return time.Time{}, time.Time{}
+	}
+	w := s.window(context.Background(), entityType)
+	return w.from, w.to
+}
+
+// CacheStats returns the window cache counters
+func (s *SealedUser) CacheStats() WindowCacheStats {
+	if s == nil {
+		return WindowCacheStats{}
+	}
+	return WindowCacheStats{
+		Hits:      s.hits.Load(),
+		Misses:    s.misses.Load(),
+		Evaluated: s.evaluated.Load(),
+	}
+}
+
+// window returns the cached eligibility window of an entity type
+func (s *SealedUser) window(ctx context.Context, entityType string) eligibilityWindow {
+	s.mu.RLock()
+	w, ok := s.windows[entityType]
+	s.mu.RUnlock()
+	if ok {
+		s.hits.Add(1)
+		return w
+	}
+
+	s.misses.Add(1)
+	w, ok = s.cfg.eligibilityWindow(ctx, &s.user, entityType)
+	if !ok {
+		// An unknown jurisdiction, such as of a lookup cancelled with ctx,
+		// must not pin the window of the default ages
+		return eligibilityWindow{}
+	}
+	s.mu.Lock()
+	s.windows[entityType] = w
+	s.mu.Unlock()
+	return w
+}
+
+// eligibilityWindow computes the dates that pass every entity date rule for
+// the user. The lower bound stays clear of day-sensitive boundaries by
+// windowMargin; it never admits a date the rules would reject. It returns
+// false when the jurisdiction of the user is unknown.
+func (c *config) eligibilityWindow(ctx context.Context, user *User, entityType string) (eligibilityWindow, bool) {
+	now := c.truncate(c.now())
+	birth := c.truncate(user.BirthDate)
+
+	from := birth
+	from = latest(from, c.historyCutoff(now))
+	from = latest(from, c.minEntityDate.Add(windowMargin))
+	if !c.allowsSameDayBirth(entityType) {
+		from = latest(from, birth.Add(windowMargin))
+	}
+	var lookup Report
+	resolved := c.withJurisdiction(ctx, user, Entity{Type: entityType}, &lookup)
+	if len(lookup.Findings) > 0 {
+		return eligibilityWindow{}, false
+	}
+	_, hasMin := resolved.minimumAges[entityType]
+	_, hasMax := resolved.maximumAges[entityType]
+	if (hasMin || hasMax) && resolved.ageCutoff(entityType) != CutoffEventDate {
+		// Ages taken on a cutoff date do not bound the entity date itself
+		return eligibilityWindow{}, true
+	}
+	if minAge, ok := resolved.minimumAges[entityType]; ok {
+		from = latest(from, agecalc.DateAtAge(birth, minAge).Add(windowMargin))
+	}
+	to := now
+	if maxAge, ok := resolved.maximumAges[entityType]; ok {
+		if last := agecalc.DateAtAge(birth, maxAge+1).Add(-windowMargin); last.Before(to) {
+			to = last
+		}
+	}
+	return eligibilityWindow{from: from, to: to}, true
+}
+
+// latest returns the later of two times
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
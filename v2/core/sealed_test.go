@@ -0,0 +1,144 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSealedUserMatchesValidateEntityDate(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	tokyo := time.FixedZone("JST", 9*3600)
+	users := []*User{
+		{ID: "leap", BirthDate: mustParseDate("2008-02-29")},
+		{ID: "tokyo", BirthDate: time.Date(1990, 5, 15, 23, 0, 0, 0, tokyo)},
+		{ID: "old", BirthDate: mustParseDate("1826-03-01"), DeathDate: mustParseDate("1905-01-01")},
+		{ID: "senior", BirthDate: mustParseDate("1950-03-01")},
+	}
+	settings := map[string][]Option{
+		"default":        {now},
+		"date precision": {now, WithPrecision(PrecisionDate)},
+		"legacy age":     {now, WithLegacyAgeCalc()},
+		"no same day":    {now, WithSameDayBirth(false)},
+		"prenatal":       {now, WithPrenatalWindow(30*24*time.Hour, "training")},
+		"entity floor":   {now, WithMinEntityDate(mustParseDate("2010-01-01"))},
+		"placeholders":   {now, WithPlaceholderDates(mustParseDate("2009-12-31"), mustParseDate("1989-01-01"))},
+		"year start":     {now, WithAgeCutoff(CutoffYearStart)},
+		"year end":       {now, WithAgeCutoff(CutoffYearEnd)},
+		"school year":    {now, WithAgeCutoff(CutoffSchoolYearEnd, "kindergarten", "license")},
+	}
+	types := []string{"certification", "employment", "license", "training", "kindergarten", "hobby"}
+
+	for name, opts := range settings {
+		t.Run(name, func(t *testing.T) {
+			for _, user := range users {
+				sealed, err := Seal(user, opts...)
+				if err != nil {
+					t.Fatalf("Seal(%s) error = %v", user.ID, err)
+				}
+				// Dates around the birth date, the minimum ages, the floor
+				// and now, every 7 hours to cross day boundaries
+				starts := []time.Time{
+					user.BirthDate.AddDate(0, 0, -40),
+					user.BirthDate.AddDate(5, 0, -3),
+					user.BirthDate.AddDate(14, 0, -3),
+					user.BirthDate.AddDate(16, 0, -3),
+					user.BirthDate.AddDate(8, 0, -3),
+					mustParseDate("2009-12-28"),
+					mustParseDate("1969-12-29"),
+					mustParseDate("1899-12-29"),
+					user.BirthDate.AddDate(16, 1, 0),
+					user.BirthDate.AddDate(15, 11, 0),
+					mustParseDate("2025-07-15"),
+				}
+				for _, start := range starts {
+					for step := range 24 {
+						date := start.Add(time.Duration(step) * 7 * time.Hour).In(tokyo)
+						for _, entityType := range types {
+							want := codeOf(ValidateEntityDate(user, date, entityType, opts...))
+							if got := codeOf(sealed.ValidateEntityDate(date, entityType)); got != want {
+								t.Errorf("%s %s at %s: sealed code = %q, want %q", user.ID, entityType, date, got, want)
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSealedUserCache(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	sealed, err := NewValidator(WithFixedNow(mustParseDate("2025-07-18"))).Seal(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, date := range []string{"2010-01-01", "2015-06-01", "2020-03-01", "1989-01-01"} {
+		sealed.ValidateEntityDate(mustParseDate(date), "license")
+	}
+	sealed.ValidateEntityDate(mustParseDate("2020-03-01"), "employment")
+
+	want := WindowCacheStats{Hits: 3, Misses: 2, Evaluated: 1}
+	if got := sealed.CacheStats(); got != want {
+		t.Errorf("CacheStats() = %+v, want %+v", got, want)
+	}
+
+	from, to := sealed.EligibilityWindow("license")
+	if !from.After(mustParseDate("2006-01-01")) || from.After(mustParseDate("2006-01-04")) || !to.Equal(mustParseDate("2025-07-18")) {
+		t.Errorf("EligibilityWindow() = %s, %s", from, to)
+	}
+}
+
+func TestSealInvalidUser(t *testing.T) {
+	if _, err := Seal(&User{BirthDate: mustParseDate("1990-01-01")}); codeOf(err) != ErrCodeInvalidUser {
+		t.Errorf("Seal() error = %v, want %s", err, ErrCodeInvalidUser)
+	}
+	if _, err := Seal(nil); codeOf(err) != ErrCodeInvalidUser {
+		t.Errorf("Seal(nil) error = %v, want %s", err, ErrCodeInvalidUser)
+	}
+}
+
+func TestSealedUserPinsReferenceTime(t *testing.T) {
+	clock := mustParseDate("2025-07-18")
+	sealed, err := Seal(&User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}, WithClock(func() time.Time { return clock }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock = clock.AddDate(1, 0, 0)
+	if err := sealed.ValidateEntityDate(mustParseDate("2026-01-01"), "training"); codeOf(err) != ErrCodeFutureDate {
+		t.Errorf("ValidateEntityDate() error = %v, want %s at the sealing time", err, ErrCodeFutureDate)
+	}
+}
+
+func BenchmarkSealedUserValidateEntityDate(b *testing.B) {
+	sealed, err := Seal(&User{ID: "user123", BirthDate: mustParseDate("1990-01-01")})
+	if err != nil {
+		b.Fatal(err)
+	}
+	entityDate := mustParseDate("2020-01-01")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = sealed.ValidateEntityDate(entityDate, "certification")
+	}
+}
+
+func TestSealedUserAgeCutoff(t *testing.T) {
+	user := &User{ID: "u1", BirthDate: mustParseDate("2000-06-01")}
+	opts := []Option{WithFixedNow(mustParseDate("2025-07-18")), WithAgeCutoff(CutoffYearStart)}
+	sealed, err := Seal(user, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	date := mustParseDate("2016-07-01")
+	want := codeOf(ValidateEntityDate(user, date, "license", opts...))
+	if got := codeOf(sealed.ValidateEntityDate(date, "license")); got != want || want != ErrCodeUnrealisticAge {
+		t.Errorf("sealed code = %q, ValidateEntityDate code = %q, want %q", got, want, ErrCodeUnrealisticAge)
+	}
+	if from, to := sealed.EligibilityWindow("license"); !from.IsZero() || !to.IsZero() {
+		t.Errorf("EligibilityWindow() = %v, %v, want an empty window", from, to)
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Shard identifies one of Count deterministic partitions of the users of a
+// dataset. Users are assigned to shards by a hash of their ID, so separate
+// runs of the same dataset on different machines, each with its own Index,
+// cover every user exactly once.
+type Shard struct {
+	Index int `json:"index"`
+	Count int `json:"count"`
+}
+
+// String returns the shard as "index/count"
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// Owns reports whether the user belongs to the shard. The zero Shard, or
+// any shard with a Count below 2, owns every user.
+func (s Shard) Owns(userID string) bool {
+	return s.Count < 2 || ShardOf(userID, s.Count) == s.Index
+}
+
+// ShardOf returns the shard of a user among count shards. It uses the
+// 64-bit FNV-1a hash of the ID, which is stable across processes, machines
+// and releases.
+func ShardOf(userID string, count int) int {
+	if count < 2 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(userID))
+	return int(h.Sum64() % uint64(count))
+}
+
+// PartitionItems splits items into count shards by user, keeping the input
+// order within each shard. Items without a user go to the shard of the
+// empty ID.
+func PartitionItems(items []Item, count int) [][]Item {
+	count = max(count, 1)
+	shards := make([][]Item, count)
+	for _, item := range items {
+		i := ShardOf(itemUserID(item), count)
+		shards[i] = append(shards[i], item)
+	}
+	return shards
+}
+
+// WithShard restricts batch and bulk validation to the users of shard index
+// among count shards, with index in [0, count). Items of other users are
+// skipped, and results are tagged with the shard.
+func WithShard(index, count int) Option {
+	return func(c *config) {
+		c.shard = Shard{Index: index, Count: count}
+	}
+}
+
+// itemUserID returns the ID of the item's user, or "" without a user
+func itemUserID(item Item) string {
+	if item.User == nil {
+		return ""
+	}
+	return item.User.ID
+}
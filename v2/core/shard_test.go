@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestShardOf(t *testing.T) {
+	// The assignment must never change between releases
+	tests := []struct {
+		userID string
+		count  int
+		want   int
+	}{
+		{"user123", 4, 2},
+		{"user123", 7, 3},
+		{"alice", 4, 3},
+		{"alice", 7, 1},
+		{"", 4, 1},
+		{"alice", 1, 0},
+		{"alice", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := ShardOf(tt.userID, tt.count); got != tt.want {
+			t.Errorf("ShardOf(%q, %d) = %d, want %d", tt.userID, tt.count, got, tt.want)
+		}
+	}
+}
+
+func shardItems(n int) []Item {
+	items := make([]Item, n)
+	for i := range items {
+		items[i] = Item{
+			User:       &User{ID: fmt.Sprintf("user-%d", i%40), BirthDate: mustParseDate("1990-01-01")},
+			EntityDate: mustParseDate("2020-01-01"),
+			EntityType: "training",
+		}
+	}
+	return items
+}
+
+func TestPartitionItems(t *testing.T) {
+	items := shardItems(100)
+	shards := PartitionItems(items, 3)
+
+	total := 0
+	for i, shard := range shards {
+		total += len(shard)
+		for _, item := range shard {
+			if got := ShardOf(item.User.ID, 3); got != i {
+				t.Errorf("item of %s in shard %d, want %d", item.User.ID, i, got)
+			}
+		}
+	}
+	if total != len(items) {
+		t.Errorf("shards hold %d items, want %d", total, len(items))
+	}
+}
+
+func TestWithShardBatch(t *testing.T) {
+	items := shardItems(100)
+	seen := make(map[string]int)
+	total := 0
+	for index := range 3 {
+		result := ValidateBatch(items, WithShard(index, 3))
+		if result.Shard != (Shard{Index: index, Count: 3}) {
+			t.Errorf("Shard = %v, want %d/3", result.Shard, index)
+		}
+		total += len(result.Reports)
+		for _, item := range result.Items {
+			seen[item.User.ID] = index
+		}
+		data, _ := json.Marshal(result)
+		if !strings.Contains(string(data), fmt.Sprintf(`"shard":{"index":%d,"count":3}`, index)) {
+			t.Errorf("JSON = %s, want the shard tag", data)
+		}
+	}
+	if total != len(items) || len(seen) != 40 {
+		t.Errorf("shards validated %d items of %d users, want %d of 40", total, len(seen), len(items))
+	}
+
+	data, _ := json.Marshal(ValidateBatch(items[:1]))
+	if strings.Contains(string(data), "shard") {
+		t.Errorf("unsharded JSON = %s, want no shard tag", data)
+	}
+}
+
+func TestWithShardBulk(t *testing.T) {
+	items := shardItems(40)
+	shard := Shard{Index: 1, Count: 4}
+	var handled []BulkResult
+	b := NewBulkValidator(BulkConfig{Workers: 1}, func(r BulkResult) {
+		handled = append(handled, r)
+	}, WithShard(shard.Index, shard.Count))
+
+	for _, item := range items {
+		if err := b.Submit(context.Background(), item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b.Close()
+
+	want := len(PartitionItems(items, 4)[1])
+	if len(handled) != want {
+		t.Fatalf("handled %d items, want %d", len(handled), want)
+	}
+	for _, r := range handled {
+		if r.Shard != shard || !shard.Owns(r.Item.User.ID) {
+			t.Errorf("result %+v does not belong to shard %v", r, shard)
+		}
+	}
+	if m := b.Metrics(); m.Skipped != uint64(len(items)-want) {
+		t.Errorf("Skipped = %d, want %d", m.Skipped, len(items)-want)
+	}
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidSignature is returned by VerifyReport when a report's signature
+// is missing or does not match its contents
+var ErrInvalidSignature = errors.New("userdate: invalid report signature")
+
+// Sign computes an HMAC-SHA256 signature over the report's canonical form
+// (inputs, findings, rule version and check time) and stores it in
+// Signature. Any later change to the report invalidates the signature.
+func (r *Report) Sign(key []byte) error {
+	if r == nil {
+		return ErrNilValue
+	}
+	mac, err := r.mac(key)
+	if err != nil {
+		return err
+	}
+	r.Signature = hex.EncodeToString(mac)
+	return nil
+}
+
+// VerifyReport checks that the report was signed with key and has not been
+// modified since. It returns ErrInvalidSignature if the check fails.
+func VerifyReport(key []byte, report *Report) error {
+	if report == nil || report.Signature == "" {
+		return ErrInvalidSignature
+	}
+	signature, err := hex.DecodeString(report.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac, err := report.mac(key)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(mac, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// mac returns the HMAC of the canonical report
+func (r *Report) mac(key []byte) ([]byte, error) {
+	data, err := r.canonical()
+	if err != nil {
+		return nil, err
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// canonical encodes the report without its signature, with fields in a fixed
+// order and times in UTC, so the encoding is stable across processes
+func (r *Report) canonical() ([]byte, error) {
+	findings := make([]canonicalFinding, len(r.Findings))
+	for i, f := range r.Findings {
+		findings[i] = canonicalFinding{Code: f.Code, Severity: f.Severity, Message: f.Message, Path: f.Path, Reasons: f.Reasons, Confidence: f.Confidence}
+	}
+	return json.Marshal(canonicalReport{
+		UserID:      r.UserID,
+		BirthDate:   canonicalTime(r.BirthDate),
+		EntityType:  r.EntityType,
+		EntityDate:  canonicalTime(r.EntityDate),
+		Findings:    findings,
+		RuleVersion: r.RuleVersion,
+		CheckedAt:   canonicalTime(r.CheckedAt),
+		Incomplete:  r.Incomplete,
+		Verdict:     r.Verdict,
+		InputHash:   r.InputHash,
+		Sampled:     r.Sampled,
+		ConfigHash:  r.ConfigHash,
+	})
+}
+
+// canonicalReport is the signed form of a Report
+type canonicalReport struct {
+	UserID      string             `json:"user_id"`
+	BirthDate   string             `json:"birth_date"`
+	EntityType  string             `json:"entity_type"`
+	EntityDate  string             `json:"entity_date"`
+	Findings    []canonicalFinding `json:"findings"`
+	RuleVersion string             `json:"rule_version"`
+	CheckedAt   string             `json:"checked_at"`
+	Incomplete  bool               `json:"incomplete,omitempty"`
+	Verdict     Verdict            `json:"verdict,omitempty"`    // Omitted for reports signed before verdicts
+	InputHash   string             `json:"input_hash,omitempty"` // Omitted for reports signed before input hashes
+	Sampled     []string           `json:"sampled,omitempty"`
+	ConfigHash  string             `json:"config_hash,omitempty"`
+}
+
+// canonicalFinding is the signed form of a finding
+type canonicalFinding struct {
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Path     string   `json:"path,omitempty"` // Omitted for findings without a path, as before paths
+	Reasons  []Reason `json:"reasons,omitempty"`
+
+	// Confidence is signed as policies decide on it
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// canonicalTime formats t in UTC with full precision
+func canonicalTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReportSign(t *testing.T) {
+	key := []byte("secret")
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	report := CheckEntityDate(user, mustParseDate("1989-01-01"), "certification")
+
+	if err := VerifyReport(key, report); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyReport() unsigned error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	if err := report.Sign(key); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if err := VerifyReport(key, report); err != nil {
+		t.Errorf("VerifyReport() error = %v", err)
+	}
+	if err := VerifyReport([]byte("other"), report); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyReport() wrong key error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	// The signature survives a JSON round trip
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyReport(key, &decoded); err != nil {
+		t.Errorf("VerifyReport() after JSON round trip error = %v", err)
+	}
+
+	// Any modification invalidates the signature
+	tampered := []func(r *Report){
+		func(r *Report) { r.UserID = "user456" },
+		func(r *Report) { r.EntityDate = r.EntityDate.Add(time.Hour) },
+		func(r *Report) { r.Findings = nil },
+		func(r *Report) {
+			r.Findings = []*DateValidationError{{Code: r.Findings[0].Code, Severity: SeverityWarning}}
+		},
+		func(r *Report) { r.Findings[0].Path = "/entities/1/date" },
+		func(r *Report) { r.Findings[0].Reasons = r.Findings[0].Reasons[1:] },
+		func(r *Report) { r.Findings[0].Reasons[0].Value = "other" },
+		func(r *Report) { r.RuleVersion = "0" },
+		func(r *Report) { r.Verdict = VerdictPass },
+		func(r *Report) { r.CheckedAt = r.CheckedAt.Add(time.Second) },
+		func(r *Report) { r.Signature = "zz" },
+	}
+	for i, tamper := range tampered {
+		var copied Report
+		if err := json.Unmarshal(data, &copied); err != nil {
+			t.Fatal(err)
+		}
+		tamper(&copied)
+		if err := VerifyReport(key, &copied); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("VerifyReport() tampered #%d error = %v, want %v", i, err, ErrInvalidSignature)
+		}
+	}
+}
+
+func TestReportMetadata(t *testing.T) {
+	now := mustParseDate("2020-06-15")
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	report := CheckEntityDate(user, mustParseDate("2020-01-01"), "certification", WithFixedNow(now))
+
+	if report.RuleVersion != RuleVersion || !report.CheckedAt.Equal(now) || !report.BirthDate.Equal(user.BirthDate) {
+		t.Errorf("CheckEntityDate() report = %+v", report)
+	}
+}
+
+func TestReportSignConfidence(t *testing.T) {
+	key := []byte("secret")
+	log := NewAuditLog()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1950-01-01")}
+	report := CheckEntityDate(user, mustParseDate("1970-01-01"), "certification", WithAuditLog(log))
+	if len(report.Findings) == 0 || report.Findings[0].Confidence == 0 {
+		t.Fatalf("CheckEntityDate() findings = %v, want a finding with a confidence", report.Findings)
+	}
+	if err := report.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+
+	report.Findings[0].Confidence /= 2
+	if err := VerifyReport(key, report); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyReport() with a lowered confidence error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	records := log.Records()
+	records[0].Report.Findings[0].Confidence /= 2
+	if err := VerifyAuditChain(records); !errors.Is(err, ErrBrokenAuditChain) {
+		t.Errorf("VerifyAuditChain() with a lowered confidence error = %v, want %v", err, ErrBrokenAuditChain)
+	}
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"time"
+)
+
+// ValidateSigningWindow checks the issuance date of a digitally signed
+// credential. The date must fall inside the signing certificate's validity
+// window [notBefore, notAfter] and pass the usual entity date checks against
+// the user's lifetime. All failures are reported together, joined with
+// errors.Join, so each can be inspected with errors.As.
+func ValidateSigningWindow(user *User, issued time.Time, entityType string, notBefore, notAfter time.Time, opts ...Option) error {
+	cfg := newConfig(opts)
+
+	var errs []error
+	if err := cfg.validateEntityDate(context.Background(), user, issued, entityType); err != nil {
+		errs = append(errs, err)
+	}
+	if issued.Before(notBefore) || issued.After(notAfter) {
+		errs = append(errs, cfg.localize(newError(msgSigningWindow, "type", entityTypeArg(entityType),
+			"issued", instantArg(issued), "not_before", instantArg(notBefore), "not_after", instantArg(notAfter))))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateSignedEntity is like ValidateSigningWindow, taking the window from
+// the certificate that signed the entity
+func ValidateSignedEntity(user *User, entity Entity, cert *x509.Certificate, opts ...Option) error {
+	if cert == nil {
+		cfg := newConfig(opts)
+		return cfg.localize(newError(msgNilCertificate))
+	}
+	return ValidateSigningWindow(user, entity.Date, entity.Type, cert.NotBefore, cert.NotAfter, opts...)
+}
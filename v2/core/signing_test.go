@@ -0,0 +1,94 @@
+package core
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateSigningWindow(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	notBefore := mustParseDate("2018-01-01")
+	notAfter := mustParseDate("2021-01-01")
+
+	tests := []struct {
+		name   string
+		issued time.Time
+		codes  []string
+	}{
+		{"inside window", mustParseDate("2019-06-01"), nil},
+		{"window start", notBefore, nil},
+		{"window end", notAfter.Add(-time.Second), nil},
+		{"before window", mustParseDate("2017-12-31"), []string{ErrCodeOutsideSigningWindow}},
+		{"before birth and window", mustParseDate("1989-01-01"), []string{ErrCodeBeforeBirth, ErrCodeOutsideSigningWindow}},
+	}
+
+	now := WithFixedNow(mustParseDate("2022-01-01"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSigningWindow(user, tt.issued, "certification", notBefore, notAfter, now)
+			if got := errorCodes(err); !equalStrings(got, tt.codes) {
+				t.Errorf("ValidateSigningWindow() codes = %v, want %v (%v)", got, tt.codes, err)
+			}
+		})
+	}
+
+	// A window extending past now still rejects future issuance dates
+	now = WithFixedNow(mustParseDate("2020-06-15"))
+	err := ValidateSigningWindow(user, mustParseDate("2020-09-01"), "certification", notBefore, notAfter, now)
+	if got := errorCodes(err); !equalStrings(got, []string{ErrCodeFutureDate}) {
+		t.Errorf("ValidateSigningWindow() codes = %v, want %v", got, []string{ErrCodeFutureDate})
+	}
+}
+
+func TestValidateSignedEntity(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	cert := &x509.Certificate{NotBefore: mustParseDate("2018-01-01"), NotAfter: mustParseDate("2021-01-01")}
+
+	if err := ValidateSignedEntity(user, Entity{Type: "certification", Date: mustParseDate("2019-01-01")}, cert); err != nil {
+		t.Errorf("ValidateSignedEntity() unexpected error = %v", err)
+	}
+
+	err := ValidateSignedEntity(user, Entity{Type: "certification", Date: mustParseDate("2017-01-01")}, cert)
+	var dateErr *DateValidationError
+	if !errors.As(err, &dateErr) || dateErr.Code != ErrCodeOutsideSigningWindow {
+		t.Errorf("ValidateSignedEntity() error = %v, want code %v", err, ErrCodeOutsideSigningWindow)
+	}
+
+	if err := ValidateSignedEntity(user, Entity{Type: "certification", Date: mustParseDate("2019-01-01")}, nil); err == nil {
+		t.Errorf("ValidateSignedEntity(nil) expected error but got none")
+	}
+}
+
+// errorCodes returns the codes of the DateValidationErrors joined in err
+func errorCodes(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var codes []string
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			codes = append(codes, errorCodes(e)...)
+		}
+		return codes
+	}
+	var dateErr *DateValidationError
+	if errors.As(err, &dateErr) {
+		codes = append(codes, dateErr.Code)
+	}
+	return codes
+}
+
+// equalStrings reports whether a and b hold the same strings in order
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
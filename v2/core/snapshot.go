@@ -0,0 +1,115 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ConfigSnapshot is the effective configuration of a Validator: its rule
+// thresholds, the versions of the datasets it reads and the features it
+// enables. It is a copy, so changing it changes nothing.
+type ConfigSnapshot struct {
+	RuleVersion string       `json:"rule_version"`
+	Rules       RuleConfig   `json:"rules"`
+	Data        DataVersions `json:"data"`
+	Features    Features     `json:"features"`
+
+	// Hash is the SHA-256 of the other fields in hex, the ConfigHash of
+	// the reports of the validator
+	Hash string `json:"hash"`
+}
+
+// DataVersions identify the datasets of a configuration by the SHA-256 of
+// their content in hex, empty when there is none
+type DataVersions struct {
+	RuleData string `json:"rule_data"`          // Jurisdiction table and credential validity periods, see LoadRuleData
+	Embedded bool   `json:"embedded"`           // Whether the binary embeds the rule data, see EmbeddedRuleData
+	Catalog  string `json:"catalog,omitempty"`  // Credential catalog, see WithCatalog
+	Locale   string `json:"locale,omitempty"`   // Locale of the messages, see WithLocale
+	Messages string `json:"messages,omitempty"` // Message catalog of the locale
+}
+
+// Features are the optional behaviors a configuration enables
+type Features struct {
+	LegacyAgeCalc        bool               `json:"legacy_age_calc,omitempty"`
+	JurisdictionResolver bool               `json:"jurisdiction_resolver,omitempty"`
+	Revocation           bool               `json:"revocation,omitempty"`
+	SampledRules         map[string]float64 `json:"sampled_rules,omitempty"` // Percent of validations by rule, see WithSampledRule
+	Prescreen            bool               `json:"prescreen,omitempty"`
+	ResultCache          bool               `json:"result_cache,omitempty"`
+	Audit                bool               `json:"audit,omitempty"`
+	Review               bool               `json:"review,omitempty"`
+	Webhook              bool               `json:"webhook,omitempty"`
+	Stats                bool               `json:"stats,omitempty"`
+	ReportPool           bool               `json:"report_pool,omitempty"`
+}
+
+// Config returns the effective configuration of the validator
+func (v *Validator) Config() ConfigSnapshot {
+	return v.config().snapshot()
+}
+
+// snapshot describes the configuration
+func (c *config) snapshot() ConfigSnapshot {
+	s := ConfigSnapshot{
+		RuleVersion: RuleVersion,
+		Rules:       c.effectiveRules(),
+		Data: DataVersions{
+			RuleData: ruleDataVersion(),
+			Embedded: EmbeddedRuleData(),
+			Locale:   c.locale,
+		},
+		Features: Features{
+			LegacyAgeCalc:        c.legacyAge,
+			JurisdictionResolver: c.jurisdictions != nil,
+			Revocation:           c.revocation != nil,
+			Prescreen:            c.prescreen != nil,
+			ResultCache:          c.resultCache != nil,
+			Audit:                c.audit != nil,
+			Review:               c.review != nil,
+			Webhook:              c.webhook != nil,
+			Stats:                c.stats != nil,
+			ReportPool:           c.poolReports,
+		},
+	}
+	if c.catalog != nil {
+		s.Data.Catalog = hashBytes(c.catalog.data)
+	}
+	if c.messages != nil {
+		// Encoding string maps cannot fail
+		data, _ := json.Marshal(c.messages)
+		s.Data.Messages = hashBytes(data)
+	}
+	if len(c.sampledRules) > 0 {
+		s.Features.SampledRules = make(map[string]float64, len(c.sampledRules))
+		for id, percent := range c.sampledRules {
+			s.Features.SampledRules[ruleInfos[id].name] = percent
+		}
+	}
+	s.Hash = s.hash()
+	return s
+}
+
+// setConfigHash sets the ConfigHash of the reports of c
+func (c *config) setConfigHash() {
+	c.configHash = c.snapshot().Hash
+	unscreened := *c
+	unscreened.prescreen = nil
+	c.unscreenedHash = unscreened.snapshot().Hash
+}
+
+// hash returns the SHA-256 of the snapshot without its hash
+func (s ConfigSnapshot) hash() string {
+	s.Hash = ""
+	// Snapshots hold plain values and maps with string keys, which encode
+	// in a stable order and cannot fail
+	data, _ := json.Marshal(s)
+	return hashBytes(data)
+}
+
+// hashBytes returns the SHA-256 of data in hex
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidatorConfig(t *testing.T) {
+	v := NewValidator()
+	snap := v.Config()
+	if !reflect.DeepEqual(snap.Rules, EffectiveRules()) {
+		t.Errorf("Config().Rules differ from EffectiveRules()")
+	}
+	if snap.RuleVersion != RuleVersion || snap.Data.RuleData == "" || snap.Data.Embedded != EmbeddedRuleData() {
+		t.Errorf("Config() = %+v, want the rule version and data versions", snap)
+	}
+	if other := NewValidator().Config(); other.Hash != snap.Hash {
+		t.Errorf("Config().Hash = %s, then %s for the same options", snap.Hash, other.Hash)
+	}
+
+	// The snapshot is a copy
+	snap.Rules.MinimumAges["license"] = 99
+	if v.Config().Rules.MinimumAges["license"] == 99 {
+		t.Error("changing the snapshot changed the validator")
+	}
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		check func(ConfigSnapshot) bool
+	}{
+		{"threshold", []Option{WithMinimumAge("license", 21)}, func(s ConfigSnapshot) bool { return s.Rules.MinimumAges["license"] == 21 }},
+		{"locale", []Option{WithLocale("fr")}, func(s ConfigSnapshot) bool { return s.Data.Locale == "fr" && s.Data.Messages != "" }},
+		{"feature", []Option{WithRevocationChecker(NewMemoryRevocationList())}, func(s ConfigSnapshot) bool { return s.Features.Revocation }},
+		{"sampled rule", []Option{WithSampledRule("revocation", 10)}, func(s ConfigSnapshot) bool { return s.Features.SampledRules["revocation"] == 10 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewValidator(tt.opts...).Config()
+			if !tt.check(s) {
+				t.Errorf("Config() = %+v", s)
+			}
+			if s.Hash == snap.Hash {
+				t.Error("Config().Hash did not change")
+			}
+		})
+	}
+}
+
+func TestReportConfigHash(t *testing.T) {
+	v := NewValidator(WithFixedNow(mustParseDate("2025-01-01")))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	report := v.CheckEntityDate(user, mustParseDate("2020-01-01"), "certification")
+	if report.ConfigHash != v.Config().Hash {
+		t.Errorf("ConfigHash = %q, want %q", report.ConfigHash, v.Config().Hash)
+	}
+
+	key := []byte("secret")
+	if err := report.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	report.ConfigHash = NewValidator(WithLocale("fr")).Config().Hash
+	if err := VerifyReport(key, report); err != ErrInvalidSignature {
+		t.Errorf("VerifyReport() after changing ConfigHash = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+// AgeCategory is the age range of a competition category. A zero MaxAge
+// leaves the range open, such as masters from 40.
+type AgeCategory struct {
+	MinAge int `json:"min_age"`
+	MaxAge int `json:"max_age"`
+}
+
+// String returns the category in the form ParseAgeCategory reads, such as
+// "U18" for users under 18, "40+" or "18-34"
+func (a AgeCategory) String() string {
+	switch {
+	case a.MaxAge == 0:
+		return fmt.Sprintf("%d+", a.MinAge)
+	case a.MinAge == 0:
+		return fmt.Sprintf("U%d", a.MaxAge+1)
+	default:
+		return fmt.Sprintf("%d-%d", a.MinAge, a.MaxAge)
+	}
+}
+
+// ParseAgeCategory parses an age category: "U18" for users under 18, "40+"
+// for users of 40 or older, or a range such as "18-34"
+func ParseAgeCategory(s string) (AgeCategory, error) {
+	var a AgeCategory
+	var err error
+	switch {
+	case strings.HasPrefix(s, "U"):
+		var under int
+		under, err = strconv.Atoi(s[1:])
+		a.MaxAge = under - 1
+	case strings.HasSuffix(s, "+"):
+		a.MinAge, err = strconv.Atoi(strings.TrimSuffix(s, "+"))
+	default:
+		low, high, ok := strings.Cut(s, "-")
+		if !ok {
+			return AgeCategory{}, fmt.Errorf("invalid age category %q, want such as U18, 40+ or 18-34", s)
+		}
+		if a.MinAge, err = strconv.Atoi(low); err == nil {
+			a.MaxAge, err = strconv.Atoi(high)
+		}
+	}
+	if err != nil || a.MinAge < 0 || a.MaxAge < 0 || (a.MaxAge != 0 && a.MaxAge < a.MinAge) || a == (AgeCategory{}) {
+		return AgeCategory{}, fmt.Errorf("invalid age category %q, want such as U18, 40+ or 18-34", s)
+	}
+	return a, nil
+}
+
+// ageCategories are the named age categories; other categories are read
+// with ParseAgeCategory
+var ageCategories = map[string]AgeCategory{
+	"masters": {MinAge: 40},
+}
+
+// WithAgeCategory names an age category, such as "veterans" for
+// AgeCategory{MinAge: 50}, for entities whose Category uses the name
+func WithAgeCategory(name string, category AgeCategory) Option {
+	return func(c *config) {
+		categories := maps.Clone(c.ageCategories)
+		if categories == nil {
+			categories = make(map[string]AgeCategory)
+		}
+		categories[name] = category
+		c.ageCategories = categories
+	}
+}
+
+// ageCategory returns the age range of a category, by name or as parsed
+func (c *config) ageCategory(name string) (AgeCategory, error) {
+	if category, ok := c.ageCategories[name]; ok {
+		return category, nil
+	}
+	return ParseAgeCategory(name)
+}
+
+// validateAgeCategory checks that the user was within the age category of
+// an entity on the cutoff date of its event
+func (c *config) validateAgeCategory(in *ruleInput) error {
+	entity := in.entity
+	if entity.Category == "" {
+		return nil
+	}
+	category, err := c.ageCategory(entity.Category)
+	if err != nil {
+		return newError(msgUnknownCategory, "type", entityTypeArg(entity.Type), "category", entity.Category)
+	}
+	cutoff := c.cutoffDate(entity.Type, in.entityDate)
+	age := c.ageAt(in.birthDate, cutoff)
+	switch {
+	case age < category.MinAge:
+		return newError(msgCategoryTooYoung, "type", entityTypeArg(entity.Type), "category", entity.Category, "age", age, "cutoff", cutoff, "min", category.MinAge)
+	case category.MaxAge != 0 && age > category.MaxAge:
+		return newError(msgCategoryTooOld, "type", entityTypeArg(entity.Type), "category", entity.Category, "age", age, "cutoff", cutoff, "max", category.MaxAge)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package core
+
+import "testing"
+
+func TestAgeCategories(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2030-01-01"))
+	junior := &User{ID: "junior", BirthDate: mustParseDate("2008-06-15")}
+	veteran := &User{ID: "veteran", BirthDate: mustParseDate("1980-03-01")}
+
+	tests := []struct {
+		name     string
+		user     *User
+		entity   Entity
+		opts     []Option
+		wantCode string
+	}{
+		{"U18 at 17", junior, Entity{Type: "competition", Date: mustParseDate("2025-07-18"), Category: "U18"}, nil, ""},
+		{"U18 at 18", junior, Entity{Type: "competition", Date: mustParseDate("2026-07-01"), Category: "U18"}, nil, ErrCodeAgeCategory},
+		{"U18 at 17 on January 1", junior, Entity{Type: "competition", Date: mustParseDate("2026-07-01"), Category: "U18"}, []Option{WithAgeCutoff(CutoffYearStart)}, ""},
+		{"U18 at 18 on December 31", junior, Entity{Type: "competition", Date: mustParseDate("2026-03-01"), Category: "U18"}, []Option{WithAgeCutoff(CutoffYearEnd)}, ErrCodeAgeCategory},
+		{"U18 at 17 on the event date", junior, Entity{Type: "competition", Date: mustParseDate("2026-03-01"), Category: "U18"}, nil, ""},
+		{"masters at 39", veteran, Entity{Type: "competition", Date: mustParseDate("2020-02-01"), Category: "masters"}, nil, ErrCodeAgeCategory},
+		{"masters at 40 on December 31", veteran, Entity{Type: "competition", Date: mustParseDate("2020-02-01"), Category: "masters"}, []Option{WithAgeCutoff(CutoffYearEnd)}, ""},
+		{"open category", veteran, Entity{Type: "competition", Date: mustParseDate("2021-02-01"), Category: "40+"}, nil, ""},
+		{"range", junior, Entity{Type: "competition", Date: mustParseDate("2025-07-18"), Category: "18-34"}, nil, ErrCodeAgeCategory},
+		{"named category", veteran, Entity{Type: "competition", Date: mustParseDate("2029-02-01"), Category: "veterans"}, []Option{WithAgeCategory("veterans", AgeCategory{MinAge: 50})}, ErrCodeAgeCategory},
+		{"unknown category", junior, Entity{Type: "competition", Date: mustParseDate("2025-07-18"), Category: "juniors"}, nil, ErrCodeAgeCategory},
+		{"no category", junior, Entity{Type: "competition", Date: mustParseDate("2025-07-18")}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntity(tt.user, tt.entity, append(tt.opts, now)...)
+			if code := codeOf(err); code != tt.wantCode {
+				t.Errorf("ValidateEntity() code = %q, want %q: %v", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestParseAgeCategory(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    AgeCategory
+		wantErr bool
+	}{
+		{"U18", AgeCategory{MaxAge: 17}, false},
+		{"40+", AgeCategory{MinAge: 40}, false},
+		{"18-34", AgeCategory{MinAge: 18, MaxAge: 34}, false},
+		{"U0", AgeCategory{}, true},
+		{"34-18", AgeCategory{}, true},
+		{"senior", AgeCategory{}, true},
+		{"", AgeCategory{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAgeCategory(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAgeCategory(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAgeCategory(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.in {
+				t.Errorf("String() = %q, want %q", got.String(), tt.in)
+			}
+		})
+	}
+}
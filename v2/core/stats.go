@@ -0,0 +1,170 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Stats counts validation outcomes. It is safe for concurrent use.
+type Stats struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	total    uint64
+	failed   uint64
+	warnings uint64
+	byCode   map[string]uint64
+	rules    [len(ruleInfos)]RuleTiming
+
+	cacheHits   uint64
+	cacheMisses uint64
+}
+
+// StatsSnapshot is a point-in-time copy of Stats
+type StatsSnapshot struct {
+	TakenAt  time.Time         `json:"taken_at"`
+	Total    uint64            `json:"total"`
+	Failed   uint64            `json:"failed"`
+	Warnings uint64            `json:"warnings"`
+	ByCode   map[string]uint64 `json:"by_code"`
+
+	// CacheHits and CacheMisses count the lookups of WithResultCache
+	CacheHits   uint64 `json:"cache_hits,omitempty"`
+	CacheMisses uint64 `json:"cache_misses,omitempty"`
+
+	// Rules holds the rule timings, slowest in total first, when rule
+	// profiling is enabled with WithRuleProfiling
+	Rules []RuleTiming `json:"rules,omitempty"`
+}
+
+// RuleTiming is the time spent evaluating a rule
+type RuleTiming struct {
+	Rule  string        `json:"rule"`
+	Count uint64        `json:"count"` // Number of evaluations
+	Total time.Duration `json:"total"`
+	Max   time.Duration `json:"max"`
+}
+
+// Mean returns the average evaluation time
+func (t RuleTiming) Mean() time.Duration {
+	if t.Count == 0 {
+		return 0
+	}
+	return t.Total / time.Duration(t.Count)
+}
+
+// NewStats creates an empty stats collector
+func NewStats() *Stats {
+	return &Stats{now: time.Now, byCode: make(map[string]uint64)}
+}
+
+// WithStats counts the outcome of every validation in s
+func WithStats(s *Stats) Option {
+	return func(c *config) {
+		c.stats = s
+	}
+}
+
+// WithRuleProfiling times every rule evaluation and records the timings in
+// the Stats given with WithStats, where snapshots rank the rules by the
+// total time spent in them. It has no effect without WithStats.
+func WithRuleProfiling() Option {
+	return func(c *config) {
+		c.profileRules = true
+	}
+}
+
+// Record counts the outcome of a report
+func (s *Stats) Record(report *Report) {
+	if s == nil || report == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.total++
+	if !report.Valid() {
+		s.failed++
+	}
+	for _, f := range report.Findings {
+		if f.Severity == SeverityWarning {
+			s.warnings++
+		}
+		s.byCode[f.Code]++
+	}
+}
+
+// Snapshot returns a copy of the current counters
+func (s *Stats) Snapshot() StatsSnapshot {
+	if s == nil {
+		return StatsSnapshot{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byCode := make(map[string]uint64, len(s.byCode))
+	for code, n := range s.byCode {
+		byCode[code] = n
+	}
+	return StatsSnapshot{
+		TakenAt:     s.now(),
+		Total:       s.total,
+		Failed:      s.failed,
+		Warnings:    s.warnings,
+		ByCode:      byCode,
+		CacheHits:   s.cacheHits,
+		CacheMisses: s.cacheMisses,
+		Rules:       s.ruleProfile(),
+	}
+}
+
+// recordCache counts a lookup of the result cache
+func (s *Stats) recordCache(hit bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hit {
+		s.cacheHits++
+	} else {
+		s.cacheMisses++
+	}
+}
+
+// recordRule adds the duration of a rule evaluation
+func (s *Stats) recordRule(id ruleID, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &s.rules[id]
+	t.Count++
+	t.Total += d
+	t.Max = max(t.Max, d)
+}
+
+// ruleProfile returns the timings of the evaluated rules, slowest in total
+// first
+func (s *Stats) ruleProfile() []RuleTiming {
+	var profile []RuleTiming
+	for id, t := range s.rules {
+		if t.Count == 0 {
+			continue
+		}
+		t.Rule = ruleInfos[id].name
+		profile = append(profile, t)
+	}
+	slices.SortStableFunc(profile, func(a, b RuleTiming) int {
+		return cmp.Compare(b.Total, a.Total)
+	})
+	return profile
+}
+
+// Save stores a snapshot of the current counters in store
+func (s *Stats) Save(ctx context.Context, store StatsStore) error {
+	if s == nil || store == nil {
+		return ErrNilValue
+	}
+	return store.SaveStats(ctx, s.Snapshot())
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithStats(t *testing.T) {
+	stats := NewStats()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	v := NewValidator(WithStats(stats), WithPrenatalWindow(60*24*time.Hour, "prenatal_screening"))
+
+	_ = v.ValidateEntityDate(user, mustParseDate("2020-01-01"), "certification")
+	_ = v.ValidateEntityDate(user, mustParseDate("1989-01-01"), "certification")
+	_ = v.CheckEntityDate(user, mustParseDate("1989-12-01"), "prenatal_screening")
+	_ = v.ValidateBatch([]Item{{User: nil, EntityDate: mustParseDate("2020-01-01"), EntityType: "training"}})
+
+	snapshot := stats.Snapshot()
+	if snapshot.Total != 4 || snapshot.Failed != 2 || snapshot.Warnings != 1 {
+		t.Errorf("Snapshot() = %+v, want 4 total, 2 failed, 1 warning", snapshot)
+	}
+	want := map[string]uint64{ErrCodeBeforeBirth: 1, ErrCodePrenatal: 1, ErrCodeInvalidUser: 1}
+	if !reflect.DeepEqual(snapshot.ByCode, want) {
+		t.Errorf("Snapshot().ByCode = %v, want %v", snapshot.ByCode, want)
+	}
+
+	// Snapshots are copies
+	snapshot.ByCode[ErrCodeBeforeBirth] = 10
+	if stats.Snapshot().ByCode[ErrCodeBeforeBirth] != 1 {
+		t.Errorf("Snapshot() shares its counters with Stats")
+	}
+}
+
+func TestWithRuleProfiling(t *testing.T) {
+	stats := NewStats()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	slow := checkerFunc(func(ctx context.Context) (bool, error) {
+		time.Sleep(2 * time.Millisecond)
+		return false, nil
+	})
+	v := NewValidator(WithStats(stats), WithRuleProfiling(), WithRevocationChecker(slow))
+
+	for range 3 {
+		v.CheckEntity(user, Entity{ID: "cert-1", Type: "certification", Date: mustParseDate("2020-01-01")})
+	}
+	v.ValidateEntityDate(user, mustParseDate("1989-01-01"), "certification")
+
+	rules := stats.Snapshot().Rules
+	if len(rules) == 0 {
+		t.Fatal("Snapshot().Rules is empty")
+	}
+	if rules[0].Rule != "revocation" || rules[0].Count != 3 {
+		t.Errorf("hottest rule = %+v, want revocation evaluated 3 times", rules[0])
+	}
+	if rules[0].Mean() < 2*time.Millisecond || rules[0].Max < rules[0].Mean() {
+		t.Errorf("revocation timing = %+v, want a mean of at least 2ms", rules[0])
+	}
+	for _, r := range rules {
+		if r.Rule == "before_birth" && r.Count != 4 {
+			t.Errorf("before_birth count = %d, want 4", r.Count)
+		}
+	}
+}
+
+func TestRuleProfilingDisabled(t *testing.T) {
+	stats := NewStats()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	ValidateEntityDate(user, mustParseDate("2020-01-01"), "certification", WithStats(stats))
+
+	if rules := stats.Snapshot().Rules; rules != nil {
+		t.Errorf("Snapshot().Rules = %v, want nil without WithRuleProfiling", rules)
+	}
+}
@@ -0,0 +1,286 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// AuditStore persists the records of an audit log
+type AuditStore interface {
+	// AppendAudit durably stores a record after the previous ones
+	AppendAudit(ctx context.Context, record AuditRecord) error
+	// LoadAudit returns all stored records in order
+	LoadAudit(ctx context.Context) ([]AuditRecord, error)
+}
+
+// StatsStore persists stats snapshots
+type StatsStore interface {
+	// SaveStats stores a snapshot after the previous ones
+	SaveStats(ctx context.Context, snapshot StatsSnapshot) error
+	// LoadStats returns all stored snapshots in order
+	LoadStats(ctx context.Context) ([]StatsSnapshot, error)
+}
+
+// FileAuditStore stores audit records in a JSON Lines file, one record per
+// line. It is safe for concurrent use within a process.
+type FileAuditStore struct {
+	file jsonlFile
+}
+
+// NewFileAuditStore returns a store using the file at path, which is created
+// on the first append if it does not exist
+func NewFileAuditStore(path string) *FileAuditStore {
+	return &FileAuditStore{file: jsonlFile{path: path}}
+}
+
+// AppendAudit appends the record as a line of the file
+func (s *FileAuditStore) AppendAudit(_ context.Context, record AuditRecord) error {
+	return s.file.append(record)
+}
+
+// LoadAudit reads every record of the file
+func (s *FileAuditStore) LoadAudit(_ context.Context) ([]AuditRecord, error) {
+	var records []AuditRecord
+	err := s.file.read(func(line []byte) error {
+		var record AuditRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// FileStatsStore stores stats snapshots in a JSON Lines file, one snapshot
+// per line. It is safe for concurrent use within a process.
+type FileStatsStore struct {
+	file jsonlFile
+}
+
+// NewFileStatsStore returns a store using the file at path, which is created
+// on the first save if it does not exist
+func NewFileStatsStore(path string) *FileStatsStore {
+	return &FileStatsStore{file: jsonlFile{path: path}}
+}
+
+// SaveStats appends the snapshot as a line of the file
+func (s *FileStatsStore) SaveStats(_ context.Context, snapshot StatsSnapshot) error {
+	return s.file.append(snapshot)
+}
+
+// LoadStats reads every snapshot of the file
+func (s *FileStatsStore) LoadStats(_ context.Context) ([]StatsSnapshot, error) {
+	var snapshots []StatsSnapshot
+	err := s.file.read(func(line []byte) error {
+		var snapshot StatsSnapshot
+		if err := json.Unmarshal(line, &snapshot); err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	})
+	return snapshots, err
+}
+
+// jsonlFile appends and reads JSON values, one per line
+type jsonlFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+// append writes v as a new line and syncs the file
+func (f *jsonlFile) append(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// read calls fn for each non-empty line. A missing file has no lines.
+func (f *jsonlFile) read(fn func(line []byte) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := fn(scanner.Bytes()); err != nil {
+			return fmt.Errorf("%s:%d: %w", f.path, line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// SQLStore stores audit records and stats snapshots through database/sql.
+// Each value is kept as a JSON document next to its ordering key, so the
+// schema works on any SQL database; see CreateTables. The caller provides
+// the driver.
+type SQLStore struct {
+	DB *sql.DB
+
+	// AuditTable and StatsTable name the tables, "userdate_audit" and
+	// "userdate_stats" by default
+	AuditTable string
+	StatsTable string
+
+	// Placeholder returns the bind parameter for the n-th argument,
+	// starting at 1. The default returns "?"; use "$n" for PostgreSQL.
+	Placeholder func(n int) string
+}
+
+// NewSQLStore returns a store using db with the default table names
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{DB: db}
+}
+
+// CreateTables creates the audit and stats tables if they do not exist
+func (s *SQLStore) CreateTables(ctx context.Context) error {
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (sequence BIGINT PRIMARY KEY, hash VARCHAR(64) NOT NULL, record TEXT NOT NULL)",
+			s.auditTable()),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (taken_at_ns BIGINT NOT NULL, snapshot TEXT NOT NULL)",
+			s.statsTable()),
+	}
+	for _, stmt := range statements {
+		if _, err := s.DB.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendAudit inserts the record. The sequence is the primary key, so a
+// record can never be stored twice.
+func (s *SQLStore) AppendAudit(ctx context.Context, record AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf("INSERT INTO %s (sequence, hash, record) VALUES (%s, %s, %s)",
+		s.auditTable(), s.placeholder(1), s.placeholder(2), s.placeholder(3))
+	_, err = s.DB.ExecContext(ctx, query, int64(record.Sequence), record.Hash, string(data))
+	return err
+}
+
+// LoadAudit returns all records ordered by sequence
+func (s *SQLStore) LoadAudit(ctx context.Context) ([]AuditRecord, error) {
+	query := fmt.Sprintf("SELECT record FROM %s ORDER BY sequence", s.auditTable())
+	var records []AuditRecord
+	err := s.query(ctx, query, func(data string) error {
+		var record AuditRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// SaveStats inserts the snapshot, keyed by the Unix time of TakenAt in
+// nanoseconds so that snapshots sort by time
+func (s *SQLStore) SaveStats(ctx context.Context, snapshot StatsSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf("INSERT INTO %s (taken_at_ns, snapshot) VALUES (%s, %s)",
+		s.statsTable(), s.placeholder(1), s.placeholder(2))
+	_, err = s.DB.ExecContext(ctx, query, snapshot.TakenAt.UnixNano(), string(data))
+	return err
+}
+
+// LoadStats returns all snapshots ordered by time
+func (s *SQLStore) LoadStats(ctx context.Context) ([]StatsSnapshot, error) {
+	query := fmt.Sprintf("SELECT snapshot FROM %s ORDER BY taken_at_ns", s.statsTable())
+	var snapshots []StatsSnapshot
+	err := s.query(ctx, query, func(data string) error {
+		var snapshot StatsSnapshot
+		if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	})
+	return snapshots, err
+}
+
+// query runs a single-column query and calls fn for each row
+func (s *SQLStore) query(ctx context.Context, query string, fn func(data string) error) error {
+	rows, err := s.DB.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// auditTable returns the audit table name
+func (s *SQLStore) auditTable() string {
+	if s.AuditTable != "" {
+		return s.AuditTable
+	}
+	return "userdate_audit"
+}
+
+// statsTable returns the stats table name
+func (s *SQLStore) statsTable() string {
+	if s.StatsTable != "" {
+		return s.StatsTable
+	}
+	return "userdate_stats"
+}
+
+// placeholder returns the bind parameter for the n-th argument
+func (s *SQLStore) placeholder(n int) string {
+	if s.Placeholder != nil {
+		return s.Placeholder(n)
+	}
+	return "?"
+}
@@ -0,0 +1,300 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileAuditStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	log, err := OpenAuditLog(ctx, NewFileAuditStore(path))
+	if err != nil {
+		t.Fatalf("OpenAuditLog() error = %v", err)
+	}
+	_ = CheckEntityDate(user, mustParseDate("2020-01-01"), "certification", WithAuditLog(log))
+	_ = CheckEntityDate(user, mustParseDate("1989-01-01"), "certification", WithAuditLog(log))
+
+	// Reopening resumes the chain from the file
+	log, err = OpenAuditLog(ctx, NewFileAuditStore(path))
+	if err != nil {
+		t.Fatalf("OpenAuditLog() reopen error = %v", err)
+	}
+	_ = CheckEntityDate(user, mustParseDate("2019-01-01"), "training", WithAuditLog(log))
+
+	records, err := NewFileAuditStore(path).LoadAudit(ctx)
+	if err != nil {
+		t.Fatalf("LoadAudit() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("LoadAudit() returned %d records, want 3", len(records))
+	}
+	if err := VerifyAuditChain(records); err != nil {
+		t.Errorf("VerifyAuditChain() error = %v", err)
+	}
+
+	// A tampered file is refused
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), "BEFORE_BIRTH", "FUTURE_DATE", 1)
+	if err := os.WriteFile(path, []byte(tampered), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenAuditLog(ctx, NewFileAuditStore(path)); !errors.Is(err, ErrBrokenAuditChain) {
+		t.Errorf("OpenAuditLog() tampered error = %v, want %v", err, ErrBrokenAuditChain)
+	}
+}
+
+func TestFileAuditStoreFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "audit.jsonl")
+	log, err := OpenAuditLog(context.Background(), NewFileAuditStore(path))
+	if err != nil {
+		t.Fatalf("OpenAuditLog() error = %v", err)
+	}
+
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	report := CheckEntityDate(user, mustParseDate("2020-01-01"), "certification", WithAuditLog(log))
+	if warnings := report.Warnings(); len(warnings) != 1 || warnings[0].Code != ErrCodeAuditFailed {
+		t.Errorf("CheckEntityDate() warnings = %v, want %v", warnings, ErrCodeAuditFailed)
+	}
+	if len(log.Records()) != 0 {
+		t.Errorf("Records() = %v, want none after a failed append", log.Records())
+	}
+}
+
+func TestFileStatsStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStatsStore(filepath.Join(t.TempDir(), "stats.jsonl"))
+	stats := NewStats()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	if snapshots, err := store.LoadStats(ctx); err != nil || len(snapshots) != 0 {
+		t.Errorf("LoadStats() empty = %v, %v", snapshots, err)
+	}
+
+	_ = ValidateEntityDate(user, mustParseDate("1989-01-01"), "certification", WithStats(stats))
+	if err := stats.Save(ctx, store); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	_ = ValidateEntityDate(user, mustParseDate("2020-01-01"), "certification", WithStats(stats))
+	if err := stats.Save(ctx, store); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	snapshots, err := store.LoadStats(ctx)
+	if err != nil {
+		t.Fatalf("LoadStats() error = %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Total != 1 || snapshots[1].Total != 2 || snapshots[1].ByCode[ErrCodeBeforeBirth] != 1 {
+		t.Errorf("LoadStats() = %+v", snapshots)
+	}
+}
+
+func TestSQLStore(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("userdate-memory", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore(db)
+	if err := store.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+
+	log, err := OpenAuditLog(ctx, store)
+	if err != nil {
+		t.Fatalf("OpenAuditLog() error = %v", err)
+	}
+	stats := NewStats()
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	v := NewValidator(WithAuditLog(log), WithStats(stats))
+	_ = v.ValidateEntityDate(user, mustParseDate("2020-01-01"), "certification")
+	_ = v.ValidateEntityDate(user, mustParseDate("1989-01-01"), "certification")
+	if err := stats.Save(ctx, store); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	records, err := store.LoadAudit(ctx)
+	if err != nil {
+		t.Fatalf("LoadAudit() error = %v", err)
+	}
+	if !reflect.DeepEqual(hashes(records), hashes(log.Records())) {
+		t.Errorf("LoadAudit() = %v, want %v", hashes(records), hashes(log.Records()))
+	}
+	if err := VerifyAuditChain(records); err != nil {
+		t.Errorf("VerifyAuditChain() error = %v", err)
+	}
+
+	snapshots, err := store.LoadStats(ctx)
+	if err != nil {
+		t.Fatalf("LoadStats() error = %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Total != 2 || snapshots[0].Failed != 1 {
+		t.Errorf("LoadStats() = %+v", snapshots)
+	}
+
+	// Snapshots load in time order, whatever the precision of their times
+	for _, at := range []string{"2020-06-15T12:00:05.5Z", "2020-06-15T12:00:05Z", "2020-06-15T12:00:04.75Z"} {
+		takenAt, _ := time.Parse(time.RFC3339Nano, at)
+		if err := store.SaveStats(ctx, StatsSnapshot{TakenAt: takenAt}); err != nil {
+			t.Fatalf("SaveStats() error = %v", err)
+		}
+	}
+	snapshots, err = store.LoadStats(ctx)
+	if err != nil {
+		t.Fatalf("LoadStats() error = %v", err)
+	}
+	if len(snapshots) != 4 || !slices.IsSortedFunc(snapshots, func(a, b StatsSnapshot) int { return a.TakenAt.Compare(b.TakenAt) }) {
+		t.Errorf("LoadStats() times are out of order: %+v", snapshots)
+	}
+
+	// Custom placeholders and table names are used in statements
+	custom := &SQLStore{DB: db, AuditTable: "audit", Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) }}
+	if err := custom.AppendAudit(ctx, records[0]); err != nil {
+		t.Fatalf("AppendAudit() error = %v", err)
+	}
+	if got := memoryDB(t.Name()).lastQuery; !strings.Contains(got, "INSERT INTO audit") || !strings.Contains(got, "$3") {
+		t.Errorf("AppendAudit() query = %q", got)
+	}
+}
+
+// hashes returns the hashes of the records
+func hashes(records []AuditRecord) []string {
+	var out []string
+	for _, r := range records {
+		out = append(out, r.Hash)
+	}
+	return out
+}
+
+// memoryDriver is a minimal database/sql driver understanding the
+// statements issued by SQLStore. Each table keeps the first and last
+// columns of its inserted rows, in insertion order, and queries sort them by
+// the first one.
+type memoryDriver struct{}
+
+type memoryDatabase struct {
+	mu        sync.Mutex
+	tables    map[string][]memoryRow
+	lastQuery string
+}
+
+// memoryRow is the ordering key and the document of a row
+type memoryRow struct {
+	key  driver.Value
+	data string
+}
+
+var memoryDatabases sync.Map
+
+func init() {
+	sql.Register("userdate-memory", memoryDriver{})
+}
+
+func memoryDB(name string) *memoryDatabase {
+	db, _ := memoryDatabases.LoadOrStore(name, &memoryDatabase{tables: make(map[string][]memoryRow)})
+	return db.(*memoryDatabase)
+}
+
+func (memoryDriver) Open(name string) (driver.Conn, error) {
+	return &memoryConn{db: memoryDB(name)}, nil
+}
+
+type memoryConn struct {
+	db *memoryDatabase
+}
+
+func (c *memoryConn) Prepare(query string) (driver.Stmt, error) {
+	return &memoryStmt{db: c.db, query: query}, nil
+}
+
+func (c *memoryConn) Close() error { return nil }
+
+func (c *memoryConn) Begin() (driver.Tx, error) { return nil, errors.New("transactions not supported") }
+
+type memoryStmt struct {
+	db    *memoryDatabase
+	query string
+}
+
+func (s *memoryStmt) Close() error { return nil }
+
+func (s *memoryStmt) NumInput() int { return -1 }
+
+func (s *memoryStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.lastQuery = s.query
+
+	fields := strings.Fields(s.query)
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE IF NOT EXISTS"):
+		if _, ok := s.db.tables[fields[5]]; !ok {
+			s.db.tables[fields[5]] = nil
+		}
+	case strings.HasPrefix(s.query, "INSERT INTO"):
+		s.db.tables[fields[2]] = append(s.db.tables[fields[2]], memoryRow{key: args[0], data: args[len(args)-1].(string)})
+	default:
+		return nil, fmt.Errorf("unsupported statement %q", s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *memoryStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.lastQuery = s.query
+
+	fields := strings.Fields(s.query)
+	if fields[0] != "SELECT" {
+		return nil, fmt.Errorf("unsupported query %q", s.query)
+	}
+	table := slices.SortedStableFunc(slices.Values(s.db.tables[fields[3]]), func(a, b memoryRow) int {
+		if a, ok := a.key.(int64); ok {
+			return cmp.Compare(a, b.key.(int64))
+		}
+		return cmp.Compare(a.key.(string), b.key.(string))
+	})
+	rows := make([]string, len(table))
+	for i, row := range table {
+		rows[i] = row.data
+	}
+	return &memoryRows{column: fields[1], rows: rows}, nil
+}
+
+type memoryRows struct {
+	column string
+	rows   []string
+}
+
+func (r *memoryRows) Columns() []string { return []string{r.column} }
+
+func (r *memoryRows) Close() error { return nil }
+
+func (r *memoryRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.rows[0]
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,53 @@
+package core
+
+import "time"
+
+// WithSwapDetection enables the day_month_swap rule. It warns with
+// ErrCodeSwappedDate about entity dates that are rejected as before birth,
+// in the future or below the minimum age, but that would pass with day and
+// month swapped, as when a DD/MM date was read as MM/DD. The warning
+// suggests the swapped date; the errors of the other rules are still
+// reported. Only dates whose day is 12 or less can be swapped.
+func WithSwapDetection() Option {
+	return func(c *config) {
+		c.swapDetection = true
+	}
+}
+
+// checkDayMonthSwap reports entity dates that would only pass swapped. The
+// swap is likelier for a date before birth or in the future than for one
+// that only makes the user young.
+func (c *config) checkDayMonthSwap(in *ruleInput, report *Report) {
+	swapped, ok := swapDayMonth(in.entityDate)
+	if !c.swapDetection || !ok || in.imprecise() {
+		return
+	}
+	if c.plausibleEntityDate(in, in.entityDate) || !c.plausibleEntityDate(in, swapped) {
+		return
+	}
+	confidence := confidenceSwapAge
+	if c.checkBeforeBirth(in, &Report{}) != nil || checkFutureDate(in) != nil {
+		confidence = confidenceSwapImpossible
+	}
+	report.add(withConfidence(newWarning(msgSwappedDate, "type", entityTypeArg(in.entity.Type), "date", in.entityDate, "swapped", swapped), confidence))
+}
+
+// plausibleEntityDate reports whether an entity dated date would pass the
+// before-birth, future-date and minimum-age rules
+func (c *config) plausibleEntityDate(in *ruleInput, date time.Time) bool {
+	dated := *in
+	dated.entityDate = date
+	return c.checkBeforeBirth(&dated, &Report{}) == nil && checkFutureDate(&dated) == nil &&
+		c.validateMinimumAge(dated.birthDate, date, dated.entity) == nil
+}
+
+// swapDayMonth returns t with its day and month swapped, if that gives a
+// different valid date
+func swapDayMonth(t time.Time) (time.Time, bool) {
+	year, month, day := t.Date()
+	if day > 12 || day == int(month) {
+		return time.Time{}, false
+	}
+	swapped := time.Date(year, time.Month(day), int(month), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return swapped, true
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSwapDetection(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+
+	tests := []struct {
+		name  string
+		date  string
+		typ   string
+		opts  []Option
+		codes []string
+	}{
+		{
+			name:  "future date valid swapped",
+			date:  "2020-12-06",
+			typ:   "training",
+			codes: []string{ErrCodeSwappedDate, ErrCodeFutureDate},
+		},
+		{
+			name:  "too young valid swapped",
+			date:  "2006-02-10",
+			typ:   "license",
+			codes: []string{ErrCodeSwappedDate, ErrCodeUnrealisticAge},
+		},
+		{
+			name:  "before birth valid swapped",
+			date:  "1990-03-10",
+			typ:   "account",
+			codes: []string{ErrCodeSwappedDate, ErrCodeBeforeBirth},
+		},
+		{
+			name:  "invalid both ways",
+			date:  "2021-12-10",
+			typ:   "training",
+			codes: []string{ErrCodeFutureDate},
+		},
+		{
+			name:  "day above 12",
+			date:  "2020-12-20",
+			typ:   "training",
+			codes: []string{ErrCodeFutureDate},
+		},
+		{
+			name:  "day equal to month",
+			date:  "2020-12-12",
+			typ:   "training",
+			codes: []string{ErrCodeFutureDate},
+		},
+		{
+			name: "valid date",
+			date: "2015-03-04",
+			typ:  "training",
+		},
+		{
+			name:  "detection off",
+			date:  "2020-12-06",
+			typ:   "training",
+			opts:  []Option{WithRules(EffectiveRules())},
+			codes: []string{ErrCodeFutureDate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{now, WithSwapDetection()}, tt.opts...)
+			report := CheckEntity(user, Entity{Type: tt.typ, Date: mustParseDate(tt.date)}, opts...)
+			if codes := findingCodes(report); !reflect.DeepEqual(codes, tt.codes) {
+				t.Errorf("codes = %v, want %v", codes, tt.codes)
+			}
+		})
+	}
+}
+
+func TestSwapDetectionMessage(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	report := CheckEntity(user, Entity{Type: "license", Date: mustParseDate("2006-02-10")},
+		WithFixedNow(mustParseDate("2020-06-15")), WithSwapDetection())
+	if len(report.Findings) == 0 || report.Findings[0].Severity != SeverityWarning {
+		t.Fatalf("findings = %v, want a SWAPPED_DATE warning first", report.Findings)
+	}
+	if msg := report.Findings[0].Message; !strings.Contains(msg, "2006-10-02") {
+		t.Errorf("message = %q, want the swapped date 2006-10-02", msg)
+	}
+}
+
+func TestSwapDetectionRuleConfig(t *testing.T) {
+	r := EffectiveRules(WithSwapDetection())
+	if !r.SwapDetection {
+		t.Fatal("EffectiveRules(WithSwapDetection()).SwapDetection = false, want true")
+	}
+	var b strings.Builder
+	if err := r.WriteYAML(&b); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseRuleConfig([]byte(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.SwapDetection {
+		t.Errorf("parsed SwapDetection = false, want true from:\n%s", b.String())
+	}
+}
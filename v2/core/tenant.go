@@ -0,0 +1,114 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ErrUnknownTenant is returned for tenants that are not registered
+var ErrUnknownTenant = errors.New("userdate: unknown tenant")
+
+// TenantRegistry holds an isolated validator per tenant of a multi-tenant
+// service, so customers can have their own jurisdiction and thresholds in
+// one process. Each tenant counts its validations in its own Stats. A
+// TenantRegistry is safe for concurrent use.
+type TenantRegistry struct {
+	common []Option
+
+	mu      sync.RWMutex
+	tenants map[string]*tenant
+}
+
+// tenant is the validator of a tenant and the stats it records into
+type tenant struct {
+	validator *Validator
+	stats     *Stats
+}
+
+// NewTenantRegistry returns an empty registry. The common options apply to
+// every tenant, before the tenant's own options.
+func NewTenantRegistry(common ...Option) *TenantRegistry {
+	return &TenantRegistry{common: common, tenants: make(map[string]*tenant)}
+}
+
+// Register sets the options of a tenant, such as WithRules with its rules
+// file, replacing the tenant's validator and stats if it was registered
+// already. The tenant's stats take the place of any WithStats option.
+func (r *TenantRegistry) Register(id string, opts ...Option) (*Validator, error) {
+	if r == nil {
+		return nil, ErrNilValue
+	}
+	if id == "" {
+		return nil, errors.New("userdate: empty tenant ID")
+	}
+	t := &tenant{stats: NewStats()}
+	all := append(slices.Clip(r.common), opts...)
+	t.validator = NewValidator(append(all, WithStats(t.stats))...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tenants[id] = t
+	return t.validator, nil
+}
+
+// Remove unregisters a tenant
+func (r *TenantRegistry) Remove(id string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tenants, id)
+}
+
+// Validator returns the validator of a tenant, or ErrUnknownTenant
+func (r *TenantRegistry) Validator(id string) (*Validator, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTenant, id)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tenants[id]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTenant, id)
+	}
+	return t.validator, nil
+}
+
+// Tenants returns the IDs of the registered tenants, sorted
+func (r *TenantRegistry) Tenants() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.tenants))
+}
+
+// Snapshots returns a snapshot of the stats of each tenant
+func (r *TenantRegistry) Snapshots() map[string]StatsSnapshot {
+	if r == nil {
+		return map[string]StatsSnapshot{}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snaps := make(map[string]StatsSnapshot, len(r.tenants))
+	for id, t := range r.tenants {
+		snaps[id] = t.stats.Snapshot()
+	}
+	return snaps
+}
+
+// WritePrometheus writes the metrics of StatsSnapshot.WritePrometheus for
+// every tenant, each series labeled with its tenant, such as
+// userdate_validations_total{tenant="acme"}
+func (r *TenantRegistry) WritePrometheus(w io.Writer) error {
+	if r == nil {
+		return ErrNilValue
+	}
+	return writePrometheus(w, "tenant", r.Snapshots())
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTenantRegistry(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	date := mustParseDate("2007-01-01")
+	registry := NewTenantRegistry(WithFixedNow(mustParseDate("2020-06-15")))
+
+	fr, err := NewRuleConfig("FR", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.Register("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.Register("globex", WithRules(fr)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.Register(""); err == nil {
+		t.Error("Register(\"\") error = nil, want an error")
+	}
+	if tenants := registry.Tenants(); !reflect.DeepEqual(tenants, []string{"acme", "globex"}) {
+		t.Errorf("Tenants() = %v", tenants)
+	}
+
+	tests := []struct {
+		tenant string
+		valid  bool
+	}{
+		{"acme", true},    // Default minimum age of 16
+		{"globex", false}, // Minimum age of 17 in FR
+	}
+	for _, tt := range tests {
+		v, err := registry.Validator(tt.tenant)
+		if err != nil {
+			t.Fatalf("Validator(%q) error = %v", tt.tenant, err)
+		}
+		if valid := v.CheckEntityDate(user, date, "license").Valid(); valid != tt.valid {
+			t.Errorf("%s: Valid() = %v, want %v", tt.tenant, valid, tt.valid)
+		}
+	}
+
+	snaps := registry.Snapshots()
+	if snaps["acme"].Total != 1 || snaps["acme"].Failed != 0 || snaps["globex"].Failed != 1 {
+		t.Errorf("Snapshots() = %+v, want one validation per tenant", snaps)
+	}
+	var b strings.Builder
+	if err := registry.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"userdate_validations_total{tenant=\"acme\"} 1\nuserdate_validations_total{tenant=\"globex\"} 1\n",
+		"userdate_findings_total{tenant=\"globex\",code=\"UNREALISTIC_AGE\"} 1\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, b.String())
+		}
+	}
+
+	registry.Remove("acme")
+	if _, err := registry.Validator("acme"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("Validator() after Remove error = %v, want %v", err, ErrUnknownTenant)
+	}
+}
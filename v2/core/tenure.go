@@ -0,0 +1,83 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// tenureCheck is the outcome of the tenure rule of a profile: the warning
+// to add to the report of the employment at index, which ends last
+type tenureCheck struct {
+	index int
+	err   error
+}
+
+// checkTenure compares the total time the employments of a profile cover,
+// counting overlapping employments once, with the time since the user
+// reached the minimum age of employment, up to the end of the employment
+// that ends last. Ongoing employments end now, or at the death of the user.
+// A longer total cannot be right, as on a padded résumé.
+func (c *config) checkTenure(user *User, entities []Entity) tenureCheck {
+	minAge, ok := c.minimumAges[employmentType]
+	if user == nil || user.BirthDate.IsZero() || !ok {
+		return tenureCheck{index: -1}
+	}
+	until := c.truncate(c.now())
+	if !user.DeathDate.IsZero() && user.DeathDate.Before(until) {
+		until = c.truncate(user.DeathDate)
+	}
+	var spans []dateSpan
+	var lastEnd time.Time
+	last := -1
+	for i, entity := range entities {
+		if entity.Type != employmentType || entity.Date.IsZero() || entity.Status == StatusRevoked {
+			continue
+		}
+		span := dateSpan{start: c.truncate(entity.Date), end: until}
+		if !entity.EndDate.IsZero() {
+			span.end = c.truncate(entity.EndDate)
+		}
+		if span.end.Before(span.start) {
+			continue
+		}
+		if last < 0 || span.end.After(lastEnd) {
+			last, lastEnd = i, span.end
+		}
+		spans = append(spans, span)
+	}
+	if len(spans) == 0 {
+		return tenureCheck{index: -1}
+	}
+	since := c.dateAtAge(c.truncate(user.BirthDate), minAge)
+	if total, available := mergedDays(spans), max(days(since, lastEnd), 0); total > available {
+		err := withConfidence(newWarning(msgLongTenure, "total", total, "end", lastEnd, "max", available, "age", minAge, "since", since),
+			excessConfidence(total, available))
+		return tenureCheck{index: last, err: atField(err, fieldEntity)}
+	}
+	return tenureCheck{index: -1}
+}
+
+// mergedDays returns the number of days closed spans cover, counting the
+// days several spans cover once
+func mergedDays(spans []dateSpan) int {
+	if len(spans) == 0 {
+		return 0
+	}
+	spans = slices.SortedFunc(slices.Values(spans), func(a, b dateSpan) int { return a.start.Compare(b.start) })
+	var total int
+	current := spans[0]
+	for _, span := range spans[1:] {
+		if !span.start.After(current.end.AddDate(0, 0, 1)) {
+			current.end = latest(current.end, span.end)
+			continue
+		}
+		total += days(current.start, current.end)
+		current = span
+	}
+	return total + days(current.start, current.end)
+}
+
+// days returns the number of days from start to end, both included
+func days(start, end time.Time) int {
+	return int(end.Sub(start).Round(24*time.Hour)/(24*time.Hour)) + 1
+}
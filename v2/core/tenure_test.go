@@ -0,0 +1,56 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTenure(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-01-01")}
+	warnAge := WithAgeWarnings("employment")
+
+	tests := []struct {
+		name      string
+		entities  []Entity
+		opts      []Option
+		wantCodes [][]string
+	}{
+		{"consecutive employments", []Entity{
+			{Type: "employment", Date: mustParseDate("2014-06-01"), EndDate: mustParseDate("2018-05-31")},
+			{Type: "employment", Date: mustParseDate("2018-06-01")},
+		}, nil, [][]string{nil, nil}},
+		{"concurrent employments count once", []Entity{
+			{Type: "employment", Date: mustParseDate("2016-01-01")},
+			{Type: "employment", Date: mustParseDate("2016-01-01"), EndDate: mustParseDate("2024-12-31")},
+		}, nil, [][]string{nil, nil}},
+		{"employment before the working age", []Entity{
+			{Type: "employment", Date: mustParseDate("2012-01-01"), EndDate: mustParseDate("2015-12-31")},
+			{Type: "certification", Date: mustParseDate("2019-01-01")},
+			{Type: "employment", Date: mustParseDate("2016-01-01"), EndDate: mustParseDate("2024-12-31")},
+		}, []Option{warnAge}, [][]string{{ErrCodeUnrealisticAge}, nil, {ErrCodeImplausibleTenure}}},
+		{"no working age", []Entity{
+			{Type: "employment", Date: mustParseDate("2012-01-01"), EndDate: mustParseDate("2015-12-31")},
+		}, []Option{WithMinimumAge("employment", 0)}, [][]string{nil}},
+		{"revoked employment", []Entity{
+			{Type: "employment", Date: mustParseDate("2012-01-01"), EndDate: mustParseDate("2015-12-31"), Status: StatusRevoked},
+			{Type: "employment", Date: mustParseDate("2016-01-01")},
+		}, []Option{warnAge}, [][]string{{ErrCodeRevoked}, nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateProfile(Profile{User: user, Entities: tt.entities}, append(tt.opts, now)...)
+			for i, report := range result.Reports {
+				if got := findingCodes(report); !slices.Equal(got, tt.wantCodes[i]) {
+					t.Errorf("entity %d findings = %v, want %v", i, got, tt.wantCodes[i])
+				}
+				for _, f := range report.Findings {
+					if f.Code == ErrCodeImplausibleTenure && f.Path != "/entities/2" {
+						t.Errorf("tenure finding path = %q, want /entities/2", f.Path)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package core
+
+import "time"
+
+// Upcoming is a user with milestones within the window of ScanUpcoming
+type Upcoming struct {
+	User       *User       `json:"user"`
+	Milestones []Milestone `json:"milestones"`
+}
+
+// ScanUpcoming returns the users of profiles who have milestones within
+// window from now: credentials that expire or are due for renewal, and
+// birthdays that make them eligible for entity types, see Milestones. It
+// is meant for scheduled jobs that feed notification systems, which run it
+// with a window as long as their period. Users come in the order of
+// profiles, and invalid users are left out.
+func ScanUpcoming(profiles []Profile, window time.Duration, opts ...Option) []Upcoming {
+	cfg := newConfig(opts)
+	until := cfg.truncate(cfg.now().Add(window))
+	var upcoming []Upcoming
+	for _, profile := range profiles {
+		milestones, err := cfg.milestones(profile)
+		if err != nil {
+			continue
+		}
+		n := 0
+		for n < len(milestones) && milestones[n].Date.Before(until) {
+			n++
+		}
+		if n > 0 {
+			upcoming = append(upcoming, Upcoming{User: profile.User, Milestones: milestones[:n:n]})
+		}
+	}
+	return upcoming
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScanUpcoming(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	profiles := []Profile{
+		{User: &User{ID: "expiring", BirthDate: mustParseDate("1990-01-01")}, Entities: []Entity{
+			{ID: "cpr-1", Type: "cpr_certification", Date: mustParseDate("2023-08-01")},
+		}},
+		{User: &User{ID: "later", BirthDate: mustParseDate("1990-01-01")}, Entities: []Entity{
+			{ID: "cpr-2", Type: "cpr_certification", Date: mustParseDate("2025-01-01")},
+		}},
+		{User: &User{ID: "turning", BirthDate: mustParseDate("2009-08-01")}},
+		{User: &User{BirthDate: mustParseDate("1990-01-01")}, Entities: []Entity{
+			{Type: "cpr_certification", Date: mustParseDate("2023-08-01")},
+		}},
+	}
+	opts := []Option{now, WithRenewalNotice(ValidityPeriod{}), WithMinimumAge("license", 16)}
+
+	got := ScanUpcoming(profiles, 30*24*time.Hour, opts...)
+	if len(got) != 2 {
+		t.Fatalf("ScanUpcoming() = %+v, want 2 users", got)
+	}
+	if got[0].User.ID != "expiring" || len(got[0].Milestones) != 1 || got[0].Milestones[0].EntityID != "cpr-1" {
+		t.Errorf("ScanUpcoming()[0] = %+v, want the expiry of cpr-1", got[0])
+	}
+	if got[1].User.ID != "turning" || got[1].Milestones[0].Kind != MilestoneAge || got[1].Milestones[0].Age != 16 {
+		t.Errorf("ScanUpcoming()[1] = %+v, want turning 16", got[1])
+	}
+
+	if got := ScanUpcoming(profiles, 0, opts...); len(got) != 0 {
+		t.Errorf("ScanUpcoming() with no window = %+v, want none", got)
+	}
+	if got := ScanUpcoming(profiles, 600*24*time.Hour, opts...); len(got) != 3 {
+		t.Errorf("ScanUpcoming() over 600 days = %d users, want 3", len(got))
+	}
+}
@@ -0,0 +1,948 @@
+package core
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/agecalc"
+)
+
+// Test helper functions
+func mustParseDate(dateStr string) time.Time {
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func TestNewUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		birthDate time.Time
+		userName  string
+		wantErr   bool
+		errCode   string
+	}{
+		{
+			name:      "valid user",
+			id:        "user123",
+			birthDate: mustParseDate("1990-01-01"),
+			userName:  "John Doe",
+			wantErr:   false,
+		},
+		{
+			name:      "empty ID",
+			id:        "",
+			birthDate: mustParseDate("1990-01-01"),
+			userName:  "John Doe",
+			wantErr:   true,
+			errCode:   ErrCodeInvalidUser,
+		},
+		{
+			name:      "future birth date",
+			id:        "user123",
+			birthDate: time.Now().AddDate(1, 0, 0),
+			userName:  "John Doe",
+			wantErr:   true,
+			errCode:   ErrCodeFutureDate,
+		},
+		{
+			name:      "unrealistic age",
+			id:        "user123",
+			birthDate: mustParseDate("1800-01-01"),
+			userName:  "John Doe",
+			wantErr:   true,
+			errCode:   ErrCodeUnrealisticAge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser(tt.id, tt.birthDate, tt.userName)
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Errorf("NewUser() expected error but got none")
+					return
+				}
+				if dateErr, ok := err.(*DateValidationError); ok {
+					if dateErr.Code != tt.errCode {
+						t.Errorf("NewUser() error code = %v, want %v", dateErr.Code, tt.errCode)
+					}
+				} else {
+					t.Errorf("NewUser() error type = %T, want *DateValidationError", err)
+				}
+			case err != nil:
+				t.Errorf("NewUser() unexpected error = %v", err)
+			default:
+				if user.ID != tt.id {
+					t.Errorf("NewUser() ID = %v, want %v", user.ID, tt.id)
+				}
+				if user.Name != tt.userName {
+					t.Errorf("NewUser() Name = %v, want %v", user.Name, tt.userName)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEntityDate(t *testing.T) {
+	validUser, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	tests := []struct {
+		name       string
+		user       *User
+		entityDate time.Time
+		entityType string
+		wantErr    bool
+		errCode    string
+	}{
+		{
+			name:       "valid certification date",
+			user:       validUser,
+			entityDate: mustParseDate("2020-01-01"),
+			entityType: "certification",
+			wantErr:    false,
+		},
+		{
+			name:       "nil user",
+			user:       nil,
+			entityDate: mustParseDate("2020-01-01"),
+			entityType: "certification",
+			wantErr:    true,
+			errCode:    ErrCodeInvalidUser,
+		},
+		{
+			name:       "date before birth",
+			user:       validUser,
+			entityDate: mustParseDate("1989-01-01"),
+			entityType: "certification",
+			wantErr:    true,
+			errCode:    ErrCodeBeforeBirth,
+		},
+		{
+			name:       "future date",
+			user:       validUser,
+			entityDate: time.Now().AddDate(1, 0, 0),
+			entityType: "certification",
+			wantErr:    true,
+			errCode:    ErrCodeFutureDate,
+		},
+		{
+			name:       "too young for certification",
+			user:       validUser,
+			entityDate: mustParseDate("1993-01-01"), // User would be 3 years old
+			entityType: "certification",
+			wantErr:    true,
+			errCode:    ErrCodeUnrealisticAge,
+		},
+		{
+			name:       "zero date",
+			user:       validUser,
+			entityDate: time.Time{},
+			entityType: "certification",
+			wantErr:    true,
+			errCode:    ErrCodeInvalidDate,
+		},
+		{
+			name:       "date too old",
+			user:       validUser,
+			entityDate: mustParseDate("1799-01-01"),
+			entityType: "certification",
+			wantErr:    true,
+			errCode:    ErrCodeDateTooOld,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntityDate(tt.user, tt.entityDate, tt.entityType)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ValidateEntityDate() expected error but got none")
+					return
+				}
+				if dateErr, ok := err.(*DateValidationError); ok {
+					if dateErr.Code != tt.errCode {
+						t.Errorf("ValidateEntityDate() error code = %v, want %v", dateErr.Code, tt.errCode)
+					}
+				} else {
+					t.Errorf("ValidateEntityDate() error type = %T, want *DateValidationError", err)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateEntityDate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCertification(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	// Valid certification
+	err := ValidateCertification(user, mustParseDate("2020-01-01"))
+	if err != nil {
+		t.Errorf("ValidateCertification() unexpected error = %v", err)
+	}
+
+	// Invalid certification (too young)
+	err = ValidateCertification(user, mustParseDate("1993-01-01"))
+	if err == nil {
+		t.Errorf("ValidateCertification() expected error but got none")
+	}
+}
+
+func TestValidateTraining(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	// Valid training
+	err := ValidateTraining(user, mustParseDate("2020-01-01"))
+	if err != nil {
+		t.Errorf("ValidateTraining() unexpected error = %v", err)
+	}
+
+	// Invalid training (future date)
+	err = ValidateTraining(user, time.Now().AddDate(1, 0, 0))
+	if err == nil {
+		t.Errorf("ValidateTraining() expected error but got none")
+	}
+}
+
+func TestValidateEducation(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	// Valid education
+	err := ValidateEducation(user, mustParseDate("2010-01-01"))
+	if err != nil {
+		t.Errorf("ValidateEducation() unexpected error = %v", err)
+	}
+}
+
+func TestValidateEmployment(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	// Valid employment (user is 16)
+	err := ValidateEmployment(user, mustParseDate("2006-01-01"))
+	if err != nil {
+		t.Errorf("ValidateEmployment() unexpected error = %v", err)
+	}
+
+	// Invalid employment (too young - user is 13)
+	err = ValidateEmployment(user, mustParseDate("2003-01-01"))
+	if err == nil {
+		t.Errorf("ValidateEmployment() expected error but got none")
+	}
+}
+
+func TestValidateLicense(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	// Valid license (user is 18)
+	err := ValidateLicense(user, mustParseDate("2008-01-01"))
+	if err != nil {
+		t.Errorf("ValidateLicense() unexpected error = %v", err)
+	}
+
+	// Invalid license (too young - user is 15)
+	err = ValidateLicense(user, mustParseDate("2005-01-01"))
+	if err == nil {
+		t.Errorf("ValidateLicense() expected error but got none")
+	}
+}
+
+func TestUserValidate(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+
+	tests := []struct {
+		name    string
+		user    *User
+		wantErr bool
+		errCode string
+	}{
+		{
+			name: "valid living user",
+			user: &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")},
+		},
+		{
+			name: "valid deceased user",
+			user: &User{ID: "user123", BirthDate: mustParseDate("1900-01-01"), DeathDate: mustParseDate("1980-01-01")},
+		},
+		{
+			name: "deceased user born more than max age ago",
+			user: &User{ID: "user123", BirthDate: mustParseDate("1820-01-01"), DeathDate: mustParseDate("1890-01-01")},
+		},
+		{
+			name:    "nil user",
+			user:    nil,
+			wantErr: true,
+			errCode: ErrCodeInvalidUser,
+		},
+		{
+			name:    "empty ID",
+			user:    &User{BirthDate: mustParseDate("1990-01-01")},
+			wantErr: true,
+			errCode: ErrCodeInvalidUser,
+		},
+		{
+			name:    "missing birth date",
+			user:    &User{ID: "user123"},
+			wantErr: true,
+			errCode: ErrCodeInvalidDate,
+		},
+		{
+			name:    "death before birth",
+			user:    &User{ID: "user123", BirthDate: mustParseDate("1990-01-01"), DeathDate: mustParseDate("1980-01-01")},
+			wantErr: true,
+			errCode: ErrCodeBeforeBirth,
+		},
+		{
+			name:    "death in the future",
+			user:    &User{ID: "user123", BirthDate: mustParseDate("1990-01-01"), DeathDate: mustParseDate("2021-01-01")},
+			wantErr: true,
+			errCode: ErrCodeFutureDate,
+		},
+		{
+			name:    "unrealistic lifespan",
+			user:    &User{ID: "user123", BirthDate: mustParseDate("1820-01-01"), DeathDate: mustParseDate("1971-01-01")},
+			wantErr: true,
+			errCode: ErrCodeUnrealisticAge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.user.Validate(now)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != tt.errCode {
+				t.Errorf("Validate() error = %v, want code %v", err, tt.errCode)
+			}
+		})
+	}
+}
+
+func TestUserValidateAfterUnmarshal(t *testing.T) {
+	var user User
+	data := `{"id":"user123","birth_date":"2990-01-01T00:00:00Z","name":"John Doe"}`
+	if err := json.Unmarshal([]byte(data), &user); err != nil {
+		t.Fatal(err)
+	}
+
+	err := user.Validate()
+	if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeFutureDate {
+		t.Errorf("Validate() error = %v, want code %v", err, ErrCodeFutureDate)
+	}
+
+	out, err := json.Marshal(&User{ID: "user123", BirthDate: mustParseDate("1990-01-01")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "death_date") {
+		t.Errorf("json.Marshal() = %s, want no death_date for living users", out)
+	}
+}
+
+func TestUserGetAge(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	age := user.GetAge()
+	expectedAge := time.Now().Year() - 1990
+	if time.Now().YearDay() < mustParseDate("1990-01-01").YearDay() {
+		expectedAge--
+	}
+
+	if age != expectedAge {
+		t.Errorf("GetAge() = %v, want %v", age, expectedAge)
+	}
+}
+
+func TestUserGetAgeAtDate(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+
+	testDate := mustParseDate("2020-01-01")
+	age := user.GetAgeAtDate(testDate)
+	expectedAge := 30 // 2020 - 1990
+
+	if age != expectedAge {
+		t.Errorf("GetAgeAtDate() = %v, want %v", age, expectedAge)
+	}
+}
+
+func TestWithFixedNow(t *testing.T) {
+	user, _ := NewUser("user123", mustParseDate("1990-05-15"), "John Doe")
+	now := WithFixedNow(mustParseDate("2019-05-14"))
+
+	if age := user.GetAge(now); age != 28 {
+		t.Errorf("GetAge() = %v, want %v", age, 28)
+	}
+
+	// The entity date is in the past for the real clock but not for the fixed one
+	err := ValidateCertification(user, mustParseDate("2021-01-01"), now)
+	if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeFutureDate {
+		t.Errorf("ValidateCertification() error = %v, want code %v", err, ErrCodeFutureDate)
+	}
+
+	// A birth date after the fixed now is rejected
+	_, err = NewUser("user456", mustParseDate("2021-01-01"), "Jane Doe", now)
+	if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeFutureDate {
+		t.Errorf("NewUser() error = %v, want code %v", err, ErrCodeFutureDate)
+	}
+}
+
+func TestDateValidationError(t *testing.T) {
+	err := &DateValidationError{
+		Message: "test error",
+		Code:    "TEST_CODE",
+	}
+
+	expected := "date validation error [TEST_CODE]: test error"
+	if err.Error() != expected {
+		t.Errorf("DateValidationError.Error() = %v, want %v", err.Error(), expected)
+	}
+}
+
+func TestValidateMinimumAge(t *testing.T) {
+	birthDate := mustParseDate("1990-01-01")
+
+	tests := []struct {
+		name       string
+		entityDate time.Time
+		entityType string
+		wantErr    bool
+	}{
+		{
+			name:       "valid certification age",
+			entityDate: mustParseDate("1996-01-01"), // Age 6
+			entityType: "certification",
+			wantErr:    false,
+		},
+		{
+			name:       "invalid certification age",
+			entityDate: mustParseDate("1993-01-01"), // Age 3
+			entityType: "certification",
+			wantErr:    true,
+		},
+		{
+			name:       "valid employment age",
+			entityDate: mustParseDate("2004-01-01"), // Age 14
+			entityType: "employment",
+			wantErr:    false,
+		},
+		{
+			name:       "invalid employment age",
+			entityDate: mustParseDate("2003-01-01"), // Age 13
+			entityType: "employment",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown entity type",
+			entityDate: mustParseDate("1991-01-01"), // Age 1
+			entityType: "unknown",
+			wantErr:    false, // Should not validate unknown types
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			err := cfg.validateMinimumAge(birthDate, tt.entityDate, Entity{Type: tt.entityType})
+			if tt.wantErr && err == nil {
+				t.Errorf("validateMinimumAge() expected error but got none")
+			} else if !tt.wantErr && err != nil {
+				t.Errorf("validateMinimumAge() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestMinimumAgeBoundaries(t *testing.T) {
+	minAges := map[string]int{
+		"certification": MinCertAge,
+		"training":      MinCertAge,
+		"education":     MinCertAge,
+		"employment":    14,
+		"license":       16,
+	}
+	births := []string{"1990-05-15", "1992-02-29", "1991-03-01", "1990-12-31", "1991-01-01"}
+
+	for entityType, minAge := range minAges {
+		for _, birth := range births {
+			birthDate := mustParseDate(birth)
+			user := &User{ID: "user123", BirthDate: birthDate}
+			birthday := agecalc.DateAtAge(birthDate, minAge)
+
+			t.Run(entityType+" "+birth, func(t *testing.T) {
+				err := ValidateEntityDate(user, birthday, entityType)
+				if err != nil {
+					t.Errorf("ValidateEntityDate(%s) unexpected error = %v", birthday.Format("2006-01-02"), err)
+				}
+
+				dayBefore := birthday.AddDate(0, 0, -1)
+				err = ValidateEntityDate(user, dayBefore, entityType)
+				if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeUnrealisticAge {
+					t.Errorf("ValidateEntityDate(%s) error = %v, want code %v",
+						dayBefore.Format("2006-01-02"), err, ErrCodeUnrealisticAge)
+				}
+			})
+		}
+	}
+}
+
+func TestEntryAgeWindows(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2010-09-15")}
+	now := WithFixedNow(mustParseDate("2035-01-01"))
+	fr, err := NewRuleConfig("FR", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		entityType string
+		age        int // Age on the entity date, a day after the birthday
+		opts       []Option
+		wantErr    bool
+	}{
+		{"kindergarten at 5", "kindergarten", 5, nil, false},
+		{"kindergarten at 2", "kindergarten", 2, nil, true},
+		{"kindergarten at 7", "kindergarten", 7, nil, false},
+		{"kindergarten at 8", "kindergarten", 8, nil, true},
+		{"kindergarten at 7 in FR", "kindergarten", 7, []Option{WithRules(fr)}, true},
+		{"primary at 6", "primary_education", 6, nil, false},
+		{"primary at 9", "primary_education", 9, nil, true},
+		{"secondary at 11", "secondary_education", 11, nil, false},
+		{"secondary at 16", "secondary_education", 16, nil, true},
+		{"tertiary at 14", "tertiary_education", 14, nil, true},
+		{"tertiary at 24", "tertiary_education", 24, nil, false},
+		{"maximum age option", "kindergarten", 8, []Option{WithMaximumAge("kindergarten", 9)}, false},
+		{"maximum age removed", "kindergarten", 8, []Option{WithMaximumAge("kindergarten", 0)}, false},
+		{"blanket education at 20", "education", 20, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := agecalc.DateAtAge(user.BirthDate, tt.age).AddDate(0, 0, 1)
+			err := ValidateEntityDate(user, date, tt.entityType, append(tt.opts, now)...)
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ValidateEntityDate() unexpected error = %v", err)
+			}
+			if dateErr, ok := err.(*DateValidationError); tt.wantErr && (!ok || dateErr.Code != ErrCodeUnrealisticAge) {
+				t.Errorf("ValidateEntityDate() error = %v, want code %s", err, ErrCodeUnrealisticAge)
+			}
+		})
+	}
+
+	// The maximum is the age reached, not the day of the birthday after it
+	eighth := agecalc.DateAtAge(user.BirthDate, 8)
+	if err := ValidateEntityDate(user, eighth.AddDate(0, 0, -1), "kindergarten", now); err != nil {
+		t.Errorf("ValidateEntityDate(day before 8th birthday) error = %v", err)
+	}
+	if err := ValidateEntityDate(user, eighth, "kindergarten", now); err == nil {
+		t.Error("ValidateEntityDate(8th birthday) error = nil, want too old")
+	}
+}
+
+func TestAgeWarnings(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2015-06-01")}
+	now := WithFixedNow(mustParseDate("2030-01-01"))
+
+	tests := []struct {
+		name       string
+		entityType string
+		date       string
+		opts       []Option
+		wantValid  bool
+		wantCodes  []string
+	}{
+		{"volunteer at 8", "volunteer", "2023-07-01", nil, true, nil},
+		{"volunteer at 4", "volunteer", "2019-07-01", nil, false, []string{ErrCodeUnrealisticAge}},
+		{"volunteer at 4 warns", "volunteer", "2019-07-01", []Option{WithAgeWarnings("volunteer")}, true, []string{ErrCodeUnrealisticAge}},
+		{"extracurricular at 3", "extracurricular", "2018-07-01", nil, true, nil},
+		{"extracurricular at 2 warns", "extracurricular", "2017-07-01", []Option{WithAgeWarnings("volunteer", "extracurricular")}, true, []string{ErrCodeUnrealisticAge}},
+		{"other types still fail", "employment", "2025-07-01", []Option{WithAgeWarnings("volunteer")}, false, []string{ErrCodeUnrealisticAge}},
+		{"maximum age warns", "kindergarten", "2024-07-01", []Option{WithAgeWarnings("kindergarten")}, true, []string{ErrCodeUnrealisticAge}},
+		{"before birth still fails", "volunteer", "2014-07-01", []Option{WithAgeWarnings("volunteer")}, false, []string{ErrCodeBeforeBirth}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntityDate(user, mustParseDate(tt.date), tt.entityType, append(tt.opts, now)...)
+			if report.Valid() != tt.wantValid {
+				t.Errorf("Valid() = %v, want %v: %v", report.Valid(), tt.wantValid, report.Findings)
+			}
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("findings = %v, want %v", got, tt.wantCodes)
+			}
+			for _, f := range report.Findings {
+				if wantWarning := tt.wantValid; (f.Severity == SeverityWarning) != wantWarning {
+					t.Errorf("finding %s severity = %s", f.Code, f.Severity)
+				}
+			}
+		})
+	}
+}
+
+func TestAcademicTypes(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2000-03-01")}
+	now := WithFixedNow(mustParseDate("2030-01-01"))
+	mississippi, err := NewRuleConfig("US-MS", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		entityType string
+		date       string
+		opts       []Option
+		wantValid  bool
+		wantCodes  []string
+	}{
+		{"award at 7", "award", "2007-06-01", nil, true, nil},
+		{"award at 3", "award", "2003-06-01", nil, false, []string{ErrCodeUnrealisticAge}},
+		{"publication at 15", "publication", "2015-06-01", nil, true, nil},
+		{"publication at 8 warns", "publication", "2008-06-01", nil, true, []string{ErrCodeUnrealisticAge}},
+		{"patent at 18", "patent", "2018-06-01", nil, true, nil},
+		{"patent at 16", "patent", "2016-06-01", nil, false, []string{ErrCodeUnrealisticAge}},
+		{"patent at 20 in US-MS", "patent", "2020-06-01", []Option{WithRules(mississippi)}, false, []string{ErrCodeUnrealisticAge}},
+		{"patent at 21 in US-MS", "patent", "2021-06-01", []Option{WithRules(mississippi)}, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEntityDate(user, mustParseDate(tt.date), tt.entityType, append(tt.opts, now)...)
+			if report.Valid() != tt.wantValid {
+				t.Errorf("Valid() = %v, want %v: %v", report.Valid(), tt.wantValid, report.Findings)
+			}
+			if got := findingCodes(report); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("findings = %v, want %v", got, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestFinancialTypes(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("2005-03-01")}
+	now := WithFixedNow(mustParseDate("2030-01-01"))
+	alabama, err := NewRuleConfig("US-AL", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		entity   Entity
+		opts     []Option
+		wantCode string
+	}{
+		{"account at 18", Entity{Type: "bank_account", Date: mustParseDate("2023-06-01")}, nil, ""},
+		{"account at 12", Entity{Type: "bank_account", Date: mustParseDate("2017-06-01")}, nil, ErrCodeUnrealisticAge},
+		{"joint account at 12", Entity{Type: "bank_account", Date: mustParseDate("2017-06-01"), Guardian: true}, nil, ""},
+		{"joint account below guardian age", Entity{Type: "bank_account", Date: mustParseDate("2010-06-01"), Guardian: true}, []Option{WithGuardianAge("bank_account", 7)}, ErrCodeUnrealisticAge},
+		{"guardian allowance removed", Entity{Type: "bank_account", Date: mustParseDate("2017-06-01"), Guardian: true}, []Option{WithGuardianAge("bank_account", -1)}, ErrCodeUnrealisticAge},
+		{"loan at 16 with guardian", Entity{Type: "loan", Date: mustParseDate("2021-06-01"), Guardian: true}, nil, ErrCodeUnrealisticAge},
+		{"credit at 18 in US-AL", Entity{Type: "credit_application", Date: mustParseDate("2023-06-01")}, []Option{WithRules(alabama)}, ErrCodeUnrealisticAge},
+		{"credit at 19 in US-AL", Entity{Type: "credit_application", Date: mustParseDate("2024-06-01")}, []Option{WithRules(alabama)}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntity(user, tt.entity, append(tt.opts, now)...)
+			if code := codeOf(err); code != tt.wantCode {
+				t.Errorf("ValidateEntity() code = %q, want %q: %v", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestMaxHumanAgeBoundaries(t *testing.T) {
+	tests := []struct {
+		name    string
+		birth   string
+		now     string
+		wantErr bool
+	}{
+		{"day before turning max age", "1870-05-15", "2020-05-14", false},
+		{"turning max age", "1870-05-15", "2020-05-15", false},
+		{"day before exceeding max age", "1869-05-15", "2020-05-14", false},
+		{"exceeding max age", "1869-05-15", "2020-05-15", true},
+		{"leap day birth, Feb 28", "1872-02-29", "2023-02-28", false},
+		{"leap day birth, Mar 1", "1872-02-29", "2023-03-01", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUser("user123", mustParseDate(tt.birth), "John Doe", WithFixedNow(mustParseDate(tt.now)))
+			if tt.wantErr {
+				if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeUnrealisticAge {
+					t.Errorf("NewUser() error = %v, want code %v", err, ErrCodeUnrealisticAge)
+				}
+			} else if err != nil {
+				t.Errorf("NewUser() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGetAgeAtDateLeapYears(t *testing.T) {
+	tests := []struct {
+		name   string
+		birth  string
+		date   string
+		want   int
+		legacy int
+	}{
+		{"day before birthday in leap year", "1990-05-15", "2020-05-14", 29, 30},
+		{"birthday in leap year", "1990-05-15", "2020-05-15", 30, 30},
+		{"leap year birth, day before birthday", "1992-05-15", "1993-05-14", 0, 0},
+		{"leap year birth, birthday in common year", "1992-05-15", "1993-05-15", 1, 0},
+		{"leap day birth, Feb 28", "1992-02-29", "1993-02-28", 0, 0},
+		{"leap day birth, Mar 1", "1992-02-29", "1993-03-01", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "user123", BirthDate: mustParseDate(tt.birth)}
+			if got := user.GetAgeAtDate(mustParseDate(tt.date)); got != tt.want {
+				t.Errorf("GetAgeAtDate() = %v, want %v", got, tt.want)
+			}
+			if got := user.GetAgeAtDate(mustParseDate(tt.date), WithLegacyAgeCalc()); got != tt.legacy {
+				t.Errorf("GetAgeAtDate(WithLegacyAgeCalc()) = %v, want %v", got, tt.legacy)
+			}
+		})
+	}
+}
+
+func TestWithPrecision(t *testing.T) {
+	utc14 := time.FixedZone("UTC+14", 14*60*60)
+	now := WithFixedNow(time.Date(2020, 3, 10, 5, 0, 0, 0, time.UTC))
+	user := &User{ID: "user123", BirthDate: time.Date(1990, 5, 15, 12, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name       string
+		entityDate time.Time
+		precision  Precision
+		errCode    string
+	}{
+		{
+			name:       "late timestamp is in the future as an instant",
+			entityDate: time.Date(2020, 3, 10, 23, 59, 0, 0, utc14),
+			precision:  PrecisionInstant,
+			errCode:    ErrCodeFutureDate,
+		},
+		{
+			name:       "late timestamp is today as a date",
+			entityDate: time.Date(2020, 3, 10, 23, 59, 0, 0, utc14),
+			precision:  PrecisionDate,
+		},
+		{
+			name:       "earlier hour on birth day is before birth as an instant",
+			entityDate: time.Date(1990, 5, 15, 8, 0, 0, 0, time.UTC),
+			precision:  PrecisionInstant,
+			errCode:    ErrCodeBeforeBirth,
+		},
+		{
+			name:       "earlier hour on birth day is the birth date as a date",
+			entityDate: time.Date(1990, 5, 15, 8, 0, 0, 0, time.UTC),
+			precision:  PrecisionDate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntityDate(user, tt.entityDate, "note", now, WithPrecision(tt.precision))
+			if tt.errCode == "" {
+				if err != nil {
+					t.Errorf("ValidateEntityDate() unexpected error = %v", err)
+				}
+				return
+			}
+			if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != tt.errCode {
+				t.Errorf("ValidateEntityDate() error = %v, want code %v", err, tt.errCode)
+			}
+		})
+	}
+
+	// A birth later today is not in the future at date precision
+	birth := time.Date(2020, 3, 10, 22, 0, 0, 0, time.UTC)
+	if _, err := NewUser("user123", birth, "", now); err == nil {
+		t.Errorf("NewUser() expected error but got none")
+	}
+	if _, err := NewUser("user123", birth, "", now, WithPrecision(PrecisionDate)); err != nil {
+		t.Errorf("NewUser(PrecisionDate) unexpected error = %v", err)
+	}
+}
+
+func TestWithSameDayBirth(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-05-15")}
+	birthDay := mustParseDate("1990-05-15")
+	dayAfter := mustParseDate("1990-05-16")
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		entityType string
+		date       time.Time
+		wantErr    bool
+	}{
+		{"allowed by default", nil, "birth_certificate", birthDay, false},
+		{"disallowed for type", []Option{WithSameDayBirth(false, "vaccination")}, "vaccination", birthDay, true},
+		{"disallowed type accepts next day", []Option{WithSameDayBirth(false, "vaccination")}, "vaccination", dayAfter, false},
+		{"other types unaffected", []Option{WithSameDayBirth(false, "vaccination")}, "birth_certificate", birthDay, false},
+		{"disallowed by default", []Option{WithSameDayBirth(false)}, "vaccination", birthDay, true},
+		{
+			name:       "allowed for type over default",
+			opts:       []Option{WithSameDayBirth(false), WithSameDayBirth(true, "birth_certificate", "newborn_screening")},
+			entityType: "newborn_screening",
+			date:       birthDay,
+			wantErr:    false,
+		},
+		{
+			name:       "later hour on birth day",
+			opts:       []Option{WithSameDayBirth(false)},
+			entityType: "vaccination",
+			date:       birthDay.Add(20 * time.Hour),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntityDate(user, tt.date, tt.entityType, tt.opts...)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateEntityDate() unexpected error = %v", err)
+				}
+				return
+			}
+			if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeBeforeBirth {
+				t.Errorf("ValidateEntityDate() error = %v, want code %v", err, ErrCodeBeforeBirth)
+			}
+		})
+	}
+}
+
+func TestHistoryCutoff(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2020-06-15"))
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		cutoff  time.Time
+		wantErr map[string]bool
+	}{
+		{
+			name:   "calendar years by default",
+			opts:   []Option{now},
+			cutoff: mustParseDate("1820-06-15"),
+			wantErr: map[string]bool{
+				"1820-06-14": true,
+				"1820-06-15": false,
+				"1820-12-01": false,
+			},
+		},
+		{
+			name:   "explicit duration",
+			opts:   []Option{now, WithMaxHistory(100 * 24 * time.Hour)},
+			cutoff: mustParseDate("2020-03-07"),
+			wantErr: map[string]bool{
+				"2020-03-06": true,
+				"2020-03-07": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HistoryCutoff(tt.opts...); !got.Equal(tt.cutoff) {
+				t.Errorf("HistoryCutoff() = %v, want %v", got, tt.cutoff)
+			}
+			for date, wantErr := range tt.wantErr {
+				cfg := newConfig(tt.opts)
+				err := cfg.validateHistoricalRealism(mustParseDate(date))
+				if wantErr != (err != nil) {
+					t.Errorf("validateHistoricalRealism(%s) error = %v, wantErr %v", date, err, wantErr)
+				}
+			}
+		})
+	}
+
+	// The history limit also applies to entity dates
+	err := ValidateEntityDate(user, mustParseDate("2020-03-06"), "note", now, WithMaxHistory(100*24*time.Hour))
+	if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeDateTooOld {
+		t.Errorf("ValidateEntityDate() error = %v, want code %v", err, ErrCodeDateTooOld)
+	}
+}
+
+func TestValidateHistoricalRealism(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "recent date",
+			date:    mustParseDate("2020-01-01"),
+			wantErr: false,
+		},
+		{
+			name:    "old but valid date",
+			date:    mustParseDate("1900-01-01"),
+			wantErr: false,
+		},
+		{
+			name:    "too old date",
+			date:    mustParseDate("1800-01-01"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			err := cfg.validateHistoricalRealism(tt.date)
+			if tt.wantErr && err == nil {
+				t.Errorf("validateHistoricalRealism() expected error but got none")
+			} else if !tt.wantErr && err != nil {
+				t.Errorf("validateHistoricalRealism() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// Benchmark tests
+func BenchmarkValidateEntityDate(b *testing.B) {
+	user, err := NewUser("user123", mustParseDate("1990-01-01"), "John Doe")
+	if err != nil {
+		b.Fatal(err)
+	}
+	entityDate := mustParseDate("2020-01-01")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = ValidateEntityDate(user, entityDate, "certification")
+	}
+}
+
+func BenchmarkNewUser(b *testing.B) {
+	birthDate := mustParseDate("1990-01-01")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = NewUser("user123", birthDate, "John Doe")
+	}
+}
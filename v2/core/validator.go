@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Validator validates dates with a fixed set of options. It is useful when
+// the same settings, such as date floors or a clock, apply to every call.
+// A Validator is safe for concurrent use. A nil Validator validates with
+// the package defaults.
+type Validator struct {
+	cfg config
+}
+
+// defaultValidatorConfig holds the settings of nil validators
+var defaultValidatorConfig = sync.OnceValue(func() *config {
+	cfg := newConfig(nil)
+	return &cfg
+})
+
+// config returns the settings of the validator
+func (v *Validator) config() *config {
+	if v == nil {
+		return defaultValidatorConfig()
+	}
+	return &v.cfg
+}
+
+// NewValidator creates a Validator that applies opts to every validation.
+// Its reports carry the hash of its Config.
+func NewValidator(opts ...Option) *Validator {
+	cfg := newConfig(opts)
+	cfg.setConfigHash()
+	return &Validator{cfg: cfg}
+}
+
+// ValidateEntityDate validates a date for a user entity using the validator's settings
+func (v *Validator) ValidateEntityDate(user *User, entityDate time.Time, entityType string) error {
+	return v.config().validateEntityDate(context.Background(), user, entityDate, entityType)
+}
+
+// ValidateBirthDate validates a birth date using the validator's settings
+func (v *Validator) ValidateBirthDate(birthDate time.Time) error {
+	return v.config().validateBirthDate(birthDate)
+}
+
+// NewUser creates a new User whose birth date is validated with the validator's settings
+func (v *Validator) NewUser(id string, birthDate time.Time, name string) (*User, error) {
+	return v.config().newUser(id, birthDate, name)
+}
+
+// CheckEntityDate validates a date for a user entity using the validator's
+// settings and returns a report holding warnings as well as the error, if any
+func (v *Validator) CheckEntityDate(user *User, entityDate time.Time, entityType string) *Report {
+	return v.config().checkEntityDate(context.Background(), user, entityDate, entityType)
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidatorDateFloors(t *testing.T) {
+	workforce := NewValidator(
+		WithMinBirthDate(mustParseDate("1900-01-01")),
+		WithMinEntityDate(mustParseDate("1950-01-01")),
+	)
+	genealogy := NewValidator(WithMinBirthDate(mustParseDate("1850-01-01")))
+
+	tests := []struct {
+		name      string
+		validator *Validator
+		birthDate time.Time
+		wantErr   bool
+	}{
+		{"workforce accepts 1900", workforce, mustParseDate("1900-01-01"), false},
+		{"workforce rejects 1899", workforce, mustParseDate("1899-12-31"), true},
+		{"genealogy accepts 1899", genealogy, mustParseDate("1899-12-31"), false},
+		{"genealogy rejects 1849", genealogy, mustParseDate("1849-12-31"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator.ValidateBirthDate(tt.birthDate)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateBirthDate() unexpected error = %v", err)
+				}
+				return
+			}
+			if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeDateTooOld {
+				t.Errorf("ValidateBirthDate() error = %v, want code %v", err, ErrCodeDateTooOld)
+			}
+		})
+	}
+}
+
+func TestValidatorEntityFloorIsSeparate(t *testing.T) {
+	v := NewValidator(
+		WithMinBirthDate(mustParseDate("1900-01-01")),
+		WithMinEntityDate(mustParseDate("1950-01-01")),
+	)
+	user, err := v.NewUser("user123", mustParseDate("1920-01-01"), "John Doe")
+	if err != nil {
+		t.Fatalf("NewUser() unexpected error = %v", err)
+	}
+
+	if err := v.ValidateEntityDate(user, mustParseDate("1950-01-01"), "employment"); err != nil {
+		t.Errorf("ValidateEntityDate() unexpected error = %v", err)
+	}
+
+	err = v.ValidateEntityDate(user, mustParseDate("1949-12-31"), "employment")
+	if dateErr, ok := err.(*DateValidationError); !ok || dateErr.Code != ErrCodeDateTooOld {
+		t.Errorf("ValidateEntityDate() error = %v, want code %v", err, ErrCodeDateTooOld)
+	}
+
+	// The package functions keep the default floor
+	if err := ValidateEntityDate(user, mustParseDate("1949-12-31"), "employment"); err != nil {
+		t.Errorf("ValidateEntityDate() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// WebhookEvent is an outcome of a validation that a WebhookSink sends
+type WebhookEvent string
+
+// Webhook events
+const (
+	WebhookFailure  WebhookEvent = "failure"  // The report holds an error
+	WebhookAnomaly  WebhookEvent = "anomaly"  // The report scores at least WebhookConfig.MinScore
+	WebhookExpiring WebhookEvent = "expiring" // The entity expires within WebhookConfig.ExpiryWindow
+)
+
+// defaultExpiryWindow is how long before an expiry a webhook sink sends
+// WebhookExpiring
+const defaultExpiryWindow = 30 * 24 * time.Hour
+
+// defaultWebhookTimeout limits each delivery attempt of a webhook sink
+// whose policy sets no timeout, as deliveries hold up the validation
+const defaultWebhookTimeout = 5 * time.Second
+
+// WebhookConfig configures a WebhookSink
+type WebhookConfig struct {
+	URL    string
+	Secret []byte         // Key of the HMAC-SHA256 signature of the payloads
+	Events []WebhookEvent // Events to send, all of them when empty
+
+	// Weights give the score of each finding code, as for DecisionPolicy.
+	// Reports scoring at least MinScore send WebhookAnomaly, which is off
+	// while MinScore is not positive.
+	Weights  map[string]int
+	MinScore int
+
+	// ExpiryWindow is how long before its expiry an entity sends
+	// WebhookExpiring, 30 days when zero. The expiry is the one of
+	// Milestones.
+	ExpiryWindow time.Duration
+
+	// Policy bounds and retries the deliveries, each attempt taking at
+	// most 5 seconds unless Policy.Timeout is set. Responses with a 4xx
+	// status other than 429 are not retried.
+	Policy ResiliencePolicy
+
+	// DeadLetter is the JSON Lines file of the payloads that could not be
+	// delivered, see ReadDeadLetters
+	DeadLetter string
+
+	Client *http.Client // http.DefaultClient when nil
+}
+
+// WebhookPayload is the JSON body of a webhook request. The request has an
+// X-Userdate-Event header with the event, and an X-Userdate-Signature
+// header with the HMAC-SHA256 of the body, see VerifyWebhook.
+type WebhookPayload struct {
+	Event  WebhookEvent `json:"event"`
+	SentAt time.Time    `json:"sent_at"`
+	User   *User        `json:"user"`
+	Entity Entity       `json:"entity"`
+	Report *Report      `json:"report"`
+	Score  int          `json:"score,omitempty"` // Set for WebhookAnomaly
+	Expiry time.Time    `json:"expiry,omitzero"` // Set for WebhookExpiring
+}
+
+// DeadLetter is a webhook payload that could not be delivered, with the
+// error of its last attempt
+type DeadLetter struct {
+	Payload WebhookPayload `json:"payload"`
+	Error   string         `json:"error"`
+}
+
+// WebhookSink POSTs signed JSON payloads of validation outcomes to a URL,
+// so that downstream systems react without polling. Deliveries that fail
+// after the retries of the policy are appended to the dead-letter file,
+// from which ReadDeadLetters and Send deliver them again. A WebhookSink is
+// safe for concurrent use.
+type WebhookSink struct {
+	cfg        WebhookConfig
+	client     *http.Client
+	guard      *guard
+	deadLetter *jsonlFile
+}
+
+// NewWebhookSink returns a sink delivering to cfg.URL
+func NewWebhookSink(cfg WebhookConfig) *WebhookSink {
+	if cfg.Policy.Timeout == 0 {
+		cfg.Policy.Timeout = defaultWebhookTimeout
+	}
+	s := &WebhookSink{cfg: cfg, client: cfg.Client, guard: newGuard(cfg.Policy)}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+	if s.cfg.ExpiryWindow == 0 {
+		s.cfg.ExpiryWindow = defaultExpiryWindow
+	}
+	if cfg.DeadLetter != "" {
+		s.deadLetter = &jsonlFile{path: cfg.DeadLetter}
+	}
+	return s
+}
+
+// WithWebhook sends the outcomes of validations to s, passing it the ctx of
+// the validation. The deliveries are made before the validation returns.
+// When a payload can neither be delivered nor dead-lettered, the report
+// gets a WEBHOOK_FAILED warning.
+func WithWebhook(s *WebhookSink) Option {
+	return func(c *config) {
+		c.webhook = s
+	}
+}
+
+// Send delivers a payload, and dead-letters it when the delivery fails. It
+// only returns an error when the payload is lost.
+func (s *WebhookSink) Send(ctx context.Context, payload WebhookPayload) error {
+	if s == nil {
+		return ErrNilValue
+	}
+	err := s.guard.do(ctx, func(ctx context.Context) error {
+		return s.post(ctx, payload)
+	})
+	if err == nil {
+		return nil
+	}
+	if s.deadLetter == nil {
+		return err
+	}
+	if dlErr := s.deadLetter.append(DeadLetter{Payload: payload, Error: err.Error()}); dlErr != nil {
+		return fmt.Errorf("%w (dead letter: %v)", err, dlErr)
+	}
+	return nil
+}
+
+// post makes one delivery attempt
+func (s *WebhookSink) post(ctx context.Context, payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return permanent(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Userdate-Event", string(payload.Event))
+	req.Header.Set("X-Userdate-Signature", signWebhook(s.cfg.Secret, body))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("userdate: webhook returned %s", resp.Status)
+	default:
+		return permanent(fmt.Errorf("userdate: webhook returned %s", resp.Status))
+	}
+}
+
+// enabled reports whether the sink sends event
+func (s *WebhookSink) enabled(event WebhookEvent) bool {
+	return len(s.cfg.Events) == 0 || slices.Contains(s.cfg.Events, event)
+}
+
+// signWebhook returns the X-Userdate-Signature header of body
+func signWebhook(secret, body []byte) string {
+	h := hmac.New(sha256.New, secret)
+	h.Write(body)
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyWebhook checks the X-Userdate-Signature header of a webhook request
+// body signed with secret. It returns ErrInvalidSignature if the check
+// fails.
+func VerifyWebhook(secret, body []byte, signature string) error {
+	if !hmac.Equal([]byte(signature), []byte(signWebhook(secret, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ReadDeadLetters reads the payloads of a dead-letter file of a WebhookSink
+func ReadDeadLetters(path string) ([]DeadLetter, error) {
+	file := jsonlFile{path: path}
+	var letters []DeadLetter
+	err := file.read(func(line []byte) error {
+		var letter DeadLetter
+		if err := json.Unmarshal(line, &letter); err != nil {
+			return err
+		}
+		letters = append(letters, letter)
+		return nil
+	})
+	return letters, err
+}
+
+// sendWebhooks sends the events of a finished report to the webhook sink
+func (c *config) sendWebhooks(ctx context.Context, user *User, entity Entity, report *Report) {
+	s := c.webhook
+	if s == nil {
+		return
+	}
+	var payloads []WebhookPayload
+	payload := func(event WebhookEvent) WebhookPayload {
+		return WebhookPayload{Event: event, SentAt: c.now(), User: user, Entity: entity, Report: copyReport(report)}
+	}
+	if s.enabled(WebhookFailure) && report.Verdict == VerdictFail {
+		payloads = append(payloads, payload(WebhookFailure))
+	}
+	if s.enabled(WebhookAnomaly) && s.cfg.MinScore > 0 {
+		if score := (DecisionPolicy{Weights: s.cfg.Weights}).Score(report); score >= s.cfg.MinScore {
+			p := payload(WebhookAnomaly)
+			p.Score = score
+			payloads = append(payloads, p)
+		}
+	}
+	if s.enabled(WebhookExpiring) && report.Valid() && user != nil {
+		now := c.truncate(c.now())
+		if expiry, ok := c.entityExpiry(entity, user.BirthDate); ok && !expiry.Before(now) && !expiry.After(now.Add(s.cfg.ExpiryWindow)) {
+			p := payload(WebhookExpiring)
+			p.Expiry = expiry
+			payloads = append(payloads, p)
+		}
+	}
+	for _, p := range payloads {
+		if err := s.Send(ctx, p); err != nil {
+			report.add(c.localize(newWarning(msgWebhookFailed, "event", string(p.Event), "error", err)))
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookServer records the payloads it receives, answering with the
+// statuses in order and then 204
+type webhookServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	attempts int
+	payloads []WebhookPayload
+}
+
+func newWebhookServer(t *testing.T, secret []byte, statuses ...int) *webhookServer {
+	s := &webhookServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := VerifyWebhook(secret, body, r.Header.Get("X-Userdate-Signature")); err != nil {
+			t.Errorf("VerifyWebhook() error = %v", err)
+		}
+		var p WebhookPayload
+		if err := json.Unmarshal(body, &p); err != nil || r.Header.Get("X-Userdate-Event") != string(p.Event) {
+			t.Errorf("payload = %s, event header %q: %v", body, r.Header.Get("X-Userdate-Event"), err)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		status := http.StatusNoContent
+		if s.attempts < len(s.statuses) {
+			status = s.statuses[s.attempts]
+		}
+		s.attempts++
+		if status < 300 {
+			s.payloads = append(s.payloads, p)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *webhookServer) events() []WebhookEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []WebhookEvent
+	for _, p := range s.payloads {
+		events = append(events, p.Event)
+	}
+	return events
+}
+
+func TestWebhookEvents(t *testing.T) {
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	secret := []byte("secret")
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+
+	tests := []struct {
+		name   string
+		entity Entity
+		cfg    WebhookConfig
+		want   []WebhookEvent
+	}{
+		{"failure", Entity{Type: "certification", Date: mustParseDate("2030-01-01")}, WebhookConfig{}, []WebhookEvent{WebhookFailure}},
+		{"pass", Entity{Type: "certification", Date: mustParseDate("2020-01-01")}, WebhookConfig{}, nil},
+		{"anomaly", Entity{Type: "certification", Date: mustParseDate("2030-01-01")},
+			WebhookConfig{Weights: map[string]int{ErrCodeFutureDate: 5}, MinScore: 5}, []WebhookEvent{WebhookFailure, WebhookAnomaly}},
+		{"below the threshold", Entity{Type: "certification", Date: mustParseDate("2030-01-01")},
+			WebhookConfig{Weights: map[string]int{ErrCodeFutureDate: 5}, MinScore: 6}, []WebhookEvent{WebhookFailure}},
+		{"expiring", Entity{Type: "cpr_certification", Date: mustParseDate("2023-08-01")}, WebhookConfig{}, []WebhookEvent{WebhookExpiring}},
+		{"expiring later", Entity{Type: "cpr_certification", Date: mustParseDate("2023-09-01")}, WebhookConfig{}, nil},
+		{"longer expiry window", Entity{Type: "cpr_certification", Date: mustParseDate("2023-09-01")},
+			WebhookConfig{ExpiryWindow: 60 * 24 * time.Hour}, []WebhookEvent{WebhookExpiring}},
+		{"filtered events", Entity{Type: "certification", Date: mustParseDate("2030-01-01")},
+			WebhookConfig{Events: []WebhookEvent{WebhookExpiring}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWebhookServer(t, secret)
+			tt.cfg.URL, tt.cfg.Secret = server.URL, secret
+			report := CheckEntity(user, tt.entity, now, WithWebhook(NewWebhookSink(tt.cfg)))
+			if got := server.events(); !slices.Equal(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+			if slices.Contains(findingCodes(report), ErrCodeWebhookFailed) {
+				t.Errorf("findings = %v", findingCodes(report))
+			}
+		})
+	}
+}
+
+func TestWebhookFastPath(t *testing.T) {
+	server := newWebhookServer(t, nil)
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	sink := NewWebhookSink(WebhookConfig{URL: server.URL})
+	err := ValidateEntityDate(user, mustParseDate("2030-01-01"), "certification", WithFixedNow(mustParseDate("2025-07-18")), WithWebhook(sink))
+	if codeOf(err) != ErrCodeFutureDate {
+		t.Fatalf("ValidateEntityDate() error = %v", err)
+	}
+	if got := server.events(); !slices.Equal(got, []WebhookEvent{WebhookFailure}) {
+		t.Errorf("events = %v, want a failure", got)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	failing := Entity{Type: "certification", Date: mustParseDate("2030-01-01")}
+	now := WithFixedNow(mustParseDate("2025-07-18"))
+	retries := ResiliencePolicy{MaxRetries: 2}
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		deadLetter   bool
+		wantAttempts int
+		wantLetters  int
+		wantWarning  bool
+	}{
+		{"retried server errors", []int{500, 503}, true, 3, 0, false},
+		{"retried rate limit", []int{429}, true, 2, 0, false},
+		{"retries exhausted", []int{500, 500, 500}, true, 3, 1, false},
+		{"client error not retried", []int{400}, true, 1, 1, false},
+		{"lost without dead letter", []int{400}, false, 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWebhookServer(t, nil, tt.statuses...)
+			cfg := WebhookConfig{URL: server.URL, Policy: retries}
+			if tt.deadLetter {
+				cfg.DeadLetter = filepath.Join(t.TempDir(), "dead.jsonl")
+			}
+			report := CheckEntity(user, failing, now, WithWebhook(NewWebhookSink(cfg)))
+			if server.attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", server.attempts, tt.wantAttempts)
+			}
+			if got := slices.Contains(findingCodes(report), ErrCodeWebhookFailed); got != tt.wantWarning {
+				t.Errorf("findings = %v, want WEBHOOK_FAILED %v", findingCodes(report), tt.wantWarning)
+			}
+			if !tt.deadLetter {
+				return
+			}
+			letters, err := ReadDeadLetters(cfg.DeadLetter)
+			if err != nil || len(letters) != tt.wantLetters {
+				t.Fatalf("ReadDeadLetters() = %d letters, %v, want %d", len(letters), err, tt.wantLetters)
+			}
+			for _, letter := range letters {
+				if letter.Payload.Event != WebhookFailure || letter.Payload.Report.Err() == nil || letter.Error == "" {
+					t.Errorf("dead letter = %+v", letter)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	if got := NewWebhookSink(WebhookConfig{}).cfg.Policy.Timeout; got != defaultWebhookTimeout {
+		t.Errorf("default attempt timeout = %v, want %v", got, defaultWebhookTimeout)
+	}
+
+	// A hanging endpoint holds the validation up to the caller's deadline
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-block:
+		}
+	}))
+	defer server.Close()
+	defer close(block)
+	user := &User{ID: "user123", BirthDate: mustParseDate("1990-01-01")}
+	failing := Entity{Type: "certification", Date: mustParseDate("2030-01-01")}
+	cfg := WebhookConfig{URL: server.URL, DeadLetter: filepath.Join(t.TempDir(), "dead.jsonl")}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	report := CheckEntityContext(ctx, user, failing, WithFixedNow(mustParseDate("2025-07-18")), WithWebhook(NewWebhookSink(cfg)))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CheckEntityContext() took %v, want it to stop at the context deadline", elapsed)
+	}
+	if slices.Contains(findingCodes(report), ErrCodeWebhookFailed) {
+		t.Errorf("findings = %v, want the payload dead-lettered", findingCodes(report))
+	}
+	if letters, err := ReadDeadLetters(cfg.DeadLetter); err != nil || len(letters) != 1 {
+		t.Errorf("ReadDeadLetters() = %d letters, %v, want 1", len(letters), err)
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	body := []byte(`{"event":"failure"}`)
+	signature := signWebhook([]byte("secret"), body)
+	if err := VerifyWebhook([]byte("secret"), body, signature); err != nil {
+		t.Errorf("VerifyWebhook() error = %v", err)
+	}
+	for _, tt := range []struct{ secret, body, signature string }{
+		{"other", string(body), signature},
+		{"secret", `{"event":"anomaly"}`, signature},
+		{"secret", string(body), ""},
+	} {
+		if err := VerifyWebhook([]byte(tt.secret), []byte(tt.body), tt.signature); err != ErrInvalidSignature {
+			t.Errorf("VerifyWebhook(%q, %s) error = %v, want ErrInvalidSignature", tt.secret, tt.body, err)
+		}
+	}
+}
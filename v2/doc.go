@@ -0,0 +1,15 @@
+// Package v2 is the root of the v2 layout of the module, which groups the
+// API of the userdate package into focused packages:
+//
+//   - core: validators, users, entities and the validation functions
+//   - rules: thresholds, rule files, layers and the rule datasets
+//   - report: reports, findings, verdicts, explanations and audit logs
+//   - adapters: HTTP handler, webhooks, caches, stores and remote checkers
+//   - i18n: locales and message catalogs
+//
+// The implementation lives in core, and the other packages declare aliases
+// of its types and forward to its functions. The flat userdate package of
+// v1 is a generated wrapper over core, so values pass freely between both
+// layouts and programs can move one import at a time. New capabilities get
+// their home in these packages.
+package v2
@@ -0,0 +1,3 @@
+module github.com/i2sac/user-entity-date-verification/v2
+
+go 1.24.5
@@ -0,0 +1,28 @@
+// Package i18n localizes the messages of validation findings. It groups
+// part of the API of the core package, see the v2 package.
+package i18n
+
+import "github.com/i2sac/user-entity-date-verification/v2/core"
+
+// Catalog holds the messages of a locale
+type Catalog = core.MessageCatalog
+
+// Locales returns the locales of the registered catalogs
+func Locales() []string {
+	return core.Locales()
+}
+
+// Missing returns the message keys a locale has no translation for
+func Missing(locale string) []string {
+	return core.MissingMessages(locale)
+}
+
+// Register adds or replaces a message catalog from its JSON form
+func Register(data []byte) error {
+	return core.RegisterMessageCatalog(data)
+}
+
+// WithLocale writes the messages of findings in locale
+func WithLocale(locale string) core.Option {
+	return core.WithLocale(locale)
+}
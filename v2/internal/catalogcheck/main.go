@@ -0,0 +1,183 @@
+// Command catalogcheck verifies the built-in message catalogs. It is run by
+// go generate in the core package directory and fails when:
+//
+//   - an error code declared in main.go has no English message,
+//   - a message key does not start with a declared error code or a prefix
+//     of non-error messages,
+//   - a catalog lacks a message of the English catalog or has extra ones,
+//   - a message is not valid ICU MessageFormat,
+//   - a translation does not use the same arguments as the English text.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/i2sac/user-entity-date-verification/v2/internal/msgformat"
+)
+
+// nonErrorPrefixes start the keys of messages that are not errors, such as
+// the summaries of milestones and the sentences of narratives
+var nonErrorPrefixes = []string{"MILESTONE", "NARRATIVE"}
+
+// catalog is the JSON form of a message catalog
+type catalog struct {
+	Locale   string            `json:"locale"`
+	Messages map[string]string `json:"messages"`
+}
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the core package")
+	flag.Parse()
+
+	problems, err := check(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "catalogcheck: %v\n", err)
+		os.Exit(2)
+	}
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// check returns the problems found in the catalogs of the package in dir
+func check(dir string) ([]string, error) {
+	codes, err := errorCodes(filepath.Join(dir, "main.go"))
+	if err != nil {
+		return nil, err
+	}
+	catalogs, err := loadCatalogs(filepath.Join(dir, "locales"))
+	if err != nil {
+		return nil, err
+	}
+	en, ok := catalogs["en"]
+	if !ok {
+		return nil, fmt.Errorf("no English catalog")
+	}
+
+	var problems []string
+	for _, code := range codes {
+		found := false
+		for key := range en.Messages {
+			if strings.HasPrefix(key, code+".") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("en: no message for error code %s", code))
+		}
+	}
+
+	for _, locale := range sortedKeys(catalogs) {
+		cat := catalogs[locale]
+		for _, key := range sortedKeys(cat.Messages) {
+			code, _, _ := strings.Cut(key, ".")
+			if !slices.Contains(codes, code) && !slices.Contains(nonErrorPrefixes, code) {
+				problems = append(problems, fmt.Sprintf("%s: message %s has unknown error code %s", locale, key, code))
+			}
+			msg, err := msgformat.Parse(cat.Messages[key])
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: message %s: %v", locale, key, err))
+				continue
+			}
+			english, ok := en.Messages[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: message %s is not in the English catalog", locale, key))
+				continue
+			}
+			if want, err := msgformat.Parse(english); err == nil && !slices.Equal(msg.Args(), want.Args()) {
+				problems = append(problems, fmt.Sprintf("%s: message %s does not use the arguments %v",
+					locale, key, want.Args()))
+			}
+		}
+		for _, key := range sortedKeys(en.Messages) {
+			if _, ok := cat.Messages[key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing message %s", locale, key))
+			}
+		}
+	}
+	return problems, nil
+}
+
+// errorCodes returns the values of the ErrCode constants declared in file
+func errorCodes(file string) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	var codes []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, "ErrCode") || i >= len(vs.Values) {
+					continue
+				}
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				code, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return nil, err
+				}
+				codes = append(codes, code)
+			}
+		}
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("no error codes in %s", file)
+	}
+	return codes, nil
+}
+
+// loadCatalogs reads the catalogs of dir by locale
+func loadCatalogs(dir string) (map[string]catalog, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	catalogs := make(map[string]catalog)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var cat catalog
+		if err := json.Unmarshal(data, &cat); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if want := strings.TrimSuffix(filepath.Base(file), ".json"); cat.Locale != want {
+			return nil, fmt.Errorf("%s: locale %q does not match the file name", file, cat.Locale)
+		}
+		catalogs[cat.Locale] = cat
+	}
+	return catalogs, nil
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckBuiltinCatalogs(t *testing.T) {
+	problems, err := check("../../core")
+	if err != nil {
+		t.Fatalf("check() error = %v", err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+func TestCheckReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("main.go", `package userdate
+
+const (
+	ErrCodeFutureDate = "FUTURE_DATE"
+	ErrCodeExpired    = "EXPIRED"
+)
+`)
+	if err := os.Mkdir(filepath.Join(dir, "locales"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write("locales/en.json", `{"locale": "en", "messages": {
+		"FUTURE_DATE.entity": "{type} date ({date}) cannot be in the future",
+		"FUTURE_DATE.birth": "birth date cannot be in the future"}}`)
+	write("locales/fr.json", `{"locale": "fr", "messages": {
+		"FUTURE_DATE.entity": "{type} : la date ne peut pas être dans le futur",
+		"FUTURE_DATE.birth": "{n, plural, one {#}}",
+		"BOGUS.extra": "?"}}`)
+
+	problems, err := check(dir)
+	if err != nil {
+		t.Fatalf("check() error = %v", err)
+	}
+	got := strings.Join(problems, "\n")
+	for _, want := range []string{
+		"en: no message for error code EXPIRED",
+		"fr: message BOGUS.extra has unknown error code BOGUS",
+		"fr: message FUTURE_DATE.entity does not use the arguments [date type]",
+		"fr: message FUTURE_DATE.birth: msgformat: n has no other branch",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("problems do not contain %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package msgformat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date styles of {name, date, style} arguments
+const (
+	DateShort  = "short"
+	DateMedium = "medium"
+	DateLong   = "long"
+	DateISO    = "iso"
+)
+
+// dateRules are the month names and CLDR date patterns of a locale
+type dateRules struct {
+	months, abbr [12]string
+	short        string
+	medium       string
+	long         string
+}
+
+var (
+	englishMonths = [12]string{"January", "February", "March", "April", "May", "June", "July",
+		"August", "September", "October", "November", "December"}
+	englishAbbr  = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	frenchMonths = [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet",
+		"août", "septembre", "octobre", "novembre", "décembre"}
+	frenchAbbr    = [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."}
+	spanishMonths = [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+		"agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+	spanishAbbr  = [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"}
+	germanMonths = [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli",
+		"August", "September", "Oktober", "November", "Dezember"}
+	germanAbbr       = [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."}
+	portugueseMonths = [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho",
+		"agosto", "setembro", "outubro", "novembro", "dezembro"}
+	portugueseAbbr = [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."}
+)
+
+// dates holds the date rules of the locales of the built-in catalogs.
+// Other languages get ISO 8601 dates.
+var dates = map[string]*dateRules{
+	"en":    {months: englishMonths, abbr: englishAbbr, short: "M/d/yy", medium: "MMM d, y", long: "MMMM d, y"},
+	"en-gb": {months: englishMonths, abbr: englishAbbr, short: "dd/MM/y", medium: "d MMM y", long: "d MMMM y"},
+	"en-ca": {months: englishMonths, abbr: englishAbbr, short: "y-MM-dd", medium: "MMM d, y", long: "MMMM d, y"},
+	"fr":    {months: frenchMonths, abbr: frenchAbbr, short: "dd/MM/y", medium: "d MMM y", long: "d MMMM y"},
+	"fr-ca": {months: frenchMonths, abbr: frenchAbbr, short: "y-MM-dd", medium: "d MMM y", long: "d MMMM y"},
+	"es":    {months: spanishMonths, abbr: spanishAbbr, short: "d/M/yy", medium: "d MMM y", long: "d 'de' MMMM 'de' y"},
+	"de":    {months: germanMonths, abbr: germanAbbr, short: "dd.MM.yy", medium: "dd.MM.y", long: "d. MMMM y"},
+	"pt":    {months: portugueseMonths, abbr: portugueseAbbr, short: "dd/MM/y", medium: "d 'de' MMM 'de' y", long: "d 'de' MMMM 'de' y"},
+	"pt-pt": {months: portugueseMonths, abbr: portugueseAbbr, short: "dd/MM/yy", medium: "dd/MM/y", long: "d 'de' MMMM 'de' y"},
+}
+
+// FormatDate writes the calendar date of t in locale with a style: short,
+// medium, long or iso. Locales without date rules get ISO 8601 dates.
+func FormatDate(locale string, t time.Time, style string) string {
+	id := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	dr, ok := dates[id]
+	if !ok {
+		lang, _, _ := strings.Cut(id, "-")
+		dr, ok = dates[lang]
+	}
+	if !ok || style == DateISO {
+		return t.Format("2006-01-02")
+	}
+
+	pattern := dr.medium
+	switch style {
+	case DateShort:
+		pattern = dr.short
+	case DateLong:
+		pattern = dr.long
+	}
+	return dr.format(pattern, t)
+}
+
+// format renders a CLDR date pattern. It supports the d, M and y fields and
+// quoted literals, which is what the built-in patterns use.
+func (dr *dateRules) format(pattern string, t time.Time) string {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				end = len(pattern) - i - 1
+			}
+			sb.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		switch c {
+		case 'd':
+			sb.WriteString(pad(t.Day(), n))
+		case 'M':
+			switch n {
+			case 1, 2:
+				sb.WriteString(pad(int(t.Month()), n))
+			case 3:
+				sb.WriteString(dr.abbr[t.Month()-1])
+			default:
+				sb.WriteString(dr.months[t.Month()-1])
+			}
+		case 'y':
+			if n == 2 {
+				sb.WriteString(pad(t.Year()%100, 2))
+			} else {
+				sb.WriteString(strconv.Itoa(t.Year()))
+			}
+		default:
+			sb.WriteString(pattern[i : i+n])
+		}
+		i += n
+	}
+	return sb.String()
+}
+
+// pad writes n with at least width digits
+func pad(n, width int) string {
+	s := strconv.Itoa(n)
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
@@ -0,0 +1,467 @@
+// Package msgformat implements the subset of ICU MessageFormat used by the
+// userdate message catalogs:
+//
+//	{name}                          the value of an argument
+//	{name, number}                  an integer with the locale's digit grouping
+//	{name, date, style}             a time.Time as a short, medium, long or iso date
+//	{name, plural, one {…} other {…}}
+//	{name, select, a {…} other {…}}
+//
+// Plain arguments write integers as {name, number} and times as medium
+// {name, date}. Plural messages accept an offset:n prefix and =n exact matches, and #
+// inside a plural branch stands for the number minus the offset. Plural and
+// select arguments must have an other branch. Apostrophes follow the ICU
+// DOUBLE_OPTIONAL mode: two apostrophes stand for one, an apostrophe before
+// {, }, or # starts quoted text, and any other apostrophe is literal, so
+// "user's" needs no escaping.
+package msgformat
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a parsed message pattern. It is safe for concurrent use.
+type Message struct {
+	parts []part
+}
+
+// part is a piece of a message: literal text, an argument, or # in a
+// plural branch
+type part struct {
+	text   string
+	arg    string
+	kind   argKind
+	offset int
+	style  string
+	pound  bool
+	cases  []branch
+}
+
+// argKind is the type of an argument
+type argKind uint8
+
+// Argument kinds
+const (
+	kindText argKind = iota
+	kindSimple
+	kindNumber
+	kindDate
+	kindPlural
+	kindSelect
+)
+
+// branch is a selector and the sub-message of a plural or select argument
+type branch struct {
+	key string
+	msg *Message
+}
+
+// Args is how Format looks up argument values by name
+type Args func(name string) (value any, ok bool)
+
+// Render writes values that are neither integers nor times, which are
+// formatted with the conventions of the locale.
+type Render func(value any) string
+
+// Parse parses a message pattern
+func Parse(pattern string) (*Message, error) {
+	p := &parser{src: pattern}
+	msg, err := p.message(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unmatched }")
+	}
+	return msg, nil
+}
+
+// MustParse is like Parse but panics on errors
+func MustParse(pattern string) *Message {
+	msg, err := Parse(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return msg
+}
+
+// Args returns the names of the arguments of the message, sorted
+func (m *Message) Args() []string {
+	var names []string
+	m.collectArgs(&names)
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// collectArgs appends the argument names of m and its branches to names
+func (m *Message) collectArgs(names *[]string) {
+	for _, p := range m.parts {
+		if p.kind != kindText {
+			*names = append(*names, p.arg)
+		}
+		for _, b := range p.cases {
+			b.msg.collectArgs(names)
+		}
+	}
+}
+
+// Format renders the message in locale, a BCP 47 tag such as "fr" or
+// "pt-BR". Arguments that args does not know are written as {name}.
+func (m *Message) Format(locale string, args Args, render Render) string {
+	var sb strings.Builder
+	m.format(&sb, locale, args, render, nil)
+	return sb.String()
+}
+
+// format writes the message to sb. pound is the value of # in the
+// enclosing plural branch, if any.
+func (m *Message) format(sb *strings.Builder, locale string, args Args, render Render, pound *string) {
+	lr := rulesFor(locale)
+	for _, p := range m.parts {
+		if p.pound {
+			if pound != nil {
+				sb.WriteString(*pound)
+			} else {
+				sb.WriteByte('#')
+			}
+			continue
+		}
+		if p.kind == kindText {
+			sb.WriteString(p.text)
+			continue
+		}
+
+		value, ok := args(p.arg)
+		if !ok {
+			sb.WriteString("{" + p.arg + "}")
+			continue
+		}
+		n, isInt := toInt(value)
+		t, isTime := value.(time.Time)
+		switch p.kind {
+		case kindSimple, kindNumber, kindDate:
+			if isTime {
+				style := p.style
+				if style == "" {
+					style = DateMedium
+				}
+				sb.WriteString(FormatDate(locale, t, style))
+			} else if isInt {
+				sb.WriteString(lr.formatInt(n))
+			} else {
+				sb.WriteString(render(value))
+			}
+		case kindPlural:
+			key := "other"
+			if isInt {
+				key = lr.plural(n - p.offset)
+			}
+			msg := p.branch("="+strconv.Itoa(n), isInt)
+			if msg == nil {
+				msg = p.branch(key, true)
+			}
+			if msg == nil {
+				msg = p.branch("other", true)
+			}
+			number := render(value)
+			if isInt {
+				number = lr.formatInt(n - p.offset)
+			}
+			msg.format(sb, locale, args, render, &number)
+		case kindSelect:
+			var key string
+			if isInt {
+				key = strconv.Itoa(n)
+			} else {
+				key = render(value)
+			}
+			msg := p.branch(key, true)
+			if msg == nil {
+				msg = p.branch("other", true)
+			}
+			msg.format(sb, locale, args, render, pound)
+		}
+	}
+}
+
+// branch returns the sub-message selected by key, or nil
+func (p *part) branch(key string, ok bool) *Message {
+	if !ok {
+		return nil
+	}
+	for _, b := range p.cases {
+		if b.key == key {
+			return b.msg
+		}
+	}
+	return nil
+}
+
+// toInt returns the value of integer arguments
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// parser reads a pattern
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("msgformat: %s at offset %d in %q", fmt.Sprintf(format, args...), p.pos, p.src)
+}
+
+// message parses parts up to an unmatched } or the end of the pattern.
+// inPlural enables # as the number of the enclosing plural branch.
+func (p *parser) message(inPlural bool) (*Message, error) {
+	msg := &Message{}
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			msg.parts = append(msg.parts, part{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\'':
+			p.quoted(&text, inPlural)
+		case c == '{':
+			flush()
+			arg, err := p.argument(inPlural)
+			if err != nil {
+				return nil, err
+			}
+			msg.parts = append(msg.parts, arg)
+		case c == '}':
+			flush()
+			return msg, nil
+		case c == '#' && inPlural:
+			flush()
+			msg.parts = append(msg.parts, part{pound: true})
+			p.pos++
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	flush()
+	return msg, nil
+}
+
+// quoted reads an apostrophe at the current position into text
+func (p *parser) quoted(text *strings.Builder, inPlural bool) {
+	p.pos++
+	if p.pos >= len(p.src) {
+		text.WriteByte('\'')
+		return
+	}
+	switch c := p.src[p.pos]; {
+	case c == '\'':
+		text.WriteByte('\'')
+		p.pos++
+	case c == '{' || c == '}' || (c == '#' && inPlural):
+		for p.pos < len(p.src) {
+			if p.src[p.pos] == '\'' {
+				if p.pos+1 < len(p.src) && p.src[p.pos+1] == '\'' {
+					text.WriteByte('\'')
+					p.pos += 2
+					continue
+				}
+				p.pos++
+				return
+			}
+			text.WriteByte(p.src[p.pos])
+			p.pos++
+		}
+	default:
+		text.WriteByte('\'')
+	}
+}
+
+// argument parses an argument starting at {
+func (p *parser) argument(inPlural bool) (part, error) {
+	p.pos++
+	name := p.word()
+	if name == "" {
+		return part{}, p.errorf("missing argument name")
+	}
+	arg := part{arg: name, kind: kindSimple}
+	p.space()
+	if p.accept('}') {
+		return arg, nil
+	}
+	if !p.accept(',') {
+		return part{}, p.errorf("expected , or } after %s", name)
+	}
+
+	p.space()
+	kind := p.word()
+	switch kind {
+	case "number":
+		arg.kind = kindNumber
+		p.space()
+		if p.accept(',') {
+			p.space()
+			if style := p.word(); style != "integer" {
+				return part{}, p.errorf("unsupported number style %q", style)
+			}
+			p.space()
+		}
+		if !p.accept('}') {
+			return part{}, p.errorf("expected } after %s, number", name)
+		}
+		return arg, nil
+	case "date":
+		arg.kind = kindDate
+		p.space()
+		if p.accept(',') {
+			p.space()
+			arg.style = p.word()
+			switch arg.style {
+			case DateShort, DateMedium, DateLong, DateISO:
+			default:
+				return part{}, p.errorf("unsupported date style %q", arg.style)
+			}
+			p.space()
+		}
+		if !p.accept('}') {
+			return part{}, p.errorf("expected } after %s, date", name)
+		}
+		return arg, nil
+	case "plural":
+		arg.kind = kindPlural
+	case "select":
+		arg.kind = kindSelect
+	default:
+		return part{}, p.errorf("unsupported argument type %q", kind)
+	}
+
+	p.space()
+	if !p.accept(',') {
+		return part{}, p.errorf("expected , after %s, %s", name, kind)
+	}
+	if err := p.branches(&arg, inPlural); err != nil {
+		return part{}, err
+	}
+	return arg, nil
+}
+
+// branches parses the selectors and sub-messages of a plural or select
+// argument, up to its closing }
+func (p *parser) branches(arg *part, inPlural bool) error {
+	p.space()
+	if arg.kind == kindPlural && strings.HasPrefix(p.src[p.pos:], "offset:") {
+		p.pos += len("offset:")
+		p.space()
+		n, err := strconv.Atoi(p.word())
+		if err != nil {
+			return p.errorf("invalid offset")
+		}
+		arg.offset = n
+	}
+
+	for {
+		p.space()
+		if p.accept('}') {
+			break
+		}
+		key := p.word()
+		if key == "" {
+			return p.errorf("missing selector in %s", arg.arg)
+		}
+		if arg.kind == kindPlural && !isPluralSelector(key) {
+			return p.errorf("invalid plural selector %q", key)
+		}
+		if arg.branch(key, true) != nil {
+			return p.errorf("duplicate selector %q", key)
+		}
+		p.space()
+		if !p.accept('{') {
+			return p.errorf("expected { after selector %q", key)
+		}
+		msg, err := p.message(arg.kind == kindPlural || inPlural)
+		if err != nil {
+			return err
+		}
+		if !p.accept('}') {
+			return p.errorf("unterminated branch %q", key)
+		}
+		arg.cases = append(arg.cases, branch{key: key, msg: msg})
+	}
+	if arg.branch("other", true) == nil {
+		return p.errorf("%s has no other branch", arg.arg)
+	}
+	return nil
+}
+
+// isPluralSelector reports whether key is a plural category or =n
+func isPluralSelector(key string) bool {
+	if n, ok := strings.CutPrefix(key, "="); ok {
+		_, err := strconv.Atoi(n)
+		return err == nil
+	}
+	switch key {
+	case "zero", "one", "two", "few", "many", "other":
+		return true
+	}
+	return false
+}
+
+// word reads a name, selector or number
+func (p *parser) word() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '_' || c == '=' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+// space skips white space
+func (p *parser) space() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\n\r", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// accept consumes c if it is next
+func (p *parser) accept(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
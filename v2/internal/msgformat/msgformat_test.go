@@ -0,0 +1,173 @@
+package msgformat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// argsOf returns the Args of name, value pairs
+func argsOf(pairs ...any) Args {
+	return func(name string) (any, bool) {
+		for i := 0; i+1 < len(pairs); i += 2 {
+			if pairs[i] == name {
+				return pairs[i+1], true
+			}
+		}
+		return nil, false
+	}
+}
+
+func render(v any) string { return fmt.Sprint(v) }
+
+func TestFormat(t *testing.T) {
+	const years = "{n, plural, one {# year} other {# years}} ago"
+	tests := []struct {
+		name    string
+		locale  string
+		pattern string
+		args    []any
+		want    string
+	}{
+		{"simple", "en", "{type} date ({date})", []any{"type", "training", "date", "2020-01-01"}, "training date (2020-01-01)"},
+		{"missing argument", "en", "date ({date})", nil, "date ({date})"},
+		{"apostrophe", "en", "user's birth date", nil, "user's birth date"},
+		{"double apostrophe", "en", "it''s", nil, "it's"},
+		{"quoted braces", "en", "'{type}' is {type}", []any{"type", "x"}, "{type} is x"},
+		{"one", "en", years, []any{"n", 1}, "1 year ago"},
+		{"other", "en", years, []any{"n", 0}, "0 years ago"},
+		{"french zero", "fr", "{n, plural, one {# an} other {# ans}}", []any{"n", 0}, "0 an"},
+		{"french many falls back to other", "fr", "{n, plural, one {# an} other {# ans}}", []any{"n", 2000000}, "2 000 000 ans"},
+		{"exact match", "en", "{n, plural, =0 {never} one {once} other {# times}}", []any{"n", 0}, "never"},
+		{"offset", "en", "{n, plural, offset:1 =0 {nobody} =1 {you} one {you and # other} other {you and # others}}",
+			[]any{"n", 3}, "you and 2 others"},
+		{"quoted pound", "en", "{n, plural, other {'#'#}}", []any{"n", 4}, "#4"},
+		{"pound outside plural", "en", "item #{n}", []any{"n", 4}, "item #4"},
+		{"not an integer", "en", "{n, plural, one {# year} other {# years}}", []any{"n", "many"}, "many years"},
+		{"select", "en", "{g, select, f {she} m {he} other {they}} left", []any{"g", "x"}, "they left"},
+		{"nested", "en", "{g, select, f {{n, plural, one {# daughter} other {# daughters}}} other {{n} kids}}",
+			[]any{"g", "f", "n", 2}, "2 daughters"},
+		{"grouping", "de", "{n, number}", []any{"n", 1234567}, "1.234.567"},
+		{"spanish minimum grouping", "es", "{n} {m}", []any{"n", 1234, "m", 12345}, "1234 12.345"},
+		{"unknown locale", "tlh", "{n, plural, one {# year} other {# years}}", []any{"n", 1000}, "1,000 years"},
+		{"region", "pt-PT", "{n, plural, one {# ano} other {# anos}}", []any{"n", 0}, "0 anos"},
+		{"brazilian", "pt-BR", "{n, plural, one {# ano} other {# anos}}", []any{"n", 0}, "0 ano"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := Parse(tt.pattern)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.pattern, err)
+			}
+			if got := msg.Format(tt.locale, argsOf(tt.args...), render); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"{}", "missing argument name"},
+		{"{n", "expected , or }"},
+		{"a } b", "unmatched }"},
+		{"{n, time}", `unsupported argument type "time"`},
+		{"{n, date, full}", `unsupported date style "full"`},
+		{"{n, number, percent}", `unsupported number style "percent"`},
+		{"{n, plural, one {x}}", "has no other branch"},
+		{"{n, plural, few {x} other {y}", "missing selector"},
+		{"{n, plural, lots {x} other {y}}", `invalid plural selector "lots"`},
+		{"{n, plural, one {x} one {y} other {z}}", `duplicate selector "one"`},
+		{"{n, select, a x}", `expected { after selector "a"`},
+		{"{n, plural, other {x", "unterminated branch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			_, err := Parse(tt.pattern)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %v, want %q", tt.pattern, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgs(t *testing.T) {
+	msg := MustParse("{type} ({date}) {age, plural, one {# {unit}} other {# {unit}s}} {type}")
+	if got, want := msg.Args(), []string{"age", "date", "type", "unit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Args() = %v, want %v", got, want)
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      int
+		want   string
+	}{
+		{"en", 1, "one"},
+		{"en", 0, "other"},
+		{"en-GB", 2, "other"},
+		{"fr", 0, "one"},
+		{"fr", 1, "one"},
+		{"fr", 2, "other"},
+		{"fr_CA", 1000000, "many"},
+		{"es", 1, "one"},
+		{"es", 0, "other"},
+		{"es", 3000000, "many"},
+		{"de", 1, "one"},
+		{"pt", 0, "one"},
+		{"pt-PT", 0, "other"},
+		{"ja", 1, "other"},
+	}
+
+	for _, tt := range tests {
+		if got := PluralCategory(tt.locale, tt.n); got != tt.want {
+			t.Errorf("PluralCategory(%q, %d) = %s, want %s", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 23, 0, 0, 0, time.UTC)
+	tests := []struct {
+		locale string
+		style  string
+		want   string
+	}{
+		{"en", DateShort, "3/5/24"},
+		{"en-US", DateMedium, "Mar 5, 2024"},
+		{"en-GB", DateMedium, "5 Mar 2024"},
+		{"en", DateLong, "March 5, 2024"},
+		{"fr", DateShort, "05/03/2024"},
+		{"fr", DateMedium, "5 mars 2024"},
+		{"fr_CA", DateShort, "2024-03-05"},
+		{"es", DateLong, "5 de marzo de 2024"},
+		{"de", DateMedium, "05.03.2024"},
+		{"de-AT", DateLong, "5. März 2024"},
+		{"pt-BR", DateMedium, "5 de mar. de 2024"},
+		{"pt-PT", DateMedium, "05/03/2024"},
+		{"fr", DateISO, "2024-03-05"},
+		{"it", DateLong, "2024-03-05"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDate(tt.locale, date, tt.style); got != tt.want {
+			t.Errorf("FormatDate(%q, %s) = %q, want %q", tt.locale, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDateArguments(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	msg := MustParse("{d} | {d, date} | {d, date, long} | {d, date, iso}")
+	if got, want := msg.Format("fr", argsOf("d", date), render), "5 mars 2024 | 5 mars 2024 | 5 mars 2024 | 2024-03-05"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
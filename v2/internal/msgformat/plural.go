@@ -0,0 +1,114 @@
+package msgformat
+
+import (
+	"strconv"
+	"strings"
+)
+
+// localeRules are the plural rule and digit grouping of a language
+type localeRules struct {
+	plural func(n int) string
+
+	group       string // Separator between groups of three digits
+	minGrouping int    // Number of digits before the first group, as in CLDR
+}
+
+// Plural rules of the CLDR for integers
+var (
+	// one for 1: English, German, Dutch, Swedish...
+	pluralOne = func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}
+
+	// one for 0 and 1: French, Brazilian Portuguese
+	pluralZeroOne = func(n int) string {
+		switch {
+		case n == 0 || n == 1:
+			return "one"
+		case n != 0 && n%1000000 == 0:
+			return "many"
+		}
+		return "other"
+	}
+
+	// one for 1, many for millions: Spanish, European Portuguese, Italian
+	pluralOneMany = func(n int) string {
+		switch {
+		case n == 1:
+			return "one"
+		case n != 0 && n%1000000 == 0:
+			return "many"
+		}
+		return "other"
+	}
+
+	// no plural forms: Japanese, Chinese, Korean...
+	pluralNone = func(int) string { return "other" }
+)
+
+// rules holds the rules of the languages that catalogs are likely to use.
+// Other languages get the English rules.
+var rules = map[string]*localeRules{
+	"en":    {plural: pluralOne, group: ",", minGrouping: 1},
+	"de":    {plural: pluralOne, group: ".", minGrouping: 1},
+	"nl":    {plural: pluralOne, group: ".", minGrouping: 1},
+	"sv":    {plural: pluralOne, group: "\u00a0", minGrouping: 1},
+	"da":    {plural: pluralOne, group: ".", minGrouping: 1},
+	"fi":    {plural: pluralOne, group: "\u00a0", minGrouping: 1},
+	"it":    {plural: pluralOneMany, group: ".", minGrouping: 1},
+	"es":    {plural: pluralOneMany, group: ".", minGrouping: 2},
+	"pt":    {plural: pluralZeroOne, group: ".", minGrouping: 1},
+	"pt-pt": {plural: pluralOneMany, group: "\u00a0", minGrouping: 2},
+	"fr":    {plural: pluralZeroOne, group: "\u202f", minGrouping: 1},
+	"ja":    {plural: pluralNone, group: ",", minGrouping: 1},
+	"zh":    {plural: pluralNone, group: ",", minGrouping: 1},
+	"ko":    {plural: pluralNone, group: ",", minGrouping: 1},
+}
+
+// rulesFor returns the rules of a locale, of its language, or the English
+// ones
+func rulesFor(locale string) *localeRules {
+	id := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if lr, ok := rules[id]; ok {
+		return lr
+	}
+	lang, _, _ := strings.Cut(id, "-")
+	if lr, ok := rules[lang]; ok {
+		return lr
+	}
+	return rules["en"]
+}
+
+// PluralCategory returns the CLDR plural category of n in locale: one of
+// zero, one, two, few, many or other
+func PluralCategory(locale string, n int) string {
+	return rulesFor(locale).plural(n)
+}
+
+// formatInt writes n with the digit grouping of the locale
+func (lr *localeRules) formatInt(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) < 4+lr.minGrouping-1 {
+		return sign + digits
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	sb.WriteString(digits[:first])
+	for i := first; i < len(digits); i += 3 {
+		sb.WriteString(lr.group)
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+// Package report holds the outcomes of validations: reports, findings,
+// verdicts, explanations, decisions and the audit trail. It groups part of
+// the API of the core package, see the v2 package.
+package report
+
+import (
+	"context"
+
+	"github.com/i2sac/user-entity-date-verification/v2/core"
+)
+
+// Outcomes
+type (
+	Report         = core.Report
+	Finding        = core.DateValidationError
+	Reason         = core.Reason
+	Severity       = core.Severity
+	Verdict        = core.Verdict
+	Profile        = core.ProfileReport
+	Batch          = core.BatchResult
+	Explanation    = core.Explanation
+	Step           = core.Step
+	Decision       = core.Decision
+	DecisionPolicy = core.DecisionPolicy
+	DecisionResult = core.DecisionResult
+	ConfigSnapshot = core.ConfigSnapshot
+	AuditLog       = core.AuditLog
+	AuditRecord    = core.AuditRecord
+	AuditStore     = core.AuditStore
+)
+
+// Severities
+const (
+	SeverityError   = core.SeverityError
+	SeverityWarning = core.SeverityWarning
+	SeverityInfo    = core.SeverityInfo
+)
+
+// Verdicts
+const (
+	VerdictPass          = core.VerdictPass
+	VerdictFail          = core.VerdictFail
+	VerdictWarn          = core.VerdictWarn
+	VerdictIndeterminate = core.VerdictIndeterminate
+	VerdictNotApplicable = core.VerdictNotApplicable
+	VerdictSkipped       = core.VerdictSkipped
+)
+
+// Decisions
+const (
+	DecisionAccept = core.DecisionAccept
+	DecisionReview = core.DecisionReview
+	DecisionReject = core.DecisionReject
+)
+
+// Errors
+var (
+	ErrInvalidSignature = core.ErrInvalidSignature
+	ErrBrokenAuditChain = core.ErrBrokenAuditChain
+)
+
+// Verify checks the signature of a report
+func Verify(key []byte, r *Report) error {
+	return core.VerifyReport(key, r)
+}
+
+// HashInputs returns the SHA-256 key of the inputs of a validation
+func HashInputs(user *core.User, entity core.Entity, rulesVersion string) string {
+	return core.HashInputs(user, entity, rulesVersion)
+}
+
+// Explain validates an entity and records the evaluation of every rule
+func Explain(user *core.User, entity core.Entity, opts ...core.Option) *Explanation {
+	return core.Explain(user, entity, opts...)
+}
+
+// DefaultDecisionPolicy returns the default decision policy
+func DefaultDecisionPolicy() DecisionPolicy {
+	return core.DefaultDecisionPolicy()
+}
+
+// NewAuditLog returns an empty in-memory audit log
+func NewAuditLog() *AuditLog {
+	return core.NewAuditLog()
+}
+
+// OpenAuditLog opens an audit log kept in store
+func OpenAuditLog(ctx context.Context, store AuditStore) (*AuditLog, error) {
+	return core.OpenAuditLog(ctx, store)
+}
+
+// VerifyAuditChain checks the hash chain of audit records
+func VerifyAuditChain(records []AuditRecord) error {
+	return core.VerifyAuditChain(records)
+}
+
+// WithAuditLog appends every report to log
+func WithAuditLog(log *AuditLog) core.Option {
+	return core.WithAuditLog(log)
+}
@@ -0,0 +1,163 @@
+// Package rules configures the validation rules: thresholds by entity type,
+// rule files and layers, jurisdictions and the datasets behind them. It
+// groups part of the API of the core package, see the v2 package.
+package rules
+
+import (
+	"io/fs"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/core"
+)
+
+// Rule settings
+type (
+	Config           = core.RuleConfig
+	ConfigIssue      = core.ConfigIssue
+	Layer            = core.RuleLayer
+	Stack            = core.RuleStack
+	Setting          = core.RuleSetting
+	Order            = core.RuleOrder
+	ValidityPeriod   = core.ValidityPeriod
+	ChildValidity    = core.ChildValidity
+	AgeCutoff        = core.AgeCutoff
+	AgeCategory      = core.AgeCategory
+	Data             = core.RuleData
+	JurisdictionData = core.JurisdictionData
+	Resolver         = core.JurisdictionResolver
+	Static           = core.StaticJurisdictions
+)
+
+// Rule orders
+const (
+	OrderDeclaration   = core.OrderDeclaration
+	OrderCheapestFirst = core.OrderCheapestFirst
+	OrderSeverityFirst = core.OrderSeverityFirst
+)
+
+// Age cutoffs
+const (
+	CutoffEventDate     = core.CutoffEventDate
+	CutoffYearStart     = core.CutoffYearStart
+	CutoffYearEnd       = core.CutoffYearEnd
+	CutoffSchoolYearEnd = core.CutoffSchoolYearEnd
+)
+
+// Version identifies the revision of the validation rules
+const Version = core.RuleVersion
+
+// SchemaVersion is the version of the rule file format
+const SchemaVersion = core.RuleSchemaVersion
+
+// New returns the rules of a jurisdiction and preset, either of which may
+// be empty
+func New(jurisdiction, preset string) (Config, error) {
+	return core.NewRuleConfig(jurisdiction, preset)
+}
+
+// Parse reads a rule file
+func Parse(data []byte) (Config, error) {
+	return core.ParseRuleConfig(data)
+}
+
+// ParseWarnings reads a rule file, returning the issues of its settings
+func ParseWarnings(data []byte) (Config, []ConfigIssue, error) {
+	return core.ParseRuleConfigWarnings(data)
+}
+
+// Effective returns the rules that validations with opts apply
+func Effective(opts ...core.Option) Config {
+	return core.EffectiveRules(opts...)
+}
+
+// Validate checks a rule configuration before it is deployed
+func Validate(r Config) []ConfigIssue {
+	return core.ValidateConfig(r)
+}
+
+// ParseLayer reads a rule file as a layer
+func ParseLayer(name string, data []byte) (Layer, error) {
+	return core.ParseRuleLayer(name, data)
+}
+
+// NewStack merges layers over the defaults
+func NewStack(layers ...Layer) *Stack {
+	return core.NewRuleStack(layers...)
+}
+
+// Presets returns the names of the rule presets
+func Presets() []string {
+	return core.Presets()
+}
+
+// Jurisdictions returns the codes of the countries with rules
+func Jurisdictions() []string {
+	return core.Jurisdictions()
+}
+
+// Subdivisions returns the codes of the subdivisions of a country with
+// rules
+func Subdivisions(country string) []string {
+	return core.Subdivisions(country)
+}
+
+// ReadData reads the datasets of a directory
+func ReadData(fsys fs.FS) (Data, error) {
+	return core.ReadRuleData(fsys)
+}
+
+// LoadData replaces the datasets of the rules
+func LoadData(fsys fs.FS) error {
+	return core.LoadRuleData(fsys)
+}
+
+// EmbeddedData reports whether the datasets are embedded in the binary
+func EmbeddedData() bool {
+	return core.EmbeddedRuleData()
+}
+
+// With applies a rule configuration
+func With(r Config) core.Option {
+	return core.WithRules(r)
+}
+
+// WithMinimumAge sets the minimum age of the user for an entity type
+func WithMinimumAge(entityType string, age int) core.Option {
+	return core.WithMinimumAge(entityType, age)
+}
+
+// WithMaximumAge sets the maximum age of the user for an entity type
+func WithMaximumAge(entityType string, age int) core.Option {
+	return core.WithMaximumAge(entityType, age)
+}
+
+// WithValidityPeriod sets how long an entity type stays valid
+func WithValidityPeriod(entityType string, period ValidityPeriod) core.Option {
+	return core.WithValidityPeriod(entityType, period)
+}
+
+// WithMaxHistory sets the exact history limit
+func WithMaxHistory(d time.Duration) core.Option {
+	return core.WithMaxHistory(d)
+}
+
+// WithOrder sets the order in which rules are evaluated
+func WithOrder(order Order) core.Option {
+	return core.WithRuleOrder(order)
+}
+
+// WithShortCircuit sets whether evaluation stops at the first error
+func WithShortCircuit(enabled bool) core.Option {
+	return core.WithShortCircuit(enabled)
+}
+
+// WithSampled runs an expensive rule for only percent of the validations
+func WithSampled(rule string, percent float64) core.Option {
+	return core.WithSampledRule(rule, percent)
+}
+
+// WithResolver applies the rules of the jurisdiction r resolves for each
+// entity
+func WithResolver(r Resolver) core.Option {
+	return core.WithJurisdictionResolver(r)
+}
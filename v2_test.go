@@ -0,0 +1,49 @@
+package userdate_test
+
+import (
+	"testing"
+	"time"
+
+	userdate "github.com/i2sac/user-entity-date-verification"
+	"github.com/i2sac/user-entity-date-verification/v2/core"
+	"github.com/i2sac/user-entity-date-verification/v2/i18n"
+	"github.com/i2sac/user-entity-date-verification/v2/report"
+	"github.com/i2sac/user-entity-date-verification/v2/rules"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// The v2 packages and the flat package share their types, so programs can
+// move one import at a time
+func TestLayoutsInteroperate(t *testing.T) {
+	user, err := userdate.NewUser("user123", date("2000-01-01"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var opts []userdate.Option = []core.Option{
+		core.WithFixedNow(date("2025-01-01")),
+		rules.WithMinimumAge("license", 21),
+		i18n.WithLocale("fr"),
+	}
+
+	got := core.CheckEntityDate(user, date("2019-01-01"), "license", opts...)
+	want := userdate.CheckEntityDate(user, date("2019-01-01"), "license", opts...)
+	if got.Verdict != report.VerdictFail || got.Err().Error() != want.Err().Error() {
+		t.Errorf("CheckEntityDate() = %v, %v, want %v, %v", got.Verdict, got.Err(), want.Verdict, want.Err())
+	}
+
+	v := core.NewValidator(opts...)
+	var snapshot report.ConfigSnapshot = v.Config()
+	if snapshot.Rules.MinimumAges["license"] != 21 || snapshot.Data.Locale != "fr" {
+		t.Errorf("Config() = %+v, want the options of the v2 packages", snapshot)
+	}
+	if r, err := rules.New("FR", ""); err != nil || r.Jurisdiction != "FR" {
+		t.Errorf("rules.New() = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,1851 @@
+// Code generated by wrapgen from github.com/i2sac/user-entity-date-verification/v2/core; DO NOT EDIT.
+
+package userdate
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"io"
+	"io/fs"
+	"net/http"
+	"time"
+
+	"github.com/i2sac/user-entity-date-verification/v2/core"
+)
+
+// ErrBrokenAuditChain is returned by VerifyAuditChain when records have been
+// modified, removed, inserted or reordered
+var ErrBrokenAuditChain = core.ErrBrokenAuditChain
+
+// AuditRecord is an entry of an audit log. Each record holds the hash of the
+// previous one, so the log can only be extended, not rewritten.
+type AuditRecord = core.AuditRecord
+
+// AuditLog is an append-only, hash-chained log of validation reports. It is
+// safe for concurrent use.
+type AuditLog = core.AuditLog
+
+// NewAuditLog creates an empty, in-memory audit log
+func NewAuditLog() *AuditLog {
+	return core.NewAuditLog()
+}
+
+// OpenAuditLog creates an audit log persisted in store. Existing records are
+// loaded and verified, and new records are written to the store as they are
+// appended.
+func OpenAuditLog(ctx context.Context, store AuditStore) (*AuditLog, error) {
+	return core.OpenAuditLog(ctx, store)
+}
+
+// WithAuditLog records every report produced by the validation in log.
+// Validations returning only an error are recorded too.
+func WithAuditLog(log *AuditLog) Option {
+	return core.WithAuditLog(log)
+}
+
+// VerifyAuditChain checks that records form an unbroken chain starting at
+// the first record of a log: sequences are consecutive, each record links to
+// the hash of its predecessor and every hash matches its record's contents.
+func VerifyAuditChain(records []AuditRecord) error {
+	return core.VerifyAuditChain(records)
+}
+
+// Item is a single entity date to validate as part of a batch
+type Item = core.Item
+
+// BatchResult holds the reports of a batch validation. Reports[i] is the
+// report for Items[i].
+type BatchResult = core.BatchResult
+
+// ValidateBatch validates every item and returns their reports in input order
+func ValidateBatch(items []Item, opts ...Option) *BatchResult {
+	return core.ValidateBatch(items, opts...)
+}
+
+// BloomFilter is a probabilistic set of input hashes, see HashInputs. It
+// answers that a hash may be in the set, with a false positive rate chosen
+// on creation, or that it certainly is not. It is safe for concurrent use.
+type BloomFilter = core.BloomFilter
+
+// NewBloomFilter returns an empty filter sized for expected keys with the
+// given false positive rate, such as 0.001
+func NewBloomFilter(expected int, falsePositiveRate float64) *BloomFilter {
+	return core.NewBloomFilter(expected, falsePositiveRate)
+}
+
+// NewKnownBadFilter returns a filter of the inputs of rejected items, such
+// as the ones ReadRejects reads, for WithPrescreen and Prioritize. Rejects
+// that may pass later are left out: the ones without a report, and the
+// ones with a finding of a failed lookup, a future date or a minimum age
+// not yet reached.
+func NewKnownBadFilter(rejects []Reject, falsePositiveRate float64) *BloomFilter {
+	return core.NewKnownBadFilter(rejects, falsePositiveRate)
+}
+
+// WithPrescreen short-circuits the entity date validations whose inputs
+// may be in filter, such as a NewKnownBadFilter of the rejects of an
+// earlier run: their reports hold a KNOWN_BAD error instead of the
+// findings of the rules. As the keys cover RuleVersion and the ConfigHash
+// of the rejects, records fail the pre-screen only while they, the rules
+// and the options of the validator are unchanged. A valid
+// record fails it at the false positive rate of the filter, so keep the
+// rate low and validate KNOWN_BAD items again without the pre-screen
+// before acting on them.
+func WithPrescreen(filter *BloomFilter) Option {
+	return core.WithPrescreen(filter)
+}
+
+// Errors returned by BulkValidator.Submit
+var (
+	ErrQueueFull  = core.ErrQueueFull
+	ErrBulkClosed = core.ErrBulkClosed
+)
+
+// Backpressure is what Submit does when the queue of a BulkValidator is full
+type Backpressure = core.Backpressure
+
+// Backpressure strategies
+const (
+	BackpressureBlock = core.BackpressureBlock
+	BackpressureDrop  = core.BackpressureDrop
+	BackpressureError = core.BackpressureError
+)
+
+// BulkConfig tunes a BulkValidator
+type BulkConfig = core.BulkConfig
+
+// BulkResult is the outcome of a submitted item
+type BulkResult = core.BulkResult
+
+// BulkMetrics describes the state of a BulkValidator
+type BulkMetrics = core.BulkMetrics
+
+// BulkValidator validates a stream of items on a pool of workers, such as
+// records consumed from a message queue. Results are passed to a handler
+// as they complete, so they may arrive out of submission order; use
+// BulkResult.Seq to restore it. A BulkValidator is safe for concurrent use.
+type BulkValidator = core.BulkValidator
+
+// NewBulkValidator starts a bulk validator applying opts to every item.
+// handle is called from the worker goroutines, concurrently, for every
+// validated item.
+func NewBulkValidator(bulk BulkConfig, handle func(BulkResult), opts ...Option) *BulkValidator {
+	return core.NewBulkValidator(bulk, handle, opts...)
+}
+
+// MilestoneKind is the kind of a compliance milestone
+type MilestoneKind = core.MilestoneKind
+
+const (
+	MilestoneAge        = core.MilestoneAge
+	MilestoneRenewalDue = core.MilestoneRenewalDue
+	MilestoneExpiry     = core.MilestoneExpiry
+)
+
+// Milestone is an upcoming compliance event of a user
+type Milestone = core.Milestone
+
+// WithRenewalNotice sets how long before an entity expires its renewal is
+// due in Milestones, 3 months by default. A zero period leaves renewal
+// milestones out.
+func WithRenewalNotice(period ValidityPeriod) Option {
+	return core.WithRenewalNotice(period)
+}
+
+// Milestones returns the upcoming compliance events of the user of a
+// profile, from today on and sorted by date:
+//   - the birthdays on which the user reaches the minimum age of entity
+//     types, such as turning 16 and becoming eligible for a license;
+//   - the expiry of each entity, from its EndDate for documents and from
+//     the validity period of its type otherwise;
+//   - the date each entity is due for renewal, see WithRenewalNotice.
+//
+// Entities that fail validation are left out, and nothing falls after the
+// death of the user. It returns the error of an invalid user.
+func Milestones(profile Profile, opts ...Option) ([]Milestone, error) {
+	return core.Milestones(profile, opts...)
+}
+
+// WriteCalendar writes the Milestones of the user of a profile to w as an
+// iCalendar (RFC 5545) file of all-day events, which HR tools can import or
+// subscribe to. Events keep their UID across exports, so calendars update
+// them in place when a renewal moves an expiry. It returns the error of an
+// invalid user.
+func WriteCalendar(w io.Writer, profile Profile, opts ...Option) error {
+	return core.WriteCalendar(w, profile, opts...)
+}
+
+// CallOptions vary the settings of a single validation, such as those of
+// an HTTP request, without building a new Validator: they only replace a
+// few fields of a copy of the settings, and the rule set is shared. Zero
+// fields keep the configured settings.
+type CallOptions = core.CallOptions
+
+// ContextWithCallOptions returns a copy of ctx carrying opts for the
+// validations run with that context: the Context variants of the
+// validation functions and methods, and the HTTP handlers.
+func ContextWithCallOptions(ctx context.Context, opts CallOptions) context.Context {
+	return core.ContextWithCallOptions(ctx, opts)
+}
+
+// CallOptionsFromContext returns the call options stored in ctx by
+// ContextWithCallOptions
+func CallOptionsFromContext(ctx context.Context) (CallOptions, bool) {
+	return core.CallOptionsFromContext(ctx)
+}
+
+// ErrInvalidCatalog is returned for catalog data that cannot be decoded
+var ErrInvalidCatalog = core.ErrInvalidCatalog
+
+// Catalog is a read-only credential catalog decoded lazily from a compact
+// binary encoding (see EncodeCatalog). Only the index is checked when the
+// catalog is opened; an entry is decoded on its first lookup. This keeps
+// large catalogs cheap to load compared to Go map literals. It backs the
+// catalogs of WithCatalog, not the built-in datasets of LoadRuleData. A
+// Catalog is safe for concurrent use.
+//
+// Layout: the magic "UDC1", the entry count as a uint32, then one index
+// slot per entry sorted by type (key offset uint32, key length uint16,
+// value offset uint32), then the keys and the varint-encoded values. All
+// integers are little endian.
+type Catalog = core.Catalog
+
+// CatalogEntry describes a credential type
+type CatalogEntry = core.CatalogEntry
+
+// CatalogStats describes the memory use and activity of a catalog
+type CatalogStats = core.CatalogStats
+
+// EncodeCatalog encodes entries in the catalog format. Types must be unique.
+func EncodeCatalog(entries []CatalogEntry) ([]byte, error) {
+	return core.EncodeCatalog(entries)
+}
+
+// NewCatalog opens a catalog over data, which must not be modified afterwards
+func NewCatalog(data []byte) (*Catalog, error) {
+	return core.NewCatalog(data)
+}
+
+// LoadCatalog reads a catalog file written with EncodeCatalog
+func LoadCatalog(path string) (*Catalog, error) {
+	return core.LoadCatalog(path)
+}
+
+// ErrColumnLengths is returned by ValidateColumns for columns of different
+// lengths
+var ErrColumnLengths = core.ErrColumnLengths
+
+// ValidateColumns validates entity dates held in columns: row i is the
+// entity of type entityTypes[i] dated entityDates[i], for a user born on
+// birthDates[i]. It returns the error code of each row, or "" for valid
+// rows. The slices must have the same length, or ErrColumnLengths is
+// returned.
+//
+// Rows are checked in one pass against a single reference time, reusing
+// the same scratch user, so valid rows do not allocate. Use it for
+// analytics workloads that already hold columnar data in memory; use
+// ValidateBatch when the findings and messages are needed.
+func ValidateColumns(birthDates, entityDates []time.Time, entityTypes []string, opts ...Option) ([]string, error) {
+	return core.ValidateColumns(birthDates, entityDates, entityTypes, opts...)
+}
+
+// Difference is an item accepted under one rule set and rejected under the
+// other
+type Difference = core.Difference
+
+// Comparison is the outcome of validating a dataset under two rule sets
+type Comparison = core.Comparison
+
+// Compare validates every item under rule sets a and b, such as the
+// WithRules options of the current and of a proposed rules file, and
+// returns the items whose verdict differs. The rule responsible for a
+// difference is the first failing rule on the side that rejects the item,
+// found with Explain.
+func Compare(items []Item, a, b []Option) *Comparison {
+	return core.Compare(items, a, b)
+}
+
+// ReadItems reads a dataset of items from a JSON Lines file, one Item per
+// line, as ValidateBatch takes them
+func ReadItems(path string) ([]Item, error) {
+	return core.ReadItems(path)
+}
+
+// ConformanceCase is a case of the conformance dataset, see
+// conformance/cases.jsonl: an entity of a user, validated at Now under the
+// default rules, and the codes of the findings it gets, sorted. Ports of
+// the rules to other languages prove that they match this implementation
+// by giving the same codes for every case.
+type ConformanceCase = core.ConformanceCase
+
+// ConformanceResult is the outcome of a case under an implementation, as
+// a port writes it for the conformance runner
+type ConformanceResult = core.ConformanceResult
+
+// ConformanceFailure is a case an implementation gets wrong, or gives no
+// result for
+type ConformanceFailure = core.ConformanceFailure
+
+// ReadConformanceCases reads a conformance dataset from a JSON Lines file,
+// one ConformanceCase per line
+func ReadConformanceCases(path string) ([]ConformanceCase, error) {
+	return core.ReadConformanceCases(path)
+}
+
+// WriteConformanceCases writes cases as JSON Lines, the format of
+// ReadConformanceCases
+func WriteConformanceCases(w io.Writer, cases []ConformanceCase) error {
+	return core.WriteConformanceCases(w, cases)
+}
+
+// ConformanceCodes returns the sorted codes of the findings of a case
+// under this implementation, the reference of the dataset
+func ConformanceCodes(c ConformanceCase) []string {
+	return core.ConformanceCodes(c)
+}
+
+// UpdateConformanceCases sets the codes and rule version of every case to
+// the ones of this implementation. Run it when RuleVersion changes, and
+// review the codes that changed.
+func UpdateConformanceCases(cases []ConformanceCase) {
+	core.UpdateConformanceCases(cases)
+}
+
+// CheckConformance compares the results of an implementation with the
+// expected codes of the cases, by name and in any order of the codes. A
+// case without a result fails.
+func CheckConformance(cases []ConformanceCase, results []ConformanceResult) []ConformanceFailure {
+	return core.CheckConformance(cases, results)
+}
+
+// DefaultAgeOfMajority is the age of majority of jurisdictions without one
+// of their own, see WithAgeOfMajority
+const DefaultAgeOfMajority = core.DefaultAgeOfMajority
+
+// WithConsentTypes replaces the entity types that are legal consents, such
+// as terms acceptances and contract signatures, which the user must give
+// on or after the age of majority. Call it without types to turn the
+// consent rule off.
+func WithConsentTypes(entityTypes ...string) Option {
+	return core.WithConsentTypes(entityTypes...)
+}
+
+// WithAgeOfMajority sets the age from which users give legal consents
+// alone, instead of the age of majority of the jurisdiction whose rules
+// apply, or DefaultAgeOfMajority.
+func WithAgeOfMajority(age int) Option {
+	return core.WithAgeOfMajority(age)
+}
+
+// ContextWithNow returns a copy of ctx carrying t as the reference time for
+// validations run with that context, such as a request's transaction time.
+func ContextWithNow(ctx context.Context, t time.Time) context.Context {
+	return core.ContextWithNow(ctx, t)
+}
+
+// NowFromContext returns the reference time stored in ctx by ContextWithNow
+func NowFromContext(ctx context.Context) (time.Time, bool) {
+	return core.NowFromContext(ctx)
+}
+
+// ValidateEntityDateContext validates a date for a user entity like
+// ValidateEntityDate, taking the current time from ctx when it carries one
+// (see ContextWithNow) and from the clock otherwise
+func ValidateEntityDateContext(ctx context.Context, user *User, entityDate time.Time, entityType string, opts ...Option) error {
+	return core.ValidateEntityDateContext(ctx, user, entityDate, entityType, opts...)
+}
+
+// CheckEntityDateContext is like CheckEntityDate, taking the current time
+// from ctx when it carries one
+func CheckEntityDateContext(ctx context.Context, user *User, entityDate time.Time, entityType string, opts ...Option) *Report {
+	return core.CheckEntityDateContext(ctx, user, entityDate, entityType, opts...)
+}
+
+// AgeCutoff is the date on which the age of a user is taken for the age
+// rules of an entity: its minimum and maximum ages and its age category
+type AgeCutoff = core.AgeCutoff
+
+// Age cutoffs
+const (
+	CutoffEventDate     = core.CutoffEventDate
+	CutoffYearStart     = core.CutoffYearStart
+	CutoffYearEnd       = core.CutoffYearEnd
+	CutoffSchoolYearEnd = core.CutoffSchoolYearEnd
+)
+
+// WithAgeCutoff sets the date on which users must be of the minimum,
+// maximum or category age of entities of the given types, as institutions
+// rarely use the exact date: schools admit children by their age at the
+// end of the school year, and federations fix ages for a season. When no
+// types are given it sets the default for all types not configured
+// explicitly, the entity date by default.
+func WithAgeCutoff(cutoff AgeCutoff, entityTypes ...string) Option {
+	return core.WithAgeCutoff(cutoff, entityTypes...)
+}
+
+// WithSchoolYearEnd sets the last day of the school year for
+// CutoffSchoolYearEnd, August 31 by default
+func WithSchoolYearEnd(month time.Month, day int) Option {
+	return core.WithSchoolYearEnd(month, day)
+}
+
+// ErrInvalidRuleData is returned for datasets that cannot be loaded
+var ErrInvalidRuleData = core.ErrInvalidRuleData
+
+// RuleData holds the datasets behind the built-in rules: the minimum ages
+// of each jurisdiction, keyed by ISO 3166-1 alpha-2 code, and the validity
+// periods of common credentials.
+type RuleData = core.RuleData
+
+// JurisdictionData holds the minimum and maximum ages that differ in a
+// jurisdiction. Employment is the youngest age at which light or holiday
+// work is allowed, license the age for a car driving license, and the
+// stages of education, such as kindergarten, have a window of entry ages.
+// ChildValidity holds the validity of documents issued to young users,
+// such as passports, and AgeOfMajority the age from which users give legal
+// consents alone. Subdivisions of a country, keyed by ISO 3166-2 code
+// such as US-CA, hold the ages that differ from the country's, such as the
+// license age of a state.
+type JurisdictionData = core.JurisdictionData
+
+// EmbeddedRuleData reports whether the datasets are embedded in the binary.
+// Builds with the userdate_nodata tag leave them out to save space, and
+// start without jurisdictions or credential validity periods until
+// LoadRuleData is called.
+func EmbeddedRuleData() bool {
+	return core.EmbeddedRuleData()
+}
+
+// ReadRuleData reads the datasets of a directory laid out like the data
+// directory of this module: jurisdictions.json, an object of
+// JurisdictionData by code, and credentials.json, an array of
+// CatalogEntry. A missing file is an empty dataset.
+func ReadRuleData(fsys fs.FS) (RuleData, error) {
+	return core.ReadRuleData(fsys)
+}
+
+// LoadRuleData replaces the datasets with those of a directory, see
+// ReadRuleData, such as os.DirFS("/etc/userdate/data") in builds with the
+// userdate_nodata tag. Validators and rule configurations made before keep
+// the datasets they were made with.
+func LoadRuleData(fsys fs.FS) error {
+	return core.LoadRuleData(fsys)
+}
+
+// DateStats describes the distribution of one date column of a dataset
+type DateStats = core.DateStats
+
+// RecencyBucket counts the entity dates of an age range
+type RecencyBucket = core.RecencyBucket
+
+// DatasetProfile describes the dates of a dataset as a whole. Problems such
+// as placeholder birth dates or dates typed as a year only pass validation
+// one by one but stand out in the distributions.
+type DatasetProfile = core.DatasetProfile
+
+// ProfileDataset validates the records like ValidateBatch and profiles
+// their dates, surfacing systemic data issues that the findings of single
+// records do not reveal
+func ProfileDataset(records []Item, opts ...Option) *DatasetProfile {
+	return core.ProfileDataset(records, opts...)
+}
+
+// Decision is the terminal outcome a service acts on
+type Decision = core.Decision
+
+// Decisions
+const (
+	DecisionAccept = core.DecisionAccept
+	DecisionReview = core.DecisionReview
+	DecisionReject = core.DecisionReject
+)
+
+// DecisionRule maps the reports it matches to a decision. Every condition
+// that is set must hold; a list matches when any of its values does.
+// Codes and Severities match the findings: a report matches when one of
+// its findings has one of the codes and one of the severities. A rule
+// without conditions matches every report.
+type DecisionRule = core.DecisionRule
+
+// DecisionPolicy decides what to do with reports. Rules are tried in order
+// and the first one matching decides; Default applies when none does.
+// Weights give the score of each finding code, a report scoring the sum of
+// the weights of its findings.
+type DecisionPolicy = core.DecisionPolicy
+
+// DecisionResult is a decision with the reasons that led to it: the rule
+// that matched, then the findings and score it matched on
+type DecisionResult = core.DecisionResult
+
+// DefaultDecisionPolicy rejects reports with an error, sends indeterminate
+// reports and reports with warnings to review, and accepts the others
+func DefaultDecisionPolicy() DecisionPolicy {
+	return core.DefaultDecisionPolicy()
+}
+
+// ChildValidity is the shorter validity of documents issued to users
+// younger than UnderAge, such as 5 years for passports issued before 16
+type ChildValidity = core.ChildValidity
+
+// LikelyDuplicate is a pair of users that may be the same person, as
+// candidates for merging their records. First and Second are indexes in the
+// users given to FindLikelyDuplicates, First being the lower one.
+type LikelyDuplicate = core.LikelyDuplicate
+
+// FindLikelyDuplicates returns the pairs of users born the same day whose
+// names or IDs are identical or nearly so, sorted by index. Names and IDs
+// are compared once normalized, see WithIdentityNormalizer. Names are then
+// nearly identical when they differ by at most one edit per 5 letters, and
+// IDs when they differ by one edit, such as a mistyped or transposed
+// character. Users without a birth date are left
+// out. It only flags candidates: a merge needs a review.
+func FindLikelyDuplicates(users []User, opts ...Option) []LikelyDuplicate {
+	return core.FindLikelyDuplicates(users, opts...)
+}
+
+// Entity is a dated record belonging to a user, such as a certification or
+// a license
+type Entity = core.Entity
+
+// EntityStatus is the lifecycle state of an entity on a verification platform
+type EntityStatus = core.EntityStatus
+
+// Entity statuses
+const (
+	StatusClaimed  = core.StatusClaimed
+	StatusVerified = core.StatusVerified
+	StatusExpired  = core.StatusExpired
+	StatusRevoked  = core.StatusRevoked
+)
+
+// ValidityPeriod is how long a credential stays valid after it is issued or
+// renewed, in calendar years and months
+type ValidityPeriod = core.ValidityPeriod
+
+// DefaultValidityPeriod returns the validity period of an entity type in
+// the credential dataset, see LoadRuleData
+func DefaultValidityPeriod(entityType string) (ValidityPeriod, bool) {
+	return core.DefaultValidityPeriod(entityType)
+}
+
+// CheckEntity validates an entity of a user. Revoked entities fail with
+// ErrCodeRevoked. Besides the checks of CheckEntityDate, it warns with
+// ErrCodeExpired when the entity is marked expired, or when the entity type
+// has a validity period and the entity would have expired by now, unless a
+// renewal keeps it valid.
+func CheckEntity(user *User, entity Entity, opts ...Option) *Report {
+	return core.CheckEntity(user, entity, opts...)
+}
+
+// CheckEntityContext is like CheckEntity. The context is passed to the
+// revocation checker and may carry the reference time (see ContextWithNow).
+func CheckEntityContext(ctx context.Context, user *User, entity Entity, opts ...Option) *Report {
+	return core.CheckEntityContext(ctx, user, entity, opts...)
+}
+
+// ValidateEntity validates an entity of a user and returns the first error
+func ValidateEntity(user *User, entity Entity, opts ...Option) error {
+	return core.ValidateEntity(user, entity, opts...)
+}
+
+// WithIndustries restricts ExperienceYears to the employments of the given
+// industries, see Entity.Industry. Employments without an industry are
+// then left out.
+func WithIndustries(industries ...string) Option {
+	return core.WithIndustries(industries...)
+}
+
+// ExperienceYears returns the years of experience of the user of a profile
+// as of asOf: the days their employment entities cover up to asOf, counting
+// the days of concurrent employments once, divided by the average length
+// of a year. Ongoing employments run until asOf, or the death of the user.
+// Employments that fail validation with opts as of asOf are left out, so
+// experience before the working age or after death never counts. It
+// returns the error of an invalid user.
+func ExperienceYears(profile Profile, asOf time.Time, opts ...Option) (float64, error) {
+	return core.ExperienceYears(profile, asOf, opts...)
+}
+
+// Verdict is the outcome of a step of an explanation
+type Verdict = core.Verdict
+
+// Verdicts
+const (
+	VerdictPass          = core.VerdictPass
+	VerdictFail          = core.VerdictFail
+	VerdictWarn          = core.VerdictWarn
+	VerdictIndeterminate = core.VerdictIndeterminate
+	VerdictNotApplicable = core.VerdictNotApplicable
+	VerdictSkipped       = core.VerdictSkipped
+)
+
+// Step is the evaluation of one check. Threshold is what the rule compares
+// against, such as "at least 16 years", and Value the computed value, such
+// as "14 years".
+type Step = core.Step
+
+// Explanation is the rule-by-rule evaluation of an entity, as returned by
+// Explain. Steps start with the status and input checks, then list the
+// rules in the order they ran.
+type Explanation = core.Explanation
+
+// Explain validates an entity like CheckEntity and records how each rule
+// decided, with its threshold and the computed values. It answers "why was
+// this rejected?" for support tools.
+func Explain(user *User, entity Entity, opts ...Option) *Explanation {
+	return core.Explain(user, entity, opts...)
+}
+
+// GapExplanation accounts for a period of a profile without employment or
+// education, such as parental leave, illness or travel
+type GapExplanation = core.GapExplanation
+
+// WithMaxGap sets the longest gap between the employments and studies of a
+// profile that needs no explanation, 6 months by default. A zero period
+// turns gap detection off.
+func WithMaxGap(period ValidityPeriod) Option {
+	return core.WithMaxGap(period)
+}
+
+// MaxRequestBytes is the largest request body NewHandler accepts
+const MaxRequestBytes = core.MaxRequestBytes
+
+// CheckRequest is the body of the check and explain endpoints of NewHandler
+type CheckRequest = core.CheckRequest
+
+// BatchRequest is the body of the batch endpoint of NewHandler
+type BatchRequest = core.BatchRequest
+
+// NewHandler returns an HTTP handler serving the validation API:
+//
+//	POST /v1/check    CheckRequest, answers a Report
+//	POST /v1/explain  CheckRequest, answers an Explanation
+//	POST /v1/batch    BatchRequest, answers a BatchResult
+//
+// Check answers with the HTTPStatusForError status of the report error, so
+// clients can branch on 200, 400 and 422 alone; explain and batch answer 200
+// whatever the outcome. Bodies that are not valid JSON get a 400 with an
+// {"error": "..."} body. The reference time of a request can be pinned with
+// ContextWithNow on its context, by middleware for example.
+func NewHandler(opts ...Option) http.Handler {
+	return core.NewHandler(opts...)
+}
+
+// HTTPStatusFor returns the HTTP status for an error code. Codes registered
+// with RegisterHTTPStatus take precedence over the default table; unknown
+// codes map to 500 Internal Server Error.
+func HTTPStatusFor(code string) int {
+	return core.HTTPStatusFor(code)
+}
+
+// ErrorCodes returns the codes of the findings of the package, sorted
+func ErrorCodes() []string {
+	return core.ErrorCodes()
+}
+
+// HTTPStatusForError returns the HTTP status for a validation error: 200 OK
+// for nil, the status of the code for a DateValidationError, and 500 for
+// any other error
+func HTTPStatusForError(err error) int {
+	return core.HTTPStatusForError(err)
+}
+
+// RegisterHTTPStatus makes HTTPStatusFor return status for code, replacing
+// the default for built-in codes. It is meant to be called during program
+// initialization and is safe for concurrent use.
+func RegisterHTTPStatus(code string, status int) {
+	core.RegisterHTTPStatus(code, status)
+}
+
+// IdentityNormalizer turns the names and IDs of users into the forms that
+// identity cross-checks such as FindLikelyDuplicates compare, so that
+// "Zoë Müller" and "ZOE MULLER" match. See DefaultNormalizer.
+type IdentityNormalizer = core.IdentityNormalizer
+
+// WithIdentityNormalizer replaces the normalization of names and IDs in
+// identity cross-checks. A nil normalizer restores DefaultNormalizer.
+func WithIdentityNormalizer(n IdentityNormalizer) Option {
+	return core.WithIdentityNormalizer(n)
+}
+
+// DefaultNormalizer is the IdentityNormalizer of identity cross-checks. It
+// folds case, strips diacritics and transliterates letters to ASCII, such
+// as ß to ss, æ to ae and the Cyrillic alphabet as in passports.
+type DefaultNormalizer = core.DefaultNormalizer
+
+// HashInputs returns the SHA-256 of the canonical form of the inputs of a
+// validation, in hex: the user's ID, birth and death dates, every field of
+// the entity and the rules version, usually RuleVersion. Times are taken in
+// UTC and defaults are spelled out, such as the claimed status of an entity
+// without one, so equal inputs hash the same in every process. The user's
+// name is left out, as no rule reads it. Caches, deduplication and the
+// InputHash of reports all use it, so consumers need not roll their own.
+func HashInputs(user *User, entity Entity, rulesVersion string) string {
+	return core.HashInputs(user, entity, rulesVersion)
+}
+
+// WithEndAge sets the age of users by which entities of the given type must
+// end, such as 26 for child insurance policies, overriding the built-in end
+// age. Zero removes it.
+func WithEndAge(entityType string, age int) Option {
+	return core.WithEndAge(entityType, age)
+}
+
+// JurisdictionResolver returns the jurisdiction whose minimum ages apply to
+// an entity of a user, such as the country stored on the caller's user
+// record or the state of the issuer. The code is one accepted by
+// NewRuleConfig, such as FR or US-CA; an empty code keeps the configured
+// minimum ages.
+type JurisdictionResolver = core.JurisdictionResolver
+
+// StaticJurisdictions is a JurisdictionResolver over fixed mappings. The
+// issuer of the entity is looked up first, as a credential follows the rules
+// of where it was issued, then the ID of the user, then Default.
+type StaticJurisdictions = core.StaticJurisdictions
+
+// WithJurisdictionResolver applies the minimum and maximum ages and the
+// child validities of the jurisdiction r resolves for each validated entity
+// over the configured ones, except those set with WithMinimumAge,
+// WithMaximumAge and WithChildValidity. A failed
+// lookup, or a jurisdiction without rules, keeps the configured ages and is
+// reported as a JURISDICTION_UNKNOWN warning.
+func WithJurisdictionResolver(r JurisdictionResolver) Option {
+	return core.WithJurisdictionResolver(r)
+}
+
+// Sources of the settings of a RuleStack that no layer sets
+const (
+	SourceDefault = core.SourceDefault
+	SourceOptions = core.SourceOptions
+)
+
+// RuleLayer is one level of a layered rule configuration, such as the
+// rules file of an organization or of a tenant. Unlike ParseRuleConfig,
+// which fills in the defaults, a layer only holds the settings its file
+// sets.
+type RuleLayer = core.RuleLayer
+
+// ParseRuleLayer reads a layer named name, such as "organization" or
+// "tenant acme", from a file in the format of WriteYAML. Mapping entries
+// override the same entries of lower layers one by one; an empty mapping
+// {} drops the entries of lower layers.
+func ParseRuleLayer(name string, data []byte) (RuleLayer, error) {
+	return core.ParseRuleLayer(name, data)
+}
+
+// RuleStack is a layered rule configuration: the library defaults, then
+// each layer in turn, such as an organization's rules then a tenant's. It
+// records which layer set each setting, to answer questions such as "why
+// is the minimum age 15 here?".
+type RuleStack = core.RuleStack
+
+// RuleSetting is the effective value of a setting of a RuleStack and where
+// it came from. Keys are those of WriteYAML, with entries of mappings
+// written section.key, such as minimum_ages.license. Source is the name of
+// the layer that set the value, SourceDefault or SourceOptions.
+type RuleSetting = core.RuleSetting
+
+// NewRuleStack merges the layers over the library defaults, later layers
+// taking precedence
+func NewRuleStack(layers ...RuleLayer) *RuleStack {
+	return core.NewRuleStack(layers...)
+}
+
+// ConfigIssue is a problem ValidateConfig found in a rule configuration.
+// Errors are settings that contradict each other or cannot be right;
+// warnings are settings that are likely mistakes, such as an entity type
+// the library does not know.
+type ConfigIssue = core.ConfigIssue
+
+// ValidateConfig checks a rule configuration before it is deployed, for
+// unknown jurisdictions and presets, out of range values, rules that
+// contradict each other and unknown entity types. It returns the errors in
+// the order of the settings in WriteYAML, then the warnings.
+func ValidateConfig(r RuleConfig) []ConfigIssue {
+	return core.ValidateConfig(r)
+}
+
+// User represents a user entity with basic information for date validation
+type User = core.User
+
+// DateValidationError represents an error during date validation
+type DateValidationError = core.DateValidationError
+
+// Validation error codes
+const (
+	ErrCodeInvalidDate          = core.ErrCodeInvalidDate
+	ErrCodeBeforeBirth          = core.ErrCodeBeforeBirth
+	ErrCodeFutureDate           = core.ErrCodeFutureDate
+	ErrCodeUnrealisticAge       = core.ErrCodeUnrealisticAge
+	ErrCodeInvalidUser          = core.ErrCodeInvalidUser
+	ErrCodeDateTooOld           = core.ErrCodeDateTooOld
+	ErrCodePrenatal             = core.ErrCodePrenatal
+	ErrCodeExpired              = core.ErrCodeExpired
+	ErrCodeRevoked              = core.ErrCodeRevoked
+	ErrCodeInvalidStatus        = core.ErrCodeInvalidStatus
+	ErrCodeRevocationUnknown    = core.ErrCodeRevocationUnknown
+	ErrCodeOutsideSigningWindow = core.ErrCodeOutsideSigningWindow
+	ErrCodeAuditFailed          = core.ErrCodeAuditFailed
+	ErrCodeSwappedDate          = core.ErrCodeSwappedDate
+	ErrCodePlaceholderDate      = core.ErrCodePlaceholderDate
+	ErrCodeTwoDigitYear         = core.ErrCodeTwoDigitYear
+	ErrCodeImpreciseDate        = core.ErrCodeImpreciseDate
+	ErrCodeReviewFailed         = core.ErrCodeReviewFailed
+	ErrCodeJurisdictionUnknown  = core.ErrCodeJurisdictionUnknown
+	ErrCodeImplausibleDuration  = core.ErrCodeImplausibleDuration
+	ErrCodeOutsideValidity      = core.ErrCodeOutsideValidity
+	ErrCodeDocumentValidity     = core.ErrCodeDocumentValidity
+	ErrCodeRenewalChain         = core.ErrCodeRenewalChain
+	ErrCodeAfterDeath           = core.ErrCodeAfterDeath
+	ErrCodeJuvenileRecord       = core.ErrCodeJuvenileRecord
+	ErrCodeAgeCategory          = core.ErrCodeAgeCategory
+	ErrCodeImplausibleTenure    = core.ErrCodeImplausibleTenure
+	ErrCodeCareerGap            = core.ErrCodeCareerGap
+	ErrCodeWebhookFailed        = core.ErrCodeWebhookFailed
+	ErrCodeKnownBad             = core.ErrCodeKnownBad
+	ErrCodeRulePanic            = core.ErrCodeRulePanic
+	ErrCodeMinorConsent         = core.ErrCodeMinorConsent
+	ErrCodeBeforeSignup         = core.ErrCodeBeforeSignup
+)
+
+// Constants for validation limits
+const (
+	MaxHumanAge     = core.MaxHumanAge
+	MinCertAge      = core.MinCertAge
+	MaxHistoryYears = core.MaxHistoryYears
+)
+
+// ValidateEntityDate validates a date for a user entity (certification, training, etc.)
+func ValidateEntityDate(user *User, entityDate time.Time, entityType string, opts ...Option) error {
+	return core.ValidateEntityDate(user, entityDate, entityType, opts...)
+}
+
+// HistoryCutoff returns the earliest date accepted by the historical realism
+// check at the current time. Dates strictly before the cutoff are reported
+// with ErrCodeDateTooOld; the cutoff itself is valid.
+func HistoryCutoff(opts ...Option) time.Time {
+	return core.HistoryCutoff(opts...)
+}
+
+// ValidateCertification validates a certification date for a user
+func ValidateCertification(user *User, certDate time.Time, opts ...Option) error {
+	return core.ValidateCertification(user, certDate, opts...)
+}
+
+// ValidateTraining validates a training date for a user
+func ValidateTraining(user *User, trainingDate time.Time, opts ...Option) error {
+	return core.ValidateTraining(user, trainingDate, opts...)
+}
+
+// ValidateEducation validates an education date for a user
+func ValidateEducation(user *User, educationDate time.Time, opts ...Option) error {
+	return core.ValidateEducation(user, educationDate, opts...)
+}
+
+// ValidateEmployment validates an employment date for a user
+func ValidateEmployment(user *User, employmentDate time.Time, opts ...Option) error {
+	return core.ValidateEmployment(user, employmentDate, opts...)
+}
+
+// ValidateLicense validates a license date for a user
+func ValidateLicense(user *User, licenseDate time.Time, opts ...Option) error {
+	return core.ValidateLicense(user, licenseDate, opts...)
+}
+
+// NewUser creates a new User with validation
+func NewUser(id string, birthDate time.Time, name string, opts ...Option) (*User, error) {
+	return core.NewUser(id, birthDate, name, opts...)
+}
+
+// WithLocale writes the messages of validation errors in the given locale,
+// see Locales, with dates in the conventions of the locale, such as
+// "5 mars 2024" for fr or "05.03.2024" for de. Codes and structured fields
+// are not affected and keep ISO dates.
+func WithLocale(locale string) Option {
+	return core.WithLocale(locale)
+}
+
+// MessageCatalog holds the messages of a locale. Its JSON form is the
+// format of the built-in catalogs in the locales directory:
+//
+//	{
+//	  "locale": "fr",
+//	  "entity_types": {"training": "formation"},
+//	  "messages": {"FUTURE_DATE.entity": "{type} : la date ({date}) ne peut pas être dans le futur"}
+//	}
+//
+// Message keys are an error code and a variant. Messages use the ICU
+// MessageFormat syntax: {name} is replaced with an argument of the error,
+// and numbers such as ages can select plural forms with the plural rules of
+// the locale:
+//
+//	"({age, plural, one {# an} other {# ans}})"
+//
+// Dates use the 2006-01-02 format by default and the conventions of the
+// locale asked for with WithLocale or Localize, which {date, date, long}
+// arguments can refine. Entity types missing from entity_types and messages
+// missing from messages are written in English.
+type MessageCatalog = core.MessageCatalog
+
+// RegisterMessageCatalog adds a catalog in the JSON format described on
+// MessageCatalog, replacing any catalog of the same locale. It is meant to
+// be called during program initialization and is safe for concurrent use.
+func RegisterMessageCatalog(data []byte) error {
+	return core.RegisterMessageCatalog(data)
+}
+
+// Locales returns the locales that have a message catalog, sorted
+func Locales() []string {
+	return core.Locales()
+}
+
+// MissingMessages returns the keys of the English catalog that the catalog
+// of the locale does not translate, sorted. It is nil for complete catalogs
+// and for locales without a catalog.
+func MissingMessages(locale string) []string {
+	return core.MissingMessages(locale)
+}
+
+// Option configures how dates are validated. Options can be passed to any
+// validation function to override the package defaults for that call.
+type Option = core.Option
+
+// MinYear is the earliest year accepted for birth and entity dates by default
+const MinYear = core.MinYear
+
+// Precision controls how finely dates are compared with each other.
+type Precision = core.Precision
+
+const (
+	// PrecisionInstant compares exact instants, including time of day and
+	// zone offset. It is the default.
+	PrecisionInstant = core.PrecisionInstant
+	// PrecisionDate compares calendar dates as written in their own
+	// location, ignoring time of day and zone offset. A timestamp of
+	// 2020-03-10T23:59+14:00 is treated as March 10, and "today" is the
+	// calendar date of the clock's current time.
+	PrecisionDate = core.PrecisionDate
+)
+
+// WithClock sets the function used to obtain the current time. It is the
+// reference for future-date checks, current ages and historical limits.
+func WithClock(now func() time.Time) Option {
+	return core.WithClock(now)
+}
+
+// WithFixedNow pins the current time to t. It is mainly intended for tests
+// and examples whose expectations would otherwise drift as time passes.
+func WithFixedNow(t time.Time) Option {
+	return core.WithFixedNow(t)
+}
+
+// WithLegacyAgeCalc restores the day-of-year age calculation used by earlier
+// releases. It is off by default because it is off by one day around leap
+// years and for people born on February 29; use it only when results must
+// match records produced by those releases.
+func WithLegacyAgeCalc() Option {
+	return core.WithLegacyAgeCalc()
+}
+
+// WithPrecision sets how dates are compared. The precision applies uniformly
+// to every rule: birth date checks, before-birth and future checks, and ages.
+func WithPrecision(p Precision) Option {
+	return core.WithPrecision(p)
+}
+
+// WithMaxHistory sets how far back in time a date may be, as an exact
+// duration before now. By default the limit is MaxHistoryYears calendar
+// years, so the cutoff falls on the same month and day as today.
+func WithMaxHistory(d time.Duration) Option {
+	return core.WithMaxHistory(d)
+}
+
+// WithMinBirthDate sets the earliest accepted birth date. Earlier birth dates
+// are reported with ErrCodeDateTooOld. The default is January 1 of MinYear;
+// a workforce product might use 1900 while genealogy data needs 1850 or less.
+func WithMinBirthDate(t time.Time) Option {
+	return core.WithMinBirthDate(t)
+}
+
+// WithMinEntityDate sets the earliest accepted entity date, independently of
+// the birth date floor. Earlier entity dates are reported with
+// ErrCodeDateTooOld. The default is January 1 of MinYear.
+func WithMinEntityDate(t time.Time) Option {
+	return core.WithMinEntityDate(t)
+}
+
+// WithSameDayBirth sets whether entities of the given types may be dated on
+// the user's birth date, as birth certificates or newborn screenings are.
+// When no types are given it sets the default for all types not configured
+// explicitly. Same-day entities are allowed by default; disallowed ones are
+// reported with ErrCodeBeforeBirth.
+func WithSameDayBirth(allowed bool, entityTypes ...string) Option {
+	return core.WithSameDayBirth(allowed, entityTypes...)
+}
+
+// WithPrenatalWindow lets entities of the given types, such as prenatal
+// screening records, be dated up to window before the user's birth. Such
+// dates produce an ErrCodePrenatal warning instead of ErrCodeBeforeBirth;
+// earlier dates are still rejected.
+func WithPrenatalWindow(window time.Duration, entityTypes ...string) Option {
+	return core.WithPrenatalWindow(window, entityTypes...)
+}
+
+// WithValidityPeriod sets how long entities of the given type stay valid
+// after issuance or renewal, overriding the built-in catalog. A zero period
+// disables expiry checks for the type.
+func WithValidityPeriod(entityType string, period ValidityPeriod) Option {
+	return core.WithValidityPeriod(entityType, period)
+}
+
+// WithMinimumAge sets the minimum age of users on the date of entities of
+// the given type, overriding the built-in minimum. Zero removes the minimum.
+func WithMinimumAge(entityType string, age int) Option {
+	return core.WithMinimumAge(entityType, age)
+}
+
+// WithMaximumAge sets the maximum age of users on the date of entities of
+// the given type, such as the latest age to start kindergarten, overriding
+// the built-in maximum. Zero removes the maximum.
+func WithMaximumAge(entityType string, age int) Option {
+	return core.WithMaximumAge(entityType, age)
+}
+
+// WithAgeWarnings reports users younger than the minimum age, or older than
+// the maximum age, of entities of the given types as UNREALISTIC_AGE
+// warnings instead of errors, for types such as volunteer work where the
+// age floors are guidance rather than rules.
+func WithAgeWarnings(entityTypes ...string) Option {
+	return core.WithAgeWarnings(entityTypes...)
+}
+
+// WithGuardianAge sets the minimum age of users on the date of entities of
+// the given type held jointly with a guardian, see Entity.Guardian, such as
+// 7 for children's bank accounts. Zero allows any age, and a negative age
+// removes the allowance so the minimum age of the type applies.
+func WithGuardianAge(entityType string, age int) Option {
+	return core.WithGuardianAge(entityType, age)
+}
+
+// WithChildValidity sets the validity of documents of the given type, such
+// as passports, issued to users younger than underAge, overriding the
+// built-in rules. A zero underAge removes the shorter validity, so the
+// validity period of the type applies at every age.
+func WithChildValidity(entityType string, underAge int, validity ValidityPeriod) Option {
+	return core.WithChildValidity(entityType, underAge, validity)
+}
+
+// WithMaxDuration sets the usual longest duration of entities of the given
+// type, from their date to their end date or to now while ongoing. Longer
+// entities get an IMPLAUSIBLE_DURATION warning. A zero period disables the
+// check for the type.
+func WithMaxDuration(entityType string, maxDuration ValidityPeriod) Option {
+	return core.WithMaxDuration(entityType, maxDuration)
+}
+
+// WithCatalog looks up the validity periods of entity types that have no
+// built-in or WithValidityPeriod period in cat
+func WithCatalog(cat *Catalog) Option {
+	return core.WithCatalog(cat)
+}
+
+// DefaultTwoDigitYearPivot is the default of WithTwoDigitYearPivot, as in
+// POSIX strptime and the time package: 69 to 99 are in the 1900s, 00 to 68
+// in the 2000s
+const DefaultTwoDigitYearPivot = core.DefaultTwoDigitYearPivot
+
+// WithTwoDigitYearPivot sets how ParseDate completes two-digit years: years
+// below pivot are in the 2000s, the others in the 1900s. With a pivot of 30,
+// "15-05-29" is 2029 and "15-05-90" is 1990. The default is
+// DefaultTwoDigitYearPivot.
+func WithTwoDigitYearPivot(pivot int) Option {
+	return core.WithTwoDigitYearPivot(pivot)
+}
+
+// WithRollingYearPivot completes two-digit years relative to the current
+// year instead of a fixed pivot: a two-digit year is the latest year ending
+// with its digits that is at most ahead years after the current year. With
+// ahead 1 in 2026, "15-05-27" is 2027 and "15-05-28" is 1928. Unlike a fixed
+// pivot, it does not age as the years pass.
+func WithRollingYearPivot(ahead int) Option {
+	return core.WithRollingYearPivot(ahead)
+}
+
+// ParseDate parses a date in one of the encodings of spreadsheets and legacy
+// exports, so that it can be validated:
+//   - 2006-01-02 and RFC 3339 timestamps
+//   - YYYYMMDD integers, such as 20060102
+//   - Excel serial numbers of the 1900 date system, such as 38719 or
+//     38719.5 for noon, rejecting the nonexistent 1900-02-29 (serial 60)
+//   - day-first dates with -, / or . separators and four or two-digit
+//     years, such as 02/01/2006 or 02.01.06; see WithTwoDigitYearPivot
+//
+// Dates without a zone are in UTC. Other input is reported with
+// ErrCodeInvalidDate.
+func ParseDate(s string, opts ...Option) (time.Time, error) {
+	return core.ParseDate(s, opts...)
+}
+
+// ParseDateWarnings is like ParseDate, but also returns the assumptions made
+// as warnings, so that ingestion of old records is explicit: a two-digit
+// year gives an ErrCodeTwoDigitYear warning naming the year it was read as.
+func ParseDateWarnings(s string, opts ...Option) (time.Time, []*DateValidationError, error) {
+	return core.ParseDateWarnings(s, opts...)
+}
+
+// PayloadPaths locates the user and the entity of a validation in the
+// caller's payload, as JSON pointers
+type PayloadPaths = core.PayloadPaths
+
+// DefaultPlaceholderDates returns the dates reported with
+// ErrCodePlaceholderDate by default: 0001-01-01, 1900-01-01 and 1970-01-01
+func DefaultPlaceholderDates() []time.Time {
+	return core.DefaultPlaceholderDates()
+}
+
+// WithPlaceholderDates replaces the dates that stand for a missing date,
+// such as the default of a form or of a database column. Birth, death and
+// entity dates on them are reported with ErrCodePlaceholderDate instead of
+// the rule they would happen to break. Dates that pass every rule are
+// still valid, with an ErrCodePlaceholderDate warning in reports. Call it
+// without dates to accept them like any other date.
+func WithPlaceholderDates(dates ...time.Time) Option {
+	return core.WithPlaceholderDates(dates...)
+}
+
+// DatePrecision is how precisely an entity date is known. Dates of month
+// or year precision hold the first day of their month or year.
+type DatePrecision = core.DatePrecision
+
+// Date precisions
+const (
+	DatePrecisionDay         = core.DatePrecisionDay
+	DatePrecisionMonth       = core.DatePrecisionMonth
+	DatePrecisionYear        = core.DatePrecisionYear
+	DatePrecisionApproximate = core.DatePrecisionApproximate
+)
+
+// DefaultUncertainty is the radius of approximate dates without an
+// Entity.Uncertainty
+//
+// It is a copy: assign core.DefaultUncertainty to change validation.
+var DefaultUncertainty = core.DefaultUncertainty
+
+// Uncertainty is the radius of an approximate date, such as a year for
+// "circa 1890", in calendar years, months and days
+type Uncertainty = core.Uncertainty
+
+// ParseUncertainty parses an uncertainty written as by String, such as
+// "1y", "6m" or "1y6m15d"
+func ParseUncertainty(s string) (Uncertainty, error) {
+	return core.ParseUncertainty(s)
+}
+
+// Profile is a user together with their dated entities
+type Profile = core.Profile
+
+// ProfileReport holds the reports of a profile validation. The findings
+// carry the JSON pointer of the offending value in the profile, such as
+// /entities/2/date or /user/birth_date.
+type ProfileReport = core.ProfileReport
+
+// ValidateProfile checks every entity of a profile
+func ValidateProfile(profile Profile, opts ...Option) *ProfileReport {
+	return core.ValidateProfile(profile, opts...)
+}
+
+// ValidateProfileWithDeadline checks the entities of a profile in order
+// until ctx is done. Instead of blocking past the deadline or returning
+// nothing, it then returns the reports gathered so far with Incomplete set.
+// Rules run in the configured order, so combine it with
+// WithRuleOrder(OrderSeverityFirst) or OrderCheapestFirst to decide which
+// rules get the time budget first.
+func ValidateProfileWithDeadline(ctx context.Context, profile Profile, opts ...Option) *ProfileReport {
+	return core.ValidateProfileWithDeadline(ctx, profile, opts...)
+}
+
+// Reason is a fact a finding derives from, such as the birth date of the
+// user, the date of the entity or the minimum age of its type. Values are
+// not localized and keep ISO dates.
+type Reason = core.Reason
+
+// DefaultJuvenileAge is the age under which records are juvenile records,
+// see WithJuvenileAge
+const DefaultJuvenileAge = core.DefaultJuvenileAge
+
+// WithJuvenileAge sets the age of the user under which criminal records
+// and court dates are juvenile records, DefaultJuvenileAge by default, for
+// jurisdictions that try older minors as adults.
+func WithJuvenileAge(age int) Option {
+	return core.WithJuvenileAge(age)
+}
+
+// Reject is a batch item that failed validation, stored with its report so
+// it can be reprocessed once the cause is fixed
+type Reject = core.Reject
+
+// RejectWriter persists rejected batch items
+type RejectWriter = core.RejectWriter
+
+// WithRejectWriter makes batch validation write every item that fails to w
+func WithRejectWriter(w RejectWriter) Option {
+	return core.WithRejectWriter(w)
+}
+
+// FileRejectWriter writes rejects to a JSON Lines file, one reject per line,
+// which ReadRejects and Replay read back. It is safe for concurrent use
+// within a process.
+type FileRejectWriter = core.FileRejectWriter
+
+// NewFileRejectWriter returns a writer appending to the file at path, which
+// is created on the first write if it does not exist
+func NewFileRejectWriter(path string) *FileRejectWriter {
+	return core.NewFileRejectWriter(path)
+}
+
+// ReadRejects reads the rejects stored in a file written by FileRejectWriter
+func ReadRejects(path string) ([]Reject, error) {
+	return core.ReadRejects(path)
+}
+
+// Replay validates again the items of a rejects file, typically after the
+// data or the rules were fixed. Items that still fail are written to the
+// reject writer of opts, if any.
+func Replay(path string, opts ...Option) (*BatchResult, error) {
+	return core.Replay(path, opts...)
+}
+
+// Severity ranks how serious a validation finding is
+type Severity = core.Severity
+
+const (
+	// SeverityError marks a finding that makes a date invalid. It is the zero
+	// value, so a DateValidationError without an explicit severity is a hard
+	// failure.
+	SeverityError = core.SeverityError
+	// SeverityWarning marks a date that is unusual but still accepted
+	SeverityWarning = core.SeverityWarning
+	// SeverityInfo marks an informational note that needs no action, such
+	// as a gap in a profile the caller explained
+	SeverityInfo = core.SeverityInfo
+	// SeverityNone means there is no finding at all. It only appears in
+	// summaries such as BatchResult.Worst.
+	SeverityNone = core.SeverityNone
+)
+
+// Report collects the findings of a validation, including warnings that do
+// not make the date invalid
+type Report = core.Report
+
+// RuleVersion identifies the revision of the validation rules. It changes
+// whenever a rule change can alter the outcome for the same inputs.
+const RuleVersion = core.RuleVersion
+
+// CheckEntityDate validates a date for a user entity like ValidateEntityDate,
+// but returns a report holding warnings as well as the error, if any
+func CheckEntityDate(user *User, entityDate time.Time, entityType string, opts ...Option) *Report {
+	return core.CheckEntityDate(user, entityDate, entityType, opts...)
+}
+
+// WithReportPool takes reports from a sync.Pool instead of allocating them,
+// for services running enough validations that report garbage matters. The
+// caller must call Release on every report it receives once done with it,
+// and must not use the report afterwards. The findings themselves are not
+// recycled, so errors returned by Err stay valid after Release. Reports
+// kept by the audit log are copies and are not affected.
+func WithReportPool() Option {
+	return core.WithReportPool()
+}
+
+// Errors returned by remote calls guarded by a ResiliencePolicy
+var (
+	ErrCircuitOpen = core.ErrCircuitOpen
+	ErrRateLimited = core.ErrRateLimited
+)
+
+// ResiliencePolicy bounds the cost of calls to remote providers so that a
+// slow or failing service cannot stall validation. Zero fields disable the
+// corresponding protection.
+type ResiliencePolicy = core.ResiliencePolicy
+
+// ResilientRevocationChecker guards a remote RevocationChecker with a
+// ResiliencePolicy. When a lookup fails, times out, is rate limited or the
+// circuit is open, it falls back to the local Fallback checker if one is
+// set, and otherwise returns the error (reported as REVOCATION_UNKNOWN).
+type ResilientRevocationChecker = core.ResilientRevocationChecker
+
+// NewResilientRevocationChecker wraps next with policy. fallback may be nil.
+func NewResilientRevocationChecker(next RevocationChecker, policy ResiliencePolicy, fallback RevocationChecker) *ResilientRevocationChecker {
+	return core.NewResilientRevocationChecker(next, policy, fallback)
+}
+
+// ResilientJurisdictionResolver guards a remote JurisdictionResolver, the
+// provider of the rules of each entity, with a ResiliencePolicy. When a
+// lookup fails it falls back to the local Fallback resolver if one is set,
+// such as StaticJurisdictions, and otherwise returns the error, which
+// keeps the configured ages and is reported as JURISDICTION_UNKNOWN.
+type ResilientJurisdictionResolver = core.ResilientJurisdictionResolver
+
+// NewResilientJurisdictionResolver wraps next with policy. fallback may be
+// nil.
+func NewResilientJurisdictionResolver(next JurisdictionResolver, policy ResiliencePolicy, fallback JurisdictionResolver) *ResilientJurisdictionResolver {
+	return core.NewResilientJurisdictionResolver(next, policy, fallback)
+}
+
+// ResilientReviewSink guards a remote ReviewSink, such as the adapter of a
+// ticketing system, with a ResiliencePolicy. When a review cannot be sent
+// it goes to the local Fallback sink if one is set, such as a
+// ChanReviewSink drained later, and otherwise the error is reported as
+// REVIEW_FAILED. A closed sink is not retried.
+type ResilientReviewSink = core.ResilientReviewSink
+
+// NewResilientReviewSink wraps next with policy. fallback may be nil.
+func NewResilientReviewSink(next ReviewSink, policy ResiliencePolicy, fallback ReviewSink) *ResilientReviewSink {
+	return core.NewResilientReviewSink(next, policy, fallback)
+}
+
+// ResultCache stores the reports of entity date validations by key. Caches
+// keep reports for a time and may evict them earlier. Implementations must
+// be safe for concurrent use.
+type ResultCache = core.ResultCache
+
+// WithResultCache reuses the reports of earlier validations of the same
+// entity date for ttl instead of evaluating the rules again, as historical
+// records seldom change. Reports are keyed by the HashInputs of the user
+// ID, birth and death dates, entity date and type and RuleVersion, and by
+// the ConfigSnapshot hash of the options, so a change of any of them misses
+// the cache. Cached reports keep the CheckedAt of their evaluation, and
+// still go to the review sink, webhook, audit log and stats. Reports whose
+// jurisdiction or revocation lookup failed are not cached, nor are the
+// reports of calls with a reference time or call options from their
+// context. Entity checks such as CheckEntity, which depend on more than
+// the date, are not cached.
+func WithResultCache(cache ResultCache, ttl time.Duration) Option {
+	return core.WithResultCache(cache, ttl)
+}
+
+// MemoryResultCache is an in-process ResultCache. Expired reports are
+// dropped when they are looked up.
+type MemoryResultCache = core.MemoryResultCache
+
+// ResultCacheStats counts the lookups of a MemoryResultCache
+type ResultCacheStats = core.ResultCacheStats
+
+// NewMemoryResultCache returns an empty cache
+func NewMemoryResultCache() *MemoryResultCache {
+	return core.NewMemoryResultCache()
+}
+
+// ResultFormat is the file format of a ResultWriter
+type ResultFormat = core.ResultFormat
+
+// Result formats
+const (
+	FormatJSONL = core.FormatJSONL
+	FormatCSV   = core.FormatCSV
+)
+
+// ParseResultFormat returns the format named by s, "jsonl" or "csv"
+func ParseResultFormat(s string) (ResultFormat, error) {
+	return core.ParseResultFormat(s)
+}
+
+// ResultWriterConfig tunes a ResultWriter
+type ResultWriterConfig = core.ResultWriterConfig
+
+// ResultWriter streams validation results to a series of files, starting a
+// new file when the current one grows too large or too old, so that long
+// running jobs do not produce one huge file. Files are named after a prefix
+// and a sequence number, such as results-000001.jsonl.gz. A ResultWriter is
+// safe for concurrent use.
+type ResultWriter = core.ResultWriter
+
+// NewResultWriter returns a writer creating its files at prefix followed by
+// a sequence number and the format extension. The first file is created on
+// the first write. Existing files are never overwritten; writing fails
+// instead.
+func NewResultWriter(prefix string, cfg ResultWriterConfig) *ResultWriter {
+	return core.NewResultWriter(prefix, cfg)
+}
+
+// Errors of ChanReviewSink
+var (
+	ErrReviewQueueFull  = core.ErrReviewQueueFull
+	ErrReviewSinkClosed = core.ErrReviewSinkClosed
+)
+
+// Review is a validation that needs a human decision, as sent to a
+// ReviewSink
+type Review = core.Review
+
+// ReviewSink receives the validations to review manually: reports that
+// pass with warnings and reports with VerdictIndeterminate. Failed reports
+// are not sent, their verdict is definite.
+type ReviewSink = core.ReviewSink
+
+// WithReviewSink sends the validations needing manual review to s, such as
+// a ChanReviewSink or an adapter for a ticketing system. Reviews hold a
+// copy of the report. When s fails, the report gets a REVIEW_FAILED
+// warning.
+func WithReviewSink(s ReviewSink) Option {
+	return core.WithReviewSink(s)
+}
+
+// ChanReviewSink is an in-process ReviewSink that queues reviews on a
+// buffered channel, for review tooling running in the same process. It
+// never blocks validation: reviews sent while the buffer is full fail with
+// ErrReviewQueueFull.
+type ChanReviewSink = core.ChanReviewSink
+
+// NewChanReviewSink returns a sink queuing up to size reviews
+func NewChanReviewSink(size int) *ChanReviewSink {
+	return core.NewChanReviewSink(size)
+}
+
+// RevocationChecker reports whether an issuer has revoked a credential. It is
+// consulted for entities that carry an ID, so that revoked credentials fail
+// even when their dates are plausible. asOf is the validation's reference
+// time; revocations after it are not taken into account.
+type RevocationChecker = core.RevocationChecker
+
+// MemoryRevocationList is an in-memory RevocationChecker. It is safe for
+// concurrent use.
+type MemoryRevocationList = core.MemoryRevocationList
+
+// NewMemoryRevocationList creates an empty revocation list
+func NewMemoryRevocationList() *MemoryRevocationList {
+	return core.NewMemoryRevocationList()
+}
+
+// CachingRevocationChecker caches the answers of another RevocationChecker
+// for a fixed time. An answer is reused for later reference times only as
+// long as it holds: a revocation for any later time, and a credential not
+// revoked for a reference time up to the same fixed time later, so that an
+// answer cached for an old reference time is not given for a recent one.
+// Failed lookups are not cached. It is safe for concurrent use.
+type CachingRevocationChecker = core.CachingRevocationChecker
+
+// NewCachingRevocationChecker wraps next with a cache keeping answers for ttl
+func NewCachingRevocationChecker(next RevocationChecker, ttl time.Duration) *CachingRevocationChecker {
+	return core.NewCachingRevocationChecker(next, ttl)
+}
+
+// WithRevocationChecker sets the checker consulted for entities with an ID
+func WithRevocationChecker(rc RevocationChecker) Option {
+	return core.WithRevocationChecker(rc)
+}
+
+// RuleOrder is the order in which validation rules are evaluated
+type RuleOrder = core.RuleOrder
+
+// Rule orders
+const (
+	OrderDeclaration   = core.OrderDeclaration
+	OrderCheapestFirst = core.OrderCheapestFirst
+	OrderSeverityFirst = core.OrderSeverityFirst
+)
+
+// WithRuleOrder sets the order in which rules are evaluated. The input
+// checks (nil user, invalid birth, death or entity date) always run first
+// and stop evaluation, since no other rule is meaningful without them.
+func WithRuleOrder(order RuleOrder) Option {
+	return core.WithRuleOrder(order)
+}
+
+// WithShortCircuit sets whether evaluation stops at the first error, which
+// is the default. With short-circuiting disabled, reports hold every error
+// found; the functions returning an error still return the first one.
+func WithShortCircuit(enabled bool) Option {
+	return core.WithShortCircuit(enabled)
+}
+
+// RuleConfig describes the validation rules in a form that can be written
+// to and read from a configuration file, see WriteYAML and ParseRuleConfig.
+// Apply it with WithRules.
+type RuleConfig = core.RuleConfig
+
+// EffectiveRules returns the rules that validations with opts apply
+func EffectiveRules(opts ...Option) RuleConfig {
+	return core.EffectiveRules(opts...)
+}
+
+// WithRules applies a rule configuration, replacing the rule settings of
+// earlier options
+func WithRules(r RuleConfig) Option {
+	return core.WithRules(r)
+}
+
+// Presets returns the names of the rule presets, sorted
+func Presets() []string {
+	return core.Presets()
+}
+
+// Jurisdictions returns the codes of the countries with built-in rules,
+// sorted
+func Jurisdictions() []string {
+	return core.Jurisdictions()
+}
+
+// Subdivisions returns the ISO 3166-2 codes of the subdivisions of a
+// country with rules of their own, such as US-CA, sorted. Other
+// subdivisions of the country have the country's rules.
+func Subdivisions(country string) []string {
+	return core.Subdivisions(country)
+}
+
+// NewRuleConfig returns the default rules adjusted by a preset, see Presets,
+// and by the rules of a jurisdiction, see Jurisdictions. The jurisdiction
+// may be a subdivision such as US-CA, see Subdivisions; subdivisions without
+// rules of their own fall back to those of their country. An empty preset is
+// the default one and an empty jurisdiction keeps the default rules.
+func NewRuleConfig(jurisdiction, preset string) (RuleConfig, error) {
+	return core.NewRuleConfig(jurisdiction, preset)
+}
+
+// RuleSchemaVersion is the version of the rules file format that WriteYAML
+// writes as schema_version. Files without one are version 1.
+const RuleSchemaVersion = core.RuleSchemaVersion
+
+// ParseRuleConfig reads rules written by WriteYAML. It accepts the subset of
+// YAML that WriteYAML uses: comments, scalars and one level of mappings.
+// Settings left out keep the library defaults; mappings replace the
+// defaults as a whole. Files of older schema versions are migrated, see
+// ParseRuleConfigWarnings.
+func ParseRuleConfig(data []byte) (RuleConfig, error) {
+	return core.ParseRuleConfig(data)
+}
+
+// ParseRuleConfigWarnings is like ParseRuleConfig, and also returns a
+// warning for each deprecated setting the file uses, such as a setting
+// renamed since the file's schema version
+func ParseRuleConfigWarnings(data []byte) (RuleConfig, []ConfigIssue, error) {
+	return core.ParseRuleConfigWarnings(data)
+}
+
+// ErrNilValue is returned by methods called on a nil value, or given a nil
+// value they cannot do without, instead of panicking
+var ErrNilValue = core.ErrNilValue
+
+// SafeValidator is a Validator that never panics. A panic in a rule, such
+// as one in a RevocationChecker, gives the report a RULE_PANIC error
+// naming the rule, and evaluation goes on with the next rule as after any
+// error. A panic elsewhere, such as in a JurisdictionResolver or a sink,
+// gives a report holding a single RULE_PANIC error. A SafeValidator is
+// safe for concurrent use.
+type SafeValidator = core.SafeValidator
+
+// NewSafeValidator returns a SafeValidator with the settings of v, or the
+// package defaults when v is nil
+func NewSafeValidator(v *Validator) *SafeValidator {
+	return core.NewSafeValidator(v)
+}
+
+// WithSampledRule runs an expensive rule, such as the remote "revocation"
+// lookup, for only percent of the validations, so that it does not drive
+// the tail latency. The rule is named as in the steps of Explain. It always
+// runs when an earlier rule found a warning or an error, where the signal
+// matters most. Whether a validation is sampled depends on its inputs, so
+// the same record is always sampled or always skipped, and cached or
+// replayed reports agree. Skipped rules are listed in Report.Sampled and
+// count as passed in the verdict. Percents of 100 or more run the rule
+// every time; unknown rule names are ignored.
+func WithSampledRule(rule string, percent float64) Option {
+	return core.WithSampledRule(rule, percent)
+}
+
+// DefaultScopeAge is the age from which records count in a background
+// check, see ScopeWindow
+const DefaultScopeAge = core.DefaultScopeAge
+
+// WithScopeAge sets the age of the user from which records count in a
+// background check, DefaultScopeAge by default. Zero counts records from
+// birth.
+func WithScopeAge(age int) Option {
+	return core.WithScopeAge(age)
+}
+
+// ScopeWindow returns the lookback window of a background check of user:
+// from the later of the user's birthday at the scope age, see WithScopeAge,
+// and years before now, to now or the user's death. Years of zero or less
+// do not cap the window. The window is empty, from after to, when the user
+// has not reached the scope age.
+func ScopeWindow(user *User, years int, opts ...Option) (from, to time.Time) {
+	return core.ScopeWindow(user, years, opts...)
+}
+
+// FilterScope splits the entities of user between those a background check
+// of the last years may consider, see ScopeWindow, and those outside its
+// scope, which must be left out rather than reported as invalid. An entity
+// is in scope when any day from its date to its end date falls within the
+// window; imprecise dates count every day they stand for. Entities without
+// a date stay in scope, so their validation reports them.
+func FilterScope(user *User, entities []Entity, years int, opts ...Option) (inScope, outOfScope []Entity) {
+	return core.FilterScope(user, entities, years, opts...)
+}
+
+// SealedUser is a validated user bound to a validator's settings and to the
+// reference time at sealing. It caches, per entity type, the eligibility
+// window of dates that pass every entity date rule, so checking a date
+// inside the window takes two comparisons. Dates outside the window and
+// placeholder dates are checked in full, so results match
+// ValidateEntityDate at the sealing time.
+// Seal again to move the reference time. A SealedUser is safe for
+// concurrent use.
+type SealedUser = core.SealedUser
+
+// WindowCacheStats describes the use of a sealed user's window cache
+type WindowCacheStats = core.WindowCacheStats
+
+// Seal validates a user and binds it to the given options and the current
+// time for repeated entity date checks
+func Seal(user *User, opts ...Option) (*SealedUser, error) {
+	return core.Seal(user, opts...)
+}
+
+// Shard identifies one of Count deterministic partitions of the users of a
+// dataset. Users are assigned to shards by a hash of their ID, so separate
+// runs of the same dataset on different machines, each with its own Index,
+// cover every user exactly once.
+type Shard = core.Shard
+
+// ShardOf returns the shard of a user among count shards. It uses the
+// 64-bit FNV-1a hash of the ID, which is stable across processes, machines
+// and releases.
+func ShardOf(userID string, count int) int {
+	return core.ShardOf(userID, count)
+}
+
+// PartitionItems splits items into count shards by user, keeping the input
+// order within each shard. Items without a user go to the shard of the
+// empty ID.
+func PartitionItems(items []Item, count int) [][]Item {
+	return core.PartitionItems(items, count)
+}
+
+// WithShard restricts batch and bulk validation to the users of shard index
+// among count shards, with index in [0, count). Items of other users are
+// skipped, and results are tagged with the shard.
+func WithShard(index, count int) Option {
+	return core.WithShard(index, count)
+}
+
+// ErrInvalidSignature is returned by VerifyReport when a report's signature
+// is missing or does not match its contents
+var ErrInvalidSignature = core.ErrInvalidSignature
+
+// VerifyReport checks that the report was signed with key and has not been
+// modified since. It returns ErrInvalidSignature if the check fails.
+func VerifyReport(key []byte, report *Report) error {
+	return core.VerifyReport(key, report)
+}
+
+// ValidateSigningWindow checks the issuance date of a digitally signed
+// credential. The date must fall inside the signing certificate's validity
+// window [notBefore, notAfter] and pass the usual entity date checks against
+// the user's lifetime. All failures are reported together, joined with
+// errors.Join, so each can be inspected with errors.As.
+func ValidateSigningWindow(user *User, issued time.Time, entityType string, notBefore, notAfter time.Time, opts ...Option) error {
+	return core.ValidateSigningWindow(user, issued, entityType, notBefore, notAfter, opts...)
+}
+
+// ValidateSignedEntity is like ValidateSigningWindow, taking the window from
+// the certificate that signed the entity
+func ValidateSignedEntity(user *User, entity Entity, cert *x509.Certificate, opts ...Option) error {
+	return core.ValidateSignedEntity(user, entity, cert, opts...)
+}
+
+// ConfigSnapshot is the effective configuration of a Validator: its rule
+// thresholds, the versions of the datasets it reads and the features it
+// enables. It is a copy, so changing it changes nothing.
+type ConfigSnapshot = core.ConfigSnapshot
+
+// DataVersions identify the datasets of a configuration by the SHA-256 of
+// their content in hex, empty when there is none
+type DataVersions = core.DataVersions
+
+// Features are the optional behaviors a configuration enables
+type Features = core.Features
+
+// AgeCategory is the age range of a competition category. A zero MaxAge
+// leaves the range open, such as masters from 40.
+type AgeCategory = core.AgeCategory
+
+// ParseAgeCategory parses an age category: "U18" for users under 18, "40+"
+// for users of 40 or older, or a range such as "18-34"
+func ParseAgeCategory(s string) (AgeCategory, error) {
+	return core.ParseAgeCategory(s)
+}
+
+// WithAgeCategory names an age category, such as "veterans" for
+// AgeCategory{MinAge: 50}, for entities whose Category uses the name
+func WithAgeCategory(name string, category AgeCategory) Option {
+	return core.WithAgeCategory(name, category)
+}
+
+// Stats counts validation outcomes. It is safe for concurrent use.
+type Stats = core.Stats
+
+// StatsSnapshot is a point-in-time copy of Stats
+type StatsSnapshot = core.StatsSnapshot
+
+// RuleTiming is the time spent evaluating a rule
+type RuleTiming = core.RuleTiming
+
+// NewStats creates an empty stats collector
+func NewStats() *Stats {
+	return core.NewStats()
+}
+
+// WithStats counts the outcome of every validation in s
+func WithStats(s *Stats) Option {
+	return core.WithStats(s)
+}
+
+// WithRuleProfiling times every rule evaluation and records the timings in
+// the Stats given with WithStats, where snapshots rank the rules by the
+// total time spent in them. It has no effect without WithStats.
+func WithRuleProfiling() Option {
+	return core.WithRuleProfiling()
+}
+
+// AuditStore persists the records of an audit log
+type AuditStore = core.AuditStore
+
+// StatsStore persists stats snapshots
+type StatsStore = core.StatsStore
+
+// FileAuditStore stores audit records in a JSON Lines file, one record per
+// line. It is safe for concurrent use within a process.
+type FileAuditStore = core.FileAuditStore
+
+// NewFileAuditStore returns a store using the file at path, which is created
+// on the first append if it does not exist
+func NewFileAuditStore(path string) *FileAuditStore {
+	return core.NewFileAuditStore(path)
+}
+
+// FileStatsStore stores stats snapshots in a JSON Lines file, one snapshot
+// per line. It is safe for concurrent use within a process.
+type FileStatsStore = core.FileStatsStore
+
+// NewFileStatsStore returns a store using the file at path, which is created
+// on the first save if it does not exist
+func NewFileStatsStore(path string) *FileStatsStore {
+	return core.NewFileStatsStore(path)
+}
+
+// SQLStore stores audit records and stats snapshots through database/sql.
+// Each value is kept as a JSON document next to its ordering key, so the
+// schema works on any SQL database; see CreateTables. The caller provides
+// the driver.
+type SQLStore = core.SQLStore
+
+// NewSQLStore returns a store using db with the default table names
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return core.NewSQLStore(db)
+}
+
+// WithSwapDetection enables the day_month_swap rule. It warns with
+// ErrCodeSwappedDate about entity dates that are rejected as before birth,
+// in the future or below the minimum age, but that would pass with day and
+// month swapped, as when a DD/MM date was read as MM/DD. The warning
+// suggests the swapped date; the errors of the other rules are still
+// reported. Only dates whose day is 12 or less can be swapped.
+func WithSwapDetection() Option {
+	return core.WithSwapDetection()
+}
+
+// ErrUnknownTenant is returned for tenants that are not registered
+var ErrUnknownTenant = core.ErrUnknownTenant
+
+// TenantRegistry holds an isolated validator per tenant of a multi-tenant
+// service, so customers can have their own jurisdiction and thresholds in
+// one process. Each tenant counts its validations in its own Stats. A
+// TenantRegistry is safe for concurrent use.
+type TenantRegistry = core.TenantRegistry
+
+// NewTenantRegistry returns an empty registry. The common options apply to
+// every tenant, before the tenant's own options.
+func NewTenantRegistry(common ...Option) *TenantRegistry {
+	return core.NewTenantRegistry(common...)
+}
+
+// Upcoming is a user with milestones within the window of ScanUpcoming
+type Upcoming = core.Upcoming
+
+// ScanUpcoming returns the users of profiles who have milestones within
+// window from now: credentials that expire or are due for renewal, and
+// birthdays that make them eligible for entity types, see Milestones. It
+// is meant for scheduled jobs that feed notification systems, which run it
+// with a window as long as their period. Users come in the order of
+// profiles, and invalid users are left out.
+func ScanUpcoming(profiles []Profile, window time.Duration, opts ...Option) []Upcoming {
+	return core.ScanUpcoming(profiles, window, opts...)
+}
+
+// Validator validates dates with a fixed set of options. It is useful when
+// the same settings, such as date floors or a clock, apply to every call.
+// A Validator is safe for concurrent use. A nil Validator validates with
+// the package defaults.
+type Validator = core.Validator
+
+// NewValidator creates a Validator that applies opts to every validation.
+// Its reports carry the hash of its Config.
+func NewValidator(opts ...Option) *Validator {
+	return core.NewValidator(opts...)
+}
+
+// WebhookEvent is an outcome of a validation that a WebhookSink sends
+type WebhookEvent = core.WebhookEvent
+
+// Webhook events
+const (
+	WebhookFailure  = core.WebhookFailure
+	WebhookAnomaly  = core.WebhookAnomaly
+	WebhookExpiring = core.WebhookExpiring
+)
+
+// WebhookConfig configures a WebhookSink
+type WebhookConfig = core.WebhookConfig
+
+// WebhookPayload is the JSON body of a webhook request. The request has an
+// X-Userdate-Event header with the event, and an X-Userdate-Signature
+// header with the HMAC-SHA256 of the body, see VerifyWebhook.
+type WebhookPayload = core.WebhookPayload
+
+// DeadLetter is a webhook payload that could not be delivered, with the
+// error of its last attempt
+type DeadLetter = core.DeadLetter
+
+// WebhookSink POSTs signed JSON payloads of validation outcomes to a URL,
+// so that downstream systems react without polling. Deliveries that fail
+// after the retries of the policy are appended to the dead-letter file,
+// from which ReadDeadLetters and Send deliver them again. A WebhookSink is
+// safe for concurrent use.
+type WebhookSink = core.WebhookSink
+
+// NewWebhookSink returns a sink delivering to cfg.URL
+func NewWebhookSink(cfg WebhookConfig) *WebhookSink {
+	return core.NewWebhookSink(cfg)
+}
+
+// WithWebhook sends the outcomes of validations to s, passing it the ctx of
+// the validation. The deliveries are made before the validation returns.
+// When a payload can neither be delivered nor dead-lettered, the report
+// gets a WEBHOOK_FAILED warning.
+func WithWebhook(s *WebhookSink) Option {
+	return core.WithWebhook(s)
+}
+
+// VerifyWebhook checks the X-Userdate-Signature header of a webhook request
+// body signed with secret. It returns ErrInvalidSignature if the check
+// fails.
+func VerifyWebhook(secret, body []byte, signature string) error {
+	return core.VerifyWebhook(secret, body, signature)
+}
+
+// ReadDeadLetters reads the payloads of a dead-letter file of a WebhookSink
+func ReadDeadLetters(path string) ([]DeadLetter, error) {
+	return core.ReadDeadLetters(path)
+}